@@ -15,9 +15,12 @@
 package sqle
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -200,12 +203,18 @@ func (e *Engine) QueryNodeWithBindings(
 		return nil, nil, err
 	}
 
+	ctx, cancelQuery, err := withQueryDeadline(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if p, ok := e.preparedDataForSession(ctx.Session); ok && p.Query == query {
 		analyzed, err = e.analyzePreparedQuery(ctx, query, bindings)
 	} else {
 		analyzed, err = e.analyzeQuery(ctx, query, parsed, bindings)
 	}
 	if err != nil {
+		err = cancelQueryWithError(ctx, cancelQuery, err)
 		err2 := clearAutocommitTransaction(ctx)
 		if err2 != nil {
 			err = errors.Wrap(err, "unable to clear autocommit transaction: "+err2.Error())
@@ -226,6 +235,7 @@ func (e *Engine) QueryNodeWithBindings(
 		iter, err = analyzed.RowIter(ctx, nil)
 	}
 	if err != nil {
+		err = cancelQueryWithError(ctx, cancelQuery, err)
 		err2 := clearAutocommitTransaction(ctx)
 		if err2 != nil {
 			err = errors.Wrap(err, "unable to clear autocommit transaction: "+err2.Error())
@@ -234,6 +244,14 @@ func (e *Engine) QueryNodeWithBindings(
 		return nil, nil, err
 	}
 
+	if cancelQuery != nil {
+		iter = &queryDeadlineIter{
+			ctx:         ctx,
+			iter:        iter,
+			cancelQuery: cancelQuery,
+		}
+	}
+
 	if useIter2 {
 		iter = rowFormatSelectorIter{
 			iter:    iter,
@@ -451,6 +469,83 @@ func (t rowFormatSelectorIter) IsNode2() bool {
 	return t.isNode2
 }
 
+// maxExecutionTime returns the maximum execution time for the query, in milliseconds. The limit is taken from the
+// MAX_EXECUTION_TIME optimizer hint if one is given, and from the max_execution_time system variable otherwise. As in
+// MySQL, only SELECT statements are limited. A limit of zero means that the query may run indefinitely. The hint and
+// the kind of statement are those that the parser recorded on the context.
+func maxExecutionTime(ctx *sql.Context) (int64, error) {
+	isSelect, hint, ok := ctx.MaxExecutionTimeHint()
+	if !isSelect {
+		return 0, nil
+	}
+	if ok {
+		return hint, nil
+	}
+	val, err := ctx.GetSessionVariable(ctx, "max_execution_time")
+	if err != nil {
+		return 0, err
+	}
+	timeout, ok := val.(int64)
+	if !ok || timeout < 0 {
+		timeout = 0
+	}
+	return timeout, nil
+}
+
+// withQueryDeadline returns a context that expires once the query has exceeded its maximum execution time, along
+// with the function that releases the deadline. If the query has no maximum execution time, then the given context
+// is returned along with a nil function.
+func withQueryDeadline(ctx *sql.Context) (*sql.Context, context.CancelFunc, error) {
+	timeout, err := maxExecutionTime(ctx)
+	if err != nil || timeout == 0 {
+		return ctx, nil, err
+	}
+	newCtx, cancelQuery := context.WithTimeout(ctx.Context, time.Duration(timeout)*time.Millisecond)
+	return ctx.WithContext(newCtx), cancelQuery, nil
+}
+
+// cancelQueryWithError releases the query's deadline, if it has one, and returns the error that the query should
+// return. Errors that were caused by an expired deadline are returned as sql.ErrQueryTimeout.
+func cancelQueryWithError(ctx *sql.Context, cancelQuery context.CancelFunc, err error) error {
+	if cancelQuery == nil {
+		return err
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		err = sql.ErrQueryTimeout.New()
+	}
+	cancelQuery()
+	return err
+}
+
+// queryDeadlineIter is a wrapping row iter for queries with a maximum execution time. Rows are read using the context
+// that holds the query's deadline, and any error that occurs once the deadline has passed is returned as
+// sql.ErrQueryTimeout.
+type queryDeadlineIter struct {
+	ctx         *sql.Context
+	iter        sql.RowIter
+	cancelQuery context.CancelFunc
+}
+
+var _ sql.RowIter = (*queryDeadlineIter)(nil)
+
+// Next implements the interface sql.RowIter.
+func (i *queryDeadlineIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if i.ctx.Err() == context.DeadlineExceeded {
+		return nil, sql.ErrQueryTimeout.New()
+	}
+	row, err := i.iter.Next(i.ctx)
+	if err != nil && err != io.EOF && i.ctx.Err() == context.DeadlineExceeded {
+		return nil, sql.ErrQueryTimeout.New()
+	}
+	return row, err
+}
+
+// Close implements the interface sql.RowIter.
+func (i *queryDeadlineIter) Close(ctx *sql.Context) error {
+	defer i.cancelQuery()
+	return i.iter.Close(i.ctx)
+}
+
 const (
 	enableIter2EnvVar = "ENABLE_ROW_ITER_2"
 )
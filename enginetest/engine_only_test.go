@@ -88,6 +88,11 @@ func TestTracing(t *testing.T) {
 	enginetest.TestTracing(t, enginetest.NewDefaultMemoryHarness())
 }
 
+func TestExplainAnalyze(t *testing.T) {
+	enginetest.TestExplainAnalyze(t, enginetest.NewDefaultMemoryHarness())
+	enginetest.TestExplainAnalyze(t, enginetest.NewMemoryHarness("parallel", 2, testNumPartitions, true, nil))
+}
+
 func TestCurrentTimestamp(t *testing.T) {
 	enginetest.TestCurrentTimestamp(t, enginetest.NewDefaultMemoryHarness())
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -4006,6 +4007,64 @@ func TestWindowFunctions(t *testing.T, harness Harness) {
 	AssertErr(t, e, harness, "SELECT a, lag(a, -1) over (partition by c) FROM t1", expression.ErrInvalidOffset)
 	AssertErr(t, e, harness, "SELECT a, lag(a, 's') over (partition by c) FROM t1", expression.ErrInvalidOffset)
 
+	TestQueryWithContext(t, ctx, e, harness, `SELECT a, ntile(4) over (order by a), ntile(2) over (partition by c order by a) FROM t1 order by a`, []sql.Row{
+		{0, uint64(1), uint64(1)},
+		{1, uint64(1), uint64(1)},
+		{2, uint64(2), uint64(1)},
+		{3, uint64(2), uint64(1)},
+		{4, uint64(3), uint64(2)},
+		{5, uint64(4), uint64(2)},
+	}, nil, nil)
+
+	TestQueryWithContext(t, ctx, e, harness, `SELECT a, cume_dist() over (order by b), cume_dist() over (partition by c order by b) FROM t1 order by a`, []sql.Row{
+		{0, float64(2) / float64(6), float64(2) / float64(5)},
+		{1, float64(4) / float64(6), float64(1)},
+		{2, float64(5) / float64(6), float64(4) / float64(5)},
+		{3, float64(2) / float64(6), float64(2) / float64(5)},
+		{4, float64(4) / float64(6), float64(3) / float64(5)},
+		{5, float64(1), float64(1)},
+	}, nil, nil)
+
+	TestQueryWithContext(t, ctx, e, harness, `SELECT a, nth_value(b, 4) over (order by b) FROM t1 order by a`, []sql.Row{
+		{0, nil},
+		{1, 1},
+		{2, 1},
+		{3, nil},
+		{4, 1},
+		{5, 1},
+	}, nil, nil)
+
+	TestQueryWithContext(t, ctx, e, harness, `SELECT a, nth_value(a, 2) FROM FIRST over (order by a rows between 1 preceding and 1 following), nth_value(b, 2) FROM FIRST RESPECT NULLS over (order by a) FROM t1 order by a`, []sql.Row{
+		{0, 1, nil},
+		{1, 1, 1},
+		{2, 2, 1},
+		{3, 3, 1},
+		{4, 4, 1},
+		{5, 5, 1},
+	}, nil, nil)
+
+	AssertErr(t, e, harness, "SELECT a, ntile(0) over (order by a) FROM t1", sql.ErrInvalidArgument)
+	AssertErr(t, e, harness, "SELECT a, ntile(a) over (order by a) FROM t1", expression.ErrInvalidOffset)
+	AssertErr(t, e, harness, "SELECT a, nth_value(a, 0) over (order by a) FROM t1", sql.ErrInvalidArgument)
+	AssertErr(t, e, harness, "SELECT a, nth_value(a, 2) FROM LAST over (order by a) FROM t1", sql.ErrNotSupportedYet)
+	AssertErr(t, e, harness, "SELECT a, nth_value(b, 1) IGNORE NULLS over (order by a) FROM t1", sql.ErrNotSupportedYet)
+
+	RunQuery(t, e, harness, "CREATE VIEW nth_values AS SELECT a, nth_value(b, 2) FROM FIRST RESPECT NULLS over (order by a) FROM t1")
+	TestQueryWithContext(t, ctx, e, harness, `SHOW CREATE VIEW nth_values`, []sql.Row{{
+		"nth_values",
+		"CREATE VIEW `nth_values` AS SELECT a, nth_value(b, 2) FROM FIRST RESPECT NULLS over (order by a) FROM t1",
+		"utf8mb4",
+		"utf8mb4_0900_bin",
+	}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, harness, `SELECT * FROM nth_values order by a`, []sql.Row{
+		{0, nil},
+		{1, 1},
+		{2, 1},
+		{3, 1},
+		{4, 1},
+		{5, 1},
+	}, nil, nil)
+
 	RunQuery(t, e, harness, "CREATE TABLE t2 (a int, b int, c int)")
 	RunQuery(t, e, harness, "INSERT INTO t2 VALUES (1,1,1), (3,2,2), (7,4,5)")
 	TestQueryWithContext(t, ctx, e, harness, `SELECT bit_and(a), bit_or(b), bit_xor(c) FROM t2`, []sql.Row{
@@ -4711,6 +4770,91 @@ func TestTracing(t *testing.T, harness Harness) {
 	require.Equal(t, expectedSpans, spanOperations)
 }
 
+func TestExplainAnalyze(t *testing.T, harness Harness) {
+	harness.Setup(setup.MydbData, setup.MytableData, setup.OthertableData)
+	e := mustNewEngine(t, harness)
+	defer e.Close()
+
+	// The time spent in each node varies between runs, so it isn't compared
+	timeRegex := regexp.MustCompile(`time=[0-9.]+ms`)
+	for _, tt := range []struct {
+		query    string
+		expected []string
+	}{
+		{
+			query: "explain analyze select * from mytable where i in (select i2 from othertable where s2 <> 'x') order by i",
+			expected: []string{
+				"Sort(mytable.i ASC) (actual rows=3 loops=1 time=?ms)",
+				" └─ IndexedInSubqueryFilter(mytable.i IN ((Project",
+				"     ├─ columns: [othertable.i2]",
+				"     └─ Filter(NOT((othertable.s2 = 'x')))",
+				"         └─ IndexedTableAccess(othertable)",
+				"             ├─ index: [othertable.s2]",
+				"             ├─ filters: [{(x, ∞)}, {(NULL, x)}]",
+				"             └─ columns: [s2 i2]",
+				"    ))) (actual rows=3 loops=1 time=?ms)",
+				"     └─ IndexedTableAccess(mytable)",
+				"         └─ index: [mytable.i] (actual rows=3 loops=3 time=?ms)",
+			},
+		},
+		{
+			query: "explain analyze select * from mytable where i > (select min(i2) from othertable where s2 = mytable.s)",
+			expected: []string{
+				"Filter(mytable.i > (GroupBy",
+				" ├─ SelectedExprs(MIN(othertable.i2))",
+				" ├─ Grouping() (actual rows=3 loops=3 time=?ms)",
+				" └─ Filter(othertable.s2 = mytable.s) (actual rows=0 loops=3 time=?ms)",
+				"     └─ IndexedTableAccess(othertable)",
+				"         ├─ index: [othertable.s2]",
+				"         └─ columns: [s2 i2] (actual rows=0 loops=3 time=?ms)",
+				")) (actual rows=0 loops=1 time=?ms)",
+				" └─ Table(mytable) (actual rows=3 loops=1 time=?ms)",
+			},
+		},
+		{
+			query: "explain analyze select * from mytable where s <> 'a\x01b\x02c\nd'",
+			expected: []string{
+				"Filter(NOT((mytable.s = 'a\x01b\x02c",
+				"d'))) (actual rows=3 loops=1 time=?ms)",
+				" └─ IndexedTableAccess(mytable)",
+				"     ├─ index: [mytable.s]",
+				"     ├─ filters: [{(a\x01b\x02c",
+				"     │  d, ∞)}, {(NULL, a\x01b\x02c",
+				"     │  d)}]",
+				"     └─ columns: [i s] (actual rows=3 loops=1 time=?ms)",
+			},
+		},
+		{
+			query: "explain analyze select * from mytable a join othertable b on a.i + 1 = b.i2 + 1",
+			expected: []string{
+				"HashJoin((a.i + 1) = (b.i2 + 1)) (actual rows=3 loops=1 time=?ms)",
+				" ├─ TableAlias(a) (actual rows=3 loops=1 time=?ms)",
+				" │   └─ Table(mytable)",
+				" │       └─ columns: [i s] (actual rows=3 loops=1 time=?ms)",
+				" └─ HashLookup(child: ((b.i2 + 1)), lookup: ((a.i + 1))) (actual rows=5 loops=3 time=?ms)",
+				"     └─ CachedResults",
+				"         └─ TableAlias(b) (actual rows=3 loops=1 time=?ms)",
+				"             └─ Table(othertable)",
+				"                 └─ columns: [s2 i2] (actual rows=3 loops=1 time=?ms)",
+			},
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			ctx := NewContext(harness)
+			sch, iter, err := e.Query(ctx, tt.query)
+			require.NoError(t, err)
+			rows, err := sql.RowIterToRows(ctx, sch, iter)
+			require.NoError(t, err)
+
+			var lines []string
+			for _, row := range rows {
+				lines = append(lines, timeRegex.ReplaceAllString(row[0].(string), "time=?ms"))
+			}
+			require.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
 func TestCurrentTimestamp(t *testing.T, harness Harness) {
 	harness.Setup(setup.MydbData)
 	e := mustNewEngine(t, harness)
@@ -1876,7 +1876,7 @@ var InsertIgnoreScripts = []ScriptTest{
 				Expected: []sql.Row{
 					{sql.OkResult{RowsAffected: 1}},
 				},
-				ExpectedWarning: mysql.ERUnknownError,
+				ExpectedWarning: 1265, // WARN_DATA_TRUNCATED,
 			},
 			{
 				Query: "SELECT * FROM t2",
@@ -1913,7 +1913,7 @@ var InsertIgnoreScripts = []ScriptTest{
 				Expected: []sql.Row{
 					{sql.OkResult{RowsAffected: 1}},
 				},
-				ExpectedWarning: mysql.ERUnknownError,
+				ExpectedWarning: 1265, // WARN_DATA_TRUNCATED,
 			},
 			{
 				Query: "SELECT * FROM t2",
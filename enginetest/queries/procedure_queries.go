@@ -755,6 +755,160 @@ END;`,
 			},
 		},
 	},
+	{
+		Name: "IF condition with function calls",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk BIGINT PRIMARY KEY);",
+			`CREATE PROCEDURE p1(x INT)
+BEGIN
+	INSERT INTO t1 VALUES (x), (x + 1);
+	IF ROW_COUNT() = 2 AND UPPER('a') = 'A' THEN
+		SELECT 'inserted';
+	ELSE
+		SELECT 'other';
+	END IF;
+END;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "CALL p1(1)",
+				Expected: []sql.Row{{"inserted"}},
+			},
+		},
+	},
+	{
+		Name: "DECLARE variables",
+		SetUpScript: []string{
+			`CREATE PROCEDURE p1(x INT)
+BEGIN
+	DECLARE a, b INT DEFAULT 5;
+	DECLARE c VARCHAR(20);
+	SET a = a + x;
+	SELECT b * 2 INTO b;
+	BEGIN
+		DECLARE a INT DEFAULT 100;
+		SET c = CONCAT('inner ', a);
+	END;
+	SELECT a, b, c;
+END;`,
+			`CREATE PROCEDURE p2(x INT)
+BEGIN
+	DECLARE a INT DEFAULT x * 2;
+	DECLARE b INT DEFAULT a + 1;
+	SELECT a, b;
+END;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "CALL p1(3)",
+				Expected: []sql.Row{{int32(8), int32(10), "inner 100"}},
+			},
+			{
+				Query:    "CALL p2(4)",
+				Expected: []sql.Row{{int32(8), int32(9)}},
+			},
+		},
+	},
+	{
+		Name: "DECLARE HANDLER",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk BIGINT PRIMARY KEY);",
+			`CREATE PROCEDURE p1()
+BEGIN
+	DECLARE dup INT DEFAULT 0;
+	DECLARE cur CURSOR FOR SELECT pk FROM t1;
+	DECLARE CONTINUE HANDLER FOR 1062 SET dup = dup + 1;
+	INSERT INTO t1 VALUES (1);
+	INSERT INTO t1 VALUES (1);
+	INSERT INTO t1 VALUES (2);
+	INSERT INTO t1 VALUES (2);
+	SELECT dup;
+END;`,
+			`CREATE PROCEDURE p2(x INT)
+BEGIN
+	DECLARE res VARCHAR(20) DEFAULT 'none';
+	BEGIN
+		DECLARE EXIT HANDLER FOR SQLEXCEPTION SET res = 'exited';
+		IF x = 1 THEN
+			SIGNAL SQLSTATE '45000';
+		END IF;
+		SET res = 'finished';
+	END;
+	SELECT res;
+END;`,
+			`CREATE PROCEDURE p3(x INT)
+BEGIN
+	DECLARE res INT DEFAULT 0;
+	DECLARE custom CONDITION FOR SQLSTATE '45001';
+	DECLARE CONTINUE HANDLER FOR custom SET res = res + 10;
+	DECLARE CONTINUE HANDLER FOR SQLSTATE '45002' SET res = res + 1;
+	SIGNAL custom;
+	SIGNAL SQLSTATE '45002';
+	SIGNAL SQLSTATE '45002';
+	IF x = 1 THEN
+		SIGNAL SQLSTATE '45003';
+	END IF;
+	SELECT res;
+END;`,
+			`CREATE PROCEDURE p4()
+BEGIN
+	DECLARE CONTINUE HANDLER FOR SQLEXCEPTION INSERT INTO t1 VALUES (1);
+	INSERT INTO t1 VALUES (1);
+	SELECT 'unreachable';
+END;`,
+			`CREATE PROCEDURE p5()
+BEGIN
+	DECLARE res INT DEFAULT 0;
+	BEGIN
+		DECLARE CONTINUE HANDLER FOR SQLEXCEPTION SET res = res + 10;
+		BEGIN
+			DECLARE CONTINUE HANDLER FOR SQLEXCEPTION
+			BEGIN
+				SET res = res + 1;
+				INSERT INTO t1 VALUES (1);
+			END;
+			INSERT INTO t1 VALUES (1);
+		END;
+	END;
+	SELECT res;
+END;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "CALL p1()",
+				Expected: []sql.Row{{int32(2)}},
+			},
+			{
+				Query:    "SELECT * FROM t1 ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}, {int64(2)}},
+			},
+			{
+				Query:    "CALL p2(0)",
+				Expected: []sql.Row{{"finished"}},
+			},
+			{
+				Query:    "CALL p2(1)",
+				Expected: []sql.Row{{"exited"}},
+			},
+			{
+				Query:    "CALL p3(0)",
+				Expected: []sql.Row{{int32(12)}},
+			},
+			{
+				Query:          "CALL p3(1)",
+				ExpectedErrStr: "Unhandled user-defined exception condition (errno 1644) (sqlstate 45003)",
+			},
+			{
+				// A handler is not active while its own statement runs
+				Query:       "CALL p4()",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:    "CALL p5()",
+				Expected: []sql.Row{{int32(11)}},
+			},
+		},
+	},
 	{
 		Name:        "Duplicate parameter names",
 		Query:       "CREATE PROCEDURE p1(abc DATETIME, abc DOUBLE) SELECT abc",
@@ -843,14 +997,14 @@ BEGIN
 END;`,
 		ExpectedErr: sql.ErrDeclareConditionDuplicate,
 	},
-	{ //TODO: change this test when we implement DECLARE CONDITION for MySQL error codes
+	{
 		Name: "SIGNAL references condition name for MySQL error code",
 		Query: `CREATE PROCEDURE p1(x INT)
 BEGIN
 	DECLARE mysql_err_code CONDITION FOR 1000;
 	SIGNAL mysql_err_code;
 END;`,
-		ExpectedErr: sql.ErrUnsupportedSyntax,
+		ExpectedErr: sql.ErrSignalOnlySqlState,
 	},
 	{
 		Name: "SIGNAL non-existent condition name",
@@ -861,6 +1015,88 @@ BEGIN
 END;`,
 		ExpectedErr: sql.ErrDeclareConditionNotFound,
 	},
+	{
+		Name: "DECLARE variables, cursors, and handlers wrong positions",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	DECLARE CONTINUE HANDLER FOR SQLEXCEPTION SET @x = 1;
+	DECLARE a INT;
+END;`,
+				ExpectedErr: sql.ErrDeclareVariableOrConditionAfterCursor,
+			},
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	DECLARE cur CURSOR FOR SELECT 1;
+	DECLARE cond_name CONDITION FOR SQLSTATE '45000';
+END;`,
+				ExpectedErr: sql.ErrDeclareVariableOrConditionAfterCursor,
+			},
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	DECLARE CONTINUE HANDLER FOR SQLEXCEPTION SET @x = 1;
+	DECLARE cur CURSOR FOR SELECT 1;
+END;`,
+				ExpectedErr: sql.ErrDeclareCursorAfterHandler,
+			},
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	SELECT 1;
+	DECLARE a INT;
+END;`,
+				ExpectedErr: sql.ErrDeclareOrderInvalid,
+			},
+			{
+				Query: `CREATE PROCEDURE p1(x INT)
+BEGIN
+	IF x = 0 THEN
+		DECLARE a INT;
+	END IF;
+END;`,
+				ExpectedErr: sql.ErrDeclareOrderInvalid,
+			},
+		},
+	},
+	{
+		Name: "DECLARE duplicates",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	DECLARE a INT;
+	DECLARE b, A INT;
+END;`,
+				ExpectedErr: sql.ErrDeclareVariableDuplicate,
+			},
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	DECLARE cur CURSOR FOR SELECT 1;
+	DECLARE cur CURSOR FOR SELECT 2;
+END;`,
+				ExpectedErr: sql.ErrDeclareCursorDuplicate,
+			},
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	DECLARE CONTINUE HANDLER FOR SQLSTATE '45000' SET @x = 1;
+	DECLARE EXIT HANDLER FOR SQLSTATE '45000' SET @x = 1;
+END;`,
+				ExpectedErr: sql.ErrDeclareHandlerDuplicate,
+			},
+			{
+				Query: `CREATE PROCEDURE p1()
+BEGIN
+	DECLARE CONTINUE HANDLER FOR no_such_condition SET @x = 1;
+END;`,
+				ExpectedErr: sql.ErrDeclareConditionNotFound,
+			},
+		},
+	},
 	{
 		Name: "Duplicate procedure name",
 		SetUpScript: []string{
@@ -1066,6 +1066,54 @@ var QueryTests = []QueryTest{
 			{sql.MustJSON(`{ "a": [5, 3], "d": 6, "e": 2}`)},
 		},
 	},
+	{
+		Query: `SELECT JSON_SET('{"a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]', '$.b[5]', 4)`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`{"a": 10, "b": [2, 3, 4], "c": "[true, false]"}`)},
+		},
+	},
+	{
+		Query: `SELECT JSON_INSERT('{"a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`{"a": 1, "b": [2, 3], "c": "[true, false]"}`)},
+		},
+	},
+	{
+		Query: `SELECT JSON_REPLACE('{"a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`{"a": 10, "b": [2, 3]}`)},
+		},
+	},
+	{
+		Query: `SELECT JSON_REMOVE('["a", ["b", "c"], "d"]', '$[1]', '$[1]')`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`["a"]`)},
+		},
+	},
+	{
+		Query: `SELECT JSON_ARRAY_APPEND('["a", ["b", "c"], "d"]', '$[1]', 1, '$[0]', 2, '$[3]', 3)`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`[["a", 2], ["b", "c", 1], "d"]`)},
+		},
+	},
+	{
+		Query: `SELECT JSON_ARRAY_INSERT('["a", {"b": [1, 2]}, [3, 4]]', '$[1]', 'x', '$[2][1]', 'y')`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`["a", "x", {"b": [1, 2]}, [3, 4]]`)},
+		},
+	},
+	{
+		Query: `SELECT JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6, "b": null}')`,
+		Expected: []sql.Row{
+			{sql.MustJSON(`{"a": 5, "c": 4, "d": 6}`)},
+		},
+	},
+	{
+		Query: `SELECT JSON_SET(NULL, '$.a', 1), JSON_SET('{}', NULL, 1), JSON_SET('{}', '$.a', NULL)`,
+		Expected: []sql.Row{
+			{nil, nil, sql.MustJSON(`{"a": null}`)},
+		},
+	},
 	{
 		Query: `SELECT JSON_ARRAY()`,
 		Expected: []sql.Row{
@@ -4307,6 +4355,10 @@ var QueryTests = []QueryTest{
 		Query:    `SELECT JSON_UNQUOTE(JSON_EXTRACT('{"xid":null}', '$.xid'))`,
 		Expected: []sql.Row{{"null"}},
 	},
+	{
+		Query:    `SELECT json_unquote(json_extract('{"hi":"there"}', '$.nope'))`,
+		Expected: []sql.Row{{nil}},
+	},
 	{
 		Query:    `select JSON_EXTRACT('{"id":234}', '$.id')-1;`,
 		Expected: []sql.Row{{233.0}},
@@ -7678,11 +7730,6 @@ var BrokenQueries = []QueryTest{
 			},
 		},
 	},
-	// https://github.com/dolthub/go-mysql-server/issues/600
-	{
-		Query:    `SELECT json_unquote(json_extract('{"hi":"there"}', '$.nope'))`,
-		Expected: []sql.Row{{nil}}, // currently returns string "null"
-	},
 	// Null-safe and type conversion tuple comparison is not correctly
 	// implemented yet.
 	{
@@ -9159,16 +9206,16 @@ var ErrorQueries = []QueryErrorTest{
 		ExpectedErr: sql.ErrCteRecursionLimitExceeded,
 	},
 	{
-		Query:       `alter table a add fulltext index idx (id)`,
-		ExpectedErr: sql.ErrUnsupportedFeature,
+		Query:       `alter table mytable add fulltext index idx (i)`,
+		ExpectedErr: sql.ErrFulltextColumn,
 	},
 	{
-		Query:       `CREATE TABLE test (pk int primary key, body text, FULLTEXT KEY idx_body (body))`,
-		ExpectedErr: sql.ErrUnsupportedFeature,
+		Query:       `CREATE TABLE test (pk int primary key, body text, FULLTEXT KEY idx_body (pk))`,
+		ExpectedErr: sql.ErrFulltextColumn,
 	},
 	{
-		Query:       `CREATE FULLTEXT INDEX idx ON opening_lines(opening_line)`,
-		ExpectedErr: sql.ErrUnsupportedFeature,
+		Query:       `SELECT * FROM mytable WHERE MATCH (s) AGAINST ('first row')`,
+		ExpectedErr: sql.ErrNoFulltextIndex,
 	},
 	{
 		Query:       `SELECT * FROM datetime_table where date_col >= 'not a valid date'`,
@@ -17,9 +17,8 @@ package queries
 import (
 	"gopkg.in/src-d/go-errors.v1"
 
-	"github.com/dolthub/go-mysql-server/sql/analyzer"
-
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -598,12 +597,8 @@ var ScriptTests = []ScriptTest{
 				ExpectedErr: sql.ErrMoreThanOneRow,
 			},
 			{
-				Query:       `SELECT 1 INTO OUTFILE 'x.txt'`,
-				ExpectedErr: sql.ErrUnsupportedSyntax,
-			},
-			{
-				Query:       `SELECT id INTO DUMPFILE 'dump.txt' FROM tab1 ORDER BY id DESC LIMIT 15`,
-				ExpectedErr: sql.ErrUnsupportedSyntax,
+				Query:       `SELECT id INTO DUMPFILE './testdata/test1.txt' FROM tab1 ORDER BY id DESC LIMIT 15`,
+				ExpectedErr: sql.ErrFileExists,
 			},
 			{
 				Query:       `select 1, 2, 3 into @my1, @my2`,
@@ -1345,17 +1340,25 @@ var ScriptTests = []ScriptTest{
 				Query:    "SELECT col0 BETWEEN 2 and 4 from tab1 group by col0",
 				Expected: []sql.Row{{false}, {false}, {false}},
 			},
+			{
+				Query:    "set sql_mode = 'ONLY_FULL_GROUP_BY'",
+				Expected: []sql.Row{{}},
+			},
 			{
 				Query:       "SELECT col0, col1 FROM tab1 GROUP by col0;",
-				ExpectedErr: analyzer.ErrValidationGroupBy,
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
 			},
 			{
 				Query:       "SELECT col0, floor(col1) FROM tab1 GROUP by col0;",
-				ExpectedErr: analyzer.ErrValidationGroupBy,
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
 			},
 			{
 				Query:       "SELECT floor(cor0.col1) * ceil(cor0.col0) AS col2 FROM tab1 AS cor0 GROUP BY cor0.col0",
-				ExpectedErr: analyzer.ErrValidationGroupBy,
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:    "set sql_mode = 'STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION'",
+				Expected: []sql.Row{{}},
 			},
 		},
 	},
@@ -2113,6 +2116,47 @@ var ScriptTests = []ScriptTest{
 			// TODO: Does not include tests with column renames and defaults.
 		},
 	},
+	{
+		Name: "indexes remain usable after columns are dropped, added, renamed and moved",
+		SetUpScript: []string{
+			"create table z (id int primary key, a int, b int, index ab (a,b), index b (b))",
+			"insert into z values (1,1,10), (2,2,20), (3,2,30)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "alter table z drop column b",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "select * from z where a = 2",
+				Expected: []sql.Row{{2, 2}, {3, 2}},
+			},
+			{
+				Query:    "select index_name, column_name from information_schema.statistics where table_name = 'z' order by index_name",
+				Expected: []sql.Row{{"PRIMARY", "id"}, {"ab", "a"}},
+			},
+			{
+				Query:    "alter table z add column c int first",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "select * from z where a = 2",
+				Expected: []sql.Row{{nil, 2, 2}, {nil, 3, 2}},
+			},
+			{
+				Query:    "alter table z rename column a to aa",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "alter table z modify column aa bigint after id",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "select * from z where aa = 2",
+				Expected: []sql.Row{{nil, 2, 2}, {nil, 3, 2}},
+			},
+		},
+	},
 	{
 		// https://github.com/dolthub/dolt/issues/3065
 		Name: "join index lookups do not handle filters",
@@ -2364,305 +2408,1309 @@ var ScriptTests = []ScriptTest{
 			},
 		},
 	},
-}
-
-var SpatialScriptTests = []ScriptTest{
 	{
-		Name: "create table using default point value",
-		SetUpScript: []string{
-			"CREATE TABLE test (i int primary key, p point default (point(123.456, 7.89)));",
-			"insert into test (i) values (0);",
-		},
+		Name: "max_execution_time and the MAX_EXECUTION_TIME optimizer hint",
 		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "select st_aswkt(p) from test",
-				Expected: []sql.Row{{"POINT(123.456 7.89)"}},
+				Query:       "select /*+ MAX_EXECUTION_TIME(50) */ sleep(5)",
+				ExpectedErr: sql.ErrQueryTimeout,
 			},
 			{
-				Query:    "show create table test",
-				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `p` point DEFAULT (POINT(123.456, 7.89)),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    "select /*+ MAX_EXECUTION_TIME(5000) */ sleep(0.01)",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "describe test",
-				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"p", "point", "YES", "", "(POINT(123.456, 7.89))", ""}},
+				Query:       "with cte as (select 1 as x) select /*+ MAX_EXECUTION_TIME(50) */ sleep(5) from cte",
+				ExpectedErr: sql.ErrQueryTimeout,
 			},
-		},
-	},
-	{
-		Name: "create table using default linestring value",
-		SetUpScript: []string{
-			"CREATE TABLE test (i int primary key, l linestring default (linestring(point(1,2), point(3,4))));",
-			"insert into test (i) values (0);",
-		},
-		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "select st_aswkt(l) from test",
-				Expected: []sql.Row{{"LINESTRING(1 2,3 4)"}},
+				Query:       "/* leading comment */ select /*+ MAX_EXECUTION_TIME(50) */ sleep(5)",
+				ExpectedErr: sql.ErrQueryTimeout,
 			},
 			{
-				Query:    "show create table test",
-				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `l` linestring DEFAULT (LINESTRING(POINT(1, 2),POINT(3, 4))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    "set @@max_execution_time = 50",
+				Expected: []sql.Row{{}},
 			},
 			{
-				Query:    "describe test",
-				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"l", "linestring", "YES", "", "(LINESTRING(POINT(1, 2),POINT(3, 4)))", ""}},
+				Query:       "select sleep(5)",
+				ExpectedErr: sql.ErrQueryTimeout,
 			},
-		},
-	},
-	{
-		Name: "create table using default polygon value",
-		SetUpScript: []string{
-			"CREATE TABLE test (i int primary key, p polygon default (polygon(linestring(point(0,0), point(1,1), point(2,2), point(0,0)))));",
-			"insert into test (i) values (0);",
-		},
-		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "select st_aswkt(p) from test",
-				Expected: []sql.Row{{"POLYGON((0 0,1 1,2 2,0 0))"}},
+				Query:       "with cte as (select 1 as x) select sleep(5) from cte",
+				ExpectedErr: sql.ErrQueryTimeout,
 			},
 			{
-				Query:    "show create table test",
-				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `p` polygon DEFAULT (POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0)))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    "select /*+ MAX_EXECUTION_TIME(0) */ sleep(0.1)",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "describe test",
-				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"p", "polygon", "YES", "", "(POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0))))", ""}},
+				Query:    "set @x = sleep(0.1)",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "set @@max_execution_time = 0",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select sleep(0.1)",
+				Expected: []sql.Row{{0}},
 			},
 		},
 	},
 	{
-		Name: "create geometry table using default point value",
+		Name: "generated columns",
 		SetUpScript: []string{
-			"CREATE TABLE test (i int primary key, g geometry  default (point(123.456, 7.89)));",
-			"insert into test (i) values (0);",
+			"create table t (a int primary key, b int, c int generated always as (a + b) stored, d int as (c * 2), e varchar(20) as (concat('x', b)) virtual)",
+			"insert into t (a, b) values (1, 2), (3, 4)",
 		},
 		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "select st_aswkt(g) from test",
-				Expected: []sql.Row{{"POINT(123.456 7.89)"}},
+				Query:    "select * from t order by a",
+				Expected: []sql.Row{{1, 2, 3, 6, "x2"}, {3, 4, 7, 14, "x4"}},
 			},
 			{
-				Query:    "show create table test",
-				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `g` geometry DEFAULT (POINT(123.456, 7.89)),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    "insert into t values (5, 6, default, default, default)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
 			},
 			{
-				Query:    "describe test",
-				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"g", "geometry", "YES", "", "(POINT(123.456, 7.89))", ""}},
+				Query:    "update t set b = 10 where a = 1",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
 			},
-		},
-	},
-	{
-		Name: "create geometry table using default linestring value",
-		SetUpScript: []string{
-			"CREATE TABLE test (i int primary key, g geometry default (linestring(point(1,2), point(3,4))));",
-			"insert into test (i) values (0);",
-		},
-		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "select st_aswkt(g) from test",
-				Expected: []sql.Row{{"LINESTRING(1 2,3 4)"}},
+				Query:    "insert into t (a, b) values (3, 0) on duplicate key update b = 20",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
 			},
 			{
-				Query:    "show create table test",
-				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `g` geometry DEFAULT (LINESTRING(POINT(1, 2),POINT(3, 4))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    "select * from t order by a",
+				Expected: []sql.Row{{1, 10, 11, 22, "x10"}, {3, 20, 23, 46, "x20"}, {5, 6, 11, 22, "x6"}},
 			},
 			{
-				Query:    "describe test",
-				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"g", "geometry", "YES", "", "(LINESTRING(POINT(1, 2),POINT(3, 4)))", ""}},
+				Query:    "select a from t where c = 11 order by a",
+				Expected: []sql.Row{{1}, {5}},
 			},
-		},
-	},
-	{
-		Name: "create geometry table using default polygon value",
-		SetUpScript: []string{
-			"CREATE TABLE test (i int primary key, g geometry default (polygon(linestring(point(0,0), point(1,1), point(2,2), point(0,0)))));",
-			"insert into test (i) values (0);",
-		},
-		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "select st_aswkt(g) from test",
-				Expected: []sql.Row{{"POLYGON((0 0,1 1,2 2,0 0))"}},
+				Query:       "insert into t (a, b, c) values (7, 8, 9)",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
 			},
 			{
-				Query:    "show create table test",
-				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `g` geometry DEFAULT (POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0)))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:       "insert into t (a, b, c) select 7, 8, 9",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
 			},
 			{
-				Query:    "describe test",
-				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"g", "geometry", "YES", "", "(POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0))))", ""}},
+				Query:       "update t set c = 1",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
 			},
-		},
-	},
-	{
-		Name: "create table with NULL default values for geometry types",
-		SetUpScript: []string{
-			"CREATE TABLE null_default (pk int NOT NULL PRIMARY KEY, v1 geometry DEFAULT NULL, v2 linestring DEFAULT NULL, v3 point DEFAULT NULL, v4 polygon DEFAULT NULL)",
-			"insert into null_default(pk) values (0)",
-		},
-		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "select * from null_default",
-				Expected: []sql.Row{{0, nil, nil, nil, nil}},
+				Query: "show create table t",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `a` int NOT NULL,\n" +
+					"  `b` int,\n" +
+					"  `c` int GENERATED ALWAYS AS ((a + b)) STORED,\n" +
+					"  `d` int GENERATED ALWAYS AS ((c * 2)) VIRTUAL,\n" +
+					"  `e` varchar(20) GENERATED ALWAYS AS (concat('x', b)) VIRTUAL,\n" +
+					"  PRIMARY KEY (`a`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
 			},
-		},
-	},
-	{
-		Name: "create table using SRID value for geometry type",
-		SetUpScript: []string{
-			"CREATE TABLE tab0 (i int primary key, g geometry srid 4326 default (point(1,1)));",
-		},
-		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "show create table tab0",
-				Expected: []sql.Row{{"tab0", "CREATE TABLE `tab0` (\n  `i` int NOT NULL,\n  `g` geometry SRID 4326 DEFAULT (POINT(1, 1)),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query: "select column_name, extra, generation_expression from information_schema.columns where table_name = 't' order by ordinal_position",
+				Expected: []sql.Row{
+					{"a", "", ""},
+					{"b", "", ""},
+					{"c", "STORED GENERATED", "(a + b)"},
+					{"d", "VIRTUAL GENERATED", "(c * 2)"},
+					{"e", "VIRTUAL GENERATED", "concat('x', b)"},
+				},
 			},
 			{
-				Query:    "INSERT INTO tab0 VALUES (1, ST_GEOMFROMTEXT(ST_ASWKT(POINT(1,2)), 4326))",
-				Expected: []sql.Row{{sql.NewOkResult(1)}},
+				Query:    "alter table t add column f int as (a * 10)",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(g) FROM tab0",
-				Expected: []sql.Row{{1, "POINT(1 2)"}},
+				Query:    "select a, f from t order by a",
+				Expected: []sql.Row{{1, 10}, {3, 30}, {5, 50}},
 			},
 			{
-				Query:       "INSERT INTO tab0 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(POINT(2,4))))",
-				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+				Query:       "create table t2 (a int, b int as (c), c int as (a))",
+				ExpectedErr: sql.ErrGeneratedColumnNonPriorGenerated,
 			},
 			{
-				Query:    "INSERT INTO tab0 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(LINESTRING(POINT(1, 6),POINT(4, 3))), 4326))",
+				Query:       "create table t2 (a int, b int as (rand()))",
+				ExpectedErr: sql.ErrGeneratedColumnFunctionIsNotAllowed,
+			},
+			{
+				Query:       "create table t2 (a int primary key auto_increment, b int as (a))",
+				ExpectedErr: sql.ErrGeneratedColumnRefAutoInc,
+			},
+			{
+				Query:       "create table t2 (a int, b int as (a) default 1)",
+				ExpectedErr: sql.ErrGeneratedColumnWrongUsage,
+			},
+			{
+				Query:       "alter table t drop column b",
+				ExpectedErr: sql.ErrDependentByGeneratedColumn,
+			},
+			{
+				Query:       "alter table t drop column c",
+				ExpectedErr: sql.ErrDependentByGeneratedColumn,
+			},
+			{
+				Query:       "alter table t rename column b to bb",
+				ExpectedErr: sql.ErrDependentByGeneratedColumn,
+			},
+			{
+				Query:       "alter table t change column b bb int",
+				ExpectedErr: sql.ErrDependentByGeneratedColumn,
+			},
+			{
+				Query:    "alter table t drop column d",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "insert into t (a, b) values (7, 8)",
 				Expected: []sql.Row{{sql.NewOkResult(1)}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(g) FROM tab0",
-				Expected: []sql.Row{{1, "POINT(1 2)"}, {2, "LINESTRING(1 6,4 3)"}},
+				Query:    "select * from t where a = 7",
+				Expected: []sql.Row{{7, 8, 15, "x8", 70}},
 			},
 		},
 	},
 	{
-		Name: "create table using SRID value for linestring type",
+		Name: "values of generated columns are converted to the column type",
 		SetUpScript: []string{
-			"CREATE TABLE tab1 (i int primary key, l linestring srid 0);",
+			"create table t (a varchar(10), s varchar(2) as (concat(a, 'xyz')), i int as (a))",
 		},
 		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "show create table tab1",
-				Expected: []sql.Row{{"tab1", "CREATE TABLE `tab1` (\n  `i` int NOT NULL,\n  `l` linestring SRID 0,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:       "insert into t (a) values ('a')",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
 			},
 			{
-				Query:    "INSERT INTO tab1 VALUES (1, LINESTRING(POINT(0, 0),POINT(2, 2)))",
+				Query:       "insert into t (a) values ('12')",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
+			},
+			{
+				Query:    "insert ignore into t (a) values ('12')",
 				Expected: []sql.Row{{sql.NewOkResult(1)}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(l) FROM tab1",
-				Expected: []sql.Row{{1, "LINESTRING(0 0,2 2)"}},
+				Query:    "select * from t",
+				Expected: []sql.Row{{"12", "12", 12}},
 			},
 			{
-				Query:       "INSERT INTO tab1 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(LINESTRING(POINT(1, 6),POINT(4, 3))), 4326))",
-				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+				Query:       "alter table t add column s2 varchar(1) as (a)",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
+			},
+		},
+	},
+	{
+		Name: "stored generated column over a json document",
+		SetUpScript: []string{
+			"create table docs (id int primary key, doc json, x int as (json_extract(doc, '$.x')) stored, key (x))",
+			`insert into docs (id, doc) values (1, '{"x": 1}'), (2, '{"x": 2}'), (3, '{"x": 3}')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, x from docs where x = 2",
+				Expected: []sql.Row{{2, 2}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(l) FROM tab1",
-				Expected: []sql.Row{{1, "LINESTRING(0 0,2 2)"}},
+				Query:    `update docs set doc = '{"x": 2}' where id = 3`,
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "select id, x from docs where x = 2 order by id",
+				Expected: []sql.Row{{2, 2}, {3, 2}},
 			},
 		},
 	},
 	{
-		Name: "create table using SRID value for point type",
+		Name: "updating json documents in place",
 		SetUpScript: []string{
-			"CREATE TABLE tab2 (i int primary key);",
+			"create table docs (id int primary key, doc json)",
+			`insert into docs values (1, '{"name": "ann", "tags": ["a"]}'), (2, '{"name": "bob", "tags": "b"}'), (3, '{"name": "cat"}')`,
 		},
 		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "ALTER TABLE tab2 ADD COLUMN p POINT NOT NULL SRID 0",
-				Expected: []sql.Row{{sql.NewOkResult(0)}},
+				Query:    `update docs set doc = json_set(doc, '$.name', upper(doc->>'$.name'), '$.seen', true)`,
+				Expected: []sql.Row{{newUpdateResult(3, 3)}},
 			},
 			{
-				Query:    "show create table tab2",
-				Expected: []sql.Row{{"tab2", "CREATE TABLE `tab2` (\n  `i` int NOT NULL,\n  `p` point NOT NULL SRID 0,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    `update docs set doc = json_array_append(doc, '$.tags', 'x') where id in (1, 2)`,
+				Expected: []sql.Row{{newUpdateResult(2, 2)}},
 			},
 			{
-				Query:    "INSERT INTO tab2 VALUES (1, POINT(2, 2))",
-				Expected: []sql.Row{{sql.NewOkResult(1)}},
+				Query:    `update docs set doc = json_remove(json_insert(doc, '$.tags', json_array()), '$.seen') where id = 3`,
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(p) FROM tab2",
-				Expected: []sql.Row{{1, "POINT(2 2)"}},
+				Query: "select id, doc from docs order by id",
+				Expected: []sql.Row{
+					{1, sql.MustJSON(`{"name": "ANN", "tags": ["a", "x"], "seen": true}`)},
+					{2, sql.MustJSON(`{"name": "BOB", "tags": ["b", "x"], "seen": true}`)},
+					{3, sql.MustJSON(`{"name": "CAT", "tags": []}`)},
+				},
 			},
 			{
-				Query:       "INSERT INTO tab2 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(POINT(1, 6)), 4326))",
-				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+				Query:       `update docs set doc = json_set(doc, '$.tags[*]', 1)`,
+				ExpectedErr: sql.ErrInvalidJSONPathWildcard,
 			},
 			{
-				Query:    "select i, ST_ASWKT(p) FROM tab2",
-				Expected: []sql.Row{{1, "POINT(2 2)"}},
+				Query:       `select json_remove(doc, '$') from docs`,
+				ExpectedErr: sql.ErrJSONVacuousPath,
 			},
 			{
-				Query:    "ALTER TABLE tab2 CHANGE COLUMN p p POINT NOT NULL",
-				Expected: []sql.Row{{sql.NewOkResult(0)}},
+				Query:       `select json_array_insert(doc, '$.tags', 1) from docs`,
+				ExpectedErr: sql.ErrInvalidJSONPathArrayCell,
 			},
 			{
-				Query:    "INSERT INTO tab2 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(POINT(1, 6)), 4326))",
-				Expected: []sql.Row{{sql.NewOkResult(1)}},
+				Query:       `select json_insert(doc, '$.', 1) from docs`,
+				ExpectedErr: sql.ErrInvalidJSONPath,
+			},
+		},
+	},
+	{
+		Name: "json path operators",
+		SetUpScript: []string{
+			"create table people (id int primary key, doc json, name varchar(20) as (doc->>'$.name'), key ((cast(doc->>'$.age' as signed))))",
+			`insert into people (id, doc) values (1, '{"name": "ann", "age": 30, "team": "a"}'), (2, '{"name": "bob", "age": 25, "team": "b"}'), (3, '{"name": "cat", "age": 41, "team": "a", "nick": null}'), (4, null)`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, doc->'$.age', doc->>'$.name' from people order by id",
+				Expected: []sql.Row{{1, sql.MustJSON("30"), "ann"}, {2, sql.MustJSON("25"), "bob"}, {3, sql.MustJSON("41"), "cat"}, {4, nil, nil}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(p) FROM tab2",
-				Expected: []sql.Row{{1, "POINT(2 2)"}, {2, "POINT(1 6)"}},
+				Query:    "select id from people where doc->>'$.team' = 'a' order by doc->'$.age' desc",
+				Expected: []sql.Row{{3}, {1}},
 			},
 			{
-				Query:       "ALTER TABLE tab2 CHANGE COLUMN p p POINT NOT NULL SRID 4326",
-				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+				Query:    "select doc->>'$.team' as team, count(*) from people where doc is not null group by doc->>'$.team' order by team",
+				Expected: []sql.Row{{"a", 2}, {"b", 1}},
 			},
 			{
-				Query:    "delete from tab2 where i = 1",
-				Expected: []sql.Row{{sql.NewOkResult(1)}},
+				Query:    "select id, doc->>'$.nick', doc->'$.nick' is null from people where id in (1, 3) order by id",
+				Expected: []sql.Row{{1, nil, true}, {3, "null", false}},
 			},
 			{
-				Query:    "ALTER TABLE tab2 CHANGE COLUMN p p POINT NOT NULL SRID 4326",
-				Expected: []sql.Row{{sql.NewOkResult(0)}},
+				Query:    "select id, name from people where name is not null order by name desc",
+				Expected: []sql.Row{{3, "cat"}, {2, "bob"}, {1, "ann"}},
 			},
 			{
-				Query:    "show create table tab2",
-				Expected: []sql.Row{{"tab2", "CREATE TABLE `tab2` (\n  `i` int NOT NULL,\n  `p` point NOT NULL SRID 4326,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    "select id from people where cast(doc->>'$.age' as signed) > 28 order by id",
+				Expected: []sql.Row{{1}, {3}},
 			},
 		},
 	},
 	{
-		Name: "create table using SRID value for polygon type",
+		Name: "searching json documents",
 		SetUpScript: []string{
-			"CREATE TABLE tab3 (i int primary key, y polygon NOT NULL);",
+			"create table docs (id int primary key, doc json)",
+			`insert into docs values (1, '{"name": "ann", "tags": ["a_b", "abc"], "info": {"city": "oslo", "zip": "0150"}}'), (2, '{"name": "bob", "tags": [], "score": 12.25}'), (3, '[1, "abc", {"k": "10"}]'), (4, null)`,
 		},
 		Assertions: []ScriptTestAssertion{
 			{
-				Query:    "show create table tab3",
-				Expected: []sql.Row{{"tab3", "CREATE TABLE `tab3` (\n  `i` int NOT NULL,\n  `y` polygon NOT NULL,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+				Query:    "select id, json_keys(doc), json_keys(doc, '$.info') from docs order by id",
+				Expected: []sql.Row{{1, sql.MustJSON(`["info", "name", "tags"]`), sql.MustJSON(`["city", "zip"]`)}, {2, sql.MustJSON(`["name", "score", "tags"]`), nil}, {3, nil, nil}, {4, nil, nil}},
 			},
 			{
-				Query:    "INSERT INTO tab3 VALUES (1, polygon(linestring(point(0,0),point(8,0),point(12,9),point(0,9),point(0,0))))",
-				Expected: []sql.Row{{sql.NewOkResult(1)}},
+				Query:    "select id, json_search(doc, 'one', 'abc'), json_search(doc, 'all', '%b%') from docs order by id",
+				Expected: []sql.Row{{1, sql.MustJSON(`"$.tags[1]"`), sql.MustJSON(`["$.tags[0]", "$.tags[1]"]`)}, {2, nil, sql.MustJSON(`"$.name"`)}, {3, sql.MustJSON(`"$[1]"`), sql.MustJSON(`"$[1]"`)}, {4, nil, nil}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(y) FROM tab3",
-				Expected: []sql.Row{{1, "POLYGON((0 0,8 0,12 9,0 9,0 0))"}},
+				Query:    "select json_search(doc, 'all', 'a|_b', '|'), json_search(doc, 'all', 'a%', null, '$.tags'), json_search(doc, 'all', '0%', null, '$.info', '$.name') from docs where id = 1",
+				Expected: []sql.Row{{sql.MustJSON(`"$.tags[0]"`), sql.MustJSON(`["$.tags[0]", "$.tags[1]"]`), sql.MustJSON(`"$.info.zip"`)}},
 			},
 			{
-				Query:    "ALTER TABLE tab3 MODIFY COLUMN y POLYGON NOT NULL SRID 0",
-				Expected: []sql.Row{{sql.NewOkResult(0)}},
+				Query:       "select json_search(doc, 'any', 'abc') from docs",
+				ExpectedErr: sql.ErrInvalidJSONOneOrAll,
 			},
 			{
-				Query:       "ALTER TABLE tab3 MODIFY COLUMN y POLYGON NOT NULL SRID 4326",
-				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+				Query:    "select id from docs where json_contains_path(doc, 'one', '$.score', '$.info.zip') order by id",
+				Expected: []sql.Row{{1}, {2}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(y) FROM tab3",
-				Expected: []sql.Row{{1, "POLYGON((0 0,8 0,12 9,0 9,0 0))"}},
+				Query:    "select id, json_contains_path(doc, 'all', '$.name', '$.tags') from docs order by id",
+				Expected: []sql.Row{{1, true}, {2, true}, {3, false}, {4, nil}},
 			},
 			{
-				Query:    "ALTER TABLE tab3 MODIFY COLUMN y GEOMETRY NULL SRID 0",
-				Expected: []sql.Row{{sql.NewOkResult(0)}},
+				Query:    "select id from docs where json_overlaps(doc->'$.tags', '[\"abc\", \"xyz\"]') or json_overlaps(doc, '{\"name\": \"bob\"}') order by id",
+				Expected: []sql.Row{{1}, {2}},
 			},
 			{
-				Query:    "select i, ST_ASWKT(y) FROM tab3",
-				Expected: []sql.Row{{1, "POLYGON((0 0,8 0,12 9,0 9,0 0))"}},
+				Query:    "select id, json_value(doc, '$.name'), json_value(doc, '$.info.city') from docs order by id",
+				Expected: []sql.Row{{1, "ann", "oslo"}, {2, "bob", nil}, {3, nil, nil}, {4, nil, nil}},
+			},
+		},
+	},
+	{
+		Name: "json schema validation",
+		SetUpScript: []string{
+			`set @schema = '{"type": "object", "properties": {"latitude": {"type": "number", "minimum": -90, "maximum": 90}, "longitude": {"type": "number", "minimum": -180, "maximum": 180}}, "required": ["latitude", "longitude"]}'`,
+			`create table geo (id int primary key, coordinate json, check (json_schema_valid('{"type": "object", "properties": {"latitude": {"type": "number", "minimum": -90, "maximum": 90}, "longitude": {"type": "number", "minimum": -180, "maximum": 180}}, "required": ["latitude", "longitude"]}', coordinate)))`,
+			`insert into geo values (1, '{"latitude": 59.3, "longitude": 18.1}'), (2, null)`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    `select json_schema_valid(@schema, '{"latitude": 63.4, "longitude": 10.4}'), json_schema_valid(@schema, '{"latitude": 63.4}')`,
+				Expected: []sql.Row{{true, false}},
+			},
+			{
+				Query:    `select json_schema_validation_report(@schema, '{"latitude": 63.4, "longitude": 10.4}')`,
+				Expected: []sql.Row{{sql.MustJSON(`{"valid": true}`)}},
+			},
+			{
+				Query: `select json_schema_validation_report(@schema, '{"latitude": 63.4, "longitude": 310.4}')`,
+				Expected: []sql.Row{{sql.MustJSON(`{
+					"valid": false,
+					"reason": "The JSON document location '#/longitude' failed requirement 'maximum' at JSON Schema location '#/properties/longitude'",
+					"schema-location": "#/properties/longitude",
+					"document-location": "#/longitude",
+					"schema-failed-keyword": "maximum"
+				}`)}},
+			},
+			{
+				Query:       `insert into geo values (3, '{"latitude": 91, "longitude": 0}')`,
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:       `insert into geo values (3, '{"latitude": 10}')`,
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:       `update geo set coordinate = json_set(coordinate, '$.latitude', 'north') where id = 1`,
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:    `insert into geo values (3, '{"latitude": -33.9, "longitude": 151.2}')`,
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select id from geo order by id",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:       `select json_schema_valid('"object"', coordinate) from geo`,
+				ExpectedErr: sql.ErrInvalidJSONType,
+			},
+			{
+				Query:       `select json_schema_valid('{"required": "latitude"}', coordinate) from geo`,
+				ExpectedErr: sql.ErrInvalidJSONSchema,
+			},
+		},
+	},
+	{
+		Name: "json number precision",
+		SetUpScript: []string{
+			"create table accounts (id bigint unsigned primary key, balance decimal(20,4), doc json)",
+			`insert into accounts values (1234567890123456789, '12345678901234.5678', '{"id": 1234567890123456789, "max": 18446744073709551615, "rate": 1.0}')`,
+			"insert into accounts values (18446744073709551615, '1.0000', null)",
+			"update accounts set doc = json_object('id', id, 'balance', balance, 'balances', json_array(balance)) where doc is null",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select cast(doc as char) from accounts order by id",
+				Expected: []sql.Row{
+					{`{"id":1234567890123456789,"max":18446744073709551615,"rate":1.0}`},
+					{`{"balance":1.0000,"balances":[1.0000],"id":18446744073709551615}`},
+				},
+			},
+			{
+				Query:    "select doc->>'$.id', json_type(doc->'$.id'), json_type(doc->'$.max'), json_type(doc->'$.rate') from accounts where id = 1234567890123456789",
+				Expected: []sql.Row{{"1234567890123456789", "INTEGER", "UNSIGNED INTEGER", "DOUBLE"}},
+			},
+			{
+				Query:    "select doc->>'$.balance', json_type(doc->'$.balance'), json_type(doc->'$.id') from accounts where id = 18446744073709551615",
+				Expected: []sql.Row{{"1.0000", "DECIMAL", "UNSIGNED INTEGER"}},
+			},
+			{
+				Query:    "select cast(json_array(balance, id) as char) from accounts order by id",
+				Expected: []sql.Row{{"[12345678901234.5678,1234567890123456789]"}, {"[1.0000,18446744073709551615]"}},
+			},
+			{
+				Query:    "select id from accounts where doc->'$.id' = id order by id",
+				Expected: []sql.Row{{uint64(1234567890123456789)}, {uint64(18446744073709551615)}},
+			},
+			{
+				Query:    "select count(*) from accounts where doc->'$.id' = 1234567890123456788",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select cast(doc->'$.id' as unsigned), cast(json_extract(doc, '$.balance') as decimal(20,4)) from accounts where id = 18446744073709551615",
+				Expected: []sql.Row{{uint64(18446744073709551615), "1.0000"}},
+			},
+			{
+				Query:    "select a from json_table('[{\"a\": 1234567890123456789}]', '$[*]' columns(a bigint path '$.a')) t",
+				Expected: []sql.Row{{1234567890123456789}},
+			},
+			{
+				Query:    `select json_type('1'), json_type('1.0'), json_type('[]'), json_type('{}'), json_type('"a"'), json_type('null'), json_type('true'), json_type(null)`,
+				Expected: []sql.Row{{"INTEGER", "DOUBLE", "ARRAY", "OBJECT", "STRING", "NULL", "BOOLEAN", nil}},
+			},
+			{
+				Query:       "select json_type('{')",
+				ExpectedErr: sql.ErrInvalidJSONText,
+			},
+		},
+	},
+	{
+		Name: "join using",
+		SetUpScript: []string{
+			"create table t1 (id int primary key, a varchar(10))",
+			"create table t2 (id int primary key, b varchar(10))",
+			"create table t3 (id int primary key, c int)",
+			"insert into t1 values (1, 'a1'), (2, 'a2'), (3, 'a3')",
+			"insert into t2 values (2, 'b2'), (3, 'b3'), (4, 'b4')",
+			"insert into t3 values (3, 30), (4, 40)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select * from t1 join t2 using (id) order by id",
+				Expected: []sql.Row{{2, "a2", "b2"}, {3, "a3", "b3"}},
+			},
+			{
+				Query:    "select * from t1 left join t2 using (id) order by id",
+				Expected: []sql.Row{{1, "a1", nil}, {2, "a2", "b2"}, {3, "a3", "b3"}},
+			},
+			{
+				Query:    "select * from t1 right join t2 using (id) order by id",
+				Expected: []sql.Row{{2, "b2", "a2"}, {3, "b3", "a3"}, {4, "b4", nil}},
+			},
+			{
+				Query:    "select id, t1.id, t2.id from t1 left join t2 using (id) order by id",
+				Expected: []sql.Row{{1, 1, nil}, {2, 2, 2}, {3, 3, 3}},
+			},
+			{
+				Query:    "select t1.* from t1 left join t2 using (id) where t2.id is null",
+				Expected: []sql.Row{{1, "a1"}},
+			},
+			{
+				Query:    "select * from t1 join t2 using (id) join t3 using (id)",
+				Expected: []sql.Row{{3, "a3", "b3", 30}},
+			},
+			{
+				Query:    "select id, count(*) from t1 x join t2 y using (id) where id > 2 group by id",
+				Expected: []sql.Row{{3, 1}},
+			},
+			{
+				Query:       "select * from t1 join t2 using (c)",
+				ExpectedErr: sql.ErrUnknownColumn,
+			},
+		},
+	},
+	{
+		Name: "strict and non-strict sql_mode on writes",
+		SetUpScript: []string{
+			"create table t (pk int primary key, ti tinyint, ut tinyint unsigned, s varchar(3), d datetime, i int)",
+			"insert into t values (1, 1, 1, 'a', '2020-01-01', 1)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "insert into t values (2, 300, 1, 'a', '2020-01-01', 1)",
+				ExpectedErr: sql.ErrOutOfRange,
+			},
+			{
+				Query:       "insert into t values (2, 1, 1, 'abcdef', '2020-01-01', 1)",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
+			},
+			{
+				Query:       "update t set ut = -1 where pk = 1",
+				ExpectedErr: sql.ErrOutOfRange,
+			},
+			{
+				Query:    "set sql_mode = ''",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:           "insert into t values (2, 300, -5, 'a', '2020-01-01', 1)",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query:           "insert into t values (3, 1, 1, 'abcdef', '2020-01-01', 1)",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:           "insert into t values (4, 1, 1, 'a', '2020-01-01', 'abc')",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1366,
+			},
+			{
+				Query:           "update t set ti = -1000 where pk = 1",
+				Expected:        []sql.Row{{newUpdateResult(1, 1)}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query: "select pk, ti, ut, s, i from t order by pk",
+				Expected: []sql.Row{
+					{1, -128, uint8(1), "a", 1},
+					{2, 127, uint8(0), "a", 1},
+					{3, 1, uint8(1), "abc", 1},
+					{4, 1, uint8(1), "a", 0},
+				},
+			},
+			{
+				Query:    "set sql_mode = 'TRADITIONAL'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:       "insert into t values (5, 1, 1, 'a', 'not a date', 1)",
+				ExpectedErr: sql.ErrConvertingToTime,
+			},
+			{
+				Query:           "insert ignore into t values (5, 1, 1000, 'a', '2020-01-01', 1)",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query:    "select pk, ut from t where pk = 5",
+				Expected: []sql.Row{{5, uint8(255)}},
+			},
+		},
+	},
+	{
+		Name: "non-strict sql_mode truncates strings and clamps decimals",
+		SetUpScript: []string{
+			"create table n (pk int primary key, s varchar(3), b varbinary(3), d decimal(4,1))",
+			"set sql_mode = ''",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:           "insert into n (pk, s) values (1, 123456)",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:           "insert into n (pk, s, b) values (2, 'ééééé', 'ééééé')",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:           "insert into n (pk, d) values (3, 999.99)",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query:           "insert into n (pk, d) values (4, -12345)",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1264,
+			},
+			{
+				Query:           "update n set s = 98765 where pk = 3",
+				Expected:        []sql.Row{{newUpdateResult(1, 1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query: "select pk, s, hex(s), hex(b), cast(d as char) from n order by pk",
+				Expected: []sql.Row{
+					{1, "123", "313233", nil, nil},
+					{2, "ééé", "C3A9C3A9C3A9", "C3A9C3", nil},
+					{3, "987", "393837", nil, "999.9"},
+					{4, nil, nil, nil, "-999.9"},
+				},
+			},
+		},
+	},
+	{
+		Name: "ONLY_FULL_GROUP_BY",
+		SetUpScript: []string{
+			"create table t (pk int primary key, a int, b int)",
+			"create table u (pk int primary key, t_pk int, c int)",
+			"insert into t values (1, 1, 1), (2, 1, 2), (3, 2, 3)",
+			"insert into u values (1, 1, 10), (2, 1, 20), (3, 3, 30)",
+			"set sql_mode = 'ONLY_FULL_GROUP_BY'",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "select a, b from t group by a",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:       "select a, b + 1 from t group by a order by a",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:       "select t.pk, u.c, sum(u.c) from t join u on t.pk = u.t_pk group by t.pk",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:       "select * from (select a, b from t group by a) s",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:    "select a, any_value(b) is not null, count(*), max(b) from t group by a order by a",
+				Expected: []sql.Row{{1, true, 2, 2}, {2, true, 1, 3}},
+			},
+			{
+				Query:    "select a + 1 as x, count(*) from t group by a + 1 order by 1",
+				Expected: []sql.Row{{2, 2}, {3, 1}},
+			},
+			{
+				Query:    "select pk, a, b from t group by pk order by pk",
+				Expected: []sql.Row{{1, 1, 1}, {2, 1, 2}, {3, 2, 3}},
+			},
+			{
+				Query:    "select x.pk, x.a, sum(u.c) from t x join u on x.pk = u.t_pk group by x.pk order by 1",
+				Expected: []sql.Row{{1, 1, float64(30)}, {3, 2, float64(30)}},
+			},
+			{
+				Query:    "select a, (select max(c) from u where u.t_pk = t.a) from t group by a order by a",
+				Expected: []sql.Row{{1, 20}, {2, nil}},
+			},
+			{
+				Query:    "select pk, a from t group by pk",
+				Expected: []sql.Row{{1, 1}, {2, 1}, {3, 2}},
+			},
+			{
+				Query:    "select a + 1 from t group by a + 1",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "select a, count(*) from t group by a having count(*) > 1",
+				Expected: []sql.Row{{1, 2}},
+			},
+			{
+				Query:       "select a from t group by a having b > 1",
+				ExpectedErr: sql.ErrNonGroupingFieldUsed,
+			},
+			{
+				Query:       "select a, count(*) from t group by a having b > 1",
+				ExpectedErr: sql.ErrNonGroupingFieldUsed,
+			},
+			{
+				Query:       "select a, count(*) from t group by a having b > 1 order by a",
+				ExpectedErr: sql.ErrNonGroupingFieldUsed,
+			},
+			{
+				Query:       "select sum(b), b from t",
+				ExpectedErr: sql.ErrMixOfGroupFuncAndFields,
+			},
+			{
+				Query:       "select count(*) from t having b > 1",
+				ExpectedErr: sql.ErrNonGroupingFieldUsed,
+			},
+			{
+				Query:    "select sum(b), any_value(a) is not null from t",
+				Expected: []sql.Row{{float64(6), true}},
+			},
+			{
+				Query:    "set sql_mode = ''",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select sum(b), count(a) from t",
+				Expected: []sql.Row{{float64(6), 3}},
+			},
+			{
+				Query:    "select a, count(b) from t group by a order by a",
+				Expected: []sql.Row{{1, 2}, {2, 1}},
+			},
+			{
+				Query:    "select a, b from t where pk = 3 group by a",
+				Expected: []sql.Row{{2, 3}},
+			},
+		},
+	},
+	{
+		Name: "LIKE under NO_BACKSLASH_ESCAPES",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    `select 'a\\b' like 'a\\%', 'a%' like 'a\\%'`,
+				Expected: []sql.Row{{false, true}},
+			},
+			{
+				Query:    "set sql_mode = 'NO_BACKSLASH_ESCAPES'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    `select 'a\\b' like 'a\\%', 'a%' like 'a\\%', 'a_' like 'a|_' escape '|'`,
+				Expected: []sql.Row{{true, false, true}},
+			},
+		},
+	},
+	{
+		Name: "cross joins in correlated subqueries",
+		SetUpScript: []string{
+			"create table xy (x int primary key, y int)",
+			"insert into xy values (1, 10), (2, 20)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    `select x, (select max(a.x + b.y + xy.x) from xy a, xy b) from xy order by x`,
+				Expected: []sql.Row{{1, 23}, {2, 24}},
+			},
+		},
+	},
+	{
+		Name: "FULLTEXT indexes",
+		SetUpScript: []string{
+			"create table articles (id int primary key, title varchar(200), body text, fulltext key ft_tb (title, body))",
+			`insert into articles values
+				(1, 'MySQL Tutorial', 'DBMS stands for DataBase ...'),
+				(2, 'How To Use MySQL Well', 'After you went through a ...'),
+				(3, 'Optimizing MySQL', 'In this tutorial, we show ...'),
+				(4, '1001 MySQL Tricks', '1. Never run mysqld as root. 2. ...'),
+				(5, 'MySQL vs. YourSQL', 'In the following database comparison ...'),
+				(6, 'MySQL Security', 'When configured properly, MySQL ...')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, title from articles where match (title, body) against ('database')",
+				Expected: []sql.Row{{1, "MySQL Tutorial"}, {5, "MySQL vs. YourSQL"}},
+			},
+			{
+				Query:    "select id, round(match (title, body) against ('database'), 6) from articles order by id",
+				Expected: []sql.Row{{1, 0.227645}, {2, 0.0}, {3, 0.0}, {4, 0.0}, {5, 0.227645}, {6, 0.0}},
+			},
+			{
+				Query:    "select id from articles where match (title, body) against ('tutorial security')",
+				Expected: []sql.Row{{6}, {1}, {3}},
+			},
+			{
+				Query:    "select id from articles where match (body, title) against ('+mysql -yoursql' in boolean mode)",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}, {6}},
+			},
+			{
+				Query:    "select id from articles where match (title, body) against ('tutor*' in boolean mode)",
+				Expected: []sql.Row{{1}, {3}},
+			},
+			{
+				Query:    `select id from articles where match (title, body) against ('"database comparison"' in boolean mode)`,
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "select id from articles where match (title, body) against ('+mysql +(security tricks)' in boolean mode)",
+				Expected: []sql.Row{{4}, {6}},
+			},
+			{
+				Query:    "select id from articles a where match (a.title, a.body) against ('tutorial') and id > 1",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "update articles set body = 'Database security' where id = 6",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "delete from articles where id = 1",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select id from articles where match (title, body) against ('database') order by id",
+				Expected: []sql.Row{{5}, {6}},
+			},
+			{
+				Query: "show create table articles",
+				Expected: []sql.Row{{"articles", "CREATE TABLE `articles` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `title` varchar(200),\n" +
+					"  `body` text,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  FULLTEXT KEY `ft_tb` (`title`,`body`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:       "select id from articles where match (title) against ('database')",
+				ExpectedErr: sql.ErrNoFulltextIndex,
+			},
+			{
+				Query:       "create fulltext index ft_id on articles (id)",
+				ExpectedErr: sql.ErrFulltextColumn,
+			},
+			{
+				Query:       "select id from articles where match (title, body) against ('database' with query expansion)",
+				ExpectedErr: sql.ErrUnsupportedFeature,
+			},
+		},
+	},
+	{
+		Name: "functional indexes",
+		SetUpScript: []string{
+			"create table users (id int primary key, email varchar(100), n int, unique key uemail ((lower(email))))",
+			"insert into users values (1, 'A@x.com', 1), (2, 'b@x.com', 2), (3, NULL, 3), (4, NULL, 4)",
+			"create index nidx on users ((n * 2), id)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id from users where lower(email) = 'a@x.com'",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query: "explain select id from users where lower(email) = 'a@x.com'",
+				Expected: []sql.Row{
+					{"Project"},
+					{" ├─ columns: [users.id]"},
+					{" └─ Filter(LOWER(users.email) = 'a@x.com')"},
+					{"     └─ IndexedTableAccess(users)"},
+					{"         ├─ index: [LOWER(users.email)]"},
+					{"         ├─ filters: [{[a@x.com, a@x.com]}]"},
+					{"         └─ columns: [id email]"},
+				},
+			},
+			{
+				Query:    "select id from users u where u.n * 2 > 4 order by id",
+				Expected: []sql.Row{{3}, {4}},
+			},
+			{
+				Query:       "insert into users values (5, 'a@X.COM', 5)",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:       "update users set email = 'B@X.COM' where id = 1",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:    "update users set email = 'c@x.com' where id = 1",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "select id, email from users where lower(email) = 'c@x.com'",
+				Expected: []sql.Row{{1, "c@x.com"}},
+			},
+			{
+				Query: "show create table users",
+				Expected: []sql.Row{{"users", "CREATE TABLE `users` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `email` varchar(100),\n" +
+					"  `n` int,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `nidx` (((`n` * 2)),`id`),\n" +
+					"  UNIQUE KEY `uemail` ((LOWER(`email`)))\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query: "select index_name, seq_in_index, column_name, expression from information_schema.statistics where table_name = 'users' order by 1, 2",
+				Expected: []sql.Row{
+					{"PRIMARY", 1, "id", nil},
+					{"nidx", 1, nil, "(`n` * 2)"},
+					{"nidx", 2, "id", nil},
+					{"uemail", 1, nil, "LOWER(`email`)"},
+				},
+			},
+			{
+				Query:       "create unique index ndup on users ((n - n))",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:       "create index bad on users ((n))",
+				ExpectedErr: sql.ErrFunctionalIndexOnField,
+			},
+			{
+				Query:       "alter table users add primary key ((id + 1))",
+				ExpectedErr: sql.ErrFunctionalIndexPrimaryKey,
+			},
+			{
+				Query:       "create index bad on users ((n + rand()))",
+				ExpectedErr: sql.ErrFunctionalIndexFunctionIsNotAllowed,
+			},
+			{
+				Query:       "create table t (a text, key ((lower(a))))",
+				ExpectedErr: sql.ErrFunctionalIndexOnLob,
+			},
+			{
+				Query:       "create table t (a json, key ((json_extract(a, '$.id'))))",
+				ExpectedErr: sql.ErrFunctionalIndexOnJsonOrGeometryFunction,
+			},
+			{
+				Query:    "create table jt (id int primary key, j json, key k ((cast(json_value(j, '$.a') as unsigned))))",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "insert into jt values (1, '{\"a\": 5}'), (2, '{\"a\": 7}')",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "select id from jt where cast(json_value(j, '$.a') as unsigned) = 7",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:       "create table t (a int, key ((a +)))",
+				ExpectedErr: sql.ErrSyntaxError,
+			},
+			{
+				Query:       "alter table users drop column email",
+				ExpectedErr: sql.ErrDependentByFunctionalIndex,
+			},
+			{
+				Query:       "alter table users rename column n to m",
+				ExpectedErr: sql.ErrDependentByFunctionalIndex,
+			},
+			{
+				Query:       "alter table users change column email mail varchar(100)",
+				ExpectedErr: sql.ErrDependentByFunctionalIndex,
+			},
+			{
+				Query:    "alter table users modify column email varchar(200)",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "alter table users drop index uemail",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "alter table users drop column email",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+		},
+	},
+	{
+		Name: "merge joins",
+		SetUpScript: []string{
+			"create table lt (id int primary key, k int, v int, index (k))",
+			"create table rt (id int primary key, k int, w int, index (k))",
+			"insert into lt values (1, NULL, 0), (2, NULL, 0), (3, 1, 0), (4, 1, 5), (5, 2, 0), (6, 2, 1), (7, 3, 0), (8, 4, 0), (9, 5, 0), (10, 6, 0)," +
+				" (11, 7, 0), (12, 8, 0), (13, 9, 0), (14, 10, 0), (15, 11, 0), (16, 12, 0), (17, 13, 0), (18, 14, 0), (19, 15, 0), (20, 16, 0)",
+			"insert into rt values (1, NULL, 1), (2, 2, 1), (3, 2, 2)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "explain select lt.id, rt.id from lt left join rt on lt.k = rt.k and rt.w > lt.v",
+				Expected: []sql.Row{
+					{"Project"},
+					{" ├─ columns: [lt.id, rt.id]"},
+					{" └─ LeftOuterMergeJoin((lt.k = rt.k) AND (rt.w > lt.v))"},
+					{"     ├─ IndexedTableAccess(lt)"},
+					{"     │   ├─ index: [lt.k]"},
+					{"     │   ├─ filters: [{[NULL, ∞)}]"},
+					{"     │   └─ columns: [id k v]"},
+					{"     └─ IndexedTableAccess(rt)"},
+					{"         ├─ index: [rt.k]"},
+					{"         ├─ filters: [{[NULL, ∞)}]"},
+					{"         └─ columns: [id k w]"},
+				},
+			},
+			{
+				Query: "select lt.id, rt.id from lt left join rt on lt.k = rt.k and rt.w > lt.v",
+				Expected: []sql.Row{
+					{1, nil}, {2, nil}, {3, nil}, {4, nil}, {5, 2}, {5, 3}, {6, 3}, {7, nil}, {8, nil}, {9, nil}, {10, nil},
+					{11, nil}, {12, nil}, {13, nil}, {14, nil}, {15, nil}, {16, nil}, {17, nil}, {18, nil}, {19, nil}, {20, nil},
+				},
+			},
+			{
+				Query:    "select lt.id, rt.id from lt left join rt on lt.k = rt.k and rt.w > lt.v where lt.k < 3",
+				Expected: []sql.Row{{3, nil}, {4, nil}, {5, 2}, {5, 3}, {6, 3}},
+			},
+			{
+				Query: "explain select /*+ JOIN_ORDER(lt, rt) */ lt.id, rt.id from lt join rt on lt.k = rt.k and rt.w > lt.v",
+				Expected: []sql.Row{
+					{"Project"},
+					{" ├─ columns: [lt.id, rt.id]"},
+					{" └─ MergeJoin((lt.k = rt.k) AND (rt.w > lt.v))"},
+					{"     ├─ IndexedTableAccess(lt)"},
+					{"     │   ├─ index: [lt.k]"},
+					{"     │   ├─ filters: [{[NULL, ∞)}]"},
+					{"     │   └─ columns: [id k v]"},
+					{"     └─ IndexedTableAccess(rt)"},
+					{"         ├─ index: [rt.k]"},
+					{"         ├─ filters: [{[NULL, ∞)}]"},
+					{"         └─ columns: [id k w]"},
+				},
+			},
+			{
+				Query:    "select /*+ JOIN_ORDER(lt, rt) */ lt.id, rt.id from lt join rt on lt.k = rt.k and rt.w > lt.v",
+				Expected: []sql.Row{{5, 2}, {5, 3}, {6, 3}},
+			},
+		},
+	},
+}
+
+var SpatialScriptTests = []ScriptTest{
+	{
+		Name: "create table using default point value",
+		SetUpScript: []string{
+			"CREATE TABLE test (i int primary key, p point default (point(123.456, 7.89)));",
+			"insert into test (i) values (0);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select st_aswkt(p) from test",
+				Expected: []sql.Row{{"POINT(123.456 7.89)"}},
+			},
+			{
+				Query:    "show create table test",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `p` point DEFAULT (POINT(123.456, 7.89)),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "describe test",
+				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"p", "point", "YES", "", "(POINT(123.456, 7.89))", ""}},
+			},
+		},
+	},
+	{
+		Name: "create table using default linestring value",
+		SetUpScript: []string{
+			"CREATE TABLE test (i int primary key, l linestring default (linestring(point(1,2), point(3,4))));",
+			"insert into test (i) values (0);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select st_aswkt(l) from test",
+				Expected: []sql.Row{{"LINESTRING(1 2,3 4)"}},
+			},
+			{
+				Query:    "show create table test",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `l` linestring DEFAULT (LINESTRING(POINT(1, 2),POINT(3, 4))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "describe test",
+				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"l", "linestring", "YES", "", "(LINESTRING(POINT(1, 2),POINT(3, 4)))", ""}},
+			},
+		},
+	},
+	{
+		Name: "create table using default polygon value",
+		SetUpScript: []string{
+			"CREATE TABLE test (i int primary key, p polygon default (polygon(linestring(point(0,0), point(1,1), point(2,2), point(0,0)))));",
+			"insert into test (i) values (0);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select st_aswkt(p) from test",
+				Expected: []sql.Row{{"POLYGON((0 0,1 1,2 2,0 0))"}},
+			},
+			{
+				Query:    "show create table test",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `p` polygon DEFAULT (POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0)))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "describe test",
+				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"p", "polygon", "YES", "", "(POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0))))", ""}},
+			},
+		},
+	},
+	{
+		Name: "create geometry table using default point value",
+		SetUpScript: []string{
+			"CREATE TABLE test (i int primary key, g geometry  default (point(123.456, 7.89)));",
+			"insert into test (i) values (0);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select st_aswkt(g) from test",
+				Expected: []sql.Row{{"POINT(123.456 7.89)"}},
+			},
+			{
+				Query:    "show create table test",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `g` geometry DEFAULT (POINT(123.456, 7.89)),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "describe test",
+				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"g", "geometry", "YES", "", "(POINT(123.456, 7.89))", ""}},
+			},
+		},
+	},
+	{
+		Name: "create geometry table using default linestring value",
+		SetUpScript: []string{
+			"CREATE TABLE test (i int primary key, g geometry default (linestring(point(1,2), point(3,4))));",
+			"insert into test (i) values (0);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select st_aswkt(g) from test",
+				Expected: []sql.Row{{"LINESTRING(1 2,3 4)"}},
+			},
+			{
+				Query:    "show create table test",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `g` geometry DEFAULT (LINESTRING(POINT(1, 2),POINT(3, 4))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "describe test",
+				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"g", "geometry", "YES", "", "(LINESTRING(POINT(1, 2),POINT(3, 4)))", ""}},
+			},
+		},
+	},
+	{
+		Name: "create geometry table using default polygon value",
+		SetUpScript: []string{
+			"CREATE TABLE test (i int primary key, g geometry default (polygon(linestring(point(0,0), point(1,1), point(2,2), point(0,0)))));",
+			"insert into test (i) values (0);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select st_aswkt(g) from test",
+				Expected: []sql.Row{{"POLYGON((0 0,1 1,2 2,0 0))"}},
+			},
+			{
+				Query:    "show create table test",
+				Expected: []sql.Row{{"test", "CREATE TABLE `test` (\n  `i` int NOT NULL,\n  `g` geometry DEFAULT (POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0)))),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "describe test",
+				Expected: []sql.Row{{"i", "int", "NO", "PRI", "NULL", ""}, {"g", "geometry", "YES", "", "(POLYGON(LINESTRING(POINT(0, 0),POINT(1, 1),POINT(2, 2),POINT(0, 0))))", ""}},
+			},
+		},
+	},
+	{
+		Name: "create table with NULL default values for geometry types",
+		SetUpScript: []string{
+			"CREATE TABLE null_default (pk int NOT NULL PRIMARY KEY, v1 geometry DEFAULT NULL, v2 linestring DEFAULT NULL, v3 point DEFAULT NULL, v4 polygon DEFAULT NULL)",
+			"insert into null_default(pk) values (0)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select * from null_default",
+				Expected: []sql.Row{{0, nil, nil, nil, nil}},
+			},
+		},
+	},
+	{
+		Name: "create table using SRID value for geometry type",
+		SetUpScript: []string{
+			"CREATE TABLE tab0 (i int primary key, g geometry srid 4326 default (point(1,1)));",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "show create table tab0",
+				Expected: []sql.Row{{"tab0", "CREATE TABLE `tab0` (\n  `i` int NOT NULL,\n  `g` geometry SRID 4326 DEFAULT (POINT(1, 1)),\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "INSERT INTO tab0 VALUES (1, ST_GEOMFROMTEXT(ST_ASWKT(POINT(1,2)), 4326))",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select i, ST_ASWKT(g) FROM tab0",
+				Expected: []sql.Row{{1, "POINT(1 2)"}},
+			},
+			{
+				Query:       "INSERT INTO tab0 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(POINT(2,4))))",
+				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+			},
+			{
+				Query:    "INSERT INTO tab0 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(LINESTRING(POINT(1, 6),POINT(4, 3))), 4326))",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select i, ST_ASWKT(g) FROM tab0",
+				Expected: []sql.Row{{1, "POINT(1 2)"}, {2, "LINESTRING(1 6,4 3)"}},
+			},
+		},
+	},
+	{
+		Name: "create table using SRID value for linestring type",
+		SetUpScript: []string{
+			"CREATE TABLE tab1 (i int primary key, l linestring srid 0);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "show create table tab1",
+				Expected: []sql.Row{{"tab1", "CREATE TABLE `tab1` (\n  `i` int NOT NULL,\n  `l` linestring SRID 0,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "INSERT INTO tab1 VALUES (1, LINESTRING(POINT(0, 0),POINT(2, 2)))",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select i, ST_ASWKT(l) FROM tab1",
+				Expected: []sql.Row{{1, "LINESTRING(0 0,2 2)"}},
+			},
+			{
+				Query:       "INSERT INTO tab1 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(LINESTRING(POINT(1, 6),POINT(4, 3))), 4326))",
+				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+			},
+			{
+				Query:    "select i, ST_ASWKT(l) FROM tab1",
+				Expected: []sql.Row{{1, "LINESTRING(0 0,2 2)"}},
+			},
+		},
+	},
+	{
+		Name: "create table using SRID value for point type",
+		SetUpScript: []string{
+			"CREATE TABLE tab2 (i int primary key);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "ALTER TABLE tab2 ADD COLUMN p POINT NOT NULL SRID 0",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "show create table tab2",
+				Expected: []sql.Row{{"tab2", "CREATE TABLE `tab2` (\n  `i` int NOT NULL,\n  `p` point NOT NULL SRID 0,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "INSERT INTO tab2 VALUES (1, POINT(2, 2))",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select i, ST_ASWKT(p) FROM tab2",
+				Expected: []sql.Row{{1, "POINT(2 2)"}},
+			},
+			{
+				Query:       "INSERT INTO tab2 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(POINT(1, 6)), 4326))",
+				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+			},
+			{
+				Query:    "select i, ST_ASWKT(p) FROM tab2",
+				Expected: []sql.Row{{1, "POINT(2 2)"}},
+			},
+			{
+				Query:    "ALTER TABLE tab2 CHANGE COLUMN p p POINT NOT NULL",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "INSERT INTO tab2 VALUES (2, ST_GEOMFROMTEXT(ST_ASWKT(POINT(1, 6)), 4326))",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select i, ST_ASWKT(p) FROM tab2",
+				Expected: []sql.Row{{1, "POINT(2 2)"}, {2, "POINT(1 6)"}},
+			},
+			{
+				Query:       "ALTER TABLE tab2 CHANGE COLUMN p p POINT NOT NULL SRID 4326",
+				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+			},
+			{
+				Query:    "delete from tab2 where i = 1",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "ALTER TABLE tab2 CHANGE COLUMN p p POINT NOT NULL SRID 4326",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "show create table tab2",
+				Expected: []sql.Row{{"tab2", "CREATE TABLE `tab2` (\n  `i` int NOT NULL,\n  `p` point NOT NULL SRID 4326,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+		},
+	},
+	{
+		Name: "create table using SRID value for polygon type",
+		SetUpScript: []string{
+			"CREATE TABLE tab3 (i int primary key, y polygon NOT NULL);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "show create table tab3",
+				Expected: []sql.Row{{"tab3", "CREATE TABLE `tab3` (\n  `i` int NOT NULL,\n  `y` polygon NOT NULL,\n  PRIMARY KEY (`i`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "INSERT INTO tab3 VALUES (1, polygon(linestring(point(0,0),point(8,0),point(12,9),point(0,9),point(0,0))))",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select i, ST_ASWKT(y) FROM tab3",
+				Expected: []sql.Row{{1, "POLYGON((0 0,8 0,12 9,0 9,0 0))"}},
+			},
+			{
+				Query:    "ALTER TABLE tab3 MODIFY COLUMN y POLYGON NOT NULL SRID 0",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:       "ALTER TABLE tab3 MODIFY COLUMN y POLYGON NOT NULL SRID 4326",
+				ExpectedErr: sql.ErrNotMatchingSRIDWithColName,
+			},
+			{
+				Query:    "select i, ST_ASWKT(y) FROM tab3",
+				Expected: []sql.Row{{1, "POLYGON((0 0,8 0,12 9,0 9,0 0))"}},
+			},
+			{
+				Query:    "ALTER TABLE tab3 MODIFY COLUMN y GEOMETRY NULL SRID 0",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "select i, ST_ASWKT(y) FROM tab3",
+				Expected: []sql.Row{{1, "POLYGON((0 0,8 0,12 9,0 9,0 0))"}},
 			},
 		},
 	},
@@ -2716,6 +3764,134 @@ var SpatialScriptTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "spatial relations and measurements",
+		SetUpScript: []string{
+			"CREATE TABLE shapes (i int primary key, g geometry);",
+			"INSERT INTO shapes VALUES (1, POINT(1, 1)), (2, POINT(5, 5)), (3, ST_GeomFromText('LINESTRING(0 0,4 4)')), (4, ST_GeomFromText('POLYGON((0 0,0 4,4 4,4 0,0 0))')), (5, NULL);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT i FROM shapes WHERE ST_Contains(ST_GeomFromText('POLYGON((0 0,0 4,4 4,4 0,0 0))'), g) ORDER BY i",
+				Expected: []sql.Row{{1}, {3}, {4}},
+			},
+			{
+				Query: "SELECT i, ST_Within(g, ST_GeomFromText('POLYGON((0 0,0 2,2 2,2 0,0 0))')), ST_Intersects(g, ST_GeomFromText('LINESTRING(0 4,4 0)')), ST_Touches(g, POINT(4, 4)) FROM shapes ORDER BY i",
+				Expected: []sql.Row{
+					{1, true, false, false},
+					{2, false, false, false},
+					{3, false, true, true},
+					{4, false, true, true},
+					{5, nil, nil, nil},
+				},
+			},
+			{
+				Query: "SELECT i, ST_Disjoint(g, POINT(5, 5)), ST_Equals(g, ST_GeomFromText('LINESTRING(4 4,2 2,0 0)')), ST_Distance(g, POINT(5, 5)) FROM shapes WHERE i < 5 ORDER BY i",
+				Expected: []sql.Row{
+					{1, true, false, 5.656854249492381},
+					{2, false, false, 0.0},
+					{3, true, true, 1.4142135623730951},
+					{4, true, false, 1.4142135623730951},
+				},
+			},
+			{
+				Query: "SELECT ST_AsText(ST_Envelope(g)), ST_AsText(ST_Centroid(g)), ST_AsText(ST_ConvexHull(g)) FROM shapes WHERE i in (3, 4) ORDER BY i",
+				Expected: []sql.Row{
+					{"POLYGON((0 0,4 0,4 4,0 4,0 0))", "POINT(2 2)", "LINESTRING(0 0,4 4)"},
+					{"POLYGON((0 0,4 0,4 4,0 4,0 0))", "POINT(2 2)", "POLYGON((0 0,0 4,4 4,4 0,0 0))"},
+				},
+			},
+			{
+				Query:    "SELECT ST_Distance(ST_GeomFromText('POINT(0 0)', 4326), ST_GeomFromText('POINT(0 1)', 4326)), ST_Distance_Sphere(POINT(0, 0), POINT(0, 90), 1)",
+				Expected: []sql.Row{{111319.4907932264, 1.5707963267948963}},
+			},
+			{
+				Query:    "SELECT ST_Area(ST_Buffer(POINT(0, 0), 1)) > 3.1 AND ST_Area(ST_Buffer(POINT(0, 0), 1)) < 3.15",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:       "SELECT ST_Contains(ST_GeomFromText('POINT(0 0)', 4326), POINT(0, 0))",
+				ExpectedErr: function.ErrDiffSRIDs,
+			},
+			{
+				Query:       "SELECT ST_Centroid(ST_GeomFromText('POINT(0 0)', 4326))",
+				ExpectedErr: function.ErrNotImplementedForGeographic,
+			},
+			{
+				Query:       "SELECT ST_Distance_Sphere(POINT(0, 100), POINT(0, 0))",
+				ExpectedErr: function.ErrLatitudeOutOfRange,
+			},
+		},
+	},
+	{
+		Name: "spatial index lookups",
+		SetUpScript: []string{
+			"CREATE TABLE geo (i int primary key, g geometry NOT NULL SRID 0, SPATIAL INDEX sg (g));",
+			"INSERT INTO geo VALUES (1, POINT(1, 1)), (2, POINT(5, 5)), (3, ST_GeomFromText('LINESTRING(0 0,10 10)')), (4, ST_GeomFromText('POLYGON((2 2,2 3,3 3,3 2,2 2))')), (5, POINT(100, 100));",
+			"CREATE TABLE nosrid (i int primary key, g geometry NOT NULL, SPATIAL INDEX (g));",
+			"INSERT INTO nosrid VALUES (1, POINT(1, 1));",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "EXPLAIN SELECT i FROM geo WHERE ST_Intersects(g, ST_GeomFromText('POLYGON((0 0,0 4,4 4,4 0,0 0))'))",
+				Expected: []sql.Row{
+					{"Project"},
+					{" ├─ columns: [geo.i]"},
+					{" └─ FilterST_INTERSECTS(geo.g,{0 [{0 [{0 0 0} {0 0 4} {0 4 4} {0 4 0} {0 0 0}]}]})"},
+					{"     └─ IndexedTableAccess(geo)"},
+					{"         ├─ index: [geo.g]"},
+					{"         ├─ box: MBR(0 0,4 4)"},
+					{"         └─ columns: [i g]"},
+				},
+			},
+			{
+				Query:    "SELECT i FROM geo WHERE ST_Intersects(g, ST_GeomFromText('POLYGON((0 0,0 4,4 4,4 0,0 0))')) ORDER BY i",
+				Expected: []sql.Row{{1}, {3}, {4}},
+			},
+			{
+				Query:    "SELECT i FROM geo WHERE MBRContains(ST_GeomFromText('POLYGON((0 0,0 4,4 4,4 0,0 0))'), g) ORDER BY i",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT i FROM geo AS a WHERE ST_Within(a.g, ST_GeomFromText('POLYGON((0 0,0 6,6 6,6 0,0 0))')) ORDER BY i",
+				Expected: []sql.Row{{1}, {2}, {4}},
+			},
+			{
+				Query:    "UPDATE geo SET g = POINT(3, 3) WHERE i = 5",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "DELETE FROM geo WHERE i = 1",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT i FROM geo WHERE MBRIntersects(g, ST_GeomFromText('POLYGON((0 0,0 4,4 4,4 0,0 0))')) ORDER BY i",
+				Expected: []sql.Row{{3}, {4}, {5}},
+			},
+			{
+				Query: "EXPLAIN SELECT i FROM nosrid WHERE ST_Intersects(g, POINT(1, 1))",
+				Expected: []sql.Row{
+					{"Project"},
+					{" ├─ columns: [nosrid.i]"},
+					{" └─ FilterST_INTERSECTS(nosrid.g,{0 1 1})"},
+					{"     └─ Table(nosrid)"},
+					{"         └─ columns: [i g]"},
+				},
+			},
+			{
+				Query:       "CREATE TABLE bad (i int primary key, g geometry, SPATIAL INDEX (g))",
+				ExpectedErr: sql.ErrSpatialIndexNullable,
+			},
+			{
+				Query:       "CREATE TABLE bad (i int primary key, g int NOT NULL, SPATIAL INDEX (g))",
+				ExpectedErr: sql.ErrSpatialIndexColumn,
+			},
+			{
+				Query:       "CREATE TABLE bad (i int primary key, g point NOT NULL, h point NOT NULL, SPATIAL INDEX (g, h))",
+				ExpectedErr: sql.ErrSpatialIndexKeyParts,
+			},
+		},
+	},
 }
 
 var CreateCheckConstraintsScripts = []ScriptTest{
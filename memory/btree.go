@@ -0,0 +1,328 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// btreeDegree is the minimum number of children of every non-root internal node of a btree.
+const btreeDegree = 16
+
+const (
+	btreeMinItems = btreeDegree - 1
+	btreeMaxItems = 2*btreeDegree - 1
+)
+
+// indexEntry is a single entry of an index, holding the values of the index expressions for a row alongside the row.
+type indexEntry struct {
+	key sql.Row
+	row sql.Row
+}
+
+// btree is a B-tree of index entries, ordered by its comparison function. Entries that compare equal are allowed.
+type btree struct {
+	root    *btreeNode
+	length  int
+	compare btreeCompare
+}
+
+// btreeCompare compares two entries, returning a negative number if a sorts before b, a positive number if a sorts
+// after b, and zero if they are equal. A comparison that fails stops the operation on the tree that needed it.
+type btreeCompare func(a, b indexEntry) (int, error)
+
+type btreeNode struct {
+	items    []indexEntry
+	children []*btreeNode
+}
+
+// newBtree returns an empty btree ordered by the given comparison function.
+func newBtree(compare btreeCompare) *btree {
+	return &btree{compare: compare}
+}
+
+// Len returns the number of entries in the tree.
+func (t *btree) Len() int {
+	return t.length
+}
+
+// Insert adds the entry to the tree. Entries that compare equal to an existing entry are placed after it. If a
+// comparison fails, the entry isn't added and the error is returned.
+func (t *btree) Insert(e indexEntry) error {
+	if t.root == nil {
+		t.root = &btreeNode{items: []indexEntry{e}}
+		t.length++
+		return nil
+	}
+	if len(t.root.items) >= btreeMaxItems {
+		oldRoot := t.root
+		t.root = &btreeNode{children: []*btreeNode{oldRoot}}
+		t.root.splitChild(0)
+	}
+	if err := t.root.insert(e, t.compare); err != nil {
+		return err
+	}
+	t.length++
+	return nil
+}
+
+// Delete removes a single entry that compares equal to the one given, returning whether any entry was removed. If a
+// comparison fails, no entry is removed and the error is returned.
+func (t *btree) Delete(e indexEntry) (bool, error) {
+	if t.root == nil {
+		return false, nil
+	}
+	_, removed, err := t.root.remove(e, removeItem, t.compare)
+	if len(t.root.items) == 0 {
+		if len(t.root.children) > 0 {
+			t.root = t.root.children[0]
+		} else {
+			t.root = nil
+		}
+	}
+	if removed {
+		t.length--
+	}
+	return removed, err
+}
+
+// Ascend calls visit on every entry in order, starting at the first entry for which before returns false, until visit
+// returns false or an error. The before function must return true for a prefix of the tree's order and false after.
+func (t *btree) Ascend(before func(indexEntry) bool, visit func(indexEntry) (bool, error)) error {
+	if t.root == nil {
+		return nil
+	}
+	_, err := t.root.ascend(before, visit)
+	return err
+}
+
+func (n *btreeNode) isLeaf() bool {
+	return len(n.children) == 0
+}
+
+// splitChild splits the full child at position i, moving its middle item into this node.
+func (n *btreeNode) splitChild(i int) {
+	child := n.children[i]
+	mid := btreeMaxItems / 2
+	item := child.items[mid]
+
+	right := &btreeNode{items: append([]indexEntry(nil), child.items[mid+1:]...)}
+	if !child.isLeaf() {
+		right.children = append([]*btreeNode(nil), child.children[mid+1:]...)
+		child.children = truncateChildren(child.children, mid+1)
+	}
+	child.items = truncateItems(child.items, mid)
+
+	n.items = append(n.items, indexEntry{})
+	copy(n.items[i+1:], n.items[i:])
+	n.items[i] = item
+	n.children = append(n.children, nil)
+	copy(n.children[i+2:], n.children[i+1:])
+	n.children[i+1] = right
+}
+
+// insert adds the entry to the subtree rooted at this node, which must not be full.
+func (n *btreeNode) insert(e indexEntry, compare btreeCompare) error {
+	i, err := n.search(func(item indexEntry) (bool, error) {
+		cmp, err := compare(item, e)
+		return cmp > 0, err
+	})
+	if err != nil {
+		return err
+	}
+	if n.isLeaf() {
+		n.items = append(n.items, indexEntry{})
+		copy(n.items[i+1:], n.items[i:])
+		n.items[i] = e
+		return nil
+	}
+	if len(n.children[i].items) >= btreeMaxItems {
+		n.splitChild(i)
+		cmp, err := compare(n.items[i], e)
+		if err != nil {
+			return err
+		}
+		if cmp <= 0 {
+			i++
+		}
+	}
+	return n.children[i].insert(e, compare)
+}
+
+// search returns the position of the first item of this node for which f returns true, where f must return false for
+// a prefix of the items and true after, as for sort.Search. The first error returned by f stops the search.
+func (n *btreeNode) search(f func(indexEntry) (bool, error)) (int, error) {
+	var err error
+	i := sort.Search(len(n.items), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var ok bool
+		ok, err = f(n.items[i])
+		return ok
+	})
+	return i, err
+}
+
+type removeType int
+
+const (
+	removeItem removeType = iota
+	removeMin
+	removeMax
+)
+
+// remove removes an entry from the subtree rooted at this node. Every node that is descended into is first grown to
+// hold more than the minimum number of items, so that removing from it never leaves it underfull.
+func (n *btreeNode) remove(e indexEntry, typ removeType, compare btreeCompare) (indexEntry, bool, error) {
+	var i int
+	var found bool
+	switch typ {
+	case removeMax:
+		if n.isLeaf() {
+			out := n.items[len(n.items)-1]
+			n.items = truncateItems(n.items, len(n.items)-1)
+			return out, true, nil
+		}
+		i = len(n.items)
+	case removeMin:
+		if n.isLeaf() {
+			return n.removeItemAt(0), true, nil
+		}
+		i = 0
+	case removeItem:
+		var err error
+		i, err = n.search(func(item indexEntry) (bool, error) {
+			cmp, err := compare(item, e)
+			return cmp >= 0, err
+		})
+		if err != nil {
+			return indexEntry{}, false, err
+		}
+		if i < len(n.items) {
+			cmp, err := compare(n.items[i], e)
+			if err != nil {
+				return indexEntry{}, false, err
+			}
+			found = cmp == 0
+		}
+		if n.isLeaf() {
+			if found {
+				return n.removeItemAt(i), true, nil
+			}
+			return indexEntry{}, false, nil
+		}
+	}
+
+	if len(n.children[i].items) <= btreeMinItems {
+		n.growChild(i)
+		return n.remove(e, typ, compare)
+	}
+	if found {
+		// Replace the item with its predecessor, which is the largest item of the child to its left
+		out := n.items[i]
+		n.items[i], _, _ = n.children[i].remove(indexEntry{}, removeMax, compare)
+		return out, true, nil
+	}
+	return n.children[i].remove(e, typ, compare)
+}
+
+// growChild gives the child at position i an extra item, either by taking one from a sibling or by merging it with a
+// sibling.
+func (n *btreeNode) growChild(i int) {
+	if i > 0 && len(n.children[i-1].items) > btreeMinItems {
+		child, left := n.children[i], n.children[i-1]
+		child.items = append(child.items, indexEntry{})
+		copy(child.items[1:], child.items)
+		child.items[0] = n.items[i-1]
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = truncateItems(left.items, len(left.items)-1)
+		if !left.isLeaf() {
+			child.children = append(child.children, nil)
+			copy(child.children[1:], child.children)
+			child.children[0] = left.children[len(left.children)-1]
+			left.children = truncateChildren(left.children, len(left.children)-1)
+		}
+		return
+	}
+	if i < len(n.items) && len(n.children[i+1].items) > btreeMinItems {
+		child, right := n.children[i], n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.removeItemAt(0)
+		if !right.isLeaf() {
+			child.children = append(child.children, right.children[0])
+			copy(right.children, right.children[1:])
+			right.children = truncateChildren(right.children, len(right.children)-1)
+		}
+		return
+	}
+	if i >= len(n.items) {
+		i--
+	}
+	child, right := n.children[i], n.children[i+1]
+	child.items = append(child.items, n.removeItemAt(i))
+	child.items = append(child.items, right.items...)
+	child.children = append(child.children, right.children...)
+	copy(n.children[i+1:], n.children[i+2:])
+	n.children = truncateChildren(n.children, len(n.children)-1)
+}
+
+// removeItemAt removes and returns the item at position i.
+func (n *btreeNode) removeItemAt(i int) indexEntry {
+	out := n.items[i]
+	copy(n.items[i:], n.items[i+1:])
+	n.items = truncateItems(n.items, len(n.items)-1)
+	return out
+}
+
+// ascend implements btree.Ascend for the subtree rooted at this node. It returns false if the iteration was stopped.
+func (n *btreeNode) ascend(before func(indexEntry) bool, visit func(indexEntry) (bool, error)) (bool, error) {
+	i := sort.Search(len(n.items), func(i int) bool {
+		return !before(n.items[i])
+	})
+	for ; i < len(n.items); i++ {
+		if !n.isLeaf() {
+			if ok, err := n.children[i].ascend(before, visit); !ok || err != nil {
+				return false, err
+			}
+		}
+		if ok, err := visit(n.items[i]); !ok || err != nil {
+			return false, err
+		}
+	}
+	if !n.isLeaf() {
+		return n.children[len(n.items)].ascend(before, visit)
+	}
+	return true, nil
+}
+
+// truncateItems shortens the slice to the given length, clearing the removed items so that their rows can be
+// garbage collected.
+func truncateItems(items []indexEntry, length int) []indexEntry {
+	for i := length; i < len(items); i++ {
+		items[i] = indexEntry{}
+	}
+	return items[:length]
+}
+
+// truncateChildren shortens the slice to the given length, clearing the removed children.
+func truncateChildren(children []*btreeNode, length int) []*btreeNode {
+	for i := length; i < len(children); i++ {
+		children[i] = nil
+	}
+	return children[:length]
+}
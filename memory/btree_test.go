@@ -0,0 +1,116 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestBtree(t *testing.T) {
+	require := require.New(t)
+
+	compare := func(a, b indexEntry) (int, error) {
+		return int(a.key[0].(int64) - b.key[0].(int64)), nil
+	}
+	entry := func(i int64) indexEntry {
+		return indexEntry{key: sql.Row{i}}
+	}
+	keys := func(tree *btree, from int64) []int64 {
+		var keys []int64
+		err := tree.Ascend(func(e indexEntry) bool {
+			return e.key[0].(int64) < from
+		}, func(e indexEntry) (bool, error) {
+			keys = append(keys, e.key[0].(int64))
+			return true, nil
+		})
+		require.NoError(err)
+		return keys
+	}
+	remove := func(tree *btree, val int64) bool {
+		removed, err := tree.Delete(entry(val))
+		require.NoError(err)
+		return removed
+	}
+
+	r := rand.New(rand.NewSource(1))
+	tree := newBtree(compare)
+	var expected []int64
+	for i := 0; i < 20000; i++ {
+		// Values are drawn from a small domain, so that there are many duplicates
+		val := r.Int63n(1000)
+		if r.Intn(3) == 0 {
+			removed := remove(tree, val)
+			idx := sort.Search(len(expected), func(i int) bool { return expected[i] >= val })
+			if idx < len(expected) && expected[idx] == val {
+				require.True(removed)
+				expected = append(expected[:idx], expected[idx+1:]...)
+			} else {
+				require.False(removed)
+			}
+		} else {
+			require.NoError(tree.Insert(entry(val)))
+			idx := sort.Search(len(expected), func(i int) bool { return expected[i] > val })
+			expected = append(expected, 0)
+			copy(expected[idx+1:], expected[idx:])
+			expected[idx] = val
+		}
+		require.Equal(len(expected), tree.Len())
+	}
+
+	require.Equal(expected, keys(tree, 0))
+	from := sort.Search(len(expected), func(i int) bool { return expected[i] >= 500 })
+	require.Equal(expected[from:], keys(tree, 500))
+
+	for len(expected) > 0 {
+		val := expected[r.Intn(len(expected))]
+		require.True(remove(tree, val))
+		idx := sort.Search(len(expected), func(i int) bool { return expected[i] >= val })
+		expected = append(expected[:idx], expected[idx+1:]...)
+	}
+	require.Equal(0, tree.Len())
+	require.Empty(keys(tree, 0))
+}
+
+func TestBtreeCompareError(t *testing.T) {
+	require := require.New(t)
+
+	errCompare := errors.New("cannot compare")
+	compare := func(a, b indexEntry) (int, error) {
+		if a.key[0] == nil || b.key[0] == nil {
+			return 0, errCompare
+		}
+		return int(a.key[0].(int64) - b.key[0].(int64)), nil
+	}
+
+	tree := newBtree(compare)
+	for i := int64(0); i < 100; i++ {
+		require.NoError(tree.Insert(indexEntry{key: sql.Row{i}}))
+	}
+
+	require.Equal(errCompare, tree.Insert(indexEntry{key: sql.Row{nil}}))
+	require.Equal(100, tree.Len())
+
+	removed, err := tree.Delete(indexEntry{key: sql.Row{nil}})
+	require.Equal(errCompare, err)
+	require.False(removed)
+	require.Equal(100, tree.Len())
+}
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/transform"
 
 	"github.com/dolthub/go-mysql-server/sql"
 )
@@ -208,8 +209,12 @@ func (d *BaseDatabase) RenameTable(ctx *sql.Context, oldName, newName string) er
 	for _, index := range memTbl.indexes {
 		memIndex := index.(*Index)
 		for i, expr := range memIndex.Exprs {
-			getField := expr.(*expression.GetField)
-			memIndex.Exprs[i] = expression.NewGetFieldWithTable(i, getField.Type(), newName, getField.Name(), getField.IsNullable())
+			memIndex.Exprs[i], _, _ = transform.Expr(expr, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+				if getField, ok := e.(*expression.GetField); ok {
+					return expression.NewGetFieldWithTable(getField.Index(), getField.Type(), newName, getField.Name(), getField.IsNullable()), transform.NewTree, nil
+				}
+				return e, transform.SameTree, nil
+			})
 		}
 	}
 	d.tables[newName] = tbl
@@ -0,0 +1,539 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// FulltextIndex is a FULLTEXT index of a memory table. Tables keep their FULLTEXT indexes as an Index with Fulltext
+// set, which GetIndexes wraps in a FulltextIndex bound to the table, so that relevance is always scored against the
+// rows of the table being read.
+type FulltextIndex struct {
+	idx *Index
+}
+
+var _ sql.FulltextIndex = (*FulltextIndex)(nil)
+
+func (f *FulltextIndex) ID() string            { return f.idx.ID() }
+func (f *FulltextIndex) Database() string      { return f.idx.Database() }
+func (f *FulltextIndex) Table() string         { return f.idx.Table() }
+func (f *FulltextIndex) Expressions() []string { return f.idx.Expressions() }
+func (f *FulltextIndex) IsUnique() bool        { return false }
+func (f *FulltextIndex) Comment() string       { return f.idx.Comment() }
+func (f *FulltextIndex) IndexType() string     { return "FULLTEXT" }
+func (f *FulltextIndex) IsGenerated() bool     { return false }
+func (f *FulltextIndex) ColumnExpressionTypes() []sql.ColumnExpressionType {
+	return f.idx.ColumnExpressionTypes()
+}
+func (f *FulltextIndex) CanSupport(...sql.Range) bool { return false }
+
+// Relevance implements the interface sql.FulltextIndex.
+func (f *FulltextIndex) Relevance(ctx *sql.Context, search sql.FulltextSearch, values sql.Row) (float64, error) {
+	return f.idx.Tbl.indexData.relevance(ctx, f.idx.Tbl, f.idx, search, values)
+}
+
+// The words that are left out of FULLTEXT indexes and searches, which are those of InnoDB's default stopword list.
+var fulltextStopwords = map[string]struct{}{
+	"a": {}, "about": {}, "an": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "com": {}, "de": {}, "en": {},
+	"for": {}, "from": {}, "how": {}, "i": {}, "in": {}, "is": {}, "it": {}, "la": {}, "of": {}, "on": {}, "or": {},
+	"that": {}, "the": {}, "this": {}, "to": {}, "was": {}, "what": {}, "when": {}, "where": {}, "who": {}, "will": {},
+	"with": {}, "und": {}, "www": {},
+}
+
+// The bounds on the length of indexed words, which are the defaults of innodb_ft_min_token_size and
+// innodb_ft_max_token_size.
+const (
+	fulltextMinWordLen = 3
+	fulltextMaxWordLen = 84
+)
+
+func isFulltextWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isFulltextWord returns whether the word is indexed, rather than being too short, too long or a stopword.
+func isFulltextWord(word string) bool {
+	n := utf8.RuneCountInString(word)
+	if n < fulltextMinWordLen || n > fulltextMaxWordLen {
+		return false
+	}
+	_, stopword := fulltextStopwords[word]
+	return !stopword
+}
+
+// fulltextWords returns the indexed words of the text, in order. Words are compared without regard to case.
+func fulltextWords(text string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isFulltextWordRune(r)
+	}) {
+		if isFulltextWord(word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// fulltextDocument holds the indexed words of a row.
+type fulltextDocument struct {
+	words []string
+	freqs map[string]int
+}
+
+func newFulltextDocument(values sql.Row) (*fulltextDocument, error) {
+	doc := &fulltextDocument{freqs: make(map[string]int)}
+	for _, val := range values {
+		if val == nil {
+			continue
+		}
+		text, err := sql.LongText.Convert(val)
+		if err != nil {
+			return nil, err
+		}
+		for _, word := range fulltextWords(text.(string)) {
+			doc.words = append(doc.words, word)
+			doc.freqs[word]++
+		}
+	}
+	return doc, nil
+}
+
+// fulltextOp is the operator of a clause of a search, which determines how the clause affects whether a row
+// matches, and how it contributes to the relevance of a row.
+type fulltextOp byte
+
+const (
+	fulltextOptional  fulltextOp = iota // no operator
+	fulltextRequired                    // +
+	fulltextExcluded                    // -
+	fulltextNegated                     // ~
+	fulltextIncreased                   // >
+	fulltextDecreased                   // <
+)
+
+// weight returns the factor that the relevance of a clause is multiplied by.
+func (op fulltextOp) weight() float64 {
+	switch op {
+	case fulltextNegated:
+		return -1
+	case fulltextIncreased:
+		return 1.5
+	case fulltextDecreased:
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+// fulltextClause is a single clause of a search, which is either a word, a prefix, a phrase, or a group of clauses.
+type fulltextClause struct {
+	op     fulltextOp
+	word   string
+	prefix bool
+	phrase []string
+	group  []fulltextClause
+}
+
+// parseFulltextSearch returns the clauses of the search. A search in natural language mode is a group of optional
+// words, while a search in boolean mode is parsed for its operators.
+func parseFulltextSearch(search sql.FulltextSearch) []fulltextClause {
+	if search.Mode != sql.FulltextBooleanMode {
+		var clauses []fulltextClause
+		seen := make(map[string]struct{})
+		for _, word := range fulltextWords(search.Query) {
+			if _, ok := seen[word]; !ok {
+				seen[word] = struct{}{}
+				clauses = append(clauses, fulltextClause{word: word})
+			}
+		}
+		return clauses
+	}
+	p := &fulltextParser{query: []rune(strings.ToLower(search.Query))}
+	return p.parseGroup()
+}
+
+type fulltextParser struct {
+	query []rune
+	pos   int
+}
+
+// parseGroup parses clauses until the end of the query or the end of the current group.
+func (p *fulltextParser) parseGroup() []fulltextClause {
+	var clauses []fulltextClause
+	op := fulltextOptional
+	for p.pos < len(p.query) {
+		r := p.query[p.pos]
+		switch {
+		case r == '+':
+			op = fulltextRequired
+			p.pos++
+		case r == '-':
+			op = fulltextExcluded
+			p.pos++
+		case r == '~':
+			op = fulltextNegated
+			p.pos++
+		case r == '>':
+			op = fulltextIncreased
+			p.pos++
+		case r == '<':
+			op = fulltextDecreased
+			p.pos++
+		case r == '(':
+			p.pos++
+			if group := p.parseGroup(); len(group) > 0 {
+				clauses = append(clauses, fulltextClause{op: op, group: group})
+			}
+			op = fulltextOptional
+		case r == ')':
+			p.pos++
+			return clauses
+		case r == '"':
+			p.pos++
+			end := p.pos
+			for end < len(p.query) && p.query[end] != '"' {
+				end++
+			}
+			if words := fulltextWords(string(p.query[p.pos:end])); len(words) > 0 {
+				clauses = append(clauses, fulltextClause{op: op, phrase: words})
+			}
+			p.pos = end + 1
+			op = fulltextOptional
+		case isFulltextWordRune(r):
+			start := p.pos
+			for p.pos < len(p.query) && isFulltextWordRune(p.query[p.pos]) {
+				p.pos++
+			}
+			word := string(p.query[start:p.pos])
+			prefix := p.pos < len(p.query) && p.query[p.pos] == '*'
+			if prefix {
+				p.pos++
+			}
+			if prefix || isFulltextWord(word) {
+				clauses = append(clauses, fulltextClause{op: op, word: word, prefix: prefix})
+			}
+			op = fulltextOptional
+		default:
+			op = fulltextOptional
+			p.pos++
+		}
+	}
+	return clauses
+}
+
+// invertedIndex is the structure of a FULLTEXT index, which maps each word of the indexed columns to the rows that
+// contain it. Rows are identified by their hash, and identical rows of keyless tables share a single entry.
+type invertedIndex struct {
+	exprs   []sql.Expression
+	entries map[uint64]*invertedEntry
+	words   map[string]map[uint64]struct{}
+	rows    int
+	nextSeq uint64
+}
+
+// invertedEntry is the entry of an inverted index for a row.
+type invertedEntry struct {
+	*fulltextDocument
+	row sql.Row
+	// count is the number of identical rows that share the entry
+	count int
+	// seq orders entries by insertion, which is the order of rows that are equally relevant to a search
+	seq uint64
+}
+
+// newInvertedIndex builds the structure of the given FULLTEXT index from the rows of the table.
+func newInvertedIndex(ctx *sql.Context, table *Table, idx *Index) (*invertedIndex, error) {
+	inv := &invertedIndex{
+		exprs:   idx.Exprs,
+		entries: make(map[uint64]*invertedEntry),
+		words:   make(map[string]map[uint64]struct{}),
+	}
+	for _, key := range table.partitionKeys {
+		for _, row := range table.partitions[string(key)] {
+			if err := inv.insert(ctx, row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return inv, nil
+}
+
+func (inv *invertedIndex) document(ctx *sql.Context, row sql.Row) (*fulltextDocument, error) {
+	values := make(sql.Row, len(inv.exprs))
+	for i, expr := range inv.exprs {
+		var err error
+		values[i], err = expr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newFulltextDocument(values)
+}
+
+func (inv *invertedIndex) insert(ctx *sql.Context, row sql.Row) error {
+	hash, err := sql.HashOf(row)
+	if err != nil {
+		return err
+	}
+	inv.rows++
+	if entry, ok := inv.entries[hash]; ok {
+		entry.count++
+		return nil
+	}
+	doc, err := inv.document(ctx, row)
+	if err != nil {
+		return err
+	}
+	inv.entries[hash] = &invertedEntry{fulltextDocument: doc, row: row, count: 1, seq: inv.nextSeq}
+	inv.nextSeq++
+	for word := range doc.freqs {
+		if inv.words[word] == nil {
+			inv.words[word] = make(map[uint64]struct{})
+		}
+		inv.words[word][hash] = struct{}{}
+	}
+	return nil
+}
+
+func (inv *invertedIndex) remove(ctx *sql.Context, row sql.Row) error {
+	hash, err := sql.HashOf(row)
+	if err != nil {
+		return err
+	}
+	entry, ok := inv.entries[hash]
+	if !ok {
+		return nil
+	}
+	inv.rows--
+	if entry.count--; entry.count > 0 {
+		return nil
+	}
+	delete(inv.entries, hash)
+	for word := range entry.freqs {
+		delete(inv.words[word], hash)
+		if len(inv.words[word]) == 0 {
+			delete(inv.words, word)
+		}
+	}
+	return nil
+}
+
+// idf returns the inverse document frequency of the word, which is how rare the word is among the rows of the table.
+// As in InnoDB, a word that is in every row is given a small positive value, so that rows that match a search always
+// have a positive relevance.
+func (inv *invertedIndex) idf(word string) float64 {
+	var freq int
+	for hash := range inv.words[word] {
+		freq += inv.entries[hash].count
+	}
+	// The document being scored may not have been indexed yet
+	if freq < 1 {
+		freq = 1
+	}
+	total := inv.rows
+	if total <= freq {
+		return math.Log10(1.0001)
+	}
+	return math.Log10(float64(total) / float64(freq))
+}
+
+// scoreGroup returns the relevance of the document to a group of clauses, and whether the document matches the group.
+// A document matches a group when it matches every required clause and no excluded clause, and, if there are no
+// required clauses, at least one of the other clauses.
+func (inv *invertedIndex) scoreGroup(clauses []fulltextClause, doc *fulltextDocument) (float64, bool) {
+	var score float64
+	var hasRequired, matchedOptional bool
+	for _, clause := range clauses {
+		s, ok := inv.scoreClause(clause, doc)
+		switch clause.op {
+		case fulltextExcluded:
+			if ok {
+				return 0, false
+			}
+		case fulltextRequired:
+			if !ok {
+				return 0, false
+			}
+			hasRequired = true
+			score += s
+		default:
+			if ok {
+				matchedOptional = true
+				score += clause.op.weight() * s
+			}
+		}
+	}
+	if !hasRequired && !matchedOptional {
+		return 0, false
+	}
+	return score, true
+}
+
+// scoreClause returns the relevance of the document to a single clause, and whether the document matches it. The
+// relevance of a word is its frequency in the document multiplied by the square of its inverse document frequency.
+func (inv *invertedIndex) scoreClause(clause fulltextClause, doc *fulltextDocument) (float64, bool) {
+	switch {
+	case clause.group != nil:
+		return inv.scoreGroup(clause.group, doc)
+	case clause.phrase != nil:
+		var occurrences int
+		for i := 0; i+len(clause.phrase) <= len(doc.words); i++ {
+			if wordsEqual(doc.words[i:i+len(clause.phrase)], clause.phrase) {
+				occurrences++
+			}
+		}
+		if occurrences == 0 {
+			return 0, false
+		}
+		var score float64
+		for _, word := range clause.phrase {
+			idf := inv.idf(word)
+			score += float64(occurrences) * idf * idf
+		}
+		return score, true
+	case clause.prefix:
+		var score float64
+		var matched bool
+		for word, freq := range doc.freqs {
+			if strings.HasPrefix(word, clause.word) {
+				idf := inv.idf(word)
+				score += float64(freq) * idf * idf
+				matched = true
+			}
+		}
+		return score, matched
+	default:
+		freq := doc.freqs[clause.word]
+		if freq == 0 {
+			return 0, false
+		}
+		idf := inv.idf(clause.word)
+		return float64(freq) * idf * idf, true
+	}
+}
+
+func wordsEqual(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// candidates returns the hashes of the rows that might match the clauses, using the index to narrow them down where
+// the clauses allow it.
+func (inv *invertedIndex) candidates(clauses []fulltextClause) map[uint64]struct{} {
+	for _, clause := range clauses {
+		if clause.op == fulltextRequired && clause.word != "" && !clause.prefix {
+			return inv.words[clause.word]
+		}
+	}
+	candidates := make(map[uint64]struct{})
+	for _, clause := range clauses {
+		if clause.op == fulltextExcluded {
+			continue
+		}
+		if clause.word == "" || clause.prefix {
+			for hash := range inv.entries {
+				candidates[hash] = struct{}{}
+			}
+			return candidates
+		}
+		for hash := range inv.words[clause.word] {
+			candidates[hash] = struct{}{}
+		}
+	}
+	return candidates
+}
+
+// lookup returns the rows that match the search. As in MySQL, the rows of a search in natural language mode are
+// returned in order of decreasing relevance.
+func (inv *invertedIndex) lookup(search sql.FulltextSearch) []sql.Row {
+	clauses := parseFulltextSearch(search)
+	type match struct {
+		entry *invertedEntry
+		score float64
+	}
+	var matches []match
+	for hash := range inv.candidates(clauses) {
+		entry := inv.entries[hash]
+		if score, ok := inv.scoreGroup(clauses, entry.fulltextDocument); ok {
+			matches = append(matches, match{entry: entry, score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if search.Mode != sql.FulltextBooleanMode && matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].entry.seq < matches[j].entry.seq
+	})
+
+	var rows []sql.Row
+	for _, m := range matches {
+		for i := 0; i < m.entry.count; i++ {
+			rows = append(rows, m.entry.row)
+		}
+	}
+	return rows
+}
+
+// invertedIndex returns the structure of the FULLTEXT index, building it if this is the first time that the index is
+// used. The caller must hold the lock of the index data.
+func (d *indexData) invertedIndex(ctx *sql.Context, table *Table, idx *Index) (*invertedIndex, error) {
+	if inv, ok := d.inverted[idx.ID()]; ok {
+		return inv, nil
+	}
+	inv, err := newInvertedIndex(ctx, table, idx)
+	if err != nil {
+		return nil, err
+	}
+	d.inverted[idx.ID()] = inv
+	return inv, nil
+}
+
+// fulltextLookup returns the rows of the table that match the search of the FULLTEXT index.
+func (d *indexData) fulltextLookup(ctx *sql.Context, table *Table, idx *Index, search sql.FulltextSearch) ([]sql.Row, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	inv, err := d.invertedIndex(ctx, table, idx)
+	if err != nil {
+		return nil, err
+	}
+	return inv.lookup(search), nil
+}
+
+// relevance returns the relevance to the search of a row with the given values of the columns of the FULLTEXT index.
+func (d *indexData) relevance(ctx *sql.Context, table *Table, idx *Index, search sql.FulltextSearch, values sql.Row) (float64, error) {
+	doc, err := newFulltextDocument(values)
+	if err != nil {
+		return 0, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	inv, err := d.invertedIndex(ctx, table, idx)
+	if err != nil {
+		return 0, err
+	}
+	score, _ := inv.scoreGroup(parseFulltextSearch(search), doc)
+	return score, nil
+}
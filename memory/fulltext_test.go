@@ -0,0 +1,73 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestFulltextWords(t *testing.T) {
+	require.Equal(t, []string{"mysql", "tutorial", "database", "o_o", "ünïcode"},
+		fulltextWords("MySQL Tutorial: the DataBase of a-b o_o ÜNÏCODE"))
+	require.Empty(t, fulltextWords("it is to be or with the"))
+}
+
+func TestParseFulltextSearch(t *testing.T) {
+	tests := []struct {
+		search   sql.FulltextSearch
+		expected []fulltextClause
+	}{
+		{
+			search: sql.FulltextSearch{Query: "MySQL tutorial mysql with the"},
+			expected: []fulltextClause{
+				{word: "mysql"},
+				{word: "tutorial"},
+			},
+		},
+		{
+			search: sql.FulltextSearch{Query: "+mysql -yoursql ~tricks", Mode: sql.FulltextBooleanMode},
+			expected: []fulltextClause{
+				{op: fulltextRequired, word: "mysql"},
+				{op: fulltextExcluded, word: "yoursql"},
+				{op: fulltextNegated, word: "tricks"},
+			},
+		},
+		{
+			search: sql.FulltextSearch{Query: `tut* "database comparison" +(>security <tricks)`, Mode: sql.FulltextBooleanMode},
+			expected: []fulltextClause{
+				{word: "tut", prefix: true},
+				{phrase: []string{"database", "comparison"}},
+				{op: fulltextRequired, group: []fulltextClause{
+					{op: fulltextIncreased, word: "security"},
+					{op: fulltextDecreased, word: "tricks"},
+				}},
+			},
+		},
+		{
+			search:   sql.FulltextSearch{Query: `+ () "the"`, Mode: sql.FulltextBooleanMode},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.search.Query, func(t *testing.T) {
+			require.Equal(t, test.expected, parseFulltextSearch(test.search))
+		})
+	}
+}
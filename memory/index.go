@@ -17,6 +17,7 @@ package memory
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -33,6 +34,8 @@ type Index struct {
 	Exprs      []sql.Expression
 	Name       string
 	Unique     bool
+	Fulltext   bool
+	Spatial    bool
 	CommentStr string
 }
 
@@ -73,88 +76,6 @@ func (idx *Index) IndexType() string {
 	return "BTREE" // fake but so are you
 }
 
-// NewLookup implements the interface sql.Index.
-func (idx *Index) rangeFilterExpr(ranges ...sql.Range) (sql.Expression, error) {
-	if idx.CommentStr == CommentPreventingIndexBuilding {
-		return nil, nil
-	}
-	if len(ranges) == 0 {
-		return nil, nil
-	}
-	if len(ranges[0]) != len(idx.Exprs) {
-		return nil, fmt.Errorf("expected different key count: %s=>%d/%d", idx.Name, len(idx.Exprs), len(ranges[0]))
-	}
-
-	var rangeCollectionExpr sql.Expression
-	for _, rang := range ranges {
-		var rangeExpr sql.Expression
-		for i, rce := range rang {
-			var rangeColumnExpr sql.Expression
-			switch rce.Type() {
-			// Both Empty and All may seem like strange inclusions, but if only one range is given we need some
-			// expression to evaluate, otherwise our expression would be a nil expression which would panic.
-			case sql.RangeType_Empty:
-				rangeColumnExpr = expression.NewEquals(expression.NewLiteral(1, sql.Int8), expression.NewLiteral(2, sql.Int8))
-			case sql.RangeType_All:
-				rangeColumnExpr = expression.NewEquals(expression.NewLiteral(1, sql.Int8), expression.NewLiteral(1, sql.Int8))
-			case sql.RangeType_EqualNull:
-				rangeColumnExpr = expression.NewIsNull(idx.Exprs[i])
-			case sql.RangeType_GreaterThan:
-				if sql.RangeCutIsBinding(rce.LowerBound) {
-					rangeColumnExpr = expression.NewGreaterThan(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.LowerBound), rce.Typ.Promote()))
-				} else {
-					rangeColumnExpr = expression.NewNot(expression.NewIsNull(idx.Exprs[i]))
-				}
-			case sql.RangeType_GreaterOrEqual:
-				rangeColumnExpr = expression.NewGreaterThanOrEqual(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.LowerBound), rce.Typ.Promote()))
-			case sql.RangeType_LessThanOrNull:
-				rangeColumnExpr = or(
-					expression.NewLessThan(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote())),
-					expression.NewIsNull(idx.Exprs[i]),
-				)
-			case sql.RangeType_LessOrEqualOrNull:
-				rangeColumnExpr = or(
-					expression.NewLessThanOrEqual(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote())),
-					expression.NewIsNull(idx.Exprs[i]),
-				)
-			case sql.RangeType_ClosedClosed:
-				rangeColumnExpr = and(
-					expression.NewGreaterThanOrEqual(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.LowerBound), rce.Typ.Promote())),
-					expression.NewLessThanOrEqual(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote())),
-				)
-			case sql.RangeType_OpenOpen:
-				if sql.RangeCutIsBinding(rce.LowerBound) {
-					rangeColumnExpr = and(
-						expression.NewGreaterThan(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.LowerBound), rce.Typ.Promote())),
-						expression.NewLessThan(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote())),
-					)
-				} else {
-					// Lower bound is (NULL, ...)
-					rangeColumnExpr = expression.NewLessThan(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote()))
-				}
-			case sql.RangeType_OpenClosed:
-				if sql.RangeCutIsBinding(rce.LowerBound) {
-					rangeColumnExpr = and(
-						expression.NewGreaterThan(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.LowerBound), rce.Typ.Promote())),
-						expression.NewLessThanOrEqual(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote())),
-					)
-				} else {
-					// Lower bound is (NULL, ...]
-					rangeColumnExpr = expression.NewLessThanOrEqual(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote()))
-				}
-			case sql.RangeType_ClosedOpen:
-				rangeColumnExpr = and(
-					expression.NewGreaterThanOrEqual(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.LowerBound), rce.Typ.Promote())),
-					expression.NewLessThan(idx.Exprs[i], expression.NewLiteral(sql.GetRangeCutKey(rce.UpperBound), rce.Typ.Promote())),
-				)
-			}
-			rangeExpr = and(rangeExpr, rangeColumnExpr)
-		}
-		rangeCollectionExpr = or(rangeCollectionExpr, rangeExpr)
-	}
-	return rangeCollectionExpr, nil
-}
-
 // ColumnExpressionTypes implements the interface sql.Index.
 func (idx *Index) ColumnExpressionTypes() []sql.ColumnExpressionType {
 	cets := make([]sql.ColumnExpressionType, len(idx.Exprs))
@@ -253,22 +174,278 @@ func (idx *Index) Order() sql.IndexOrder {
 	return sql.IndexOrderAsc
 }
 
-func or(expressions ...sql.Expression) sql.Expression {
-	if len(expressions) == 1 {
-		return expressions[0]
+// indexData holds the ordered structures that back the indexes of a table, the inverted indexes that back its
+// FULLTEXT indexes and the R-trees that back its SPATIAL indexes, keyed by index ID. The structure for an index is
+// built the first time the index is used for a lookup, and is then kept up to date by the table's edit accumulators.
+// Shallow copies of a table share its index data, just as they share its partitions.
+type indexData struct {
+	mu       sync.Mutex
+	trees    map[string]*indexTree
+	inverted map[string]*invertedIndex
+	spatial  map[string]*spatialTree
+}
+
+func newIndexData() *indexData {
+	return &indexData{
+		trees:    make(map[string]*indexTree),
+		inverted: make(map[string]*invertedIndex),
+		spatial:  make(map[string]*spatialTree),
+	}
+}
+
+// reset discards the structures of every index. This must be called whenever the rows of a table are replaced other
+// than through its edit accumulators.
+func (d *indexData) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.trees = make(map[string]*indexTree)
+	d.inverted = make(map[string]*invertedIndex)
+	d.spatial = make(map[string]*spatialTree)
+}
+
+// insert adds the row to the structure of every index that has been built.
+func (d *indexData) insert(ctx *sql.Context, row sql.Row) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, tree := range d.trees {
+		if err := tree.insert(ctx, row); err != nil {
+			return err
+		}
+	}
+	for _, inv := range d.inverted {
+		if err := inv.insert(ctx, row); err != nil {
+			return err
+		}
+	}
+	for _, st := range d.spatial {
+		if err := st.insert(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remove removes the row from the structure of every index that has been built.
+func (d *indexData) remove(ctx *sql.Context, row sql.Row) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, tree := range d.trees {
+		if err := tree.remove(ctx, row); err != nil {
+			return err
+		}
+	}
+	for _, inv := range d.inverted {
+		if err := inv.remove(ctx, row); err != nil {
+			return err
+		}
+	}
+	for _, st := range d.spatial {
+		if err := st.remove(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup returns the rows of the table that fall within any of the given ranges of the index, in index order.
+func (d *indexData) lookup(ctx *sql.Context, table *Table, idx *Index, ranges []sql.Range) ([]sql.Row, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	tree, ok := d.trees[idx.ID()]
+	if !ok {
+		var err error
+		tree, err = newIndexTree(ctx, table, idx)
+		if err != nil {
+			return nil, err
+		}
+		d.trees[idx.ID()] = tree
+	}
+	return tree.lookup(ranges)
+}
+
+// indexTree is the ordered structure of a single index. Entries are ordered by the values of the index expressions,
+// with NULL sorting first. Ties are broken by the primary key, or by the entire row for keyless tables, so that every
+// row has a fixed position in the tree.
+type indexTree struct {
+	exprs []sql.Expression
+	tree  *btree
+}
+
+// newIndexTree builds the structure of the given index from the rows of the table.
+func newIndexTree(ctx *sql.Context, table *Table, idx *Index) (*indexTree, error) {
+	keyTypes := make([]sql.Type, len(idx.Exprs))
+	for i, expr := range idx.Exprs {
+		keyTypes[i] = expr.Type()
+	}
+	tiebreak := rowTiebreak(table)
+
+	compare := func(a, b indexEntry) (int, error) {
+		for i, typ := range keyTypes {
+			if cmp, err := compareIndexValues(typ, a.key[i], b.key[i]); err != nil || cmp != 0 {
+				return cmp, err
+			}
+		}
+		return tiebreak(a.row, b.row)
+	}
+
+	tree := &indexTree{
+		exprs: idx.Exprs,
+		tree:  newBtree(compare),
+	}
+	for _, key := range table.partitionKeys {
+		for _, row := range table.partitions[string(key)] {
+			if err := tree.insert(ctx, row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return tree, nil
+}
+
+// rowTiebreak returns a comparison of the rows of the table that orders every row, by comparing their primary keys,
+// or their entire rows for keyless tables.
+func rowTiebreak(table *Table) func(a, b sql.Row) (int, error) {
+	sch := table.schema.Schema
+	ordinals := table.schema.PkOrdinals
+	if len(ordinals) == 0 {
+		ordinals = make([]int, len(sch))
+		for i := range sch {
+			ordinals[i] = i
+		}
+	}
+	return func(a, b sql.Row) (int, error) {
+		for _, ord := range ordinals {
+			if cmp, err := compareIndexValues(sch[ord].Type, a[ord], b[ord]); err != nil || cmp != 0 {
+				return cmp, err
+			}
+		}
+		return 0, nil
+	}
+}
+
+// compareIndexValues compares two values of the given type, with NULL sorting before every other value.
+func compareIndexValues(typ sql.Type, a, b interface{}) (int, error) {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0, nil
+		case a == nil:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+	return typ.Compare(a, b)
+}
+
+func (t *indexTree) entry(ctx *sql.Context, row sql.Row) (indexEntry, error) {
+	key := make(sql.Row, len(t.exprs))
+	for i, expr := range t.exprs {
+		var err error
+		key[i], err = expr.Eval(ctx, row)
+		if err != nil {
+			return indexEntry{}, err
+		}
+	}
+	return indexEntry{key: key, row: row}, nil
+}
+
+func (t *indexTree) insert(ctx *sql.Context, row sql.Row) error {
+	e, err := t.entry(ctx, row)
+	if err != nil {
+		return err
+	}
+	return t.tree.Insert(e)
+}
+
+func (t *indexTree) remove(ctx *sql.Context, row sql.Row) error {
+	e, err := t.entry(ctx, row)
+	if err != nil {
+		return err
+	}
+	_, err = t.tree.Delete(e)
+	return err
+}
+
+// lookup returns the rows that fall within any of the given ranges. Each range is answered by seeking to the lower
+// bound of its first column and scanning until its upper bound, so only the entries that share the first column's
+// bounds are visited.
+func (t *indexTree) lookup(ranges []sql.Range) ([]sql.Row, error) {
+	var rows []sql.Row
+	for i, rang := range ranges {
+		first := rang[0]
+		var seekErr error
+		before := func(e indexEntry) bool {
+			if seekErr != nil {
+				return false
+			}
+			var ok bool
+			ok, seekErr = lowerBoundContains(first, e.key[0])
+			return !ok
+		}
+		err := t.tree.Ascend(before, func(e indexEntry) (bool, error) {
+			if ok, err := upperBoundContains(first, e.key[0]); err != nil || !ok {
+				return false, err
+			}
+			if ok, err := rangeContains(rang, e.key); err != nil || !ok {
+				return err == nil, err
+			}
+			// Rows within more than one range are only returned for the first of them
+			for _, prev := range ranges[:i] {
+				if ok, err := rangeContains(prev, e.key); err != nil || ok {
+					return err == nil, err
+				}
+			}
+			rows = append(rows, e.row)
+			return true, nil
+		})
+		if seekErr != nil {
+			return nil, seekErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+// rangeContains returns whether the key falls within the range.
+func rangeContains(rang sql.Range, key sql.Row) (bool, error) {
+	for i, rce := range rang {
+		if ok, err := lowerBoundContains(rce, key[i]); err != nil || !ok {
+			return false, err
+		}
+		if ok, err := upperBoundContains(rce, key[i]); err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// lowerBoundContains returns whether the value is above the lower bound of the range column expression. A value sits
+// just above its Below cut, and NULL sits just above BelowNull.
+func lowerBoundContains(rce sql.RangeColumnExpr, val interface{}) (bool, error) {
+	var cut sql.RangeCut = sql.Below{Key: val}
+	if val == nil {
+		cut = sql.BelowNull{}
 	}
-	if expressions[0] == nil {
-		return or(expressions[1:]...)
+	cmp, err := rce.LowerBound.Compare(cut, rce.Typ)
+	if err != nil {
+		return false, err
 	}
-	return expression.NewOr(expressions[0], or(expressions[1:]...))
+	return cmp <= 0, nil
 }
 
-func and(expressions ...sql.Expression) sql.Expression {
-	if len(expressions) == 1 {
-		return expressions[0]
+// upperBoundContains returns whether the value is below the upper bound of the range column expression. A value sits
+// just below its Above cut, and NULL sits just below AboveNull.
+func upperBoundContains(rce sql.RangeColumnExpr, val interface{}) (bool, error) {
+	var cut sql.RangeCut = sql.Above{Key: val}
+	if val == nil {
+		cut = sql.AboveNull{}
 	}
-	if expressions[0] == nil {
-		return and(expressions[1:]...)
+	cmp, err := rce.UpperBound.Compare(cut, rce.Typ)
+	if err != nil {
+		return false, err
 	}
-	return expression.NewAnd(expressions[0], and(expressions[1:]...))
+	return cmp >= 0, nil
 }
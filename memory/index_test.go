@@ -0,0 +1,138 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestIndexLookup(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewPartitionedTable("t", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "pk", Source: "t", Type: sql.Int64, PrimaryKey: true},
+		{Name: "s", Source: "t", Type: sql.Text, Nullable: true},
+	}), nil, 3)
+	require.NoError(table.CreateIndex(ctx, "idx_s", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "s"}}, ""))
+	for _, row := range []sql.Row{
+		{int64(1), "c"},
+		{int64(2), "a"},
+		{int64(3), nil},
+		{int64(4), "b"},
+		{int64(5), "a"},
+		{int64(6), "d"},
+	} {
+		require.NoError(table.Insert(ctx, row))
+	}
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	require.Len(indexes, 1)
+	idx := indexes[0]
+
+	lookup := func(ranges ...sql.Range) []sql.Row {
+		indexed := table.IndexedAccess(idx)
+		partitions, err := indexed.LookupPartitions(ctx, sql.IndexLookup{Index: idx, Ranges: ranges})
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(ctx, nil, sql.NewTableRowIter(ctx, indexed, partitions))
+		require.NoError(err)
+		return rows
+	}
+	equals := func(key string) sql.Range {
+		return sql.Range{sql.ClosedRangeColumnExpr(key, key, sql.Text)}
+	}
+
+	// Rows are returned in index order, with ties broken by the primary key
+	require.Equal([]sql.Row{{int64(2), "a"}, {int64(5), "a"}}, lookup(equals("a")))
+	require.Equal([]sql.Row{{int64(1), "c"}, {int64(6), "d"}}, lookup(sql.Range{sql.GreaterThanRangeColumnExpr("b", sql.Text)}))
+	require.Equal([]sql.Row{{int64(3), nil}}, lookup(sql.Range{sql.NullRangeColumnExpr(sql.Text)}))
+	require.Equal([]sql.Row{
+		{int64(3), nil},
+		{int64(2), "a"},
+		{int64(5), "a"},
+		{int64(4), "b"},
+		{int64(1), "c"},
+		{int64(6), "d"},
+	}, lookup(sql.Range{sql.AllRangeColumnExpr(sql.Text)}))
+	require.Empty(lookup(equals("z")))
+
+	// Rows that fall within several ranges are only returned once
+	require.Equal([]sql.Row{{int64(4), "b"}, {int64(1), "c"}, {int64(6), "d"}}, lookup(
+		sql.Range{sql.ClosedRangeColumnExpr("b", "c", sql.Text)},
+		sql.Range{sql.ClosedRangeColumnExpr("c", "d", sql.Text)},
+	))
+
+	// The index is kept up to date as the table is edited
+	updater := table.Updater(ctx)
+	require.NoError(updater.Update(ctx, sql.Row{int64(1), "c"}, sql.Row{int64(1), "a"}))
+	require.NoError(updater.Close(ctx))
+	deleter := table.Deleter(ctx)
+	require.NoError(deleter.Delete(ctx, sql.Row{int64(2), "a"}))
+	require.NoError(deleter.Close(ctx))
+	require.NoError(table.Insert(ctx, sql.Row{int64(0), "a"}))
+	require.Equal([]sql.Row{{int64(0), "a"}, {int64(1), "a"}, {int64(5), "a"}}, lookup(equals("a")))
+	require.Empty(lookup(equals("c")))
+
+	_, err = table.Truncate(ctx)
+	require.NoError(err)
+	require.Empty(lookup(sql.Range{sql.AllRangeColumnExpr(sql.Text)}))
+}
+
+func TestIndexLookupKeyless(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewTable("t", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Source: "t", Type: sql.Int64},
+		{Name: "b", Source: "t", Type: sql.Int64},
+	}), nil)
+	require.NoError(table.CreateIndex(ctx, "idx_a", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "a"}}, ""))
+	for _, row := range []sql.Row{
+		{int64(1), int64(2)},
+		{int64(1), int64(1)},
+		{int64(1), int64(2)},
+		{int64(2), int64(1)},
+	} {
+		require.NoError(table.Insert(ctx, row))
+	}
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	idx := indexes[0]
+	lookup := func() []sql.Row {
+		indexed := table.IndexedAccess(idx)
+		partitions, err := indexed.LookupPartitions(ctx, sql.IndexLookup{Index: idx, Ranges: sql.RangeCollection{
+			{sql.ClosedRangeColumnExpr(int64(1), int64(1), sql.Int64)},
+		}})
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(ctx, nil, sql.NewTableRowIter(ctx, indexed, partitions))
+		require.NoError(err)
+		return rows
+	}
+
+	require.Equal([]sql.Row{{int64(1), int64(1)}, {int64(1), int64(2)}, {int64(1), int64(2)}}, lookup())
+
+	// Deleting one of two identical rows leaves the other in the index
+	deleter := table.Deleter(ctx)
+	require.NoError(deleter.Delete(ctx, sql.Row{int64(1), int64(2)}))
+	require.NoError(deleter.Close(ctx))
+	require.Equal([]sql.Row{{int64(1), int64(1)}, {int64(1), int64(2)}}, lookup())
+}
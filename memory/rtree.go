@@ -0,0 +1,301 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// The bounds on the number of items of every non-root node of an rtree.
+const (
+	rtreeMaxItems = 16
+	rtreeMinItems = rtreeMaxItems / 4
+)
+
+// rtree is an R-tree of index entries, each stored with the bounding box of its geometry. Nodes hold the bounding
+// boxes of their children, so that searches only descend into the subtrees whose boxes intersect the search.
+// Nodes that overflow are split with Guttman's quadratic split.
+type rtree struct {
+	root   *rtreeNode
+	length int
+	equal  func(a, b indexEntry) (bool, error)
+}
+
+// rtreeItem is an item of an rtree node. Items of leaves hold an entry, and items of internal nodes hold a child.
+type rtreeItem struct {
+	box   sql.SpatialBox
+	entry indexEntry
+	child *rtreeNode
+}
+
+type rtreeNode struct {
+	leaf  bool
+	items []rtreeItem
+}
+
+// newRtree returns an empty rtree, which identifies the entry to delete with the given equality function.
+func newRtree(equal func(a, b indexEntry) (bool, error)) *rtree {
+	return &rtree{equal: equal}
+}
+
+// Len returns the number of entries in the tree.
+func (t *rtree) Len() int {
+	return t.length
+}
+
+// Insert adds the entry to the tree with the given bounding box.
+func (t *rtree) Insert(box sql.SpatialBox, e indexEntry) {
+	t.length++
+	t.insert(rtreeItem{box: box, entry: e})
+}
+
+func (t *rtree) insert(item rtreeItem) {
+	if t.root == nil {
+		t.root = &rtreeNode{leaf: true}
+	}
+	if sibling := t.root.insert(item); sibling != nil {
+		oldRoot := t.root
+		t.root = &rtreeNode{items: []rtreeItem{
+			{box: oldRoot.box(), child: oldRoot},
+			{box: sibling.box(), child: sibling},
+		}}
+	}
+}
+
+// Delete removes a single entry with the given bounding box that is equal to the one given, returning whether any
+// entry was removed. If comparing two entries fails, no entry is removed and the error is returned.
+func (t *rtree) Delete(box sql.SpatialBox, e indexEntry) (bool, error) {
+	if t.root == nil {
+		return false, nil
+	}
+	var orphans []rtreeItem
+	if removed, err := t.root.remove(box, e, t.equal, &orphans); !removed || err != nil {
+		return false, err
+	}
+	t.length--
+
+	for !t.root.leaf && len(t.root.items) == 1 {
+		t.root = t.root.items[0].child
+	}
+	if len(t.root.items) == 0 {
+		t.root = nil
+	}
+	// The entries of nodes that became underfull are added back from the root
+	for _, item := range orphans {
+		t.insert(item)
+	}
+	return true, nil
+}
+
+// Search calls visit on every entry whose bounding box intersects the box given, until visit returns false or an
+// error.
+func (t *rtree) Search(box sql.SpatialBox, visit func(indexEntry) (bool, error)) error {
+	if t.root == nil {
+		return nil
+	}
+	_, err := t.root.search(box, visit)
+	return err
+}
+
+// box returns the bounding box of all the items of the node.
+func (n *rtreeNode) box() sql.SpatialBox {
+	box := n.items[0].box
+	for _, item := range n.items[1:] {
+		box = box.Extend(item.box)
+	}
+	return box
+}
+
+// insert adds the item to the subtree rooted at this node. If the node overflows, it's split in two, and the new
+// node holding part of its items is returned.
+func (n *rtreeNode) insert(item rtreeItem) *rtreeNode {
+	if n.leaf {
+		n.items = append(n.items, item)
+	} else {
+		i := n.chooseChild(item.box)
+		child := n.items[i].child
+		sibling := child.insert(item)
+		n.items[i].box = child.box()
+		if sibling != nil {
+			n.items = append(n.items, rtreeItem{box: sibling.box(), child: sibling})
+		}
+	}
+	if len(n.items) > rtreeMaxItems {
+		return n.split()
+	}
+	return nil
+}
+
+// chooseChild returns the position of the child whose bounding box grows the least to hold the box given.
+func (n *rtreeNode) chooseChild(box sql.SpatialBox) int {
+	best := 0
+	bestArea, bestMargin := growth(n.items[0].box, box)
+	for i := 1; i < len(n.items); i++ {
+		area, margin := growth(n.items[i].box, box)
+		if area < bestArea || (area == bestArea && margin < bestMargin) ||
+			(area == bestArea && margin == bestMargin && n.items[i].box.Area() < n.items[best].box.Area()) {
+			best, bestArea, bestMargin = i, area, margin
+		}
+	}
+	return best
+}
+
+// growth returns how much the area and the margin of the first box grow when it's extended to hold the second.
+// Margins tell apart the growth of degenerate boxes, which have no area.
+func growth(b, add sql.SpatialBox) (float64, float64) {
+	extended := b.Extend(add)
+	return extended.Area() - b.Area(), extended.Margin() - b.Margin()
+}
+
+// split moves part of the items of this node into a new node, which is returned. Each node ends up with at least the
+// minimum number of items.
+func (n *rtreeNode) split() *rtreeNode {
+	items := n.items
+
+	// The seeds of the two nodes are the pair of items that would waste the most space if they were put together
+	seed1, seed2 := 0, 1
+	worst := math.Inf(-1)
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			union := items[i].box.Extend(items[j].box)
+			waste := union.Area() - items[i].box.Area() - items[j].box.Area() + union.Margin()*1e-9
+			if waste > worst {
+				seed1, seed2, worst = i, j, waste
+			}
+		}
+	}
+
+	left := &rtreeNode{leaf: n.leaf, items: []rtreeItem{items[seed1]}}
+	right := &rtreeNode{leaf: n.leaf, items: []rtreeItem{items[seed2]}}
+	leftBox, rightBox := items[seed1].box, items[seed2].box
+	var rest []rtreeItem
+	for i, item := range items {
+		if i != seed1 && i != seed2 {
+			rest = append(rest, item)
+		}
+	}
+
+	for len(rest) > 0 {
+		// A node that needs every remaining item to reach the minimum gets all of them
+		if len(left.items)+len(rest) == rtreeMinItems {
+			left.items = append(left.items, rest...)
+			break
+		}
+		if len(right.items)+len(rest) == rtreeMinItems {
+			right.items = append(right.items, rest...)
+			break
+		}
+
+		// The next item is the one with the strongest preference for one of the nodes
+		next := 0
+		bestDiff := math.Inf(-1)
+		for i, item := range rest {
+			leftGrowth, _ := growth(leftBox, item.box)
+			rightGrowth, _ := growth(rightBox, item.box)
+			if diff := math.Abs(leftGrowth - rightGrowth); diff > bestDiff {
+				next, bestDiff = i, diff
+			}
+		}
+		item := rest[next]
+		rest = append(rest[:next], rest[next+1:]...)
+
+		leftArea, leftMargin := growth(leftBox, item.box)
+		rightArea, rightMargin := growth(rightBox, item.box)
+		toLeft := leftArea < rightArea ||
+			(leftArea == rightArea && leftMargin < rightMargin) ||
+			(leftArea == rightArea && leftMargin == rightMargin && len(left.items) <= len(right.items))
+		if toLeft {
+			left.items = append(left.items, item)
+			leftBox = leftBox.Extend(item.box)
+		} else {
+			right.items = append(right.items, item)
+			rightBox = rightBox.Extend(item.box)
+		}
+	}
+
+	n.items = left.items
+	return right
+}
+
+// remove removes an entry from the subtree rooted at this node. Children that are left with fewer than the minimum
+// number of items are removed, and their entries are added to orphans, to be inserted again.
+func (n *rtreeNode) remove(box sql.SpatialBox, e indexEntry, equal func(a, b indexEntry) (bool, error), orphans *[]rtreeItem) (bool, error) {
+	if n.leaf {
+		for i, item := range n.items {
+			if item.box != box {
+				continue
+			}
+			if eq, err := equal(item.entry, e); err != nil {
+				return false, err
+			} else if eq {
+				n.items = append(n.items[:i], n.items[i+1:]...)
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	for i, item := range n.items {
+		if !item.box.Contains(box) {
+			continue
+		}
+		if removed, err := item.child.remove(box, e, equal, orphans); err != nil {
+			return false, err
+		} else if !removed {
+			continue
+		}
+		if len(item.child.items) < rtreeMinItems {
+			*orphans = item.child.appendEntries(*orphans)
+			n.items = append(n.items[:i], n.items[i+1:]...)
+		} else {
+			n.items[i].box = item.child.box()
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// appendEntries appends the items of the leaves of the subtree rooted at this node to the items given.
+func (n *rtreeNode) appendEntries(items []rtreeItem) []rtreeItem {
+	if n.leaf {
+		return append(items, n.items...)
+	}
+	for _, item := range n.items {
+		items = item.child.appendEntries(items)
+	}
+	return items
+}
+
+// search implements rtree.Search for the subtree rooted at this node. It returns false if the search was stopped.
+func (n *rtreeNode) search(box sql.SpatialBox, visit func(indexEntry) (bool, error)) (bool, error) {
+	for _, item := range n.items {
+		if !item.box.Intersects(box) {
+			continue
+		}
+		var ok bool
+		var err error
+		if n.leaf {
+			ok, err = visit(item.entry)
+		} else {
+			ok, err = item.child.search(box, visit)
+		}
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
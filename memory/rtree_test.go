@@ -0,0 +1,100 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestRtree(t *testing.T) {
+	require := require.New(t)
+
+	equal := func(a, b indexEntry) (bool, error) {
+		return a.key[0] == b.key[0], nil
+	}
+	boxOf := func(i int64) sql.SpatialBox {
+		// Every third entry is a box rather than a point
+		x, y := float64(i%100), float64(i/100)
+		if i%3 == 0 {
+			return sql.SpatialBox{MinX: x, MinY: y, MaxX: x + 5, MaxY: y + 2}
+		}
+		return sql.SpatialBox{MinX: x, MinY: y, MaxX: x, MaxY: y}
+	}
+	entry := func(i int64) indexEntry {
+		return indexEntry{key: sql.Row{i}}
+	}
+	search := func(tree *rtree, box sql.SpatialBox) []int64 {
+		var keys []int64
+		err := tree.Search(box, func(e indexEntry) (bool, error) {
+			keys = append(keys, e.key[0].(int64))
+			return true, nil
+		})
+		require.NoError(err)
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		return keys
+	}
+	remove := func(tree *rtree, val int64) bool {
+		removed, err := tree.Delete(boxOf(val), entry(val))
+		require.NoError(err)
+		return removed
+	}
+	expectedSearch := func(present map[int64]struct{}, box sql.SpatialBox) []int64 {
+		var keys []int64
+		for i := range present {
+			if boxOf(i).Intersects(box) {
+				keys = append(keys, i)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		return keys
+	}
+
+	r := rand.New(rand.NewSource(1))
+	tree := newRtree(equal)
+	present := make(map[int64]struct{})
+	for i := 0; i < 20000; i++ {
+		val := r.Int63n(5000)
+		if _, ok := present[val]; ok {
+			require.True(remove(tree, val))
+			delete(present, val)
+		} else {
+			require.False(remove(tree, val))
+			tree.Insert(boxOf(val), entry(val))
+			present[val] = struct{}{}
+		}
+		require.Equal(len(present), tree.Len())
+	}
+
+	for _, box := range []sql.SpatialBox{
+		{MinX: 10, MinY: 10, MaxX: 20, MaxY: 15},
+		{MinX: 0, MinY: 0, MaxX: 100, MaxY: 100},
+		{MinX: 50.5, MinY: 20, MaxX: 50.5, MaxY: 20},
+		{MinX: 200, MinY: 200, MaxX: 300, MaxY: 300},
+	} {
+		require.Equal(expectedSearch(present, box), search(tree, box))
+	}
+
+	for val := range present {
+		require.True(remove(tree, val))
+	}
+	require.Equal(0, tree.Len())
+	require.Empty(search(tree, sql.SpatialBox{MinX: 0, MinY: 0, MaxX: 100, MaxY: 100}))
+}
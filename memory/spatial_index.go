@@ -0,0 +1,134 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// SpatialIndex is a SPATIAL index of a memory table. Tables keep their SPATIAL indexes as an Index with Spatial set,
+// which GetIndexes wraps in a SpatialIndex, so that it's only used for lookups of bounding boxes.
+type SpatialIndex struct {
+	idx *Index
+}
+
+var _ sql.SpatialIndex = (*SpatialIndex)(nil)
+
+func (s *SpatialIndex) ID() string            { return s.idx.ID() }
+func (s *SpatialIndex) Database() string      { return s.idx.Database() }
+func (s *SpatialIndex) Table() string         { return s.idx.Table() }
+func (s *SpatialIndex) Expressions() []string { return s.idx.Expressions() }
+func (s *SpatialIndex) IsUnique() bool        { return false }
+func (s *SpatialIndex) Comment() string       { return s.idx.Comment() }
+func (s *SpatialIndex) IndexType() string     { return "SPATIAL" }
+func (s *SpatialIndex) IsGenerated() bool     { return false }
+func (s *SpatialIndex) ColumnExpressionTypes() []sql.ColumnExpressionType {
+	return s.idx.ColumnExpressionTypes()
+}
+func (s *SpatialIndex) CanSupport(...sql.Range) bool { return false }
+
+// SRID implements the interface sql.SpatialIndex.
+func (s *SpatialIndex) SRID() (uint32, bool) {
+	if typ, ok := s.idx.Exprs[0].Type().(sql.SpatialColumnType); ok {
+		return typ.GetSpatialTypeSRID()
+	}
+	return 0, false
+}
+
+// spatialTree is the R-tree of a single SPATIAL index, which holds every row whose geometry has a bounding box. Rows
+// with NULL or empty geometries can't satisfy any spatial condition, so they aren't indexed.
+type spatialTree struct {
+	expr sql.Expression
+	tree *rtree
+}
+
+// newSpatialTree builds the R-tree of the given index from the rows of the table.
+func newSpatialTree(ctx *sql.Context, table *Table, idx *Index) (*spatialTree, error) {
+	tiebreak := rowTiebreak(table)
+	st := &spatialTree{
+		expr: idx.Exprs[0],
+		tree: newRtree(func(a, b indexEntry) (bool, error) {
+			cmp, err := tiebreak(a.row, b.row)
+			return cmp == 0, err
+		}),
+	}
+	for _, key := range table.partitionKeys {
+		for _, row := range table.partitions[string(key)] {
+			if err := st.insert(ctx, row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return st, nil
+}
+
+// box returns the bounding box of the geometry of the row, or false if the row has none.
+func (st *spatialTree) box(ctx *sql.Context, row sql.Row) (sql.SpatialBox, bool, error) {
+	val, err := st.expr.Eval(ctx, row)
+	if err != nil {
+		return sql.SpatialBox{}, false, err
+	}
+	g, ok := val.(sql.GeometryValue)
+	if !ok {
+		return sql.SpatialBox{}, false, nil
+	}
+	box, ok := sql.BoundingBox(g)
+	return box, ok, nil
+}
+
+func (st *spatialTree) insert(ctx *sql.Context, row sql.Row) error {
+	box, ok, err := st.box(ctx, row)
+	if err != nil || !ok {
+		return err
+	}
+	st.tree.Insert(box, indexEntry{row: row})
+	return nil
+}
+
+func (st *spatialTree) remove(ctx *sql.Context, row sql.Row) error {
+	box, ok, err := st.box(ctx, row)
+	if err != nil || !ok {
+		return err
+	}
+	_, err = st.tree.Delete(box, indexEntry{row: row})
+	return err
+}
+
+// lookup returns the rows whose geometries have a bounding box that intersects the box given.
+func (st *spatialTree) lookup(box sql.SpatialBox) ([]sql.Row, error) {
+	var rows []sql.Row
+	err := st.tree.Search(box, func(e indexEntry) (bool, error) {
+		rows = append(rows, e.row)
+		return true, nil
+	})
+	return rows, err
+}
+
+// spatialLookup returns the rows of the table whose geometries have a bounding box that intersects the box given,
+// building the R-tree of the SPATIAL index if this is the first time that the index is used.
+func (d *indexData) spatialLookup(ctx *sql.Context, table *Table, idx *Index, box sql.SpatialBox) ([]sql.Row, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st, ok := d.spatial[idx.ID()]
+	if !ok {
+		var err error
+		st, err = newSpatialTree(ctx, table, idx)
+		if err != nil {
+			return nil, err
+		}
+		d.spatial[idx.ID()] = st
+	}
+	return st.lookup(box)
+}
@@ -94,7 +94,8 @@ type Table struct {
 	insertPartIdx int
 
 	// Indexed lookups
-	lookup sql.DriverIndexLookup
+	lookup    sql.DriverIndexLookup
+	indexData *indexData
 
 	// AUTO_INCREMENT bookkeeping
 	autoIncVal uint64
@@ -172,6 +173,7 @@ func NewPartitionedTableWithCollation(name string, schema sql.PrimaryKeySchema,
 		collation:     collation,
 		partitions:    partitions,
 		partitionKeys: keys,
+		indexData:     newIndexData(),
 		autoIncVal:    autoIncVal,
 		autoColIdx:    autoIncIdx,
 	}
@@ -215,32 +217,34 @@ func (t *Table) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 	return &partitionIter{keys: keys}, nil
 }
 
-// rangePartitionIter returns a partition that has range and table data access
+// rangePartitionIter returns a single partition holding the rows of an index lookup
 type rangePartitionIter struct {
-	child  *partitionIter
-	ranges sql.Expression
+	lookup sql.IndexLookup
+	done   bool
 }
 
 var _ sql.PartitionIter = (*rangePartitionIter)(nil)
 
-func (i rangePartitionIter) Close(ctx *sql.Context) error {
-	return i.child.Close(ctx)
+func (i *rangePartitionIter) Close(ctx *sql.Context) error {
+	return nil
 }
 
-func (i rangePartitionIter) Next(ctx *sql.Context) (sql.Partition, error) {
-	part, err := i.child.Next(ctx)
-	if err != nil {
-		return nil, err
+func (i *rangePartitionIter) Next(ctx *sql.Context) (sql.Partition, error) {
+	if i.done {
+		return nil, io.EOF
 	}
+	i.done = true
 	return &rangePartition{
-		Partition: part.(*Partition),
-		rang:      i.ranges,
+		Partition: &Partition{key: []byte(i.lookup.Index.ID())},
+		lookup:    i.lookup,
 	}, nil
 }
 
+// rangePartition is a partition whose rows are read from the structure of an index, rather than from one of the
+// table's partitions
 type rangePartition struct {
 	*Partition
-	rang sql.Expression
+	lookup sql.IndexLookup
 }
 
 // PartitionCount implements the sql.PartitionCounter interface.
@@ -250,10 +254,25 @@ func (t *Table) PartitionCount(ctx *sql.Context) (int64, error) {
 
 // PartitionRows implements the sql.PartitionRows interface.
 func (t *Table) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
-	filters := t.filters
 	if r, ok := partition.(*rangePartition); ok {
-		// index lookup is currently a single filter applied to a full table scan
-		filters = append(t.filters, r.rang)
+		// The rows are collected before iteration begins, so that they aren't affected by edits made in the meantime
+		var rows []sql.Row
+		var err error
+		if r.lookup.Fulltext != nil {
+			rows, err = t.indexData.fulltextLookup(ctx, t, r.lookup.Index.(*FulltextIndex).idx, *r.lookup.Fulltext)
+		} else if r.lookup.Spatial != nil {
+			rows, err = t.indexData.spatialLookup(ctx, t, r.lookup.Index.(*SpatialIndex).idx, *r.lookup.Spatial)
+		} else {
+			rows, err = t.indexData.lookup(ctx, t, r.lookup.Index.(*Index), r.lookup.Ranges)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &tableIter{
+			rows:    rows,
+			columns: t.columns,
+			filters: t.filters,
+		}, nil
 	}
 
 	rows, ok := t.partitions[string(partition.Key())]
@@ -268,7 +287,7 @@ func (t *Table) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.Ro
 	return &tableIter{
 		rows:    rowsCopy,
 		columns: t.columns,
-		filters: filters,
+		filters: t.filters,
 	}, nil
 }
 
@@ -540,6 +559,7 @@ func (t *Table) AutoIncrementSetter(*sql.Context) sql.AutoIncrementSetter {
 
 func (t *Table) newTableEditor() *tableEditor {
 	var uniqIdxCols [][]int
+	var uniqIdxExprs [][]sql.Expression
 	for _, idx := range t.indexes {
 		if !idx.IsUnique() {
 			continue
@@ -547,7 +567,13 @@ func (t *Table) newTableEditor() *tableEditor {
 		var colNames []string
 		expressions := idx.(*Index).Exprs
 		for _, exp := range expressions {
-			colNames = append(colNames, exp.(*expression.GetField).Name())
+			if gf, ok := exp.(*expression.GetField); ok {
+				colNames = append(colNames, gf.Name())
+			}
+		}
+		if len(colNames) != len(expressions) {
+			uniqIdxExprs = append(uniqIdxExprs, expressions)
+			continue
 		}
 		colIdxs, err := t.columnIndexes(colNames)
 		if err != nil {
@@ -561,7 +587,8 @@ func (t *Table) newTableEditor() *tableEditor {
 		initialPartitions: nil,
 		ea:                NewTableEditAccumulator(t),
 		initialInsert:     0,
-		uniqueIdxCols:     uniqIdxCols}
+		uniqueIdxCols:     uniqIdxCols,
+		uniqueIdxExprs:    uniqIdxExprs}
 }
 
 func (t *Table) Truncate(ctx *sql.Context) (int, error) {
@@ -570,6 +597,7 @@ func (t *Table) Truncate(ctx *sql.Context) (int, error) {
 		count += len(t.partitions[key])
 		t.partitions[key] = nil
 	}
+	t.indexData.reset()
 	return count, nil
 }
 
@@ -663,9 +691,41 @@ func (t *Table) GetNextAutoIncrementValue(ctx *sql.Context, insertVal interface{
 
 func (t *Table) AddColumn(ctx *sql.Context, column *sql.Column, order *sql.ColumnOrder) error {
 	newColIdx := t.addColumnToSchema(ctx, column, order)
+	if column.Generated != nil {
+		// Values of virtual columns are stored as well, rather than computed when they are read
+		if err := t.insertValueInRows(ctx, newColIdx, nil); err != nil {
+			return err
+		}
+		return t.updateGeneratedColumn(ctx, newColIdx)
+	}
 	return t.insertValueInRows(ctx, newColIdx, column.Default)
 }
 
+// updateGeneratedColumn sets the value of the generated column at the index given in every row of the table.
+func (t *Table) updateGeneratedColumn(ctx *sql.Context, idx int) error {
+	col := t.schema.Schema[idx]
+	for _, p := range t.partitions {
+		for _, row := range p {
+			val, err := col.Generated.Expression.Eval(ctx, row)
+			if err != nil {
+				return err
+			}
+			if val != nil {
+				converted, err := col.Type.Convert(val)
+				if err != nil {
+					if sql.ErrLengthBeyondLimit.Is(err) {
+						err = sql.ErrLengthBeyondLimit.New(val, col.Name)
+					}
+					return err
+				}
+				val = converted
+			}
+			row[idx] = val
+		}
+	}
+	return nil
+}
+
 // addColumnToSchema adds the given column to the schema and returns the new index
 func (t *Table) addColumnToSchema(ctx *sql.Context, newCol *sql.Column, order *sql.ColumnOrder) int {
 	newCol.Source = t.Name()
@@ -704,6 +764,16 @@ func (t *Table) addColumnToSchema(ctx *sql.Context, newCol *sql.Column, order *s
 			return expr, transform.SameTree, nil
 		})
 		newSchCol.Default = newDefault.(*sql.ColumnDefaultValue)
+
+		if newSchCol.Generated != nil {
+			newGenerated, _, _ := transform.Expr(newSchCol.Generated, func(expr sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+				if expr, ok := expr.(*expression.GetField); ok {
+					return expr.WithIndex(newSch.IndexOf(expr.Name(), t.name)), transform.NewTree, nil
+				}
+				return expr, transform.SameTree, nil
+			})
+			newSchCol.Generated = newGenerated.(*sql.ColumnDefaultValue)
+		}
 	}
 
 	if newCol.AutoIncrement {
@@ -774,6 +844,7 @@ func (t *Table) insertValueInRows(ctx *sql.Context, idx int, colDefault *sql.Col
 		}
 		t.partitions[k] = newP
 	}
+	t.rebindIndexes(nil)
 	return nil
 }
 
@@ -789,6 +860,7 @@ func (t *Table) DropColumn(ctx *sql.Context, columnName string) error {
 		}
 		t.partitions[k] = newP
 	}
+	t.rebindIndexes(nil)
 	return nil
 }
 
@@ -893,20 +965,65 @@ func (t *Table) ModifyColumn(ctx *sql.Context, columnName string, column *sql.Co
 
 	t.schema.PkOrdinals = newPkOrds
 
-	for _, index := range t.indexes {
-		memIndex := index.(*Index)
-		nameLowercase := strings.ToLower(columnName)
-		for i, expr := range memIndex.Exprs {
-			getField := expr.(*expression.GetField)
-			if strings.ToLower(getField.Name()) == nameLowercase {
-				memIndex.Exprs[i] = expression.NewGetFieldWithTable(newIdx, column.Type, getField.Table(), column.Name, column.Nullable)
-			}
+	if column.Generated != nil {
+		if err := t.updateGeneratedColumn(ctx, newIdx); err != nil {
+			return err
 		}
 	}
 
+	t.rebindIndexes(map[string]string{strings.ToLower(columnName): column.Name})
+
 	return nil
 }
 
+// rebindIndexes binds the expressions of the table's indexes to its current schema, after columns were added, dropped,
+// moved or modified. Renamed columns are given in renames, from their lowercased old name to their new name. Key parts
+// on dropped columns are removed from their index, and indexes left without any key parts, or with a functional key
+// part on a dropped column, are dropped, as MySQL does.
+func (t *Table) rebindIndexes(renames map[string]string) {
+	for id, index := range t.indexes {
+		memIndex, ok := index.(*Index)
+		if !ok {
+			continue
+		}
+
+		exprs := make([]sql.Expression, 0, len(memIndex.Exprs))
+		dropped := false
+		for _, expr := range memIndex.Exprs {
+			bound, _, err := transform.Expr(expr, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+				getField, ok := e.(*expression.GetField)
+				if !ok {
+					return e, transform.SameTree, nil
+				}
+				name := getField.Name()
+				if newName, ok := renames[strings.ToLower(name)]; ok {
+					name = newName
+				}
+				idx, field := t.getField(name)
+				if field == nil {
+					return nil, transform.SameTree, sql.ErrKeyColumnDoesNotExist.New(name)
+				}
+				return expression.NewGetFieldWithTable(idx, field.Type, t.name, field.Name, field.Nullable), transform.NewTree, nil
+			})
+			if err == nil {
+				exprs = append(exprs, bound)
+			} else if _, ok := expr.(*expression.GetField); !ok {
+				dropped = true
+				break
+			}
+		}
+
+		if dropped || len(exprs) == 0 {
+			delete(t.indexes, id)
+			continue
+		}
+		rebound := *memIndex
+		rebound.Exprs = exprs
+		t.indexes[id] = &rebound
+	}
+	t.indexData.reset()
+}
+
 // PrimaryKeySchema implements sql.PrimaryKeyAlterableTable
 func (t *Table) PrimaryKeySchema() sql.PrimaryKeySchema {
 	return t.schema
@@ -1031,16 +1148,17 @@ type IndexedTable struct {
 }
 
 func (t *IndexedTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
-	filter, err := lookup.Index.(*Index).rangeFilterExpr(lookup.Ranges...)
-	if err != nil {
-		return nil, err
+	if lookup.Fulltext != nil || lookup.Spatial != nil {
+		return &rangePartitionIter{lookup: lookup}, nil
 	}
-	child, err := t.Table.Partitions(ctx)
-	if err != nil {
-		return nil, err
+	idx := lookup.Index.(*Index)
+	if idx.CommentStr == CommentPreventingIndexBuilding || len(lookup.Ranges) == 0 {
+		return t.Table.Partitions(ctx)
 	}
-
-	return rangePartitionIter{child: child.(*partitionIter), ranges: filter}, nil
+	if len(lookup.Ranges[0]) != len(idx.Exprs) {
+		return nil, fmt.Errorf("expected different key count: %s=>%d/%d", idx.Name, len(idx.Exprs), len(lookup.Ranges[0]))
+	}
+	return &rangePartitionIter{lookup: lookup}, nil
 }
 
 func (t *Table) IndexedAccess(sql.Index) sql.IndexedTable {
@@ -1123,6 +1241,15 @@ func (t *Table) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 	nonPrimaryIndexes := make([]sql.Index, len(t.indexes))
 	var i int
 	for _, index := range t.indexes {
+		if memIndex, ok := index.(*Index); ok && memIndex.Fulltext {
+			// Relevance is scored with the index data of this table, which may be a copy of the table that created
+			// the index
+			bound := *memIndex
+			bound.Tbl = t
+			index = &FulltextIndex{idx: &bound}
+		} else if ok && memIndex.Spatial {
+			index = &SpatialIndex{idx: memIndex}
+		}
 		nonPrimaryIndexes[i] = index
 		i++
 	}
@@ -1240,7 +1367,7 @@ func (t *Table) DropCheck(ctx *sql.Context, chName string) error {
 	return fmt.Errorf("check '%s' was not found on the table", chName)
 }
 
-func (t *Table) createIndex(name string, columns []sql.IndexColumn, constraint sql.IndexConstraint, comment string) (sql.Index, error) {
+func (t *Table) createIndex(ctx *sql.Context, name string, columns []sql.IndexColumn, constraint sql.IndexConstraint, comment string) (sql.Index, error) {
 	if name == "" {
 		for _, column := range columns {
 			name += column.Name + "_"
@@ -1252,15 +1379,21 @@ func (t *Table) createIndex(name string, columns []sql.IndexColumn, constraint s
 	}
 
 	exprs := make([]sql.Expression, len(columns))
-	colNames := make([]string, len(columns))
 	for i, column := range columns {
+		if column.IsFunctional() {
+			expr, err := t.bindIndexExpression(column.Expression)
+			if err != nil {
+				return nil, err
+			}
+			exprs[i] = expr
+			continue
+		}
 		idx, field := t.getField(column.Name)
 		exprs[i] = expression.NewGetFieldWithTable(idx, field.Type, t.name, field.Name, field.Nullable)
-		colNames[i] = column.Name
 	}
 
 	if constraint == sql.IndexConstraint_Unique {
-		err := t.errIfDuplicateEntryExist(colNames, name)
+		err := t.errIfDuplicateEntryExist(ctx, exprs, name)
 		if err != nil {
 			return nil, err
 		}
@@ -1274,20 +1407,38 @@ func (t *Table) createIndex(name string, columns []sql.IndexColumn, constraint s
 		Exprs:      exprs,
 		Name:       name,
 		Unique:     constraint == sql.IndexConstraint_Unique,
+		Fulltext:   constraint == sql.IndexConstraint_Fulltext,
+		Spatial:    constraint == sql.IndexConstraint_Spatial,
 		CommentStr: comment,
 	}, nil
 }
 
-// throws an error if any two or more rows share the same |cols| values.
-func (t *Table) errIfDuplicateEntryExist(cols []string, idxName string) error {
-	columnMapping, err := t.columnIndexes(cols)
-	if err != nil {
-		return err
-	}
+// bindIndexExpression returns the expression of a functional key part with its fields bound to the columns of this
+// table.
+func (t *Table) bindIndexExpression(expr sql.Expression) (sql.Expression, error) {
+	bound, _, err := transform.Expr(expr, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+		getField, ok := e.(*expression.GetField)
+		if !ok {
+			return e, transform.SameTree, nil
+		}
+		idx, field := t.getField(getField.Name())
+		if field == nil {
+			return nil, transform.SameTree, sql.ErrKeyColumnDoesNotExist.New(getField.Name())
+		}
+		return expression.NewGetFieldWithTable(idx, field.Type, t.name, field.Name, field.Nullable), transform.NewTree, nil
+	})
+	return bound, err
+}
+
+// throws an error if any two or more rows share the same |exprs| values.
+func (t *Table) errIfDuplicateEntryExist(ctx *sql.Context, exprs []sql.Expression, idxName string) error {
 	unique := make(map[uint64]struct{})
 	for _, partition := range t.partitions {
 		for _, row := range partition {
-			idxPrefixKey := projectOnRow(columnMapping, row)
+			idxPrefixKey, err := evalIndexKey(ctx, exprs, row)
+			if err != nil {
+				return err
+			}
 			if hasNulls(idxPrefixKey) {
 				continue
 			}
@@ -1296,7 +1447,7 @@ func (t *Table) errIfDuplicateEntryExist(cols []string, idxName string) error {
 				return err
 			}
 			if _, ok := unique[h]; ok {
-				return sql.NewUniqueKeyErr(formatRow(row, columnMapping), false, nil)
+				return sql.NewUniqueKeyErr(fmt.Sprint(idxPrefixKey), false, nil)
 			}
 			unique[h] = struct{}{}
 		}
@@ -1304,6 +1455,19 @@ func (t *Table) errIfDuplicateEntryExist(cols []string, idxName string) error {
 	return nil
 }
 
+// evalIndexKey returns the values of the index expressions given for the row given.
+func evalIndexKey(ctx *sql.Context, exprs []sql.Expression, row sql.Row) (sql.Row, error) {
+	key := make(sql.Row, len(exprs))
+	for i, expr := range exprs {
+		val, err := expr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		key[i] = val
+	}
+	return key, nil
+}
+
 func hasNulls(row sql.Row) bool {
 	for _, v := range row {
 		if v == nil {
@@ -1338,7 +1502,7 @@ func (t *Table) CreateIndex(ctx *sql.Context, indexName string, using sql.IndexU
 		t.indexes = make(map[string]sql.Index)
 	}
 
-	index, err := t.createIndex(indexName, columns, constraint, comment)
+	index, err := t.createIndex(ctx, indexName, columns, constraint, comment)
 	if err != nil {
 		return err
 	}
@@ -1354,6 +1518,7 @@ func (t *Table) DropIndex(ctx *sql.Context, indexName string) error {
 			delete(t.indexes, name)
 		}
 	}
+	t.indexData.reset()
 	return nil
 }
 
@@ -1365,6 +1530,7 @@ func (t *Table) RenameIndex(ctx *sql.Context, fromIndexName string, toIndexName
 			t.indexes[toIndexName] = index
 		}
 	}
+	t.indexData.reset()
 	return nil
 }
 
@@ -1460,6 +1626,7 @@ func (t *Table) CreatePrimaryKey(ctx *sql.Context, columns []sql.IndexColumn) er
 	t.schema = pkSchema
 	t.partitions = newTable.partitions
 	t.partitionKeys = newTable.partitionKeys
+	t.indexData.reset()
 
 	return nil
 }
@@ -1545,6 +1712,8 @@ func copyschema(sch sql.Schema) sql.Schema {
 			PrimaryKey:    c.PrimaryKey,
 			Comment:       c.Comment,
 			Extra:         c.Extra,
+			Generated:     c.Generated,
+			Virtual:       c.Virtual,
 		}
 	}
 
@@ -1597,6 +1766,7 @@ func (t *Table) DropPrimaryKey(ctx *sql.Context) error {
 	delete(t.indexes, "PRIMARY")
 
 	t.schema.PkOrdinals = []int{}
+	t.indexData.reset()
 
 	return nil
 }
@@ -30,7 +30,9 @@ type tableEditor struct {
 	initialInsert     int
 	// array of key ordinals for each unique index defined on the table
 	uniqueIdxCols [][]int
-	fkTable       *Table
+	// array of key expressions for each unique index with functional key parts defined on the table
+	uniqueIdxExprs [][]sql.Expression
+	fkTable        *Table
 }
 
 var _ sql.Table = (*tableEditor)(nil)
@@ -93,6 +95,7 @@ func (t *tableEditor) DiscardChanges(ctx *sql.Context, errorEncountered error) e
 	t.table.insertPartIdx = t.initialInsert
 	t.table.autoIncVal = t.initialAutoIncVal
 	t.table.partitions = t.initialPartitions
+	t.table.indexData.reset()
 	t.ea.Clear()
 	return nil
 }
@@ -146,6 +149,9 @@ func (t *tableEditor) Insert(ctx *sql.Context, row sql.Row) error {
 			return sql.NewUniqueKeyErr(formatRow(row, cols), false, existing)
 		}
 	}
+	if err = t.checkUniqueIdxExprs(ctx, row); err != nil {
+		return err
+	}
 
 	err = t.ea.Insert(row)
 	if err != nil {
@@ -237,6 +243,9 @@ func (t *tableEditor) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) e
 			return sql.NewUniqueKeyErr(formatRow(newRow, cols), false, existing)
 		}
 	}
+	if err = t.checkUniqueIdxExprs(ctx, newRow); err != nil {
+		return err
+	}
 
 	err = t.ea.Insert(newRow)
 	if err != nil {
@@ -313,6 +322,29 @@ func (t *tableEditor) pkColumnIndexes() []int {
 	return pkColIdxes
 }
 
+// checkUniqueIdxExprs returns a unique key error if the row given has the same key as another row for any unique index
+// with functional key parts.
+func (t *tableEditor) checkUniqueIdxExprs(ctx *sql.Context, row sql.Row) error {
+	for _, exprs := range t.uniqueIdxExprs {
+		key, err := evalIndexKey(ctx, exprs, row)
+		if err != nil {
+			return err
+		}
+		if hasNulls(key) {
+			continue
+		}
+		existing, found, err := t.ea.GetByExprs(ctx, row, exprs)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			return sql.NewUniqueKeyErr(fmt.Sprint(key), false, existing)
+		}
+	}
+	return nil
+}
+
 func (t *tableEditor) pkColsDiffer(row, row2 sql.Row) bool {
 	pkColIdxes := t.pkColumnIndexes()
 	return !columnsMatch(pkColIdxes, row, row2)
@@ -328,6 +360,25 @@ func columnsMatch(colIndexes []int, row sql.Row, row2 sql.Row) bool {
 	return true
 }
 
+// Returns whether the expressions given evaluate to equal values for the two rows provided
+func exprsMatch(ctx *sql.Context, exprs []sql.Expression, row sql.Row, row2 sql.Row) (bool, error) {
+	for _, expr := range exprs {
+		val, err := expr.Eval(ctx, row)
+		if err != nil {
+			return false, err
+		}
+		val2, err := expr.Eval(ctx, row2)
+		if err != nil {
+			return false, err
+		}
+		cmp, err := expr.Type().Compare(val, val2)
+		if err != nil || cmp != 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // tableEditAccumulator tracks the set of inserts and deletes and applies those edits to a initialTable.
 type tableEditAccumulator interface {
 	// Insert adds a row to the accumulator to be inserted in the future. Updates are modeled as a delete than an insertPartIdx.
@@ -341,6 +392,8 @@ type tableEditAccumulator interface {
 	// accumulator.
 	ApplyEdits(ctx *sql.Context) error
 	GetByCols(value sql.Row, cols []int) (sql.Row, bool, error)
+	// GetByExprs finds a row for which the |exprs| evaluate to the same values as they do for |value|.
+	GetByExprs(ctx *sql.Context, value sql.Row, exprs []sql.Expression) (sql.Row, bool, error)
 	// Clear wipes all of the stored inserts and deletes that may or may not have been applied.
 	Clear()
 }
@@ -441,6 +494,32 @@ func (pke *pkTableEditAccumulator) GetByCols(value sql.Row, cols []int) (sql.Row
 	return nil, false, nil
 }
 
+// GetByExprs implements the tableEditAccumulator interface.
+func (pke *pkTableEditAccumulator) GetByExprs(ctx *sql.Context, value sql.Row, exprs []sql.Expression) (sql.Row, bool, error) {
+	// If we have this row in any delete, bail.
+	for _, r := range pke.deletes {
+		if match, err := exprsMatch(ctx, exprs, r, value); err != nil || match {
+			return nil, false, err
+		}
+	}
+
+	for _, r := range pke.adds {
+		if match, err := exprsMatch(ctx, exprs, r, value); err != nil || match {
+			return r, match, err
+		}
+	}
+
+	for _, partition := range pke.table.partitions {
+		for _, partitionRow := range partition {
+			if match, err := exprsMatch(ctx, exprs, partitionRow, value); err != nil || match {
+				return partitionRow, match, err
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
 // ApplyEdits implements the tableEditAccumulator interface.
 func (pke *pkTableEditAccumulator) ApplyEdits(ctx *sql.Context) error {
 	for _, val := range pke.deletes {
@@ -488,35 +567,28 @@ func (pke *pkTableEditAccumulator) deleteHelper(ctx *sql.Context, table *Table,
 		return err
 	}
 
-	matches := false
 	for partitionIndex, partition := range table.partitions {
 		for partitionRowIndex, partitionRow := range partition {
-			matches = true
-
 			// For DELETE queries, we will have previously selected the row in order to delete it. For REPLACE, we will just
 			// have the row to be replaced, so we need to consider primary key information.
 			pkColIdxes := pke.pkColumnIndexes()
 			if len(pkColIdxes) > 0 {
 				if columnsMatch(pkColIdxes, partitionRow, row) {
 					table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
-					break
+					return table.indexData.remove(ctx, partitionRow)
 				}
 			}
 
-			var err error
-			matches, err = rowsAreEqual(ctx, table.Schema(), row, partitionRow)
+			matches, err := rowsAreEqual(ctx, table.Schema(), row, partitionRow)
 			if err != nil {
 				return err
 			}
 
 			if matches {
 				table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
-				break
+				return table.indexData.remove(ctx, partitionRow)
 			}
 		}
-		if matches {
-			break
-		}
 	}
 
 	return nil
@@ -548,12 +620,16 @@ func (pke *pkTableEditAccumulator) insertHelper(ctx *sql.Context, table *Table,
 	}
 
 	if savedPartitionRowIndex > -1 {
+		err := table.indexData.remove(ctx, table.partitions[savedPartitionIndex][savedPartitionRowIndex])
+		if err != nil {
+			return err
+		}
 		table.partitions[savedPartitionIndex][savedPartitionRowIndex] = row
 	} else {
 		table.partitions[key] = append(table.partitions[key], row)
 	}
 
-	return nil
+	return table.indexData.insert(ctx, row)
 }
 
 // keylessTableEditAccumulator manages updates for a keyless table.
@@ -632,6 +708,32 @@ func (k *keylessTableEditAccumulator) GetByCols(value sql.Row, cols []int) (sql.
 	return nil, false, nil
 }
 
+// GetByExprs implements the tableEditAccumulator interface.
+func (k *keylessTableEditAccumulator) GetByExprs(ctx *sql.Context, value sql.Row, exprs []sql.Expression) (sql.Row, bool, error) {
+	// If we have this row in any delete, bail.
+	for _, r := range k.deletes {
+		if match, err := exprsMatch(ctx, exprs, r, value); err != nil || match {
+			return nil, false, err
+		}
+	}
+
+	for _, r := range k.adds {
+		if match, err := exprsMatch(ctx, exprs, r, value); err != nil || match {
+			return r, match, err
+		}
+	}
+
+	for _, partition := range k.table.partitions {
+		for _, partitionRow := range partition {
+			if match, err := exprsMatch(ctx, exprs, partitionRow, value); err != nil || match {
+				return partitionRow, match, err
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
 // ApplyEdits implements the tableEditAccumulator interface.
 func (k *keylessTableEditAccumulator) ApplyEdits(ctx *sql.Context) error {
 	for _, val := range k.deletes {
@@ -663,24 +765,18 @@ func (k *keylessTableEditAccumulator) deleteHelper(ctx *sql.Context, table *Tabl
 		return err
 	}
 
-	matches := false
 	for partitionIndex, partition := range table.partitions {
 		for partitionRowIndex, partitionRow := range partition {
-			matches = true
-			var err error
-			matches, err = rowsAreEqual(ctx, table.schema.Schema, row, partitionRow)
+			matches, err := rowsAreEqual(ctx, table.schema.Schema, row, partitionRow)
 			if err != nil {
 				return err
 			}
 
 			if matches {
 				table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
-				break
+				return table.indexData.remove(ctx, partitionRow)
 			}
 		}
-		if matches {
-			break
-		}
 	}
 
 	return nil
@@ -696,7 +792,7 @@ func (k *keylessTableEditAccumulator) insertHelper(ctx *sql.Context, table *Tabl
 
 	table.partitions[key] = append(table.partitions[key], row)
 
-	return nil
+	return table.indexData.insert(ctx, row)
 }
 
 func formatRow(r sql.Row, idxs []int) string {
@@ -0,0 +1,589 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// TransactionalDatabase is an in-memory database that implements sql.TransactionDatabase. Each transaction reads from a
+// snapshot of the database that is taken when the transaction begins, and its writes only become visible to other
+// sessions once it commits. If two concurrent transactions make conflicting changes to the same rows, the transaction
+// that commits last fails and is rolled back.
+//
+// As in MySQL, creating, dropping, and renaming tables are not transactional, and take effect immediately. Writes made
+// through a context without a transaction are also applied immediately. The engine commits the transaction of each
+// statement through the session when autocommit is enabled, so sessions should be wrapped with NewTransactionalSession.
+type TransactionalDatabase struct {
+	*Database
+	mu       *sync.Mutex
+	versions map[*Table]uint64
+}
+
+var _ sql.TransactionDatabase = (*TransactionalDatabase)(nil)
+
+// NewTransactionalDatabase creates a new transactional database with the given name.
+func NewTransactionalDatabase(name string) *TransactionalDatabase {
+	return &TransactionalDatabase{
+		Database: NewDatabase(name),
+		mu:       &sync.Mutex{},
+		versions: make(map[*Table]uint64),
+	}
+}
+
+// GetTableInsensitive implements the interface sql.Database. Within a transaction, the table that is returned holds
+// the transaction's working copy of the table's rows.
+func (db *TransactionalDatabase) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Table, bool, error) {
+	tbl, ok, err := db.Database.GetTableInsensitive(ctx, tblName)
+	if err != nil || !ok {
+		return tbl, ok, err
+	}
+	memTable, ok := tbl.(*Table)
+	if !ok {
+		return tbl, true, nil
+	}
+	tx, ok := ctx.GetTransaction().(*Transaction)
+	if !ok {
+		return tbl, true, nil
+	}
+	return tx.workingTable(db, memTable), true, nil
+}
+
+// StartTransaction implements the interface sql.TransactionDatabase.
+func (db *TransactionalDatabase) StartTransaction(ctx *sql.Context, tCharacteristic sql.TransactionCharacteristic) (sql.Transaction, error) {
+	tx := &Transaction{
+		readOnly:  tCharacteristic == sql.ReadOnly,
+		databases: make(map[*TransactionalDatabase]*transactionState),
+	}
+	tx.state(db)
+	return tx, nil
+}
+
+// CommitTransaction implements the interface sql.TransactionDatabase.
+func (db *TransactionalDatabase) CommitTransaction(ctx *sql.Context, tx sql.Transaction) error {
+	memTx, ok := tx.(*Transaction)
+	if !ok {
+		return fmt.Errorf("expected a *memory.Transaction, but got %T", tx)
+	}
+	return memTx.commit()
+}
+
+// Rollback implements the interface sql.TransactionDatabase.
+func (db *TransactionalDatabase) Rollback(ctx *sql.Context, tx sql.Transaction) error {
+	memTx, ok := tx.(*Transaction)
+	if !ok {
+		return fmt.Errorf("expected a *memory.Transaction, but got %T", tx)
+	}
+	memTx.rollback()
+	return nil
+}
+
+// CreateSavepoint implements the interface sql.TransactionDatabase.
+func (db *TransactionalDatabase) CreateSavepoint(ctx *sql.Context, tx sql.Transaction, name string) error {
+	if memTx, ok := tx.(*Transaction); ok {
+		memTx.createSavepoint(name)
+	}
+	return nil
+}
+
+// RollbackToSavepoint implements the interface sql.TransactionDatabase.
+func (db *TransactionalDatabase) RollbackToSavepoint(ctx *sql.Context, tx sql.Transaction, name string) error {
+	if memTx, ok := tx.(*Transaction); ok {
+		return memTx.rollbackToSavepoint(name)
+	}
+	return nil
+}
+
+// ReleaseSavepoint implements the interface sql.TransactionDatabase.
+func (db *TransactionalDatabase) ReleaseSavepoint(ctx *sql.Context, tx sql.Transaction, name string) error {
+	if memTx, ok := tx.(*Transaction); ok {
+		return memTx.releaseSavepoint(name)
+	}
+	return nil
+}
+
+// snapshot returns a snapshot of every table in the database.
+func (db *TransactionalDatabase) snapshot() map[*Table]tableSnapshot {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	snapshots := make(map[*Table]tableSnapshot)
+	for _, tbl := range db.tables {
+		if memTable, ok := tbl.(*Table); ok {
+			snapshots[memTable] = db.snapshotTable(memTable)
+		}
+	}
+	return snapshots
+}
+
+// snapshotTable returns a snapshot of the given table. The database's mutex must be held by the caller.
+func (db *TransactionalDatabase) snapshotTable(table *Table) tableSnapshot {
+	// Writes that don't go through a transaction modify the table's partitions in place, so the snapshot can't share
+	// them with the table
+	snapshot := *table
+	snapshot.partitions = copyPartitions(table.partitions)
+	snapshot.schema.PkOrdinals = append([]int(nil), table.schema.PkOrdinals...)
+	return tableSnapshot{
+		table:   snapshot,
+		version: db.versions[table],
+	}
+}
+
+// hasTable returns whether the given table still belongs to the database. The database's mutex must be held by the
+// caller.
+func (db *TransactionalDatabase) hasTable(table *Table) bool {
+	for _, tbl := range db.tables {
+		if tbl == sql.Table(table) {
+			return true
+		}
+	}
+	return false
+}
+
+// Transaction is a transaction on one or more TransactionalDatabases. Each database that the transaction accesses is
+// snapshotted the first time that it's accessed.
+type Transaction struct {
+	readOnly   bool
+	databases  map[*TransactionalDatabase]*transactionState
+	savepoints []*transactionSavepoint
+}
+
+var _ sql.Transaction = (*Transaction)(nil)
+
+// transactionState is the state of a transaction within a single database.
+type transactionState struct {
+	snapshots map[*Table]tableSnapshot
+	working   map[*Table]*Table
+}
+
+// tableSnapshot is the state of a table when it was snapshotted, along with the table's version at that time. The
+// version is incremented each time that a transaction commits changes to the table.
+type tableSnapshot struct {
+	table   Table
+	version uint64
+}
+
+// transactionSavepoint holds the data of every working table in the transaction at the time the savepoint was created.
+type transactionSavepoint struct {
+	name string
+	data map[*TransactionalDatabase]map[*Table]tableData
+}
+
+// tableData is the portion of a table that is modified by writes and DDL.
+type tableData struct {
+	schema        sql.PrimaryKeySchema
+	partitions    map[string][]sql.Row
+	partitionKeys [][]byte
+	insertPartIdx int
+	autoIncVal    uint64
+	autoColIdx    int
+}
+
+// String implements the interface sql.Transaction.
+func (tx *Transaction) String() string {
+	var names []string
+	for db := range tx.databases {
+		names = append(names, db.Name())
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("memory transaction on [%s]", strings.Join(names, ", "))
+}
+
+// IsReadOnly implements the interface sql.Transaction.
+func (tx *Transaction) IsReadOnly() bool {
+	return tx.readOnly
+}
+
+// state returns the transaction's state for the given database, snapshotting the database if this is the first time
+// that the transaction has accessed it.
+func (tx *Transaction) state(db *TransactionalDatabase) *transactionState {
+	state, ok := tx.databases[db]
+	if !ok {
+		state = &transactionState{
+			snapshots: db.snapshot(),
+			working:   make(map[*Table]*Table),
+		}
+		tx.databases[db] = state
+	}
+	return state
+}
+
+// workingTable returns the transaction's working copy of the given table, creating it from the transaction's snapshot
+// of the table if this is the first time that the transaction has accessed the table.
+func (tx *Transaction) workingTable(db *TransactionalDatabase, table *Table) *Table {
+	state := tx.state(db)
+	if working, ok := state.working[table]; ok {
+		return working
+	}
+	snapshot, ok := state.snapshots[table]
+	if !ok {
+		// The table was created after the database was snapshotted
+		db.mu.Lock()
+		snapshot = db.snapshotTable(table)
+		db.mu.Unlock()
+		state.snapshots[table] = snapshot
+	}
+	working := snapshot.table
+	working.name = table.name
+	working.partitions = copyPartitions(snapshot.table.partitions)
+	working.indexData = newIndexData()
+	state.working[table] = &working
+	return &working
+}
+
+// commit merges the changes of the transaction into each of its databases, and resets the transaction. If any of the
+// changes conflict with changes that were committed after the transaction began, then the transaction is rolled back
+// and none of its changes are committed.
+func (tx *Transaction) commit() error {
+	dbs := make([]*TransactionalDatabase, 0, len(tx.databases))
+	for db := range tx.databases {
+		dbs = append(dbs, db)
+	}
+	// Databases are always locked in the same order, so that concurrent commits can't deadlock
+	sort.Slice(dbs, func(i, j int) bool {
+		return dbs[i].Name() < dbs[j].Name()
+	})
+	for _, db := range dbs {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+	}
+
+	type tableCommit struct {
+		db    *TransactionalDatabase
+		table *Table
+		data  *tableData
+	}
+	var commits []tableCommit
+	for _, db := range dbs {
+		state := tx.databases[db]
+		for table, working := range state.working {
+			// Changes to tables that have since been dropped are discarded
+			if !db.hasTable(table) {
+				continue
+			}
+			data, err := mergeTable(state.snapshots[table], table, db.versions[table], working)
+			if err != nil {
+				tx.rollback()
+				return err
+			}
+			if data != nil {
+				commits = append(commits, tableCommit{db: db, table: table, data: data})
+			}
+		}
+	}
+
+	for _, c := range commits {
+		c.data.applyTo(c.table)
+		c.db.versions[c.table]++
+	}
+	tx.rollback()
+	return nil
+}
+
+// rollback discards all of the transaction's changes and savepoints. If the transaction continues to be used, then
+// each database is snapshotted again.
+func (tx *Transaction) rollback() {
+	tx.databases = make(map[*TransactionalDatabase]*transactionState)
+	tx.savepoints = nil
+}
+
+// createSavepoint records the data of every working table with the given name, replacing any savepoint that already
+// has the name.
+func (tx *Transaction) createSavepoint(name string) {
+	if idx := tx.savepointIndex(name); idx >= 0 {
+		tx.savepoints = append(tx.savepoints[:idx], tx.savepoints[idx+1:]...)
+	}
+	savepoint := &transactionSavepoint{
+		name: name,
+		data: make(map[*TransactionalDatabase]map[*Table]tableData),
+	}
+	for db, state := range tx.databases {
+		dbData := make(map[*Table]tableData)
+		for table, working := range state.working {
+			dbData[table] = newTableData(working)
+		}
+		savepoint.data[db] = dbData
+	}
+	tx.savepoints = append(tx.savepoints, savepoint)
+}
+
+// rollbackToSavepoint restores every working table to the data recorded in the named savepoint. Tables that were first
+// accessed after the savepoint was created are restored to their snapshots. As in MySQL, savepoints that were created
+// after the named savepoint are removed.
+func (tx *Transaction) rollbackToSavepoint(name string) error {
+	idx := tx.savepointIndex(name)
+	if idx < 0 {
+		return sql.ErrSavepointDoesNotExist.New(name)
+	}
+	savepoint := tx.savepoints[idx]
+	for db, state := range tx.databases {
+		for table, working := range state.working {
+			data, ok := savepoint.data[db][table]
+			if !ok {
+				snapshot := state.snapshots[table]
+				data = newTableData(&snapshot.table)
+			}
+			data.copy().applyTo(working)
+		}
+	}
+	tx.savepoints = tx.savepoints[:idx+1]
+	return nil
+}
+
+// releaseSavepoint removes the named savepoint.
+func (tx *Transaction) releaseSavepoint(name string) error {
+	idx := tx.savepointIndex(name)
+	if idx < 0 {
+		return sql.ErrSavepointDoesNotExist.New(name)
+	}
+	tx.savepoints = append(tx.savepoints[:idx], tx.savepoints[idx+1:]...)
+	return nil
+}
+
+// savepointIndex returns the index of the named savepoint, or -1 if the savepoint does not exist. Savepoint names are
+// case-insensitive.
+func (tx *Transaction) savepointIndex(name string) int {
+	for i, savepoint := range tx.savepoints {
+		if strings.EqualFold(savepoint.name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// newTableData returns a copy of the data of the given table.
+func newTableData(table *Table) tableData {
+	data := tableData{
+		schema:        table.schema,
+		partitions:    table.partitions,
+		partitionKeys: table.partitionKeys,
+		insertPartIdx: table.insertPartIdx,
+		autoIncVal:    table.autoIncVal,
+		autoColIdx:    table.autoColIdx,
+	}
+	return data.copy()
+}
+
+// copy returns a copy of the data that can be modified without affecting the original.
+func (d tableData) copy() tableData {
+	d.schema.PkOrdinals = append([]int(nil), d.schema.PkOrdinals...)
+	d.partitions = copyPartitions(d.partitions)
+	return d
+}
+
+// applyTo replaces the data of the given table.
+func (d tableData) applyTo(table *Table) {
+	table.schema = d.schema
+	table.partitions = d.partitions
+	table.partitionKeys = d.partitionKeys
+	table.insertPartIdx = d.insertPartIdx
+	table.autoIncVal = d.autoIncVal
+	table.autoColIdx = d.autoColIdx
+	table.indexData.reset()
+}
+
+// copyPartitions returns a copy of the given partitions. Rows are never modified in place, so only the slices that
+// hold them are copied.
+func copyPartitions(partitions map[string][]sql.Row) map[string][]sql.Row {
+	newPartitions := make(map[string][]sql.Row, len(partitions))
+	for key, rows := range partitions {
+		newPartitions[key] = append(make([]sql.Row, 0, len(rows)), rows...)
+	}
+	return newPartitions
+}
+
+// mergeTable returns the data that results from merging the changes that a transaction made to its working copy of a
+// table into the table's current data. Returns nil if the transaction made no changes to the table, and an error if
+// its changes conflict with changes that were committed after the table was snapshotted.
+func mergeTable(snapshot tableSnapshot, table *Table, version uint64, working *Table) (*tableData, error) {
+	base := &snapshot.table
+	schemaChanged := !working.schema.Schema.Equals(base.schema.Schema) ||
+		!intSlicesEqual(working.schema.PkOrdinals, base.schema.PkOrdinals)
+	baseRows := rowsByKey(base)
+	workingRows := rowsByKey(working)
+
+	var changedKeys []string
+	for key, rows := range workingRows {
+		if !rowListsEqual(rows, baseRows[key]) {
+			changedKeys = append(changedKeys, key)
+		}
+	}
+	for key, rows := range baseRows {
+		if _, ok := workingRows[key]; !ok && len(rows) > 0 {
+			changedKeys = append(changedKeys, key)
+		}
+	}
+
+	if !schemaChanged && len(changedKeys) == 0 && working.autoIncVal == base.autoIncVal {
+		return nil, nil
+	}
+
+	// If nothing was committed since the snapshot was taken, then the working copy becomes the table's data
+	if version == snapshot.version {
+		data := newTableData(working)
+		return &data, nil
+	}
+	if schemaChanged {
+		return nil, sql.ErrLockDeadlock.New(fmt.Sprintf("the schema of table %s was changed by a concurrent transaction", table.name))
+	}
+
+	tableRows := rowsByKey(table)
+	keyless := sql.IsKeyless(table.schema.Schema)
+	newRows := make(map[string][]sql.Row, len(changedKeys))
+	for _, key := range changedKeys {
+		if keyless {
+			// Keyless tables may hold duplicate rows, so each side's changes are applied to the number of copies
+			count := len(tableRows[key]) + len(workingRows[key]) - len(baseRows[key])
+			if count < 0 {
+				return nil, sql.ErrLockDeadlock.New(fmt.Sprintf("a row in table %s was deleted by a concurrent transaction", table.name))
+			}
+			for i := 0; i < count; i++ {
+				newRows[key] = append(newRows[key], firstRow(workingRows[key], tableRows[key]))
+			}
+		} else {
+			if !rowListsEqual(baseRows[key], tableRows[key]) && !rowListsEqual(workingRows[key], tableRows[key]) {
+				return nil, sql.ErrLockDeadlock.New(fmt.Sprintf("a row in table %s was changed by a concurrent transaction", table.name))
+			}
+			newRows[key] = workingRows[key]
+		}
+	}
+
+	merged := *table
+	merged.partitions = make(map[string][]sql.Row, len(table.partitions))
+	for partKey, rows := range table.partitions {
+		newPartition := make([]sql.Row, 0, len(rows))
+		for _, row := range rows {
+			if _, ok := newRows[rowKey(&merged, row)]; !ok {
+				newPartition = append(newPartition, row)
+			}
+		}
+		merged.partitions[partKey] = newPartition
+	}
+	for _, key := range changedKeys {
+		for _, row := range newRows[key] {
+			partKey := string(merged.partitionKeys[merged.insertPartIdx])
+			merged.insertPartIdx = (merged.insertPartIdx + 1) % len(merged.partitionKeys)
+			merged.partitions[partKey] = append(merged.partitions[partKey], row)
+		}
+	}
+	if !keyless {
+		merged.sortRows()
+	}
+	if working.autoIncVal > merged.autoIncVal {
+		merged.autoIncVal = working.autoIncVal
+	}
+
+	data := newTableData(&merged)
+	return &data, nil
+}
+
+// rowsByKey returns the rows of the given table, grouped by their keys.
+func rowsByKey(table *Table) map[string][]sql.Row {
+	rows := make(map[string][]sql.Row)
+	for _, partition := range table.partitions {
+		for _, row := range partition {
+			key := rowKey(table, row)
+			rows[key] = append(rows[key], row)
+		}
+	}
+	return rows
+}
+
+// rowKey returns the key that identifies the given row. Rows of keyed tables are identified by their primary key, while
+// rows of keyless tables are identified by all of their values.
+func rowKey(table *Table, row sql.Row) string {
+	if len(table.schema.PkOrdinals) == 0 {
+		return encodeValues(row)
+	}
+	key := make(sql.Row, len(table.schema.PkOrdinals))
+	for i, ord := range table.schema.PkOrdinals {
+		key[i] = row[ord]
+	}
+	return encodeValues(key)
+}
+
+// encodeValues returns an encoding of the given values that no other list of values shares. Each value is written
+// with its type and the length of its representation, so that values of different types that print the same, or
+// values that contain the text of a boundary between values, can't be confused.
+func encodeValues(values sql.Row) string {
+	var sb strings.Builder
+	for _, v := range values {
+		if v == nil {
+			sb.WriteString("nil;")
+			continue
+		}
+		str := fmt.Sprintf("%v", v)
+		fmt.Fprintf(&sb, "%T:%d:%s;", v, len(str), str)
+	}
+	return sb.String()
+}
+
+// rowListsEqual returns whether the given lists hold the same rows, with values of different types never being equal.
+func rowListsEqual(left, right []sql.Row) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if encodeValues(left[i]) != encodeValues(right[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// firstRow returns the first row of the first list that isn't empty.
+func firstRow(lists ...[]sql.Row) sql.Row {
+	for _, rows := range lists {
+		if len(rows) > 0 {
+			return rows[0]
+		}
+	}
+	return nil
+}
+
+func intSlicesEqual(left, right []int) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if left[i] != right[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TransactionalSession is a sql.Session that commits the transactions of TransactionalDatabases. The engine commits the
+// transaction of each statement through the session when autocommit is enabled, which sql.BaseSession does not do.
+type TransactionalSession struct {
+	sql.Session
+}
+
+// NewTransactionalSession returns a TransactionalSession that wraps the given session.
+func NewTransactionalSession(sess sql.Session) *TransactionalSession {
+	return &TransactionalSession{Session: sess}
+}
+
+// CommitTransaction implements the interface sql.Session.
+func (s *TransactionalSession) CommitTransaction(ctx *sql.Context, dbName string, tx sql.Transaction) error {
+	if memTx, ok := tx.(*Transaction); ok {
+		return memTx.commit()
+	}
+	return s.Session.CommitTransaction(ctx, dbName, tx)
+}
@@ -0,0 +1,225 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+type transactionTestQuery struct {
+	client      string
+	query       string
+	expected    []sql.Row
+	expectedErr *errors.Kind
+}
+
+func TestTransactionalDatabase(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   []string
+		queries []transactionTestQuery
+	}{
+		{
+			name:  "autocommit",
+			setup: []string{"create table t (pk int primary key, v int)", "insert into t values (1, 1)"},
+			queries: []transactionTestQuery{
+				{client: "a", query: "insert into t values (2, 2)"},
+				{client: "b", query: "select * from t order by pk", expected: []sql.Row{{1, 1}, {2, 2}}},
+			},
+		},
+		{
+			name:  "uncommitted changes are isolated",
+			setup: []string{"create table t (pk int primary key, v int)", "insert into t values (1, 1)"},
+			queries: []transactionTestQuery{
+				{client: "a", query: "start transaction"},
+				{client: "a", query: "insert into t values (2, 2)"},
+				{client: "a", query: "select * from t order by pk", expected: []sql.Row{{1, 1}, {2, 2}}},
+				{client: "b", query: "select * from t order by pk", expected: []sql.Row{{1, 1}}},
+				{client: "a", query: "commit"},
+				{client: "b", query: "select * from t order by pk", expected: []sql.Row{{1, 1}, {2, 2}}},
+			},
+		},
+		{
+			name:  "repeatable reads",
+			setup: []string{"create table t (pk int primary key, v int)", "insert into t values (1, 1)"},
+			queries: []transactionTestQuery{
+				{client: "b", query: "start transaction"},
+				{client: "b", query: "select count(*) from t", expected: []sql.Row{{1}}},
+				{client: "a", query: "insert into t values (2, 2)"},
+				{client: "b", query: "select count(*) from t", expected: []sql.Row{{1}}},
+				{client: "b", query: "commit"},
+				{client: "b", query: "select count(*) from t", expected: []sql.Row{{2}}},
+			},
+		},
+		{
+			name:  "rollback",
+			setup: []string{"create table t (pk int primary key, v int)", "insert into t values (1, 1)"},
+			queries: []transactionTestQuery{
+				{client: "a", query: "start transaction"},
+				{client: "a", query: "insert into t values (2, 2)"},
+				{client: "a", query: "update t set v = 10 where pk = 1"},
+				{client: "a", query: "rollback"},
+				{client: "a", query: "select * from t order by pk", expected: []sql.Row{{1, 1}}},
+				{client: "b", query: "select * from t order by pk", expected: []sql.Row{{1, 1}}},
+			},
+		},
+		{
+			name:  "savepoints",
+			setup: []string{"create table t (pk int primary key, v int)"},
+			queries: []transactionTestQuery{
+				{client: "a", query: "start transaction"},
+				{client: "a", query: "insert into t values (1, 1)"},
+				{client: "a", query: "savepoint s1"},
+				{client: "a", query: "insert into t values (2, 2)"},
+				{client: "a", query: "savepoint s2"},
+				{client: "a", query: "insert into t values (3, 3)"},
+				{client: "a", query: "rollback to savepoint s2"},
+				{client: "a", query: "select * from t order by pk", expected: []sql.Row{{1, 1}, {2, 2}}},
+				{client: "a", query: "rollback to savepoint s1"},
+				{client: "a", query: "select * from t order by pk", expected: []sql.Row{{1, 1}}},
+				{client: "a", query: "rollback to savepoint s2", expectedErr: sql.ErrSavepointDoesNotExist},
+				{client: "a", query: "release savepoint s1"},
+				{client: "a", query: "rollback to savepoint s1", expectedErr: sql.ErrSavepointDoesNotExist},
+				{client: "a", query: "commit"},
+				{client: "b", query: "select * from t order by pk", expected: []sql.Row{{1, 1}}},
+			},
+		},
+		{
+			name:  "non-conflicting changes are merged",
+			setup: []string{"create table t (pk int primary key, v int)", "insert into t values (1, 1), (2, 2)"},
+			queries: []transactionTestQuery{
+				{client: "a", query: "start transaction"},
+				{client: "b", query: "start transaction"},
+				{client: "a", query: "update t set v = 10 where pk = 1"},
+				{client: "b", query: "update t set v = 20 where pk = 2"},
+				{client: "b", query: "insert into t values (3, 3)"},
+				{client: "a", query: "delete from t where pk = 1"},
+				{client: "b", query: "commit"},
+				{client: "a", query: "commit"},
+				{client: "a", query: "select * from t order by pk", expected: []sql.Row{{2, 20}, {3, 3}}},
+			},
+		},
+		{
+			name:  "conflicting changes fail to commit",
+			setup: []string{"create table t (pk int primary key, v int)", "insert into t values (1, 1)"},
+			queries: []transactionTestQuery{
+				{client: "a", query: "start transaction"},
+				{client: "b", query: "start transaction"},
+				{client: "a", query: "update t set v = 10 where pk = 1"},
+				{client: "b", query: "update t set v = 20 where pk = 1"},
+				{client: "a", query: "commit"},
+				{client: "b", query: "commit", expectedErr: sql.ErrLockDeadlock},
+				{client: "b", query: "select * from t", expected: []sql.Row{{1, 10}}},
+			},
+		},
+		{
+			name: "composite keys that print alike don't conflict",
+			setup: []string{
+				"create table t (a varchar(10), b varchar(10), v int, primary key (a, b))",
+				"insert into t values ('x y', 'z', 1)",
+			},
+			queries: []transactionTestQuery{
+				{client: "a", query: "start transaction"},
+				{client: "b", query: "start transaction"},
+				{client: "a", query: "insert into t values ('x', 'y z', 2)"},
+				{client: "b", query: "update t set v = 10 where a = 'x y'"},
+				{client: "a", query: "commit"},
+				{client: "b", query: "commit"},
+				{client: "a", query: "select * from t order by a", expected: []sql.Row{{"x", "y z", 2}, {"x y", "z", 10}}},
+			},
+		},
+		{
+			name:  "keyless tables",
+			setup: []string{"create table t (a int, b int)", "insert into t values (1, 1), (1, 1)"},
+			queries: []transactionTestQuery{
+				{client: "a", query: "start transaction"},
+				{client: "b", query: "start transaction"},
+				{client: "a", query: "insert into t values (1, 1)"},
+				{client: "b", query: "insert into t values (1, 1), (2, 2)"},
+				{client: "a", query: "commit"},
+				{client: "b", query: "commit"},
+				{client: "a", query: "select a, count(*) from t group by a order by a", expected: []sql.Row{{1, 4}, {2, 1}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := memory.NewTransactionalDatabase("mydb")
+			e := sqle.NewDefault(memory.NewMemoryDBProvider(db))
+			clients := map[string]*sql.Context{
+				"setup": newTransactionalContext(),
+				"a":     newTransactionalContext(),
+				"b":     newTransactionalContext(),
+			}
+			for _, query := range test.setup {
+				runTransactionTestQuery(t, e, clients["setup"], query)
+			}
+			for _, q := range test.queries {
+				ctx := clients[q.client]
+				if q.expectedErr != nil {
+					_, iter, err := e.Query(ctx, q.query)
+					if err == nil {
+						_, err = sql.RowIterToRows(ctx, nil, iter)
+					}
+					require.Error(t, err, q.query)
+					require.True(t, q.expectedErr.Is(err), "%s: unexpected error %v", q.query, err)
+					continue
+				}
+				rows := runTransactionTestQuery(t, e, ctx, q.query)
+				if q.expected != nil {
+					require.Equal(t, q.expected, widenRows(rows), q.query)
+				}
+			}
+		})
+	}
+}
+
+func newTransactionalContext() *sql.Context {
+	ctx := sql.NewContext(context.Background(), sql.WithSession(memory.NewTransactionalSession(sql.NewBaseSession())))
+	ctx.SetCurrentDatabase("mydb")
+	return ctx
+}
+
+func runTransactionTestQuery(t *testing.T, e *sqle.Engine, ctx *sql.Context, query string) []sql.Row {
+	sch, iter, err := e.Query(ctx, query)
+	require.NoError(t, err, query)
+	rows, err := sql.RowIterToRows(ctx, sch, iter)
+	require.NoError(t, err, query)
+	return rows
+}
+
+// widenRows converts the integers of the given rows to int, so that they may be compared with the expected rows.
+func widenRows(rows []sql.Row) []sql.Row {
+	for _, row := range rows {
+		for i, val := range row {
+			switch val := val.(type) {
+			case int32:
+				row[i] = int(val)
+			case int64:
+				row[i] = int(val)
+			}
+		}
+	}
+	return rows
+}
@@ -373,6 +373,17 @@ func NewSkipPruneRuleSelector(sel RuleSelector) RuleSelector {
 	}
 }
 
+// newSkipParallelizeRuleSelector returns a selector that excludes the parallelize rule, so that the plan is executed
+// without an Exchange node.
+func newSkipParallelizeRuleSelector(sel RuleSelector) RuleSelector {
+	return func(id RuleId) bool {
+		if id == parallelizeId {
+			return false
+		}
+		return sel(id)
+	}
+}
+
 func NewResolveSubqueryExprSelector(sel RuleSelector) RuleSelector {
 	return func(id RuleId) bool {
 		switch id {
@@ -444,7 +455,7 @@ func prePrepareRuleSelector(id RuleId) bool {
 		// DefaultValidation
 		validateResolvedId,
 		validateOrderById,
-		validateGroupById,
+		validateOnlyFullGroupById,
 		validateSchemaSourceId,
 		validateIndexCreationId,
 		validateOperandsId,
@@ -496,6 +507,8 @@ func postPrepareRuleSelector(id RuleId) bool {
 		flattenAggregationExprsId,
 
 		// OnceAfterDefault
+		applyFulltextIndexesId,
+		applySpatialIndexesId,
 		subqueryIndexesId,
 		inSubqueryIndexesId,
 		stripTableNameInDefaultsId,
@@ -0,0 +1,201 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+)
+
+// applyFulltextIndexes assigns every MATCH expression the FULLTEXT index over its columns, which it needs to score the
+// relevance of rows. A table that is filtered by a MATCH expression with a constant search is then replaced with a
+// lookup of the index, which returns only the rows that match the search.
+func applyFulltextIndexes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, sel RuleSelector) (sql.Node, transform.TreeIdentity, error) {
+	span, ctx := ctx.Span("apply_fulltext_indexes")
+	defer span.End()
+
+	var hasMatch bool
+	transform.InspectExpressions(n, func(e sql.Expression) bool {
+		if _, ok := e.(*expression.Match); ok {
+			hasMatch = true
+		}
+		return !hasMatch
+	})
+	if !hasMatch {
+		return n, transform.SameTree, nil
+	}
+
+	indexes, err := fulltextIndexesByTable(ctx, n)
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+
+	n, same, err := transform.NodeExprs(n, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+		m, ok := e.(*expression.Match)
+		if !ok {
+			return e, transform.SameTree, nil
+		}
+		table := matchTable(m)
+		tableIndexes, ok := indexes[table]
+		if !ok {
+			// The columns belong to a table of an outer scope, or to no single table
+			return e, transform.SameTree, nil
+		}
+		for _, idx := range tableIndexes {
+			if nm, ok := m.WithIndex(idx); ok {
+				return nm, transform.NewTree, nil
+			}
+		}
+		return nil, transform.SameTree, sql.ErrNoFulltextIndex.New()
+	})
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+
+	n, sameFilters, err := transform.Node(n, func(node sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, transform.SameTree, nil
+		}
+		return applyFulltextLookup(filter)
+	})
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+	return n, same && sameFilters, nil
+}
+
+// applyFulltextLookup replaces the table under the filter with a lookup of a FULLTEXT index, if the filter has a
+// MATCH condition with a constant search of the table. The lookup returns exactly the rows that match the search, so
+// the condition is removed from the filter. Any other MATCH condition is replaced with a comparison of the relevance
+// to zero, as filters would otherwise round the relevance to an integer.
+func applyFulltextLookup(filter *plan.Filter) (sql.Node, transform.TreeIdentity, error) {
+	var rt *plan.ResolvedTable
+	switch child := filter.Child.(type) {
+	case *plan.ResolvedTable:
+		rt = child
+	case *plan.TableAlias:
+		rt, _ = child.Child.(*plan.ResolvedTable)
+	}
+
+	var lookup *sql.IndexLookup
+	var conds []sql.Expression
+	var same = transform.SameTree
+	for _, cond := range splitConjunction(filter.Expression) {
+		m, ok := cond.(*expression.Match)
+		if !ok || m.Index == nil {
+			conds = append(conds, cond)
+			continue
+		}
+		same = transform.NewTree
+		if search, ok := m.Search(); ok && lookup == nil && rt != nil &&
+			matchTable(m) == strings.ToLower(filter.Child.(sql.Nameable).Name()) {
+			lookup = &sql.IndexLookup{Index: m.Index, Fulltext: &search}
+			continue
+		}
+		conds = append(conds, expression.NewGreaterThan(m, expression.NewLiteral(float64(0), sql.Float64)))
+	}
+	if same {
+		return filter, transform.SameTree, nil
+	}
+
+	child := filter.Child
+	if lookup != nil {
+		ita, err := plan.NewStaticIndexedAccessForResolvedTable(rt, *lookup)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		child = ita
+		if ta, ok := filter.Child.(*plan.TableAlias); ok {
+			child, err = ta.WithChildren(ita)
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+		}
+	}
+	if len(conds) == 0 {
+		return child, transform.NewTree, nil
+	}
+	return plan.NewFilter(expression.JoinAnd(conds...), child), transform.NewTree, nil
+}
+
+// matchTable returns the lowercase name of the table of the columns of the MATCH expression, or the empty string if
+// they don't all belong to the same table.
+func matchTable(m *expression.Match) string {
+	var table string
+	for i, col := range m.Columns {
+		gf, ok := col.(*expression.GetField)
+		if !ok {
+			return ""
+		}
+		if i == 0 {
+			table = strings.ToLower(gf.Table())
+		} else if strings.ToLower(gf.Table()) != table {
+			return ""
+		}
+	}
+	return table
+}
+
+// fulltextIndexesByTable returns the FULLTEXT indexes of the tables in the node, keyed by the lowercase name or alias
+// of the table. Tables that have no FULLTEXT indexes are included with no indexes.
+func fulltextIndexesByTable(ctx *sql.Context, n sql.Node) (map[string][]sql.FulltextIndex, error) {
+	indexes := make(map[string][]sql.FulltextIndex)
+	var err error
+	addTable := func(name string, rt *plan.ResolvedTable) {
+		table := rt.Table
+		if w, ok := table.(sql.TableWrapper); ok {
+			table = w.Underlying()
+		}
+		name = strings.ToLower(name)
+		indexes[name] = nil
+		it, ok := table.(sql.IndexAddressableTable)
+		if !ok {
+			return
+		}
+		var tableIndexes []sql.Index
+		tableIndexes, err = it.GetIndexes(ctx)
+		for _, idx := range tableIndexes {
+			if ftIdx, ok := idx.(sql.FulltextIndex); ok {
+				indexes[name] = append(indexes[name], ftIdx)
+			}
+		}
+	}
+
+	transform.Inspect(n, func(n sql.Node) bool {
+		if err != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *plan.TableAlias:
+			switch child := n.Child.(type) {
+			case *plan.ResolvedTable:
+				addTable(n.Name(), child)
+			case *plan.IndexedTableAccess:
+				addTable(n.Name(), child.ResolvedTable)
+			}
+		case *plan.ResolvedTable:
+			addTable(n.Name(), n)
+		case *plan.IndexedTableAccess:
+			addTable(n.Name(), n.ResolvedTable)
+		}
+		return true
+	})
+	return indexes, err
+}
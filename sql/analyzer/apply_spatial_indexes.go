@@ -0,0 +1,174 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+)
+
+// applySpatialIndexes replaces a table that is filtered by a spatial condition between one of its geometry columns
+// and a constant geometry with a lookup of a SPATIAL index on the column. The lookup returns the rows whose
+// geometries have a bounding box that intersects the bounding box of the constant, which every row that satisfies
+// the condition must have. It may return other rows too, so the condition is kept in the filter.
+func applySpatialIndexes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, sel RuleSelector) (sql.Node, transform.TreeIdentity, error) {
+	span, ctx := ctx.Span("apply_spatial_indexes")
+	defer span.End()
+
+	return transform.Node(n, func(node sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, transform.SameTree, nil
+		}
+		return applySpatialLookup(ctx, filter)
+	})
+}
+
+// applySpatialLookup replaces the table under the filter with a lookup of a SPATIAL index, for the first condition of
+// the filter that can use one.
+func applySpatialLookup(ctx *sql.Context, filter *plan.Filter) (sql.Node, transform.TreeIdentity, error) {
+	var rt *plan.ResolvedTable
+	switch child := filter.Child.(type) {
+	case *plan.ResolvedTable:
+		rt = child
+	case *plan.TableAlias:
+		rt, _ = child.Child.(*plan.ResolvedTable)
+	}
+	if rt == nil {
+		return filter, transform.SameTree, nil
+	}
+
+	indexes, err := spatialIndexes(ctx, rt)
+	if err != nil || len(indexes) == 0 {
+		return filter, transform.SameTree, err
+	}
+
+	tableName := strings.ToLower(filter.Child.(sql.Nameable).Name())
+	for _, cond := range splitConjunction(filter.Expression) {
+		col, geom, ok := spatialLookupArgs(cond)
+		if !ok || strings.ToLower(col.Table()) != tableName {
+			continue
+		}
+		idx := spatialIndexOnColumn(indexes, col.Name())
+		if idx == nil {
+			continue
+		}
+
+		// Conditions on geometries that can't be evaluated here are left for the filter to report
+		val, err := geom.Eval(ctx, nil)
+		if err != nil {
+			continue
+		}
+		g, ok := val.(sql.GeometryValue)
+		if !ok {
+			continue
+		}
+		if srid, _ := idx.SRID(); g.GetSRID() != srid {
+			continue
+		}
+		box, ok := sql.BoundingBox(g)
+		if !ok {
+			continue
+		}
+
+		var child sql.Node
+		child, err = plan.NewStaticIndexedAccessForResolvedTable(rt, sql.IndexLookup{Index: idx, Spatial: &box})
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		if ta, ok := filter.Child.(*plan.TableAlias); ok {
+			child, err = ta.WithChildren(child)
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+		}
+		return plan.NewFilter(filter.Expression, child), transform.NewTree, nil
+	}
+	return filter, transform.SameTree, nil
+}
+
+// spatialLookupArgs returns the column and the constant geometry of a spatial condition that can only be satisfied
+// by geometries whose bounding box intersects that of the constant, or false if the condition isn't one.
+func spatialLookupArgs(cond sql.Expression) (*expression.GetField, sql.Expression, bool) {
+	var left, right sql.Expression
+	switch cond := cond.(type) {
+	case *function.STContains:
+		left, right = cond.Left, cond.Right
+	case *function.STWithin:
+		left, right = cond.Left, cond.Right
+	case *function.STIntersects:
+		left, right = cond.Left, cond.Right
+	case *function.MBRContains:
+		left, right = cond.Left, cond.Right
+	case *function.MBRWithin:
+		left, right = cond.Left, cond.Right
+	case *function.MBRIntersects:
+		left, right = cond.Left, cond.Right
+	default:
+		return nil, nil, false
+	}
+
+	if gf, ok := left.(*expression.GetField); ok && isEvaluable(right) {
+		return gf, right, true
+	}
+	if gf, ok := right.(*expression.GetField); ok && isEvaluable(left) {
+		return gf, left, true
+	}
+	return nil, nil, false
+}
+
+// spatialIndexes returns the SPATIAL indexes of the table that can be used for lookups, which are those on columns
+// with a fixed SRID.
+func spatialIndexes(ctx *sql.Context, rt *plan.ResolvedTable) ([]sql.SpatialIndex, error) {
+	table := rt.Table
+	if w, ok := table.(sql.TableWrapper); ok {
+		table = w.Underlying()
+	}
+	it, ok := table.(sql.IndexAddressableTable)
+	if !ok {
+		return nil, nil
+	}
+	tableIndexes, err := it.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []sql.SpatialIndex
+	for _, idx := range tableIndexes {
+		if spIdx, ok := idx.(sql.SpatialIndex); ok && len(spIdx.Expressions()) == 1 {
+			if _, ok := spIdx.SRID(); ok {
+				indexes = append(indexes, spIdx)
+			}
+		}
+	}
+	return indexes, nil
+}
+
+// spatialIndexOnColumn returns the index of those given whose expression is the column with the name given, or nil
+// if there is none.
+func spatialIndexOnColumn(indexes []sql.SpatialIndex, column string) sql.SpatialIndex {
+	for _, idx := range indexes {
+		expr := idx.Expressions()[0]
+		if strings.EqualFold(expr[strings.LastIndex(expr, ".")+1:], column) {
+			return idx
+		}
+	}
+	return nil
+}
@@ -49,18 +49,13 @@ func validateCreateTableChecks(ctx *sql.Context, a *Analyzer, n *plan.CreateTabl
 		return nil, transform.SameTree, err
 	}
 
-	transform.InspectExpressions(n, func(e sql.Expression) bool {
-		if err != nil {
-			return false
-		}
+	// Only the check expressions must be validated, not column defaults, generated columns or functional key parts
+	for _, ch := range n.TableSpec().ChDefs {
+		sql.Inspect(ch.Expr, func(e sql.Expression) bool {
+			if err != nil {
+				return false
+			}
 
-		switch e := e.(type) {
-		case *expression.Wrapper, nil:
-			// column defaults, no need to inspect these
-			return false
-		default:
-			// check expressions, must be validated
-			// TODO: would be better to wrap these in something else to be able to identify them better
 			err = checkExpressionValid(e)
 			if err != nil {
 				return false
@@ -76,8 +71,8 @@ func validateCreateTableChecks(ctx *sql.Context, a *Analyzer, n *plan.CreateTabl
 			}
 
 			return true
-		}
-	})
+		})
+	}
 
 	if err != nil {
 		return nil, transform.SameTree, err
@@ -43,6 +43,8 @@ func (c *coster) costRel(n relExpr) (float64, error) {
 		return c.costLeftJoin(n)
 	case *hashJoin:
 		return c.costHashJoin(n)
+	case *mergeJoin:
+		return c.costMergeJoin(n)
 	case *lookupJoin:
 		return c.costLookupJoin(n)
 	case *semiJoin:
@@ -74,19 +76,11 @@ func (c *coster) costScan(t *tableScan) (float64, error) {
 }
 
 func (c *coster) costRead(t sql.Table) (float64, error) {
-	if w, ok := t.(sql.TableWrapper); ok {
-		t = w.Underlying()
-	}
-	tab, ok := t.(sql.StatisticsTable)
-	if !ok {
-		return 1000, nil
-	}
-	stats, err := tab.Statistics(c.ctx)
+	rowCount, err := sql.EstimateRowCount(c.ctx, t)
 	if err != nil {
 		return float64(0), err
 	}
-	return float64(stats.RowCount()), nil
-
+	return float64(rowCount), nil
 }
 
 func (c *coster) costValues(v *values) (float64, error) {
@@ -126,6 +120,21 @@ func (c *coster) costHashJoin(n *hashJoin) (float64, error) {
 	return l + r + buildProbe, nil
 }
 
+// costMergeJoin costs the full index scans a merge join reads its inputs
+// with, rather than the cheapest plans for its children, since any lookup
+// those plans would otherwise use is replaced by the ordered scan.
+func (c *coster) costMergeJoin(n *mergeJoin) (float64, error) {
+	l, err := c.costRead(n.leftScan.table)
+	if err != nil {
+		return float64(0), err
+	}
+	r, err := c.costRead(n.rightScan.table)
+	if err != nil {
+		return float64(0), err
+	}
+	return l + r, nil
+}
+
 func (c *coster) costLookupJoin(n *lookupJoin) (float64, error) {
 	l := n.left.cost
 	m := lookupMultiplier(n.lookup, len(n.filter))
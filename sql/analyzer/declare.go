@@ -27,6 +27,9 @@ import (
 type declarationScope struct {
 	parent     *declarationScope
 	conditions map[string]*plan.DeclareCondition
+	variables  map[string]struct{}
+	cursors    map[string]struct{}
+	handlers   map[string]struct{}
 }
 
 // newDeclarationScope returns a *declarationScope.
@@ -34,6 +37,9 @@ func newDeclarationScope(parent *declarationScope) *declarationScope {
 	return &declarationScope{
 		parent:     parent,
 		conditions: make(map[string]*plan.DeclareCondition),
+		variables:  make(map[string]struct{}),
+		cursors:    make(map[string]struct{}),
+		handlers:   make(map[string]struct{}),
 	}
 }
 
@@ -48,6 +54,42 @@ func (d *declarationScope) AddCondition(condition *plan.DeclareCondition) error
 	return nil
 }
 
+// AddVariables adds the variables to the scope. Returns an error if a variable with the same name already exists in
+// this scope.
+func (d *declarationScope) AddVariables(variables *plan.DeclareVariables) error {
+	for _, name := range variables.Names {
+		name = strings.ToLower(name)
+		if _, ok := d.variables[name]; ok {
+			return sql.ErrDeclareVariableDuplicate.New(name)
+		}
+		d.variables[name] = struct{}{}
+	}
+	return nil
+}
+
+// AddCursor adds the cursor to the scope. Returns an error if a cursor with the same name already exists in this scope.
+func (d *declarationScope) AddCursor(cursor *plan.DeclareCursor) error {
+	name := strings.ToLower(cursor.Name)
+	if _, ok := d.cursors[name]; ok {
+		return sql.ErrDeclareCursorDuplicate.New(name)
+	}
+	d.cursors[name] = struct{}{}
+	return nil
+}
+
+// AddHandler adds the conditions of the handler to the scope. Returns an error if another handler in this scope
+// already handles one of the conditions. The handler's conditions must already have been resolved.
+func (d *declarationScope) AddHandler(handler *plan.DeclareHandler) error {
+	for _, condition := range handler.Conditions {
+		key := condition.String()
+		if _, ok := d.handlers[key]; ok {
+			return sql.ErrDeclareHandlerDuplicate.New()
+		}
+		d.handlers[key] = struct{}{}
+	}
+	return nil
+}
+
 // GetCondition returns the condition from the scope. If the condition is not found in the current scope, then walks
 // up the parent until it is found. Returns a bool regarding whether it was found.
 func (d *declarationScope) GetCondition(name string) *plan.DeclareCondition {
@@ -86,24 +128,52 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 		// Documentation on the ordering of DECLARE statements.
 		// BEGIN/END is treated specially for scope regarding DECLARE statements.
 		// https://dev.mysql.com/doc/refman/8.0/en/declare.html
-		lastStatementDeclare := true
+		// Variables and conditions come first, followed by cursors, and then handlers.
+		const (
+			declareStateVariables = iota
+			declareStateCursors
+			declareStateHandlers
+			declareStateStatements
+		)
+		state := declareStateVariables
 		for _, child := range children {
 			switch child := child.(type) {
-			case *plan.DeclareCondition:
-				if !lastStatementDeclare {
+			case *plan.DeclareCondition, *plan.DeclareVariables:
+				if state == declareStateStatements {
+					return nil, transform.SameTree, sql.ErrDeclareOrderInvalid.New()
+				} else if state != declareStateVariables {
+					return nil, transform.SameTree, sql.ErrDeclareVariableOrConditionAfterCursor.New()
+				}
+				if dc, ok := child.(*plan.DeclareCondition); ok {
+					if err := scope.AddCondition(dc); err != nil {
+						return nil, transform.SameTree, err
+					}
+				} else if err := scope.AddVariables(child.(*plan.DeclareVariables)); err != nil {
+					return nil, transform.SameTree, err
+				}
+			case *plan.DeclareCursor:
+				if state == declareStateStatements {
 					return nil, transform.SameTree, sql.ErrDeclareOrderInvalid.New()
+				} else if state == declareStateHandlers {
+					return nil, transform.SameTree, sql.ErrDeclareCursorAfterHandler.New()
 				}
-				if err := scope.AddCondition(child); err != nil {
+				state = declareStateCursors
+				if err := scope.AddCursor(child); err != nil {
 					return nil, transform.SameTree, err
 				}
+			case *plan.DeclareHandler:
+				if state == declareStateStatements {
+					return nil, transform.SameTree, sql.ErrDeclareOrderInvalid.New()
+				}
+				state = declareStateHandlers
 			default:
-				lastStatementDeclare = false
+				state = declareStateStatements
 			}
 		}
 	} else {
 		for _, child := range children {
 			switch child.(type) {
-			case *plan.DeclareCondition:
+			case *plan.DeclareCondition, *plan.DeclareVariables, *plan.DeclareCursor, *plan.DeclareHandler:
 				return nil, transform.SameTree, sql.ErrDeclareOrderInvalid.New()
 			}
 		}
@@ -124,6 +194,16 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 			newChild, same, err = resolveDeclarationsInner(ctx, a, child, scope, sel)
 		case *plan.BeginEndBlock, *plan.TriggerBeginEndBlock:
 			newChild, same, err = resolveDeclarationsInner(ctx, a, child, newDeclarationScope(scope), sel)
+		case *plan.DeclareHandler:
+			c, err = resolveDeclareHandlerConditions(c, scope)
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+			if err = scope.AddHandler(c); err != nil {
+				return nil, transform.SameTree, err
+			}
+			newChild, _, err = resolveDeclarationsInner(ctx, a, c, scope, sel)
+			same = transform.NewTree
 		case *plan.SignalName:
 			condition := scope.GetCondition(c.Name)
 			if condition == nil {
@@ -159,3 +239,33 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 
 	return node, transform.SameTree, nil
 }
+
+// resolveDeclareHandlerConditions replaces any named conditions on the handler with the SQLSTATE value or MySQL error
+// code of the referenced DECLARE CONDITION.
+func resolveDeclareHandlerConditions(handler *plan.DeclareHandler, scope *declarationScope) (*plan.DeclareHandler, error) {
+	conditions := make([]plan.DeclareHandlerCondition, len(handler.Conditions))
+	for i, condition := range handler.Conditions {
+		if condition.Type != plan.DeclareHandlerConditionType_ConditionName {
+			conditions[i] = condition
+			continue
+		}
+		dc := scope.GetCondition(condition.ConditionName)
+		if dc == nil {
+			return nil, sql.ErrDeclareConditionNotFound.New(condition.ConditionName)
+		}
+		if dc.SqlStateValue != "" {
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:          plan.DeclareHandlerConditionType_SqlState,
+				SqlStateValue: dc.SqlStateValue,
+			}
+		} else {
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:         plan.DeclareHandlerConditionType_MysqlErrorCode,
+				MysqlErrCode: dc.MysqlErrCode,
+			}
+		}
+	}
+	nh := *handler
+	nh.Conditions = conditions
+	return &nh, nil
+}
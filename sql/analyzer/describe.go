@@ -27,6 +27,11 @@ func resolveDescribeQuery(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scop
 		return n, transform.SameTree, nil
 	}
 
+	// Statistics can't be collected from the nodes below an Exchange, as it locates the table to read by type
+	if d.Analyze {
+		sel = newSkipParallelizeRuleSelector(sel)
+	}
+
 	q, _, err := a.analyzeWithSelector(ctx, d.Query(), scope, SelectAllBatches, sel)
 	if err != nil {
 		return nil, transform.SameTree, err
@@ -197,6 +197,54 @@ func (b *ExecBuilder) buildHashJoin(j *hashJoin, input sql.Schema, children ...s
 	return plan.NewJoin(inner, outer, newOp, filters).WithScopeLen(j.g.m.scopeLen), nil
 }
 
+func (b *ExecBuilder) buildIndexScan(ctx *sql.Context, i *indexScan, child sql.Node) (sql.Node, error) {
+	l, err := sql.NewIndexBuilder(i.index).Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret sql.Node
+	switch n := child.(type) {
+	case *plan.ResolvedTable:
+		ret, err = plan.NewStaticIndexedAccessForResolvedTable(n, l)
+	case *plan.TableAlias:
+		ret, err = plan.NewStaticIndexedAccessForResolvedTable(n.Child.(*plan.ResolvedTable), l)
+		ret = plan.NewTableAlias(n.Name(), ret)
+	default:
+		panic("unexpected index scan child")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (b *ExecBuilder) buildMergeJoin(j *mergeJoin, input sql.Schema, children ...sql.Node) (sql.Node, error) {
+	left, err := b.buildIndexScan(j.g.m.ctx, j.leftScan, children[0])
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.buildIndexScan(j.g.m.ctx, j.rightScan, children[1])
+	if err != nil {
+		return nil, err
+	}
+	filter, err := b.buildFilters(j.g.m.scope, input, j.filter...)
+	if err != nil {
+		return nil, err
+	}
+
+	var newOp plan.JoinType
+	switch j.op {
+	case plan.JoinTypeInner:
+		newOp = plan.JoinTypeMerge
+	case plan.JoinTypeLeftOuter:
+		newOp = plan.JoinTypeLeftOuterMerge
+	default:
+		panic("can only apply merge join to InnerJoin or LeftOuterJoin")
+	}
+	return plan.NewJoin(left, right, newOp, filter).WithScopeLen(j.g.m.scopeLen), nil
+}
+
 func (b *ExecBuilder) buildSubqueryAlias(r *subqueryAlias, input sql.Schema, children ...sql.Node) (sql.Node, error) {
 	return r.table, nil
 }
@@ -169,6 +169,9 @@ func (r *indexAnalyzer) MatchingIndexes(ctx *sql.Context, db string, table strin
 
 	var indexes []idxWithLen
 	for _, idx := range r.indexesByTable[table] {
+		if !supportsRangeLookups(idx) {
+			continue
+		}
 		indexExprs := idx.Expressions()
 		if ok, prefixCount := exprsAreIndexSubset(exprStrs, indexExprs); ok && prefixCount >= 1 {
 			indexes = append(indexes, idxWithLen{idx, len(indexExprs), prefixCount})
@@ -222,6 +225,9 @@ func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expressio
 	for _, idxes := range r.indexesByTable {
 	Indexes:
 		for _, idx := range idxes {
+			if !supportsRangeLookups(idx) {
+				continue
+			}
 			var used = make(map[int]struct{})
 			var matched []sql.Expression
 			for _, ie := range idx.Expressions() {
@@ -322,3 +328,14 @@ func exprsAreIndexSubset(exprs, indexExprs []string) (ok bool, prefixCount int)
 
 	return true, len(exprs)
 }
+
+// supportsRangeLookups returns whether the index can be used for lookups of ranges of its expressions. FULLTEXT and
+// SPATIAL indexes are only used for their own kinds of lookups.
+func supportsRangeLookups(idx sql.Index) bool {
+	switch idx.(type) {
+	case sql.FulltextIndex, sql.SpatialIndex:
+		return false
+	default:
+		return true
+	}
+}
@@ -164,6 +164,7 @@ func replanJoin(ctx *sql.Context, n *plan.JoinNode, a *Analyzer, scope *Scope) (
 
 	addLookupJoins(m)
 	addHashJoins(m)
+	addMergeJoins(m)
 
 	if a.Verbose && a.Debug {
 		ctx.GetLogger().Logger.Println(m.String())
@@ -208,36 +209,8 @@ func addLookupJoins(m *Memo) error {
 			return nil
 		}
 
-		var attributeSource string
-		var indexableTable sql.IndexAddressableTable
-		var ok bool
-		switch n := right.first.(type) {
-		case *tableAlias:
-			attributeSource = strings.ToLower(n.table.Name())
-			rt, ok := n.table.Child.(*plan.ResolvedTable)
-			if !ok {
-				return nil
-			}
-			table := rt.Table
-			if w, ok := table.(sql.TableWrapper); ok {
-				table = w.Underlying()
-			}
-			indexableTable, ok = table.(sql.IndexAddressableTable)
-			if !ok {
-				return nil
-			}
-			aliases.add(n.table, indexableTable)
-		case *tableScan:
-			attributeSource = strings.ToLower(n.table.Name())
-			table := n.table.Table
-			if w, ok := table.(sql.TableWrapper); ok {
-				table = w.Underlying()
-			}
-			indexableTable, ok = table.(sql.IndexAddressableTable)
-			if !ok {
-				return nil
-			}
-		default:
+		attributeSource, indexableTable, ok := indexableSource(right, aliases)
+		if !ok {
 			return nil
 		}
 
@@ -290,6 +263,40 @@ func addLookupJoins(m *Memo) error {
 	})
 }
 
+// indexableSource returns the name and the indexable table of the table
+// read by an expression group, if there is one.
+func indexableSource(grp *exprGroup, aliases TableAliases) (string, sql.IndexAddressableTable, bool) {
+	switch n := grp.first.(type) {
+	case *tableAlias:
+		rt, ok := n.table.Child.(*plan.ResolvedTable)
+		if !ok {
+			return "", nil, false
+		}
+		table := rt.Table
+		if w, ok := table.(sql.TableWrapper); ok {
+			table = w.Underlying()
+		}
+		indexableTable, ok := table.(sql.IndexAddressableTable)
+		if !ok {
+			return "", nil, false
+		}
+		aliases.add(n.table, indexableTable)
+		return strings.ToLower(n.table.Name()), indexableTable, true
+	case *tableScan:
+		table := n.table.Table
+		if w, ok := table.(sql.TableWrapper); ok {
+			table = w.Underlying()
+		}
+		indexableTable, ok := table.(sql.IndexAddressableTable)
+		if !ok {
+			return "", nil, false
+		}
+		return strings.ToLower(n.table.Name()), indexableTable, true
+	default:
+		return "", nil, false
+	}
+}
+
 // dfsExprGroup runs a callback |cb| on all execution plans in the memo expression
 // group. And expression group itself is defined by 1) a set of child expression
 // groups that serve as logical inputs to this operator, and 2) a set of logically
@@ -355,6 +362,9 @@ func indexMatchesKeyExprs(
 	joinColExprs []*joinColExpr,
 	tableAliases TableAliases,
 ) ([]sql.Expression, []bool) {
+	if !supportsRangeLookups(i) {
+		return nil, nil
+	}
 	idxExprs := i.Expressions()
 	count := len(idxExprs)
 	if count > len(joinColExprs) {
@@ -459,6 +469,107 @@ func addHashJoins(m *Memo) error {
 	})
 }
 
+// addMergeJoins adds merge join alternatives to memo join groups for inner
+// and left joins between two tables with an equality filter on a column of
+// each. If both tables have an ascending index whose
+// first expression is the column in the filter, reading them through those
+// indexes returns both sides sorted on the join keys, and they can be merged
+// without building a hash table or performing a lookup for every row.
+func addMergeJoins(m *Memo) error {
+	var aliases = make(TableAliases)
+	seen := make(map[GroupId]struct{})
+	return dfsExprGroup(m.root, m, seen, func(e relExpr) error {
+		switch e.(type) {
+		case *innerJoin, *leftJoin:
+		default:
+			return nil
+		}
+
+		join := e.(joinRel).joinPrivate()
+		if len(join.filter) == 0 {
+			return nil
+		}
+
+		leftSource, leftTable, ok := indexableSource(join.left, aliases)
+		if !ok {
+			return nil
+		}
+		rightSource, rightTable, ok := indexableSource(join.right, aliases)
+		if !ok {
+			return nil
+		}
+		leftIndexes, err := leftTable.GetIndexes(m.ctx)
+		if err != nil {
+			return err
+		}
+		rightIndexes, err := rightTable.GetIndexes(m.ctx)
+		if err != nil {
+			return err
+		}
+
+		for i, f := range join.filter {
+			eq, ok := f.(*expression.Equals)
+			if !ok {
+				continue
+			}
+			l, lok := eq.Left().(*expression.GetField)
+			r, rok := eq.Right().(*expression.GetField)
+			if !lok || !rok {
+				continue
+			}
+			if strings.ToLower(l.Table()) == rightSource && strings.ToLower(r.Table()) == leftSource {
+				l, r = r, l
+			}
+			if strings.ToLower(l.Table()) != leftSource || strings.ToLower(r.Table()) != rightSource {
+				continue
+			}
+			// both sides have to be sorted the same way the comparison orders them
+			if !l.Type().Equals(r.Type()) {
+				continue
+			}
+
+			leftIdx := firstOrderedIndex(leftIndexes, l, aliases)
+			rightIdx := firstOrderedIndex(rightIndexes, r, aliases)
+			if leftIdx == nil || rightIdx == nil {
+				continue
+			}
+
+			// the merge comparison is the first filter, with the left
+			// column on the left
+			filter := []sql.Expression{expression.NewEquals(l, r)}
+			filter = append(filter, join.filter[:i]...)
+			filter = append(filter, join.filter[i+1:]...)
+			base := join.copy()
+			base.filter = filter
+
+			rel := &mergeJoin{
+				joinBase:  base,
+				leftScan:  &indexScan{source: leftSource, table: leftTable, index: leftIdx},
+				rightScan: &indexScan{source: rightSource, table: rightTable, index: rightIdx},
+			}
+			e.group().append(rel)
+			return nil
+		}
+		return nil
+	})
+}
+
+// firstOrderedIndex returns the first ascending index whose first expression
+// is the column given, or nil if there is none.
+func firstOrderedIndex(indexes []sql.Index, col *expression.GetField, aliases TableAliases) sql.Index {
+	name := normalizeExpression(aliases, col).String()
+	for _, idx := range indexes {
+		oi, ok := idx.(sql.OrderedIndex)
+		if !ok || oi.Order() != sql.IndexOrderAsc {
+			continue
+		}
+		if exprs := idx.Expressions(); len(exprs) > 0 && exprs[0] == name {
+			return idx
+		}
+	}
+	return nil
+}
+
 // exprMapsToSource returns true if all GetFields in the expression
 // source outputs from |grp|
 func exprMapsToSource(e sql.Expression, grp *exprGroup, tProps *tableProps) bool {
@@ -190,6 +190,16 @@ func wrapRowSource(ctx *sql.Context, insertSource sql.Node, destTbl sql.Table, s
 			}
 		}
 
+		// The values of generated columns are computed when the row is inserted. Only VALUES lists may name them, in
+		// which case they must be DEFAULT, which was already validated.
+		if f.Generated != nil {
+			if found && !isValuesSource(insertSource) {
+				return nil, sql.ErrGeneratedColumnValue.New(f.Name, f.Source)
+			}
+			projExprs[i] = expression.NewLiteral(nil, f.Type)
+			continue
+		}
+
 		if !found {
 			if !f.Nullable && f.Default == nil && !f.AutoIncrement {
 				return nil, sql.ErrInsertIntoNonNullableDefaultNullColumn.New(f.Name)
@@ -214,6 +224,15 @@ func wrapRowSource(ctx *sql.Context, insertSource sql.Node, destTbl sql.Table, s
 	return plan.NewProject(projExprs, insertSource), nil
 }
 
+// isValuesSource returns whether the insert source given is a VALUES list.
+func isValuesSource(insertSource sql.Node) bool {
+	if exchange, ok := insertSource.(*plan.Exchange); ok {
+		insertSource = exchange.Child
+	}
+	_, ok := insertSource.(*plan.Values)
+	return ok
+}
+
 func validateColumns(columnNames []string, dstSchema sql.Schema) error {
 	dstColNames := make(map[string]struct{})
 	for _, dstCol := range dstSchema {
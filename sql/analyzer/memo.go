@@ -325,6 +325,15 @@ func (e *exprGroup) prepend(rel relExpr) {
 	rel.setNext(first)
 }
 
+// append adds a new plan to the end of an expression group's list. Plans
+// only replace the group's best plan when they are strictly cheaper, so an
+// appended plan loses ties to the plans already in the group.
+func (e *exprGroup) append(rel relExpr) {
+	rel.setNext(nil)
+	e.last.setNext(rel)
+	e.last = rel
+}
+
 func (e *exprGroup) children() []*exprGroup {
 	n := e.first
 	children := make([]*exprGroup, 0)
@@ -444,6 +453,7 @@ var _ joinRel = (*fullOuterJoin)(nil)
 var _ joinRel = (*hashJoin)(nil)
 var _ joinRel = (*innerJoin)(nil)
 var _ joinRel = (*lookupJoin)(nil)
+var _ joinRel = (*mergeJoin)(nil)
 var _ joinRel = (*semiJoin)(nil)
 
 type joinBase struct {
@@ -489,6 +499,14 @@ type lookup struct {
 	parent *joinBase
 }
 
+// indexScan is a read of a whole table through an ordered index, which
+// returns the table's rows sorted on the index expressions.
+type indexScan struct {
+	source string
+	table  sql.Table
+	index  sql.Index
+}
+
 var ExprDefs support.GenDefs = []support.MemoDef{ // alphabetically sorted
 	{
 		Name:   "crossJoin",
@@ -532,6 +550,14 @@ var ExprDefs support.GenDefs = []support.MemoDef{ // alphabetically sorted
 			{"outerAttrs", "[]sql.Expression"},
 		},
 	},
+	{
+		Name:   "mergeJoin",
+		IsJoin: true,
+		JoinAttrs: [][2]string{
+			{"leftScan", "*indexScan"},
+			{"rightScan", "*indexScan"},
+		},
+	},
 	{
 		Name:   "fullOuterJoin",
 		IsJoin: true,
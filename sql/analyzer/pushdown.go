@@ -211,6 +211,13 @@ func transformPushdownFilters(ctx *sql.Context, a *Analyzer, n sql.Node, scope *
 				if !ok || lookup.expr == nil {
 					return node, transform.SameTree, nil
 				}
+				// The table may be read through an index for another reason, such as a merge join, in which
+				// case its lookup doesn't apply the filters
+				if sameLookup, err := plan.GetIndexLookup(node).Ranges.Equals(lookup.lookup.Ranges); err != nil {
+					return nil, transform.SameTree, err
+				} else if !sameLookup {
+					return node, transform.SameTree, nil
+				}
 				handled, err := getPredicateExprsHandledByLookup(ctx, a, node, lookup, tableAliases)
 				if err != nil {
 					return nil, transform.SameTree, err
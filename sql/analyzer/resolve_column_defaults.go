@@ -15,6 +15,8 @@
 package analyzer
 
 import (
+	"strings"
+
 	"github.com/dolthub/vitess/go/sqltypes"
 
 	"github.com/dolthub/go-mysql-server/sql/information_schema"
@@ -438,6 +440,76 @@ var validColumnDefaultFuncs = map[string]struct{}{
 	"yearweek":                           {},
 }
 
+// invalidGeneratedColumnFuncs is the set of functions that are legal in a column default value, but not in the
+// expression of a generated column, since they don't always return the same result for the same arguments.
+var invalidGeneratedColumnFuncs = map[string]struct{}{
+	"any_value":         {},
+	"avg":               {},
+	"benchmark":         {},
+	"bit_and":           {},
+	"bit_or":            {},
+	"bit_xor":           {},
+	"connection_id":     {},
+	"count":             {},
+	"cume_dist":         {},
+	"curdate":           {},
+	"current_role":      {},
+	"current_timestamp": {},
+	"curtime":           {},
+	"database":          {},
+	"default":           {},
+	"dense_rank":        {},
+	"first_value":       {},
+	"found_rows":        {},
+	"get_lock":          {},
+	"group_concat":      {},
+	"is_free_lock":      {},
+	"is_used_lock":      {},
+	"json_arrayagg":     {},
+	"json_objectagg":    {},
+	"lag":               {},
+	"last_insert_id":    {},
+	"last_value":        {},
+	"lead":              {},
+	"load_file":         {},
+	"localtimestamp":    {},
+	"max":               {},
+	"min":               {},
+	"now":               {},
+	"nth_value":         {},
+	"ntile":             {},
+	"percent_rank":      {},
+	"rand":              {},
+	"random_bytes":      {},
+	"rank":              {},
+	"release_all_locks": {},
+	"release_lock":      {},
+	"row_count":         {},
+	"row_number":        {},
+	"schema":            {},
+	"session_user":      {},
+	"sleep":             {},
+	"std":               {},
+	"stddev":            {},
+	"stddev_pop":        {},
+	"stddev_samp":       {},
+	"sum":               {},
+	"sysdate":           {},
+	"system_user":       {},
+	"unix_timestamp":    {},
+	"user":              {},
+	"utc_date":          {},
+	"utc_time":          {},
+	"utc_timestamp":     {},
+	"uuid":              {},
+	"uuid_short":        {},
+	"values":            {},
+	"var_pop":           {},
+	"var_samp":          {},
+	"variance":          {},
+	"version":           {},
+}
+
 // Resolving column defaults is a multi-phase process, with different analyzer rules for each phase.
 //
 // * parseColumnDefaults: Some integrators (dolt but not GMS) store their column defaults as strings, which we need to
@@ -489,6 +561,10 @@ func validateColumnDefaults(ctx *sql.Context, _ *Analyzer, n sql.Node, _ *Scope,
 				return n, transform.SameTree, nil
 			}
 
+			if err := validateGeneratedColumns(node); err != nil {
+				return nil, transform.SameTree, err
+			}
+
 			// There may be multiple DDL nodes in the plan (ALTER TABLE statements can have many clauses), and for each of them
 			// we need to count the column indexes in the very hacky way outlined above.
 			colIndex := 0
@@ -517,6 +593,105 @@ func validateColumnDefaults(ctx *sql.Context, _ *Analyzer, n sql.Node, _ *Scope,
 	})
 }
 
+// validateGeneratedColumns ensures that the expressions of the generated columns in the schema created or altered by
+// the node given only use deterministic functions, and only refer to generated columns defined before them.
+func validateGeneratedColumns(node sql.SchemaTarget) error {
+	var sch sql.Schema
+	switch n := node.(type) {
+	case *plan.CreateTable:
+		sch = n.CreateSchema.Schema
+	case *plan.AddColumn:
+		sch = schemaWithColumn(n.TargetSchema(), n.Column(), n.Order(), "")
+	case *plan.ModifyColumn:
+		sch = schemaWithColumn(n.TargetSchema(), n.NewColumn(), n.Order(), n.Column())
+	default:
+		return nil
+	}
+
+	for i, col := range sch {
+		if col.Generated == nil {
+			continue
+		}
+
+		var err error
+		sql.Inspect(col.Generated.Expression, func(e sql.Expression) bool {
+			switch e := e.(type) {
+			case sql.FunctionExpression, *expression.UnresolvedFunction:
+				var funcName string
+				switch expr := e.(type) {
+				case sql.FunctionExpression:
+					funcName = expr.FunctionName()
+				case *expression.UnresolvedFunction:
+					funcName = expr.Name()
+				}
+				funcName = strings.ToLower(funcName)
+
+				_, isValid := validColumnDefaultFuncs[funcName]
+				_, isInvalid := invalidGeneratedColumnFuncs[funcName]
+				if !isValid || isInvalid {
+					err = sql.ErrGeneratedColumnFunctionIsNotAllowed.New(col.Name)
+					return false
+				}
+				return true
+			case *plan.Subquery, *expression.UserVar, *expression.SystemVar, *expression.ProcedureParam:
+				err = sql.ErrGeneratedColumnFunctionIsNotAllowed.New(col.Name)
+				return false
+			case column:
+				refIdx := sch.IndexOfColName(e.Name())
+				if refIdx == -1 {
+					// unknown columns are reported when resolving the expression
+					return true
+				}
+				ref := sch[refIdx]
+				if ref.AutoIncrement {
+					err = sql.ErrGeneratedColumnRefAutoInc.New(col.Name)
+					return false
+				}
+				if ref.Generated != nil && refIdx >= i {
+					err = sql.ErrGeneratedColumnNonPriorGenerated.New()
+					return false
+				}
+				return true
+			default:
+				return true
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaWithColumn returns a copy of the schema given with the column added at the position given by the order, after
+// removing the column named by replacing, if any.
+func schemaWithColumn(sch sql.Schema, column *sql.Column, order *sql.ColumnOrder, replacing string) sql.Schema {
+	newSch := make(sql.Schema, 0, len(sch)+1)
+	for _, col := range sch {
+		if !strings.EqualFold(col.Name, replacing) {
+			newSch = append(newSch, col)
+		}
+	}
+
+	if order == nil {
+		if replacing != "" {
+			if idx := sch.IndexOfColName(replacing); idx != -1 {
+				return append(newSch[:idx], append(sql.Schema{column}, newSch[idx:]...)...)
+			}
+		}
+		return append(newSch, column)
+	} else if order.First {
+		return append(sql.Schema{column}, newSch...)
+	}
+
+	idx := newSch.IndexOfColName(order.AfterColumn)
+	if idx == -1 {
+		return append(newSch, column)
+	}
+	return append(newSch[:idx+1], append(sql.Schema{column}, newSch[idx+1:]...)...)
+}
+
 func resolveColumnDefaults(ctx *sql.Context, _ *Analyzer, n sql.Node, _ *Scope, _ RuleSelector) (sql.Node, transform.TreeIdentity, error) {
 	span, ctx := ctx.Span("resolveColumnDefaults")
 	defer span.End()
@@ -675,12 +850,15 @@ func stripTableNamesFromColumnDefaults(ctx *sql.Context, _ *Analyzer, n sql.Node
 			return newNode, transform.NewTree, nil
 		case sql.SchemaTarget:
 			return transform.NodeExprs(n, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
-				eWrapper, ok := e.(*expression.Wrapper)
-				if !ok {
+				switch e := e.(type) {
+				case *expression.Wrapper:
+					return stripTableNamesFromDefault(e)
+				case *sql.ColumnDefaultValue:
+					// Generated column expressions are not wrapped
+					return stripTableNamesFromColumnDefaultValue(e)
+				default:
 					return e, transform.SameTree, nil
 				}
-
-				return stripTableNamesFromDefault(eWrapper)
 			})
 		case *plan.ResolvedTable:
 			ct, ok := node.Table.(*information_schema.ColumnsTable)
@@ -875,18 +1053,29 @@ func fillInColumnDefaults(_ *sql.Context, insertInto *plan.InsertInto) error {
 
 	// Pull the column default values out into the same order the columns were specified
 	columnDefaultValues := make([]*sql.ColumnDefaultValue, len(insertInto.ColumnNames))
+	generatedColumns := make([]*sql.Column, len(insertInto.ColumnNames))
 	for i, columnName := range insertInto.ColumnNames {
 		index := schema.IndexOfColName(columnName)
 		if index == -1 {
 			return plan.ErrInsertIntoNonexistentColumn.New(columnName)
 		}
 		columnDefaultValues[i] = schema[index].Default
+		if schema[index].Generated != nil {
+			generatedColumns[i] = schema[index]
+		}
 	}
 
 	// Walk through the expression tuples looking for any column defaults to fill in
 	if values, ok := insertInto.Source.(*plan.Values); ok {
 		for _, exprTuple := range values.ExpressionTuples {
 			for i, value := range exprTuple {
+				// Generated columns only accept DEFAULT, their values are computed when the row is inserted
+				if i < len(generatedColumns) && generatedColumns[i] != nil {
+					if _, ok := value.(*expression.DefaultColumn); !ok {
+						return sql.ErrGeneratedColumnValue.New(generatedColumns[i].Name, generatedColumns[i].Source)
+					}
+				}
+
 				newExpression, _, err := transform.Expr(value, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
 					if _, ok := e.(*expression.DefaultColumn); ok {
 						return columnDefaultValues[i], transform.NewTree, nil
@@ -1054,7 +1243,26 @@ func stripTableNamesFromDefault(e *expression.Wrapper) (sql.Expression, transfor
 		return e, transform.SameTree, nil
 	}
 
-	newExpr, same, err := transform.Expr(newDefault.Expression, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+	nd, same, err := stripTableNamesFromColumnDefaultValue(newDefault)
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+
+	if same {
+		return e, transform.SameTree, nil
+	}
+
+	return expression.WrapExpression(nd), transform.NewTree, nil
+}
+
+// stripTableNamesFromColumnDefaultValue removes the table name from any GetField expressions in the column default
+// value given.
+func stripTableNamesFromColumnDefaultValue(cd *sql.ColumnDefaultValue) (sql.Expression, transform.TreeIdentity, error) {
+	if cd == nil {
+		return cd, transform.SameTree, nil
+	}
+
+	newExpr, same, err := transform.Expr(cd.Expression, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
 		if expr, ok := e.(*expression.GetField); ok {
 			return expr.WithTable(""), transform.NewTree, nil
 		}
@@ -1065,10 +1273,10 @@ func stripTableNamesFromDefault(e *expression.Wrapper) (sql.Expression, transfor
 	}
 
 	if same {
-		return e, transform.SameTree, nil
+		return cd, transform.SameTree, nil
 	}
 
-	nd := *newDefault
+	nd := *cd
 	nd.Expression = newExpr
-	return expression.WrapExpression(&nd), transform.NewTree, nil
+	return &nd, transform.NewTree, nil
 }
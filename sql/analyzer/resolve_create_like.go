@@ -21,6 +21,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/transform"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -54,10 +55,23 @@ func resolveCreateLike(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope,
 				} else {
 					constraint = sql.IndexConstraint_Unique
 				}
+			} else if _, ok := index.(sql.FulltextIndex); ok {
+				constraint = sql.IndexConstraint_Fulltext
+			} else if _, ok := index.(sql.SpatialIndex); ok {
+				constraint = sql.IndexConstraint_Spatial
 			}
 
 			columns := make([]sql.IndexColumn, len(index.Expressions()))
 			for i, col := range index.Expressions() {
+				// functional key parts are parsed again from their expression, since they refer to the original table
+				if plan.GetColumnFromIndexExpr(col, likeTable) == nil {
+					def, err := parse.StringToColumnDefaultValue(ctx, plan.IndexExpressionString(col, likeTable))
+					if err != nil {
+						return nil, transform.SameTree, err
+					}
+					columns[i] = sql.IndexColumn{Expression: def.Expression}
+					continue
+				}
 				//TODO: find a better way to get only the column name if the table is present
 				col = strings.TrimPrefix(col, indexableTable.Name()+".")
 				columns[i] = sql.IndexColumn{
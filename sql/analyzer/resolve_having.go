@@ -55,8 +55,8 @@ func resolveHaving(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope, s
 		// If any columns required by the having aren't available, pull them up.
 		if len(missingCols) > 0 {
 			var err error
-			// TODO: this should be an error for most queries. having expressions must appear in the group-by clause (even
-			//  in non-strict mode)
+			// Columns pulled up into a GroupBy this way must still be grouped when ONLY_FULL_GROUP_BY is enabled, which
+			// is enforced by validateOnlyFullGroupBy
 			having, err = pullMissingColumnsUp(having, missingCols)
 			if err != nil {
 				return nil, transform.SameTree, err
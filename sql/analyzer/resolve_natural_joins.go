@@ -29,27 +29,210 @@ func resolveNaturalJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 	defer span.End()
 
 	var replacements = make(map[tableCol]tableCol)
+	var usingCols = make(map[sql.Node][]int)
 
 	return transform.Node(n, func(n sql.Node) (sql.Node, transform.TreeIdentity, error) {
-		switch n := n.(type) {
+		same := transform.SameTree
+		switch nn := n.(type) {
 		case *plan.JoinNode:
-			if n.Op.IsNatural() {
-				newn, err := resolveNaturalJoin(n, replacements)
+			if nn.Op.IsNatural() {
+				newn, err := resolveNaturalJoin(nn, replacements)
 				if err != nil {
 					return nil, transform.SameTree, err
 				}
 				return newn, transform.NewTree, nil
 			}
+			if nn.IsUsing() {
+				newn, err := resolveUsingJoin(nn, replacements, usingCols)
+				if err != nil {
+					return nil, transform.SameTree, err
+				}
+				return newn, transform.NewTree, nil
+			}
+		case *plan.Project, *plan.GroupBy, *plan.Window:
+			if len(usingCols) > 0 {
+				var err error
+				n, same, err = expandStarsForUsingJoin(n, usingCols)
+				if err != nil {
+					return nil, transform.SameTree, err
+				}
+			}
 		default:
 		}
 		e, ok := n.(sql.Expressioner)
 		if !ok {
-			return n, transform.SameTree, nil
+			return n, same, nil
 		}
-		return replaceExpressionsForNaturalJoin(ctx, e.(sql.Node), replacements)
+		newn, sameExprs, err := replaceExpressionsForNaturalJoin(ctx, e.(sql.Node), replacements)
+		return newn, same && sameExprs, err
 	})
 }
 
+// resolveUsingJoin replaces a join with a USING clause with a join on the equivalent condition. Unqualified
+// references to the USING columns are replaced with the coalesced column, which is the left table's column for inner
+// and left joins and the right table's column for right joins. The columns visible to an unqualified star over the
+// join are recorded in |usingCols|: the coalesced columns first, then the remaining columns of both sides.
+func resolveUsingJoin(
+	n *plan.JoinNode,
+	replacements map[tableCol]tableCol,
+	usingCols map[sql.Node][]int,
+) (sql.Node, error) {
+	if !n.Left().Resolved() || !n.Right().Resolved() {
+		return n, nil
+	}
+
+	leftSchema := n.Left().Schema()
+	rightSchema := n.Right().Schema()
+	leftVisible := visibleColumns(n.Left(), usingCols)
+	rightVisible := visibleColumns(n.Right(), usingCols)
+
+	var conditions []sql.Expression
+	var commonLeft, commonRight []int
+	for _, name := range n.UsingCols {
+		lIdx := findVisibleCol(leftSchema, leftVisible, name)
+		rIdx := findVisibleCol(rightSchema, rightVisible, name)
+		if lIdx < 0 || rIdx < 0 {
+			return nil, sql.ErrUnknownColumn.New(name, "from clause")
+		}
+		lcol, rcol := leftSchema[lIdx], rightSchema[rIdx]
+		conditions = append(conditions, expression.NewEquals(
+			expression.NewGetFieldWithTable(lIdx, lcol.Type, lcol.Source, lcol.Name, lcol.Nullable),
+			expression.NewGetFieldWithTable(len(leftSchema)+rIdx, rcol.Type, rcol.Source, rcol.Name, rcol.Nullable),
+		))
+		commonLeft = append(commonLeft, lIdx)
+		commonRight = append(commonRight, rIdx)
+
+		coalesced := lcol
+		if n.Op.IsRightOuter() {
+			coalesced = rcol
+		}
+		replacements[tableCol{"", strings.ToLower(name)}] = tableCol{
+			strings.ToLower(coalesced.Source), strings.ToLower(coalesced.Name),
+		}
+	}
+
+	var leftUnique, rightUnique []int
+	for _, i := range leftVisible {
+		if !containsInt(commonLeft, i) {
+			leftUnique = append(leftUnique, i)
+		}
+	}
+	for _, i := range rightVisible {
+		if !containsInt(commonRight, i) {
+			rightUnique = append(rightUnique, len(leftSchema)+i)
+		}
+	}
+
+	var visible []int
+	if n.Op.IsRightOuter() {
+		for _, i := range commonRight {
+			visible = append(visible, len(leftSchema)+i)
+		}
+		visible = append(append(visible, rightUnique...), leftUnique...)
+	} else {
+		visible = append(append(commonLeft, leftUnique...), rightUnique...)
+	}
+
+	newn := plan.NewJoin(n.Left(), n.Right(), n.Op, expression.JoinAnd(conditions...)).WithComment(n.CommentStr).(*plan.JoinNode)
+	usingCols[newn] = visible
+	return newn, nil
+}
+
+// visibleColumns returns the indexes of the columns of |n| that are visible to an unqualified star.
+func visibleColumns(n sql.Node, usingCols map[sql.Node][]int) []int {
+	if visible, ok := usingCols[n]; ok {
+		return visible
+	}
+	visible := make([]int, len(n.Schema()))
+	for i := range visible {
+		visible[i] = i
+	}
+	return visible
+}
+
+func findVisibleCol(s sql.Schema, visible []int, name string) int {
+	for _, i := range visible {
+		if strings.EqualFold(s[i].Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, j := range ints {
+		if i == j {
+			return true
+		}
+	}
+	return false
+}
+
+// expandStarsForUsingJoin expands unqualified stars in the given node when it selects from a join with a USING
+// clause, so that the joined columns appear only once.
+func expandStarsForUsingJoin(n sql.Node, usingCols map[sql.Node][]int) (sql.Node, transform.TreeIdentity, error) {
+	var exprs []sql.Expression
+	var child sql.Node
+	switch n := n.(type) {
+	case *plan.Project:
+		exprs, child = n.Projections, n.Child
+	case *plan.GroupBy:
+		exprs, child = n.SelectedExprs, n.Child
+	case *plan.Window:
+		exprs, child = n.SelectExprs, n.Child
+	default:
+		return n, transform.SameTree, nil
+	}
+
+	join := child
+	for {
+		switch c := join.(type) {
+		case *plan.Filter:
+			join = c.Child
+			continue
+		case *plan.Having:
+			join = c.Child
+			continue
+		case *plan.Sort:
+			join = c.Child
+			continue
+		}
+		break
+	}
+	visible, ok := usingCols[join]
+	if !ok {
+		return n, transform.SameTree, nil
+	}
+
+	schema := join.Schema()
+	var expanded []sql.Expression
+	same := transform.SameTree
+	for _, e := range exprs {
+		if star, ok := e.(*expression.Star); ok && star.Table == "" {
+			same = transform.NewTree
+			for _, i := range visible {
+				expanded = append(expanded, expression.NewUnresolvedQualifiedColumn(schema[i].Source, schema[i].Name))
+			}
+		} else {
+			expanded = append(expanded, e)
+		}
+	}
+	if same {
+		return n, transform.SameTree, nil
+	}
+
+	switch n := n.(type) {
+	case *plan.Project:
+		return plan.NewProject(expanded, n.Child), transform.NewTree, nil
+	case *plan.GroupBy:
+		return plan.NewGroupBy(expanded, n.GroupByExprs, n.Child), transform.NewTree, nil
+	case *plan.Window:
+		return plan.NewWindow(expanded, n.Child), transform.NewTree, nil
+	default:
+		return n, transform.SameTree, nil
+	}
+}
+
 func resolveNaturalJoin(
 	n *plan.JoinNode,
 	replacements map[tableCol]tableCol,
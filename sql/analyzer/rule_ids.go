@@ -87,6 +87,8 @@ const (
 	hoistSelectExistsId           // hoistSelectExists
 	optimizeJoinsId               // optimizeJoins
 	pushdownFiltersId             // pushdownFilters
+	applyFulltextIndexesId        // applyFulltextIndexes
+	applySpatialIndexesId         // applySpatialIndexes
 	subqueryIndexesId             // subqueryIndexes
 	inSubqueryIndexesId           // inSubqueryIndexes
 	pruneTablesId                 // pruneTables
@@ -109,8 +111,11 @@ const (
 	applyFKsId                    // applyFKs
 
 	// validate
-	validateResolvedId          // validateResolved
-	validateOrderById           // validateOrderBy
+	validateResolvedId        // validateResolved
+	validateOrderById         // validateOrderBy
+	validateOnlyFullGroupById // validateOnlyFullGroupBy
+	// validateGroupById is deprecated and no longer names a rule, as GROUP BY is validated by validateOnlyFullGroupBy.
+	// It is kept so that the IDs of the rules that follow don't change.
 	validateGroupById           // validateGroupBy
 	validateSchemaSourceId      // validateSchemaSource
 	validateIndexCreationId     // validateIndexCreation
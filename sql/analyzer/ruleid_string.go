@@ -85,47 +85,50 @@ func _() {
 	_ = x[hoistSelectExistsId-74]
 	_ = x[optimizeJoinsId-75]
 	_ = x[pushdownFiltersId-76]
-	_ = x[subqueryIndexesId-77]
-	_ = x[inSubqueryIndexesId-78]
-	_ = x[pruneTablesId-79]
-	_ = x[setJoinScopeLenId-80]
-	_ = x[eraseProjectionId-81]
-	_ = x[replaceSortPkId-82]
-	_ = x[insertTopNId-83]
-	_ = x[cacheSubqueryResultsId-84]
-	_ = x[cacheSubqueryAliasesInJoinsId-85]
-	_ = x[applyHashLookupsId-86]
-	_ = x[applyHashInId-87]
-	_ = x[resolveInsertRowsId-88]
-	_ = x[resolvePreparedInsertId-89]
-	_ = x[applyTriggersId-90]
-	_ = x[applyProceduresId-91]
-	_ = x[assignRoutinesId-92]
-	_ = x[modifyUpdateExprsForJoinId-93]
-	_ = x[applyRowUpdateAccumulatorsId-94]
-	_ = x[wrapWithRollbackId-95]
-	_ = x[applyFKsId-96]
-	_ = x[validateResolvedId-97]
-	_ = x[validateOrderById-98]
-	_ = x[validateGroupById-99]
-	_ = x[validateSchemaSourceId-100]
-	_ = x[validateIndexCreationId-101]
-	_ = x[validateOperandsId-102]
-	_ = x[validateCaseResultTypesId-103]
-	_ = x[validateIntervalUsageId-104]
-	_ = x[validateExplodeUsageId-105]
-	_ = x[validateSubqueryColumnsId-106]
-	_ = x[validateUnionSchemasMatchId-107]
-	_ = x[validateAggregationsId-108]
-	_ = x[AutocommitId-109]
-	_ = x[TrackProcessId-110]
-	_ = x[parallelizeId-111]
-	_ = x[clearWarningsId-112]
+	_ = x[applyFulltextIndexesId-77]
+	_ = x[applySpatialIndexesId-78]
+	_ = x[subqueryIndexesId-79]
+	_ = x[inSubqueryIndexesId-80]
+	_ = x[pruneTablesId-81]
+	_ = x[setJoinScopeLenId-82]
+	_ = x[eraseProjectionId-83]
+	_ = x[replaceSortPkId-84]
+	_ = x[insertTopNId-85]
+	_ = x[cacheSubqueryResultsId-86]
+	_ = x[cacheSubqueryAliasesInJoinsId-87]
+	_ = x[applyHashLookupsId-88]
+	_ = x[applyHashInId-89]
+	_ = x[resolveInsertRowsId-90]
+	_ = x[resolvePreparedInsertId-91]
+	_ = x[applyTriggersId-92]
+	_ = x[applyProceduresId-93]
+	_ = x[assignRoutinesId-94]
+	_ = x[modifyUpdateExprsForJoinId-95]
+	_ = x[applyRowUpdateAccumulatorsId-96]
+	_ = x[wrapWithRollbackId-97]
+	_ = x[applyFKsId-98]
+	_ = x[validateResolvedId-99]
+	_ = x[validateOrderById-100]
+	_ = x[validateOnlyFullGroupById-101]
+	_ = x[validateGroupById-102]
+	_ = x[validateSchemaSourceId-103]
+	_ = x[validateIndexCreationId-104]
+	_ = x[validateOperandsId-105]
+	_ = x[validateCaseResultTypesId-106]
+	_ = x[validateIntervalUsageId-107]
+	_ = x[validateExplodeUsageId-108]
+	_ = x[validateSubqueryColumnsId-109]
+	_ = x[validateUnionSchemasMatchId-110]
+	_ = x[validateAggregationsId-111]
+	_ = x[AutocommitId-112]
+	_ = x[TrackProcessId-113]
+	_ = x[parallelizeId-114]
+	_ = x[clearWarningsId-115]
 }
 
-const _RuleId_name = "applyDefaultSelectLimitvalidateOffsetAndLimitvalidateCreateTablevalidateExprSemresolveVariablesresolveNamedWindowsresolveSetVariablesresolveViewsliftCtesresolveCtesliftRecursiveCtesresolveDatabasesresolveTablesloadStoredProceduresvalidateDropTablessetTargetSchemasresolveCreateLikeparseColumnDefaultsresolveDropConstraintvalidateDropConstraintloadCheckConstraintsassignCatalogresolveCreateSelectresolveSubqueriessetViewTargetSchemaresolveUnionsresolveDescribeQuerycheckUniqueTableNamesresolveTableFunctionsresolveDeclarationsresolveColumnDefaultsvalidateColumnDefaultsvalidateCreateTriggervalidateCreateProcedureloadInfoSchemavalidateReadOnlyDatabasevalidateReadOnlyTransactionvalidateDatabaseSetvalidatePrivilegesreresolveTablessetInsertColumnsvalidateJoinComplexityresolveNaturalJoinsresolveOrderbyLiteralsresolveFunctionsflattenTableAliasespushdownSortpushdownGroupbyAliasespushdownSubqueryAliasFiltersqualifyColumnsresolveColumnsvalidateCheckConstraintresolveBarewordSetVariablesexpandStarstransposeRightJoinsresolveHavingmergeUnionSchemasflattenAggregationExprsreorderProjectionresolveSubqueryExprsfinalizeSubqueryExprsreplaceCrossJoinsmoveJoinCondsToFilterevalFilteroptimizeDistinctfinalizeSubqueriesfinalizeUnionsloadTriggersprocessTruncateresolveAlterColumnresolveGeneratorsremoveUnnecessaryConvertspruneColumnsstripTableNamesFromColumnDefaultshoistSelectExistsoptimizeJoinspushdownFilterssubqueryIndexesinSubqueryIndexespruneTablessetJoinScopeLeneraseProjectionreplaceSortPkinsertTopNcacheSubqueryResultscacheSubqueryAliasesInJoinsapplyHashLookupsapplyHashInresolveInsertRowsresolvePreparedInsertapplyTriggersapplyProceduresassignRoutinesmodifyUpdateExprsForJoinapplyRowUpdateAccumulatorsrollback triggersapplyFKsvalidateResolvedvalidateOrderByvalidateGroupByvalidateSchemaSourcevalidateIndexCreationvalidateOperandsvalidateCaseResultTypesvalidateIntervalUsagevalidateExplodeUsagevalidateSubqueryColumnsvalidateUnionSchemasMatchvalidateAggregationsaddAutocommitNodetrackProcessparallelizeclearWarnings"
+const _RuleId_name = "applyDefaultSelectLimitvalidateOffsetAndLimitvalidateCreateTablevalidateExprSemresolveVariablesresolveNamedWindowsresolveSetVariablesresolveViewsliftCtesresolveCtesliftRecursiveCtesresolveDatabasesresolveTablesloadStoredProceduresvalidateDropTablessetTargetSchemasresolveCreateLikeparseColumnDefaultsresolveDropConstraintvalidateDropConstraintloadCheckConstraintsassignCatalogresolveCreateSelectresolveSubqueriessetViewTargetSchemaresolveUnionsresolveDescribeQuerycheckUniqueTableNamesresolveTableFunctionsresolveDeclarationsresolveColumnDefaultsvalidateColumnDefaultsvalidateCreateTriggervalidateCreateProcedureloadInfoSchemavalidateReadOnlyDatabasevalidateReadOnlyTransactionvalidateDatabaseSetvalidatePrivilegesreresolveTablessetInsertColumnsvalidateJoinComplexityresolveNaturalJoinsresolveOrderbyLiteralsresolveFunctionsflattenTableAliasespushdownSortpushdownGroupbyAliasespushdownSubqueryAliasFiltersqualifyColumnsresolveColumnsvalidateCheckConstraintresolveBarewordSetVariablesexpandStarstransposeRightJoinsresolveHavingmergeUnionSchemasflattenAggregationExprsreorderProjectionresolveSubqueryExprsfinalizeSubqueryExprsreplaceCrossJoinsmoveJoinCondsToFilterevalFilteroptimizeDistinctfinalizeSubqueriesfinalizeUnionsloadTriggersprocessTruncateresolveAlterColumnresolveGeneratorsremoveUnnecessaryConvertspruneColumnsstripTableNamesFromColumnDefaultshoistSelectExistsoptimizeJoinspushdownFiltersapplyFulltextIndexesapplySpatialIndexessubqueryIndexesinSubqueryIndexespruneTablessetJoinScopeLeneraseProjectionreplaceSortPkinsertTopNcacheSubqueryResultscacheSubqueryAliasesInJoinsapplyHashLookupsapplyHashInresolveInsertRowsresolvePreparedInsertapplyTriggersapplyProceduresassignRoutinesmodifyUpdateExprsForJoinapplyRowUpdateAccumulatorsrollback triggersapplyFKsvalidateResolvedvalidateOrderByvalidateOnlyFullGroupByvalidateGroupByvalidateSchemaSourcevalidateIndexCreationvalidateOperandsvalidateCaseResultTypesvalidateIntervalUsagevalidateExplodeUsagevalidateSubqueryColumnsvalidateUnionSchemasMatchvalidateAggregationsaddAutocommitNodetrackProcessparallelizeclearWarnings"
 
-var _RuleId_index = [...]uint16{0, 23, 45, 64, 79, 95, 114, 133, 145, 153, 164, 181, 197, 210, 230, 248, 264, 281, 300, 321, 343, 363, 376, 395, 412, 431, 444, 464, 485, 506, 525, 546, 568, 589, 612, 626, 650, 677, 696, 714, 729, 745, 767, 786, 808, 824, 843, 855, 877, 905, 919, 933, 956, 983, 994, 1013, 1026, 1043, 1066, 1083, 1103, 1124, 1141, 1162, 1172, 1188, 1206, 1220, 1232, 1247, 1265, 1282, 1307, 1319, 1352, 1369, 1382, 1397, 1412, 1429, 1440, 1455, 1470, 1483, 1493, 1513, 1540, 1556, 1567, 1584, 1605, 1618, 1633, 1647, 1671, 1697, 1714, 1722, 1738, 1753, 1768, 1788, 1809, 1825, 1848, 1869, 1889, 1912, 1937, 1957, 1974, 1986, 1997, 2010}
+var _RuleId_index = [...]uint16{0, 23, 45, 64, 79, 95, 114, 133, 145, 153, 164, 181, 197, 210, 230, 248, 264, 281, 300, 321, 343, 363, 376, 395, 412, 431, 444, 464, 485, 506, 525, 546, 568, 589, 612, 626, 650, 677, 696, 714, 729, 745, 767, 786, 808, 824, 843, 855, 877, 905, 919, 933, 956, 983, 994, 1013, 1026, 1043, 1066, 1083, 1103, 1124, 1141, 1162, 1172, 1188, 1206, 1220, 1232, 1247, 1265, 1282, 1307, 1319, 1352, 1369, 1382, 1397, 1417, 1436, 1451, 1468, 1479, 1494, 1509, 1522, 1532, 1552, 1579, 1595, 1606, 1623, 1644, 1657, 1672, 1686, 1710, 1736, 1753, 1761, 1777, 1792, 1815, 1830, 1850, 1871, 1887, 1910, 1931, 1951, 1974, 1999, 2019, 2036, 2048, 2059, 2072}
 
 func (i RuleId) String() string {
 	if i < 0 || i >= RuleId(len(_RuleId_index)-1) {
@@ -104,6 +104,8 @@ var OnceAfterDefault = []Rule{
 	{hoistSelectExistsId, hoistSelectExists},
 	{optimizeJoinsId, constructJoinPlan},
 	{pushdownFiltersId, pushdownFilters},
+	{applyFulltextIndexesId, applyFulltextIndexes},
+	{applySpatialIndexesId, applySpatialIndexes},
 	{pruneColumnsId, pruneColumns},
 	{subqueryIndexesId, applyIndexesFromOuterScope},
 	{inSubqueryIndexesId, applyIndexesForSubqueryComparisons},
@@ -130,7 +132,7 @@ var OnceAfterDefault = []Rule{
 var DefaultValidationRules = []Rule{
 	{validateResolvedId, validateIsResolved},
 	{validateOrderById, validateOrderBy},
-	{validateGroupById, validateGroupBy},
+	{validateOnlyFullGroupById, validateOnlyFullGroupBy},
 	{validateSchemaSourceId, validateSchemaSource},
 	{validateIndexCreationId, validateIndexCreation},
 	{validateOperandsId, validateOperands},
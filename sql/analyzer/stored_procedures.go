@@ -120,8 +120,22 @@ func analyzeProcedureBodies(ctx *sql.Context, a *Analyzer, node sql.Node, skipCa
 		var newChild sql.Node
 		switch child := child.(type) {
 		// Anything that may represent a collection of statements should go here
-		case *plan.Procedure, *plan.BeginEndBlock, *plan.Block, *plan.IfElseBlock, *plan.IfConditional:
+		case *plan.Procedure, *plan.BeginEndBlock, *plan.Block, *plan.IfElseBlock, *plan.DeclareHandler:
 			newChild, _, err = analyzeProcedureBodies(ctx, a, child, skipCall, scope, sel)
+		case *plan.IfConditional:
+			newChild, _, err = analyzeProcedureBodies(ctx, a, child, skipCall, scope, sel)
+			if err == nil {
+				newChild, _, err = transform.OneNodeExpressions(newChild, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+					newExpr, err := analyzeProcedureCondition(ctx, a, e, scope, procSel)
+					return newExpr, transform.NewTree, err
+				})
+			}
+		case *plan.DeclareCursor:
+			var newSelect sql.Node
+			newSelect, _, err = a.analyzeWithSelector(ctx, child.Select, scope, SelectAllBatches, procSel)
+			if err == nil {
+				newChild, err = child.WithChildren(StripPassthroughNodes(newSelect))
+			}
 		case *plan.Call:
 			if skipCall {
 				newChild = child
@@ -143,6 +157,20 @@ func analyzeProcedureBodies(ctx *sql.Context, a *Analyzer, node sql.Node, skipCa
 	return node, transform.NewTree, nil
 }
 
+// analyzeProcedureCondition analyzes the condition of a control flow statement, such as IF, by analyzing it as
+// the projection of a query that does not reference any tables.
+func analyzeProcedureCondition(ctx *sql.Context, a *Analyzer, condition sql.Expression, scope *Scope, sel RuleSelector) (sql.Expression, error) {
+	analyzed, _, err := a.analyzeWithSelector(ctx, plan.NewProject([]sql.Expression{condition}, plan.NewResolvedDualTable()), scope, SelectAllBatches, sel)
+	if err != nil {
+		return nil, err
+	}
+	project, ok := StripPassthroughNodes(analyzed).(*plan.Project)
+	if !ok || len(project.Projections) != 1 {
+		return nil, fmt.Errorf("unable to analyze the condition `%s`", condition.String())
+	}
+	return project.Projections[0], nil
+}
+
 // validateCreateProcedure handles CreateProcedure nodes, resolving references to the parameters, along with ensuring
 // that all logic contained within the stored procedure body is valid.
 func validateCreateProcedure(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope, sel RuleSelector) (sql.Node, transform.TreeIdentity, error) {
@@ -174,7 +202,7 @@ func validateCreateProcedure(ctx *sql.Context, a *Analyzer, node sql.Node, scope
 // validateStoredProcedure handles Procedure nodes, resolving references to the parameters, along with ensuring
 // that all logic contained within the stored procedure body is valid.
 func validateStoredProcedure(_ *sql.Context, proc *plan.Procedure) (map[string]struct{}, error) {
-	//TODO: handle declared variables here as well
+	// Declared variables are scoped to their BEGIN/END blocks, and are handled by resolveProcedureParams
 	paramNames := make(map[string]struct{})
 	for _, param := range proc.Params {
 		paramName := strings.ToLower(param.Name)
@@ -242,12 +270,88 @@ func validateStoredProcedure(_ *sql.Context, proc *plan.Procedure) (map[string]s
 
 // resolveProcedureParams resolves all named parameters and declared variables in a stored procedure.
 func resolveProcedureParams(ctx *sql.Context, paramNames map[string]struct{}, proc sql.Node) (sql.Node, transform.TreeIdentity, error) {
-	newProcNode, _, err := resolveProcedureParamsTransform(ctx, paramNames, proc)
+	newProcNode, _, err := resolveProcedureParamsScoped(ctx, paramNames, proc)
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+	newProc, ok := newProcNode.(*plan.Procedure)
+	if !ok {
+		return nil, transform.SameTree, fmt.Errorf("expected `*plan.Procedure` but got `%T`", newProcNode)
+	}
+	return newProc, transform.NewTree, nil
+}
+
+// resolveProcedureParamsScoped walks the statements of a stored procedure, tracking the variables that are visible
+// from each statement. Variables declared within a BEGIN/END block are only visible to the statements that follow
+// them within that block (including nested blocks).
+func resolveProcedureParamsScoped(ctx *sql.Context, paramNames map[string]struct{}, node sql.Node) (sql.Node, transform.TreeIdentity, error) {
+	switch n := node.(type) {
+	case *plan.Procedure, *plan.Block, *plan.IfElseBlock, *plan.DeclareHandler:
+		return resolveProcedureParamsChildren(ctx, paramNames, n)
+	case *plan.BeginEndBlock:
+		scopedNames := make(map[string]struct{}, len(paramNames))
+		for name := range paramNames {
+			scopedNames[name] = struct{}{}
+		}
+		children := n.Children()
+		newChildren := make([]sql.Node, len(children))
+		for i, child := range children {
+			newChild, _, err := resolveProcedureParamsScoped(ctx, scopedNames, child)
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+			newChildren[i] = newChild
+			// The default value of a DECLARE may only reference variables declared before it
+			if dv, ok := child.(*plan.DeclareVariables); ok {
+				for _, name := range dv.Names {
+					scopedNames[strings.ToLower(name)] = struct{}{}
+				}
+			}
+		}
+		newNode, err := n.WithChildren(newChildren...)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		return newNode, transform.NewTree, nil
+	case *plan.IfConditional:
+		newNode, _, err := transform.OneNodeExpressions(n, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+			return resolveProcedureParamsExpr(ctx, paramNames, e)
+		})
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		return resolveProcedureParamsChildren(ctx, paramNames, newNode)
+	default:
+		return resolveProcedureParamsStatement(ctx, paramNames, n)
+	}
+}
+
+// resolveProcedureParamsChildren calls resolveProcedureParamsScoped on each child of the given node.
+func resolveProcedureParamsChildren(ctx *sql.Context, paramNames map[string]struct{}, node sql.Node) (sql.Node, transform.TreeIdentity, error) {
+	children := node.Children()
+	newChildren := make([]sql.Node, len(children))
+	for i, child := range children {
+		newChild, _, err := resolveProcedureParamsScoped(ctx, paramNames, child)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		newChildren[i] = newChild
+	}
+	newNode, err := node.WithChildren(newChildren...)
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+	return newNode, transform.NewTree, nil
+}
+
+// resolveProcedureParamsStatement resolves all named parameters and declared variables in a single statement.
+func resolveProcedureParamsStatement(ctx *sql.Context, paramNames map[string]struct{}, n sql.Node) (sql.Node, transform.TreeIdentity, error) {
+	newNode, _, err := resolveProcedureParamsTransform(ctx, paramNames, n)
 	if err != nil {
 		return nil, transform.SameTree, err
 	}
 	// Some nodes do not expose all of their children, so we need to handle them here.
-	newProc, _, err := transform.Node(newProcNode, func(node sql.Node) (sql.Node, transform.TreeIdentity, error) {
+	return transform.Node(newNode, func(node sql.Node) (sql.Node, transform.TreeIdentity, error) {
 		switch n := node.(type) {
 		case *plan.InsertInto:
 			newSource, same, err := resolveProcedureParamsTransform(ctx, paramNames, n.Source)
@@ -277,50 +381,47 @@ func resolveProcedureParams(ctx *sql.Context, paramNames map[string]struct{}, pr
 			return n, transform.SameTree, nil
 		}
 	})
-	if err != nil {
-		return nil, transform.SameTree, err
-	}
-	newProc, ok := newProc.(*plan.Procedure)
-	if !ok {
-		return nil, transform.SameTree, fmt.Errorf("expected `*plan.Procedure` but got `%T`", newProcNode)
-	}
-	return newProc, transform.NewTree, nil
 }
 
 // resolveProcedureParamsTransform resolves all named parameters and declared variables in a node.
 // In cases where an expression contains nodes, this will also walk those nodes.
 func resolveProcedureParamsTransform(ctx *sql.Context, paramNames map[string]struct{}, n sql.Node) (sql.Node, transform.TreeIdentity, error) {
 	return transform.NodeExprs(n, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
-		switch e := e.(type) {
-		case *expression.UnresolvedColumn:
-			if strings.ToLower(e.Table()) == "" {
-				if _, ok := paramNames[strings.ToLower(e.Name())]; ok {
-					return expression.NewProcedureParam(e.Name()), transform.NewTree, nil
-				}
-			}
-			return e, transform.SameTree, nil
-		case *deferredColumn:
-			if strings.ToLower(e.Table()) == "" {
-				if _, ok := paramNames[strings.ToLower(e.Name())]; ok {
-					return expression.NewProcedureParam(e.Name()), transform.NewTree, nil
-				}
-			}
-			return e, transform.SameTree, nil
-		case *plan.Subquery: // Subqueries have an internal Query node that we need to check as well.
-			newQuery, same, err := resolveProcedureParamsTransform(ctx, paramNames, e.Query)
-			if err != nil {
-				return nil, transform.SameTree, err
+		return resolveProcedureParamsExpr(ctx, paramNames, e)
+	})
+}
+
+// resolveProcedureParamsExpr resolves the expression if it references a named parameter or declared variable.
+func resolveProcedureParamsExpr(ctx *sql.Context, paramNames map[string]struct{}, e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+	switch e := e.(type) {
+	case *expression.UnresolvedColumn:
+		if strings.ToLower(e.Table()) == "" {
+			if _, ok := paramNames[strings.ToLower(e.Name())]; ok {
+				return expression.NewProcedureParam(e.Name()), transform.NewTree, nil
 			}
-			if same {
-				return e, transform.SameTree, nil
+		}
+		return e, transform.SameTree, nil
+	case *deferredColumn:
+		if strings.ToLower(e.Table()) == "" {
+			if _, ok := paramNames[strings.ToLower(e.Name())]; ok {
+				return expression.NewProcedureParam(e.Name()), transform.NewTree, nil
 			}
-			ne := *e
-			ne.Query = newQuery
-			return &ne, transform.NewTree, nil
-		default:
+		}
+		return e, transform.SameTree, nil
+	case *plan.Subquery: // Subqueries have an internal Query node that we need to check as well.
+		newQuery, same, err := resolveProcedureParamsTransform(ctx, paramNames, e.Query)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		if same {
 			return e, transform.SameTree, nil
 		}
-	})
+		ne := *e
+		ne.Query = newQuery
+		return &ne, transform.NewTree, nil
+	default:
+		return e, transform.SameTree, nil
+	}
 }
 
 // applyProcedures applies the relevant stored procedures to the node given (if necessary).
@@ -458,6 +559,17 @@ func applyProceduresCall(ctx *sql.Context, a *Analyzer, call *plan.Call, scope *
 		return nil, transform.SameTree, err
 	}
 
+	// Nodes that handle declarations and control flow need the reference as well
+	transformedProcedure, _, err = transform.Node(transformedProcedure, func(node sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		if n, ok := node.(expression.ProcedureReferencable); ok {
+			return n.WithParamReference(pRef), transform.NewTree, nil
+		}
+		return node, transform.SameTree, nil
+	})
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+
 	transformedProcedure, _, err = transform.Node(transformedProcedure, func(node sql.Node) (sql.Node, transform.TreeIdentity, error) {
 		rt, ok := node.(*plan.ResolvedTable)
 		if !ok {
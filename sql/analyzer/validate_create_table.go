@@ -338,7 +338,7 @@ func validateAlterIndex(initialSch, sch sql.Schema, ai *plan.AlterIndex, indexes
 			return nil, sql.ErrKeyColumnDoesNotExist.New(badColName)
 		}
 
-		err := validateIndexType(ai.Columns, sch)
+		err := validateIndexType(ai.Columns, sch, ai.Constraint)
 		if err != nil {
 			return nil, err
 		}
@@ -378,11 +378,23 @@ func validateAlterIndex(initialSch, sch sql.Schema, ai *plan.AlterIndex, indexes
 	return indexes, nil
 }
 
-// validateIndexType prevents indexing blob columns
-func validateIndexType(cols []sql.IndexColumn, sch sql.Schema) error {
+// validateIndexType prevents indexing blob columns, FULLTEXT indexes of columns that don't hold text and SPATIAL
+// indexes of anything but a single NOT NULL geometry column
+func validateIndexType(cols []sql.IndexColumn, sch sql.Schema, constraint sql.IndexConstraint) error {
 	for _, c := range cols {
+		if c.IsFunctional() {
+			continue
+		}
 		i := sch.IndexOfColName(c.Name)
-		if sql.IsByteType(sch[i].Type) {
+		if constraint == sql.IndexConstraint_Spatial {
+			if err := validateSpatialIndexColumn(len(cols), sch[i]); err != nil {
+				return err
+			}
+		} else if constraint == sql.IndexConstraint_Fulltext {
+			if !sql.IsTextOnly(sch[i].Type) {
+				return sql.ErrFulltextColumn.New(sch[i].Name)
+			}
+		} else if sql.IsByteType(sch[i].Type) {
 			return sql.ErrInvalidByteIndex.New(sch[i].Name)
 		} else if sql.IsTextBlob(sch[i].Type) {
 			return sql.ErrInvalidTextIndex.New(sch[i].Name)
@@ -395,6 +407,9 @@ func validateIndexType(cols []sql.IndexColumn, sch sql.Schema) error {
 // an index Column is not in an index.
 func missingIdxColumn(cols []sql.IndexColumn, sch sql.Schema, tableName string) (string, bool) {
 	for _, c := range cols {
+		if c.IsFunctional() {
+			continue
+		}
 		if ok := sch.Contains(c.Name, tableName); !ok {
 			return c.Name, false
 		}
@@ -486,6 +501,20 @@ func validateAutoIncrement(schema sql.Schema, keyedColumns map[string]bool) erro
 
 const textIndexPrefix = 1000
 
+// validateSpatialIndexColumn checks that a column of a SPATIAL index with the number of columns given can be indexed.
+func validateSpatialIndexColumn(numCols int, col *sql.Column) error {
+	if numCols > 1 {
+		return sql.ErrSpatialIndexKeyParts.New()
+	}
+	if _, ok := col.Type.(sql.SpatialColumnType); !ok {
+		return sql.ErrSpatialIndexColumn.New()
+	}
+	if col.Nullable {
+		return sql.ErrSpatialIndexNullable.New()
+	}
+	return nil
+}
+
 func validateIndexes(tableSpec *plan.TableSpec) error {
 	lwrNames := make(map[string]*sql.Column)
 	for _, col := range tableSpec.Schema.Schema {
@@ -494,12 +523,23 @@ func validateIndexes(tableSpec *plan.TableSpec) error {
 
 	for _, idx := range tableSpec.IdxDefs {
 		for _, idxCol := range idx.Columns {
+			if idxCol.IsFunctional() {
+				continue
+			}
 			col, ok := lwrNames[strings.ToLower(idxCol.Name)]
 			if !ok {
 				return sql.ErrUnknownIndexColumn.New(idxCol.Name, idx.IndexName)
 			}
 
-			if sql.IsByteType(col.Type) {
+			if idx.Constraint == sql.IndexConstraint_Spatial {
+				if err := validateSpatialIndexColumn(len(idx.Columns), col); err != nil {
+					return err
+				}
+			} else if idx.Constraint == sql.IndexConstraint_Fulltext {
+				if !sql.IsTextOnly(col.Type) {
+					return sql.ErrFulltextColumn.New(col.Name)
+				}
+			} else if sql.IsByteType(col.Type) {
 				return sql.ErrInvalidByteIndex.New(col.Name)
 			} else if sql.IsTextBlob(col.Type) {
 				return sql.ErrInvalidTextIndex.New(col.Name)
@@ -30,14 +30,15 @@ import (
 )
 
 var (
+	// ErrValidationGroupBy is returned when a GROUP BY selects a column that isn't grouped.
+	//
+	// Deprecated: grouping is validated by the ONLY_FULL_GROUP_BY rules, which return sql.ErrWrongFieldWithGroup.
+	ErrValidationGroupBy = sql.ErrWrongFieldWithGroup
 	// ErrValidationResolved is returned when the plan can not be resolved.
 	ErrValidationResolved = errors.NewKind("plan is not resolved because of node '%T'")
 	// ErrValidationOrderBy is returned when the order by contains aggregation
 	// expressions.
 	ErrValidationOrderBy = errors.NewKind("OrderBy does not support aggregation expressions")
-	// ErrValidationGroupBy is returned when the aggregation expression does not
-	// appear in the grouping columns.
-	ErrValidationGroupBy = errors.NewKind("expression '%v' doesn't appear in the group by expressions")
 	// ErrValidationSchemaSource is returned when there is any column source
 	// that does not match the table name.
 	ErrValidationSchemaSource = errors.NewKind("one or more schema sources are empty")
@@ -207,66 +208,145 @@ func validateOrderBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, se
 	return n, transform.SameTree, nil
 }
 
-func validateGroupBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, sel RuleSelector) (sql.Node, transform.TreeIdentity, error) {
-	span, ctx := ctx.Span("validate_group_by")
+// validateOnlyFullGroupBy returns an error if ONLY_FULL_GROUP_BY is enabled and a GROUP BY selects a column that is
+// neither grouped nor functionally dependent on the grouped columns, or uses such a column in its HAVING clause. An
+// aggregated query without a GROUP BY may not select any column outside of an aggregate function. Aggregate functions
+// and ANY_VALUE may refer to any column.
+func validateOnlyFullGroupBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, sel RuleSelector) (sql.Node, transform.TreeIdentity, error) {
+	span, ctx := ctx.Span("validate_only_full_group_by")
 	defer span.End()
 
-	switch n := n.(type) {
-	case *plan.GroupBy:
-		// Allow the parser use the GroupBy node to eval the aggregation functions
-		// for sql statements that don't make use of the GROUP BY expression.
-		if len(n.GroupByExprs) == 0 {
-			return n, transform.SameTree, nil
+	if !sql.LoadSqlMode(ctx).ModeEnabled(sql.SqlModeOnlyFullGroupBy) {
+		return n, transform.SameTree, nil
+	}
+
+	var err error
+	transform.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.Having:
+			// Columns used by HAVING are pulled up into the GroupBy below it, so they're checked here first to report
+			// them as part of the HAVING clause rather than the SELECT list
+			err = checkOnlyFullGroupByHaving(n)
+		case *plan.GroupBy:
+			err = checkOnlyFullGroupBy(n)
 		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+	return n, transform.SameTree, nil
+}
 
-		var groupBys []string
-		for _, expr := range n.GroupByExprs {
-			groupBys = append(groupBys, expr.String())
+// checkOnlyFullGroupBy returns an error if any of the selected expressions of the GroupBy given refers to a column of
+// its child that isn't grouped.
+func checkOnlyFullGroupBy(gb *plan.GroupBy) error {
+	nonGrouped := nonGroupedColumnFinder(gb)
+	for i, expr := range gb.SelectedExprs {
+		if col := nonGrouped(expr); col != "" {
+			if len(gb.GroupByExprs) == 0 {
+				return sql.ErrMixOfGroupFuncAndFields.New(i+1, col)
+			}
+			return sql.ErrWrongFieldWithGroup.New(i+1, col)
 		}
+	}
+	return nil
+}
 
-		for _, expr := range n.SelectedExprs {
-			if _, ok := expr.(sql.Aggregation); !ok {
-				if !expressionReferencesOnlyGroupBys(groupBys, expr) {
-					return nil, transform.SameTree, ErrValidationGroupBy.New(expr.String())
+// checkOnlyFullGroupByHaving returns an error if the condition of the Having given refers to a column that isn't
+// grouped by the GroupBy it filters the results of. The condition refers to the selected expressions of the GroupBy
+// by their position, possibly through the projections between them.
+func checkOnlyFullGroupByHaving(having *plan.Having) error {
+	refs := []sql.Expression{having.Cond}
+	for node := having.Child; ; {
+		switch n := node.(type) {
+		case *plan.Project:
+			refs = referencedExpressions(refs, n.Projections)
+			node = n.Child
+			continue
+		case *plan.GroupBy:
+			nonGrouped := nonGroupedColumnFinder(n)
+			for _, ref := range referencedExpressions(refs, n.SelectedExprs) {
+				if col := nonGrouped(ref); col != "" {
+					return sql.ErrNonGroupingFieldUsed.New(col, "HAVING")
 				}
 			}
 		}
-
-		return n, transform.SameTree, nil
+		return nil
 	}
+}
 
-	return n, transform.SameTree, nil
+// referencedExpressions returns the expressions of a node's results that the fields of the expressions given refer to
+// by their position.
+func referencedExpressions(exprs []sql.Expression, results []sql.Expression) []sql.Expression {
+	var refs []sql.Expression
+	for _, expr := range exprs {
+		sql.Inspect(expr, func(e sql.Expression) bool {
+			if gf, ok := e.(*expression.GetField); ok && gf.Index() >= 0 && gf.Index() < len(results) {
+				refs = append(refs, results[gf.Index()])
+			}
+			return true
+		})
+	}
+	return refs
 }
 
-func expressionReferencesOnlyGroupBys(groupBys []string, expr sql.Expression) bool {
-	valid := true
-	sql.Inspect(expr, func(expr sql.Expression) bool {
-		switch expr := expr.(type) {
-		case nil, sql.Aggregation, *expression.Literal:
-			return false
-		case *expression.Alias, sql.FunctionExpression:
-			if stringContains(groupBys, expr.String()) {
+// nonGroupedColumnFinder returns a function that returns the first column of the child of the GroupBy given that the
+// expression given refers to without it being grouped, or an empty string if there is none. A column is functionally
+// dependent on the grouped columns, and so needn't be grouped, if every column of its table's primary key is grouped.
+func nonGroupedColumnFinder(gb *plan.GroupBy) func(sql.Expression) string {
+	groupBys := make(map[string]struct{})
+	groupedCols := make(map[tableCol]struct{})
+	for _, expr := range gb.GroupByExprs {
+		groupBys[strings.ToLower(expr.String())] = struct{}{}
+		if gf, ok := expr.(*expression.GetField); ok {
+			groupedCols[tableCol{strings.ToLower(gf.Table()), strings.ToLower(gf.Name())}] = struct{}{}
+		}
+	}
+
+	childCols := make(map[tableCol]struct{})
+	pkGrouped := make(map[string]bool)
+	for _, col := range gb.Child.Schema() {
+		tc := tableCol{strings.ToLower(col.Source), strings.ToLower(col.Name)}
+		childCols[tc] = struct{}{}
+		if !col.PrimaryKey {
+			continue
+		}
+		_, grouped := groupedCols[tc]
+		if prev, ok := pkGrouped[tc.table]; ok {
+			grouped = grouped && prev
+		}
+		pkGrouped[tc.table] = grouped
+	}
+
+	return func(expr sql.Expression) string {
+		var nonGrouped string
+		sql.Inspect(expr, func(e sql.Expression) bool {
+			if nonGrouped != "" {
 				return false
 			}
-			return true
-		// cc: https://dev.mysql.com/doc/refman/8.0/en/group-by-handling.html
-		// Each part of the SelectExpr must refer to the aggregated columns in some way
-		// TODO: this isn't complete, it's overly restrictive. Dependant columns are fine to reference.
-		default:
-			if stringContains(groupBys, expr.String()) {
-				return true
+			if _, ok := groupBys[strings.ToLower(e.String())]; ok {
+				return false
 			}
-
-			if len(expr.Children()) == 0 {
-				valid = false
+			switch e := e.(type) {
+			case sql.Aggregation, *function.AnyValue:
+				return false
+			case *expression.GetField:
+				tc := tableCol{strings.ToLower(e.Table()), strings.ToLower(e.Name())}
+				if _, ok := childCols[tc]; !ok {
+					// A reference to an outer scope, which is constant for each execution of this node
+					return false
+				}
+				if _, ok := groupedCols[tc]; ok || pkGrouped[tc.table] {
+					return false
+				}
+				nonGrouped = e.String()
 				return false
 			}
-
 			return true
-		}
-	})
-
-	return valid
+		})
+		return nonGrouped
+	}
 }
 
 func validateSchemaSource(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope, sel RuleSelector) (sql.Node, transform.TreeIdentity, error) {
@@ -289,6 +369,10 @@ func validateIndexCreation(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sco
 	span, ctx := ctx.Span("validate_index_creation")
 	defer span.End()
 
+	if err := validateFunctionalIndexes(n); err != nil {
+		return nil, transform.SameTree, err
+	}
+
 	ci, ok := n.(*plan.CreateIndex)
 	if !ok {
 		return n, transform.SameTree, nil
@@ -317,6 +401,66 @@ func validateIndexCreation(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sco
 	return n, transform.SameTree, nil
 }
 
+// validateFunctionalIndexes ensures that the functional key parts of the indexes created by the node given only use
+// deterministic functions, and have a type that can be indexed.
+func validateFunctionalIndexes(n sql.Node) error {
+	var err error
+	transform.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.CreateTable:
+			for _, idxDef := range n.TableSpec().IdxDefs {
+				if err = validateFunctionalKeyParts(idxDef.IndexName, idxDef.Columns); err != nil {
+					return false
+				}
+			}
+		case *plan.AlterIndex:
+			err = validateFunctionalKeyParts(n.IndexName, n.Columns)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// validateFunctionalKeyParts validates the functional key parts of the index with the name given.
+func validateFunctionalKeyParts(indexName string, cols []sql.IndexColumn) error {
+	if indexName == "" {
+		// indexes without a name are named after their functional key parts
+		indexName = "functional_index"
+	}
+	for _, col := range cols {
+		if !col.IsFunctional() {
+			continue
+		}
+
+		var err error
+		sql.Inspect(col.Expression, func(e sql.Expression) bool {
+			switch e := e.(type) {
+			case sql.FunctionExpression:
+				funcName := strings.ToLower(e.FunctionName())
+				_, isValid := validColumnDefaultFuncs[funcName]
+				_, isInvalid := invalidGeneratedColumnFuncs[funcName]
+				if !isValid || isInvalid {
+					err = sql.ErrFunctionalIndexFunctionIsNotAllowed.New(indexName)
+				}
+			case *plan.Subquery, *expression.UserVar, *expression.SystemVar, *expression.ProcedureParam:
+				err = sql.ErrFunctionalIndexFunctionIsNotAllowed.New(indexName)
+			}
+			return err == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		typ := col.Expression.Type()
+		if sql.IsJSON(typ) || sql.IsGeometry(typ) {
+			return sql.ErrFunctionalIndexOnJsonOrGeometryFunction.New()
+		} else if sql.IsTextBlob(typ) {
+			return sql.ErrFunctionalIndexOnLob.New()
+		}
+	}
+	return nil
+}
+
 func validateSchema(t *plan.ResolvedTable) error {
 	for _, col := range t.Schema() {
 		if col.Source == "" {
@@ -56,93 +56,64 @@ func TestValidateOrderBy(t *testing.T) {
 	require.Error(err)
 }
 
-func TestValidateGroupBy(t *testing.T) {
+func TestValidateOnlyFullGroupBy(t *testing.T) {
 	require := require.New(t)
+	vr := getValidationRule(validateOnlyFullGroupById)
 
-	vr := getValidationRule(validateGroupById)
-
-	_, _, err := vr.Apply(sql.NewEmptyContext(), nil, dummyNode{true}, nil, DefaultRuleSelector)
-	require.NoError(err)
-	_, _, err = vr.Apply(sql.NewEmptyContext(), nil, dummyNode{false}, nil, DefaultRuleSelector)
-	require.NoError(err)
-
-	childSchema := sql.NewPrimaryKeySchema(sql.Schema{
-		{Name: "col1", Type: sql.Text},
-		{Name: "col2", Type: sql.Int64},
-	})
+	child := memory.NewTable("test", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "pk", Type: sql.Int64, Source: "test", PrimaryKey: true},
+		{Name: "col1", Type: sql.Text, Source: "test"},
+		{Name: "col2", Type: sql.Int64, Source: "test"},
+	}), nil)
 
-	child := memory.NewTable("test", childSchema, nil)
+	pk := expression.NewGetFieldWithTable(0, sql.Int64, "test", "pk", false)
+	col1 := expression.NewGetFieldWithTable(1, sql.Text, "test", "col1", true)
+	col2 := expression.NewGetFieldWithTable(2, sql.Int64, "test", "col2", true)
 
-	rows := []sql.Row{
-		sql.NewRow("col1_1", int64(1111)),
-		sql.NewRow("col1_1", int64(2222)),
-		sql.NewRow("col1_2", int64(4444)),
-		sql.NewRow("col1_1", int64(1111)),
-		sql.NewRow("col1_2", int64(4444)),
-	}
-
-	for _, r := range rows {
-		require.NoError(child.Insert(sql.NewEmptyContext(), r))
+	testCases := []struct {
+		name     string
+		selected []sql.Expression
+		grouped  []sql.Expression
+		ok       bool
+	}{
+		{"grouped column", []sql.Expression{col1}, []sql.Expression{col1}, true},
+		{"aggregated column", []sql.Expression{col1, aggregation.NewMax(col2)}, []sql.Expression{col1}, true},
+		{"any_value", []sql.Expression{col1, function.NewAnyValue(col2)}, []sql.Expression{col1}, true},
+		{"grouped primary key", []sql.Expression{col1, col2}, []sql.Expression{pk}, true},
+		{"non-grouped column", []sql.Expression{col1, col2}, []sql.Expression{col1}, false},
+		{"non-grouped column in expression", []sql.Expression{expression.NewPlus(col1, col2)}, []sql.Expression{col1}, false},
 	}
 
-	p := plan.NewGroupBy(
-		[]sql.Expression{
-			expression.NewAlias("alias", expression.NewGetField(0, sql.Text, "col1", true)),
-			expression.NewGetField(0, sql.Text, "col1", true),
-			aggregation.NewCount(expression.NewGetField(1, sql.Int64, "col2", true)),
-		},
-		[]sql.Expression{
-			expression.NewGetField(0, sql.Text, "col1", true),
-		},
-		plan.NewResolvedTable(child, nil, nil),
-	)
-
-	_, _, err = vr.Apply(sql.NewEmptyContext(), nil, p, nil, DefaultRuleSelector)
-	require.NoError(err)
-}
-
-func TestValidateGroupByErr(t *testing.T) {
-	require := require.New(t)
-
-	vr := getValidationRule(validateGroupById)
-
-	_, _, err := vr.Apply(sql.NewEmptyContext(), nil, dummyNode{true}, nil, DefaultRuleSelector)
-	require.NoError(err)
-	_, _, err = vr.Apply(sql.NewEmptyContext(), nil, dummyNode{false}, nil, DefaultRuleSelector)
-	require.NoError(err)
-
-	childSchema := sql.NewPrimaryKeySchema(sql.Schema{
-		{Name: "col1", Type: sql.Text},
-		{Name: "col2", Type: sql.Int64},
-	})
-
-	child := memory.NewTable("test", childSchema, nil)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			node := plan.NewGroupBy(tt.selected, tt.grouped, plan.NewResolvedTable(child, nil, nil))
 
-	rows := []sql.Row{
-		sql.NewRow("col1_1", int64(1111)),
-		sql.NewRow("col1_1", int64(2222)),
-		sql.NewRow("col1_2", int64(4444)),
-		sql.NewRow("col1_1", int64(1111)),
-		sql.NewRow("col1_2", int64(4444)),
-	}
+			ctx := sql.NewEmptyContext()
+			_, _, err := vr.Apply(ctx, nil, node, nil, DefaultRuleSelector)
+			require.NoError(err)
 
-	for _, r := range rows {
-		require.NoError(child.Insert(sql.NewEmptyContext(), r))
+			require.NoError(ctx.SetSessionVariable(ctx, "sql_mode", "ONLY_FULL_GROUP_BY"))
+			_, _, err = vr.Apply(ctx, nil, node, nil, DefaultRuleSelector)
+			if tt.ok {
+				require.NoError(err)
+			} else {
+				require.True(sql.ErrWrongFieldWithGroup.Is(err), "unexpected error %v", err)
+			}
+		})
 	}
 
-	p := plan.NewGroupBy(
-		[]sql.Expression{
-			expression.NewGetField(0, sql.Text, "col1", true),
-			expression.NewGetField(1, sql.Int64, "col2", true),
-		},
-		[]sql.Expression{
-			expression.NewGetField(0, sql.Text, "col1", true),
-		},
-		plan.NewResolvedTable(child, nil, nil),
-	)
-
-	_, _, err = vr.Apply(sql.NewEmptyContext(), nil, p, nil, DefaultRuleSelector)
-	require.Error(err)
+	t.Run("non-grouped column in having", func(t *testing.T) {
+		// The having condition refers to the second selected expression, which was pulled up from it
+		node := plan.NewHaving(
+			expression.NewGreaterThan(expression.NewGetFieldWithTable(1, sql.Int64, "test", "col2", true), expression.NewLiteral(int64(1), sql.Int64)),
+			plan.NewGroupBy([]sql.Expression{col1, col2}, []sql.Expression{col1}, plan.NewResolvedTable(child, nil, nil)),
+		)
+
+		ctx := sql.NewEmptyContext()
+		require.NoError(ctx.SetSessionVariable(ctx, "sql_mode", "ONLY_FULL_GROUP_BY"))
+		_, _, err := vr.Apply(ctx, nil, node, nil, DefaultRuleSelector)
+		require.True(sql.ErrNonGroupingFieldUsed.Is(err), "unexpected error %v", err)
+	})
 }
 
 func TestValidateSchemaSource(t *testing.T) {
@@ -47,6 +47,12 @@ type Column struct {
 	Comment string
 	// Extra contains any additional information to put in the `extra` column under `information_schema.columns`.
 	Extra string
+	// Generated contains the expression used to compute the value of a generated column, or nil if the column is not
+	// generated.
+	Generated *ColumnDefaultValue
+	// Virtual is true if the column is a generated column that is not stored. Integrators may still choose to
+	// materialize the values of virtual columns.
+	Virtual bool
 }
 
 // Check ensures the value is correct for this column.
@@ -65,6 +71,8 @@ func (c *Column) Equals(c2 *Column) bool {
 		c.Source == c2.Source &&
 		c.Nullable == c2.Nullable &&
 		reflect.DeepEqual(c.Default, c2.Default) &&
+		reflect.DeepEqual(c.Generated, c2.Generated) &&
+		c.Virtual == c2.Virtual &&
 		reflect.DeepEqual(c.Type, c2.Type)
 }
 
@@ -96,6 +104,14 @@ func (c *Column) DebugString() string {
 	sb.WriteString(", ")
 	sb.WriteString("Extra: ")
 	sb.WriteString(c.Extra)
+	if c.Generated != nil {
+		sb.WriteString(", ")
+		sb.WriteString("Generated: ")
+		sb.WriteString(DebugString(c.Generated))
+		sb.WriteString(", ")
+		sb.WriteString("Virtual: ")
+		sb.WriteString(fmt.Sprintf("%v", c.Virtual))
+	}
 
 	return sb.String()
 }
@@ -111,5 +127,7 @@ func (c *Column) Copy() *Column {
 		PrimaryKey:    c.PrimaryKey,
 		Comment:       c.Comment,
 		Extra:         c.Extra,
+		Generated:     c.Generated,
+		Virtual:       c.Virtual,
 	}
 }
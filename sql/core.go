@@ -434,6 +434,15 @@ type IndexColumn struct {
 	Name string
 	// Length represents the index prefix length. If zero, then no length was specified.
 	Length int64
+	// Expression is the expression of a functional key part, which indexes the value of the expression rather than
+	// a column. Its fields refer to the columns of the table. It's nil for key parts that are columns, and Name is
+	// empty for key parts that are expressions.
+	Expression Expression
+}
+
+// IsFunctional returns whether the key part is an expression rather than a column.
+func (c IndexColumn) IsFunctional() bool {
+	return c.Expression != nil
 }
 
 // IndexAddressable is a table that can be scanned through a primary index
@@ -220,6 +220,12 @@ func (t decimalType) ConvertToNullDecimal(v interface{}) (decimal.NullDecimal, e
 			return decimal.NullDecimal{}, nil
 		}
 		res = value.Decimal
+	case JSONValue:
+		doc, err := value.Unmarshall(nil)
+		if err != nil {
+			return decimal.NullDecimal{}, err
+		}
+		return t.ConvertToNullDecimal(doc.Val)
 	default:
 		return decimal.NullDecimal{}, ErrConvertingToDecimal.New(v)
 	}
@@ -227,6 +233,20 @@ func (t decimalType) ConvertToNullDecimal(v interface{}) (decimal.NullDecimal, e
 	return decimal.NullDecimal{Decimal: res, Valid: true}, nil
 }
 
+// clamp returns the largest or smallest value of the type, whichever is closest to |v|, which is outside the type's
+// range.
+func (t decimalType) clamp(v interface{}) (interface{}, bool) {
+	dec, err := t.ConvertToNullDecimal(v)
+	if err != nil || !dec.Valid {
+		return nil, false
+	}
+	max := t.exclusiveUpperBound.Sub(decimal.New(1, -int32(t.scale)))
+	if dec.Decimal.IsNegative() {
+		return max.Neg(), true
+	}
+	return max, true
+}
+
 func (t decimalType) BoundsCheck(v decimal.Decimal) (decimal.Decimal, error) {
 	if -v.Exponent() > int32(t.scale) {
 		// TODO : add 'Data truncated' warning
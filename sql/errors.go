@@ -299,6 +299,23 @@ var (
 	// ErrSignalOnlySqlState is returned when SIGNAL/RESIGNAL references a DECLARE CONDITION for a MySQL error code.
 	ErrSignalOnlySqlState = errors.NewKind("SIGNAL/RESIGNAL can only use a condition defined with SQLSTATE")
 
+	// ErrDeclareVariableDuplicate is returned when a DECLARE statement reuses a variable name in the same scope.
+	ErrDeclareVariableDuplicate = errors.NewKind("duplicate variable: %s")
+
+	// ErrDeclareCursorDuplicate is returned when a DECLARE CURSOR statement reuses a cursor name in the same scope.
+	ErrDeclareCursorDuplicate = errors.NewKind("duplicate cursor: %s")
+
+	// ErrDeclareHandlerDuplicate is returned when a DECLARE HANDLER statement handles a condition that another handler
+	// in the same scope already handles.
+	ErrDeclareHandlerDuplicate = errors.NewKind("duplicate handler declared in the same block")
+
+	// ErrDeclareVariableOrConditionAfterCursor is returned when a variable or condition is declared after a cursor or
+	// handler.
+	ErrDeclareVariableOrConditionAfterCursor = errors.NewKind("variable or condition declaration after cursor or handler declaration")
+
+	// ErrDeclareCursorAfterHandler is returned when a cursor is declared after a handler.
+	ErrDeclareCursorAfterHandler = errors.NewKind("cursor declaration after handler declaration")
+
 	// ErrExpectedSingleRow is returned when a subquery executed in normal queries or aggregation function returns
 	// more than 1 row without an attached IN clause.
 	ErrExpectedSingleRow = errors.NewKind("the subquery returned more than 1 row")
@@ -473,6 +490,10 @@ var (
 	// ErrReadOnlyTransaction is returned when a write query is executed in a READ ONLY transaction.
 	ErrReadOnlyTransaction = errors.NewKind("cannot execute statement in a READ ONLY transaction")
 
+	// ErrQueryTimeout is returned when a query runs for longer than the limit given by the max_execution_time system
+	// variable or the MAX_EXECUTION_TIME optimizer hint.
+	ErrQueryTimeout = errors.NewKind("Query execution was interrupted, maximum statement execution time exceeded")
+
 	// ErrLockDeadlock is the go-mysql-server equivalent of ER_LOCK_DEADLOCK. Transactions throwing this error
 	// are automatically rolled back. Clients receiving this error must retry the transaction.
 	ErrLockDeadlock = errors.NewKind("serialization failure: %s, try restarting transaction.")
@@ -651,6 +672,115 @@ var (
 
 	// ErrNoTablesUsed is returned when there is no table provided or dual table is defined with column access.
 	ErrNoTablesUsed = errors.NewKind("No tables used")
+
+	// ErrGeneratedColumnWrongUsage is returned when a generated column is also given a default value or is set to
+	// auto increment.
+	ErrGeneratedColumnWrongUsage = errors.NewKind("Incorrect usage of %s and generated column")
+
+	// ErrGeneratedColumnValue is returned when a value other than DEFAULT is written to a generated column.
+	ErrGeneratedColumnValue = errors.NewKind("The value specified for generated column '%s' in table '%s' is not allowed.")
+
+	// ErrGeneratedColumnFunctionIsNotAllowed is returned when the expression of a generated column uses a function
+	// that is not deterministic, or a subquery or variable.
+	ErrGeneratedColumnFunctionIsNotAllowed = errors.NewKind("Expression of generated column '%s' contains a disallowed function.")
+
+	// ErrGeneratedColumnNonPriorGenerated is returned when the expression of a generated column refers to a generated
+	// column defined after it.
+	ErrGeneratedColumnNonPriorGenerated = errors.NewKind("Generated column can refer only to generated columns defined prior to it.")
+
+	// ErrGeneratedColumnRefAutoInc is returned when the expression of a generated column refers to an auto increment
+	// column.
+	ErrGeneratedColumnRefAutoInc = errors.NewKind("Generated column '%s' cannot refer to auto-increment column.")
+
+	// ErrDependentByGeneratedColumn is returned when a column cannot be dropped or renamed as the expression of a
+	// generated column refers to it.
+	ErrDependentByGeneratedColumn = errors.NewKind("Column '%s' has a generated column dependency.")
+
+	// ErrWrongFieldWithGroup is returned when ONLY_FULL_GROUP_BY is enabled and a grouped query selects a column that
+	// is neither grouped nor functionally dependent on the grouped columns.
+	ErrWrongFieldWithGroup = errors.NewKind("Expression #%d of SELECT list is not in GROUP BY clause and contains nonaggregated column '%s' which is not functionally dependent on columns in GROUP BY clause; this is incompatible with sql_mode=only_full_group_by")
+
+	// ErrMixOfGroupFuncAndFields is returned when ONLY_FULL_GROUP_BY is enabled and an aggregated query without a GROUP
+	// BY selects a column outside of an aggregate function.
+	ErrMixOfGroupFuncAndFields = errors.NewKind("In aggregated query without GROUP BY, expression #%d of SELECT list contains nonaggregated column '%s'; this is incompatible with sql_mode=only_full_group_by")
+
+	// ErrNonGroupingFieldUsed is returned when ONLY_FULL_GROUP_BY is enabled and the HAVING clause of a grouped query
+	// uses a column that is neither grouped nor functionally dependent on the grouped columns.
+	ErrNonGroupingFieldUsed = errors.NewKind("Non-grouping field '%s' is used in %s clause")
+
+	// ErrFileExists is returned when SELECT ... INTO OUTFILE or DUMPFILE would overwrite an existing file.
+	ErrFileExists = errors.NewKind("File '%s' already exists")
+
+	// ErrSecureFilePriv is returned when a file outside of the secure_file_priv directory is read or written.
+	ErrSecureFilePriv = errors.NewKind("The MySQL server is running with the --secure-file-priv option so it cannot execute this statement")
+
+	// ErrFulltextColumn is returned when a FULLTEXT index is declared over a column that doesn't hold text.
+	ErrFulltextColumn = errors.NewKind("Column '%s' cannot be part of FULLTEXT index")
+
+	// ErrNoFulltextIndex is returned when the columns of a MATCH expression aren't exactly the columns of a FULLTEXT
+	// index.
+	ErrNoFulltextIndex = errors.NewKind("Can't find FULLTEXT index matching the column list")
+
+	// ErrFunctionalIndexOnField is returned when a functional key part is just a column.
+	ErrFunctionalIndexOnField = errors.NewKind("Functional index on a column is not supported. Consider using a regular index instead.")
+
+	// ErrFunctionalIndexPrimaryKey is returned when a primary key has a functional key part.
+	ErrFunctionalIndexPrimaryKey = errors.NewKind("The primary key cannot be a functional index")
+
+	// ErrFulltextFunctionalIndex is returned when a FULLTEXT index has a functional key part.
+	ErrFulltextFunctionalIndex = errors.NewKind("Fulltext functional index is not supported.")
+
+	// ErrSpatialFunctionalIndex is returned when a SPATIAL index has a functional key part.
+	ErrSpatialFunctionalIndex = errors.NewKind("Spatial functional index is not supported.")
+
+	// ErrFunctionalIndexFunctionIsNotAllowed is returned when the expression of a functional key part uses a function
+	// that is not deterministic, or a subquery or variable.
+	ErrFunctionalIndexFunctionIsNotAllowed = errors.NewKind("Expression of functional index '%s' contains a disallowed function.")
+
+	// ErrFunctionalIndexOnLob is returned when the expression of a functional key part returns a BLOB or TEXT value.
+	ErrFunctionalIndexOnLob = errors.NewKind("Cannot create a functional index on an expression that returns a BLOB or TEXT. Please consider using CAST.")
+
+	// ErrFunctionalIndexOnJsonOrGeometryFunction is returned when the expression of a functional key part returns a
+	// JSON or geometry value.
+	ErrFunctionalIndexOnJsonOrGeometryFunction = errors.NewKind("Cannot create a functional index on a function that returns a JSON or GEOMETRY value.")
+
+	// ErrDependentByFunctionalIndex is returned when a column cannot be dropped or renamed as a functional key part
+	// refers to it.
+	ErrDependentByFunctionalIndex = errors.NewKind("Column '%s' has a functional index dependency and cannot be dropped or renamed.")
+
+	// ErrSpatialIndexColumn is returned when a SPATIAL index is declared over a column that doesn't hold geometries.
+	ErrSpatialIndexColumn = errors.NewKind("A SPATIAL index may only contain a geometrical type column")
+
+	// ErrSpatialIndexNullable is returned when a SPATIAL index is declared over a nullable column.
+	ErrSpatialIndexNullable = errors.NewKind("All parts of a SPATIAL index must be NOT NULL")
+
+	// ErrSpatialIndexKeyParts is returned when a SPATIAL index is declared over more than one column.
+	ErrSpatialIndexKeyParts = errors.NewKind("Too many key parts specified; max 1 parts allowed")
+
+	// ErrInvalidJSONPath is returned when a JSON path expression cannot be parsed.
+	ErrInvalidJSONPath = errors.NewKind("Invalid JSON path expression. The error is around character position %d.")
+
+	// ErrInvalidJSONPathWildcard is returned when a JSON path expression contains a wildcard or an array range where
+	// only a path to a single value is allowed.
+	ErrInvalidJSONPathWildcard = errors.NewKind("In this situation, path expressions may not contain the * and ** tokens or an array range.")
+
+	// ErrJSONVacuousPath is returned when the path '$' is given to a function that can't operate on the whole document.
+	ErrJSONVacuousPath = errors.NewKind("The path expression '$' is not allowed in this context.")
+
+	// ErrInvalidJSONPathArrayCell is returned when a JSON path expression must but does not end in an array index.
+	ErrInvalidJSONPathArrayCell = errors.NewKind("A path expression is not a path to a cell in an array.")
+
+	// ErrInvalidJSONOneOrAll is returned when the one_or_all argument of a JSON search function is not 'one' or 'all'.
+	ErrInvalidJSONOneOrAll = errors.NewKind("The oneOrAll argument to %s may take these values: 'one' or 'all'.")
+
+	// ErrInvalidJSONType is returned when a JSON argument of a function isn't of the JSON type the function requires.
+	ErrInvalidJSONType = errors.NewKind("Invalid JSON type in argument %d to function %s; an %s is required.")
+
+	// ErrInvalidJSONSchema is returned when a JSON document isn't a valid JSON Schema.
+	ErrInvalidJSONSchema = errors.NewKind("Invalid JSON Schema: %s.")
+
+	// ErrNotSupportedYet is returned for syntax that MySQL accepts but doesn't implement.
+	ErrNotSupportedYet = errors.NewKind("This version of MySQL doesn't yet support '%s'")
 )
 
 // CastSQLError returns a *mysql.SQLError with the error code and in some cases, also a SQL state, populated for the
@@ -715,6 +845,77 @@ func CastSQLError(err error) *mysql.SQLError {
 		code = 1553 // TODO: Needs to be added to vitess
 	case ErrInvalidValue.Is(err):
 		code = mysql.ERTruncatedWrongValueForField
+	case ErrQueryTimeout.Is(err):
+		code = 3024 // TODO: Needs to be added to vitess
+	case ErrGeneratedColumnWrongUsage.Is(err):
+		code = mysql.ERWrongUsage
+	case ErrGeneratedColumnValue.Is(err):
+		code = 3105 // TODO: Needs to be added to vitess
+	case ErrGeneratedColumnFunctionIsNotAllowed.Is(err):
+		code = 3102 // TODO: Needs to be added to vitess
+	case ErrGeneratedColumnNonPriorGenerated.Is(err):
+		code = 3107 // TODO: Needs to be added to vitess
+	case ErrGeneratedColumnRefAutoInc.Is(err):
+		code = 3109 // TODO: Needs to be added to vitess
+	case ErrDependentByGeneratedColumn.Is(err):
+		code = 3108 // TODO: Needs to be added to vitess
+	case ErrLengthBeyondLimit.Is(err):
+		code = mysql.ERDataTooLong
+	case ErrOutOfRange.Is(err), ErrConvertToDecimalLimit.Is(err):
+		code = 1264 // TODO: Needs to be added to vitess
+	case ErrConvertingToTime.Is(err):
+		code = mysql.ERTruncatedWrongValue
+	case ErrWrongFieldWithGroup.Is(err):
+		code = mysql.ERWrongFieldWithGroup
+	case ErrMixOfGroupFuncAndFields.Is(err):
+		code = mysql.ERMixOfGroupFuncAndFields
+	case ErrNonGroupingFieldUsed.Is(err):
+		code = 1463 // TODO: Needs to be added to vitess
+		sqlState = "42000"
+	case ErrFileExists.Is(err):
+		code = mysql.ERFileExists
+	case ErrSecureFilePriv.Is(err):
+		code = mysql.EROptionPreventsStatement
+	case ErrFulltextColumn.Is(err):
+		code = mysql.ERBadFTColumn
+	case ErrNoFulltextIndex.Is(err):
+		code = 1191 // TODO: Needs to be added to vitess
+	case ErrFunctionalIndexOnField.Is(err):
+		code = 3762 // TODO: Needs to be added to vitess
+	case ErrFunctionalIndexPrimaryKey.Is(err):
+		code = 3756 // TODO: Needs to be added to vitess
+	case ErrFulltextFunctionalIndex.Is(err):
+		code = 3759 // TODO: Needs to be added to vitess
+	case ErrSpatialFunctionalIndex.Is(err):
+		code = 3760 // TODO: Needs to be added to vitess
+	case ErrFunctionalIndexFunctionIsNotAllowed.Is(err):
+		code = 3758 // TODO: Needs to be added to vitess
+	case ErrFunctionalIndexOnLob.Is(err):
+		code = 3757 // TODO: Needs to be added to vitess
+	case ErrFunctionalIndexOnJsonOrGeometryFunction.Is(err):
+		code = 3753 // TODO: Needs to be added to vitess
+	case ErrDependentByFunctionalIndex.Is(err):
+		code = 3837 // TODO: Needs to be added to vitess
+	case ErrSpatialIndexColumn.Is(err):
+		code = 1687 // TODO: Needs to be added to vitess
+	case ErrSpatialIndexNullable.Is(err):
+		code = 1252 // TODO: Needs to be added to vitess
+	case ErrSpatialIndexKeyParts.Is(err):
+		code = mysql.ERTooManyKeyParts
+	case ErrInvalidJSONPath.Is(err):
+		code = 3143 // TODO: Needs to be added to vitess
+	case ErrInvalidJSONPathWildcard.Is(err):
+		code = 3149 // TODO: Needs to be added to vitess
+	case ErrJSONVacuousPath.Is(err):
+		code = 3153 // TODO: Needs to be added to vitess
+	case ErrInvalidJSONPathArrayCell.Is(err):
+		code = 3165 // TODO: Needs to be added to vitess
+	case ErrInvalidJSONOneOrAll.Is(err):
+		code = 3154 // TODO: Needs to be added to vitess
+	case ErrInvalidJSONType.Is(err):
+		code = 3853 // TODO: Needs to be added to vitess
+	case ErrNotSupportedYet.Is(err):
+		code = mysql.ERNotSupportedYet
 	case ErrLockDeadlock.Is(err):
 		// ER_LOCK_DEADLOCK signals that the transaction was rolled back
 		// due to a deadlock between concurrent transactions.
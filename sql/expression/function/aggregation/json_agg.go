@@ -142,13 +142,9 @@ func (j *jsonObjectBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		return err
 	}
 
-	// unwrap JSON values
-	if js, ok := val.(sql.JSONValue); ok {
-		doc, err := js.Unmarshall(ctx)
-		if err != nil {
-			return err
-		}
-		val = doc.Val
+	val, err = sql.JSONDocumentValue(ctx, val)
+	if err != nil {
+		return err
 	}
 
 	// Update the map.
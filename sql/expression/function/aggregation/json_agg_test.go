@@ -37,8 +37,8 @@ func TestJsonArrayAgg_SimpleIntField(t *testing.T) {
 	j := NewJsonArray(expression.NewGetField(0, sql.Int32, "field", true))
 	b, _ := j.NewBuffer()
 
-	b.Update(ctx, sql.NewRow(float64(7)))
-	b.Update(ctx, sql.NewRow(float64(2)))
+	b.Update(ctx, sql.NewRow(int32(7)))
+	b.Update(ctx, sql.NewRow(int32(2)))
 
 	v, err := b.Eval(ctx)
 	assert.NoError(err)
@@ -588,13 +588,9 @@ func (j *jsonArrayBuffer) Update(ctx *sql.Context, row sql.Row) error {
 		return err
 	}
 
-	// unwrap JSON values
-	if js, ok := v.(sql.JSONValue); ok {
-		doc, err := js.Unmarshall(ctx)
-		if err != nil {
-			return err
-		}
-		v = doc.Val
+	v, err = sql.JSONDocumentValue(ctx, v)
+	if err != nil {
+		return err
 	}
 
 	j.vals = append(j.vals, v)
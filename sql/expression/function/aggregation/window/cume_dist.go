@@ -0,0 +1,115 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+)
+
+type CumeDist struct {
+	window *sql.WindowDefinition
+	pos    int
+}
+
+var _ sql.FunctionExpression = (*CumeDist)(nil)
+var _ sql.WindowAggregation = (*CumeDist)(nil)
+var _ sql.WindowAdaptableExpression = (*CumeDist)(nil)
+
+func NewCumeDist() sql.Expression {
+	return &CumeDist{}
+}
+
+// Description implements sql.FunctionExpression
+func (c *CumeDist) Description() string {
+	return "returns cumulative distribution value."
+}
+
+// Window implements sql.WindowExpression
+func (c *CumeDist) Window() *sql.WindowDefinition {
+	return c.window
+}
+
+func (c *CumeDist) Resolved() bool {
+	return windowResolved(c.window)
+}
+
+func (c *CumeDist) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("cume_dist()")
+	if c.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(c.window.String())
+	}
+	return sb.String()
+}
+
+func (c *CumeDist) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString("cume_dist()")
+	if c.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(c.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *CumeDist) FunctionName() string {
+	return "CUME_DIST"
+}
+
+// Type implements sql.Expression
+func (c *CumeDist) Type() sql.Type {
+	return sql.Float64
+}
+
+// IsNullable implements sql.Expression
+func (c *CumeDist) IsNullable() bool {
+	return false
+}
+
+// Eval implements sql.Expression
+func (c *CumeDist) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (c *CumeDist) Children() []sql.Expression {
+	return c.window.ToExpressions()
+}
+
+// WithChildren implements sql.Expression
+func (c *CumeDist) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	window, err := c.window.FromExpressions(children)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.WithWindow(window)
+}
+
+// WithWindow implements sql.WindowAggregation
+func (c *CumeDist) WithWindow(window *sql.WindowDefinition) (sql.WindowAggregation, error) {
+	nr := *c
+	nr.window = window
+	return &nr, nil
+}
+
+func (c *CumeDist) NewWindowFunction() (sql.WindowFunction, error) {
+	return aggregation.NewCumeDist(c.window.OrderBy.ToExpressions()), nil
+}
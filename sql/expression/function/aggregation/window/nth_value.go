@@ -0,0 +1,153 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql/transform"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+)
+
+type NthValue struct {
+	window *sql.WindowDefinition
+	expression.UnaryExpression
+	n int
+}
+
+var _ sql.FunctionExpression = (*NthValue)(nil)
+var _ sql.WindowAggregation = (*NthValue)(nil)
+var _ sql.WindowAdaptableExpression = (*NthValue)(nil)
+
+// NewNthValue creates a new NthValue node from the expression and the row number. The row number is constrained to a
+// positive integer expression.Literal.
+// TODO: support user-defined variable row numbers
+func NewNthValue(e ...sql.Expression) (sql.Expression, error) {
+	if len(e) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("NTH_VALUE", 2, len(e))
+	}
+	n, err := expression.LiteralToInt(e[1])
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, sql.ErrInvalidArgument.New("nth_value")
+	}
+	return &NthValue{UnaryExpression: expression.UnaryExpression{Child: e[0]}, n: n}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (n *NthValue) Description() string {
+	return "returns value of argument from N-th row of window frame."
+}
+
+// Window implements sql.WindowExpression
+func (n *NthValue) Window() *sql.WindowDefinition {
+	return n.window
+}
+
+func (n *NthValue) Resolved() bool {
+	return n.Child.Resolved() && windowResolved(n.window)
+}
+
+func (n *NthValue) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(n.functionString(n.Child.String()))
+	if n.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(n.window.String())
+	}
+	return sb.String()
+}
+
+func (n *NthValue) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString(n.functionString(sql.DebugString(n.Child)))
+	if n.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(n.window))
+	}
+	return sb.String()
+}
+
+// functionString returns the call of the function, without its window.
+func (n *NthValue) functionString(child string) string {
+	return fmt.Sprintf("nth_value(%s, %d)", child, n.n)
+}
+
+// FunctionName implements sql.FunctionExpression
+func (n *NthValue) FunctionName() string {
+	return "NTH_VALUE"
+}
+
+// Type implements sql.Expression
+func (n *NthValue) Type() sql.Type {
+	return n.Child.Type()
+}
+
+// IsNullable implements sql.Expression
+func (n *NthValue) IsNullable() bool {
+	return true
+}
+
+// Eval implements sql.Expression
+func (n *NthValue) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (n *NthValue) Children() []sql.Expression {
+	if n == nil {
+		return nil
+	}
+	return append(n.window.ToExpressions(), n.Child)
+}
+
+// WithChildren implements sql.Expression
+func (n *NthValue) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) < 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(children), 1)
+	}
+
+	nn := *n
+	window, err := n.window.FromExpressions(children[:len(children)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	nn.Child = children[len(children)-1]
+	nn.window = window
+
+	return &nn, nil
+}
+
+// WithWindow implements sql.WindowAggregation
+func (n *NthValue) WithWindow(window *sql.WindowDefinition) (sql.WindowAggregation, error) {
+	nn := *n
+	nn.window = window
+	return &nn, nil
+}
+
+func (n *NthValue) NewWindowFunction() (sql.WindowFunction, error) {
+	c, err := transform.Clone(n.Child)
+	if err != nil {
+		return nil, err
+	}
+	return aggregation.NewNthValue(c, n.n).WithWindow(n.window)
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package window
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+)
+
+type Ntile struct {
+	window  *sql.WindowDefinition
+	buckets int
+}
+
+var _ sql.FunctionExpression = (*Ntile)(nil)
+var _ sql.WindowAggregation = (*Ntile)(nil)
+var _ sql.WindowAdaptableExpression = (*Ntile)(nil)
+
+// NewNtile creates a new Ntile node dividing each partition into the number of buckets given, which is constrained
+// to a positive integer expression.Literal.
+// TODO: support user-defined variable buckets
+func NewNtile(e ...sql.Expression) (sql.Expression, error) {
+	if len(e) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New("NTILE", 1, len(e))
+	}
+	buckets, err := expression.LiteralToInt(e[0])
+	if err != nil {
+		return nil, err
+	}
+	if buckets == 0 {
+		return nil, sql.ErrInvalidArgument.New("ntile")
+	}
+	return &Ntile{buckets: buckets}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (n *Ntile) Description() string {
+	return "returns the number of the bucket of the current row within its partition."
+}
+
+// Window implements sql.WindowExpression
+func (n *Ntile) Window() *sql.WindowDefinition {
+	return n.window
+}
+
+func (n *Ntile) Resolved() bool {
+	return windowResolved(n.window)
+}
+
+func (n *Ntile) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("ntile(%d)", n.buckets))
+	if n.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(n.window.String())
+	}
+	return sb.String()
+}
+
+func (n *Ntile) DebugString() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("ntile(%d)", n.buckets))
+	if n.window != nil {
+		sb.WriteString(" ")
+		sb.WriteString(sql.DebugString(n.window))
+	}
+	return sb.String()
+}
+
+// FunctionName implements sql.FunctionExpression
+func (n *Ntile) FunctionName() string {
+	return "NTILE"
+}
+
+// Type implements sql.Expression
+func (n *Ntile) Type() sql.Type {
+	return sql.Uint64
+}
+
+// IsNullable implements sql.Expression
+func (n *Ntile) IsNullable() bool {
+	return false
+}
+
+// Eval implements sql.Expression
+func (n *Ntile) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	panic("eval called on window function")
+}
+
+// Children implements sql.Expression
+func (n *Ntile) Children() []sql.Expression {
+	return n.window.ToExpressions()
+}
+
+// WithChildren implements sql.Expression
+func (n *Ntile) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	window, err := n.window.FromExpressions(children)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.WithWindow(window)
+}
+
+// WithWindow implements sql.WindowAggregation
+func (n *Ntile) WithWindow(window *sql.WindowDefinition) (sql.WindowAggregation, error) {
+	nn := *n
+	nn.window = window
+	return &nn, nil
+}
+
+func (n *Ntile) NewWindowFunction() (sql.WindowFunction, error) {
+	return aggregation.NewNtile(n.buckets), nil
+}
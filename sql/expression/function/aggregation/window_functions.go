@@ -995,13 +995,9 @@ func (a *WindowedJSONArrayAgg) aggregateVals(ctx *sql.Context, interval sql.Wind
 			return nil, err
 		}
 
-		// unwrap JSON values
-		if js, ok := v.(sql.JSONValue); ok {
-			doc, err := js.Unmarshall(ctx)
-			if err != nil {
-				return nil, err
-			}
-			v = doc.Val
+		v, err = sql.JSONDocumentValue(ctx, v)
+		if err != nil {
+			return nil, err
 		}
 
 		vals = append(vals, v)
@@ -1083,13 +1079,9 @@ func (a *WindowedJSONObjectAgg) aggregateVals(ctx *sql.Context, interval sql.Win
 			return nil, err
 		}
 
-		// unwrap JSON values
-		if js, ok := val.(sql.JSONValue); ok {
-			doc, err := js.Unmarshall(ctx)
-			if err != nil {
-				return nil, err
-			}
-			val = doc.Val
+		val, err = sql.JSONDocumentValue(ctx, val)
+		if err != nil {
+			return nil, err
 		}
 
 		// Update the map.
@@ -1357,3 +1349,158 @@ func (a *leadLagBase) Compute(ctx *sql.Context, interval sql.WindowInterval, buf
 	a.pos++
 	return res
 }
+
+type CumeDist struct {
+	*rankBase
+}
+
+func NewCumeDist(orderBy []sql.Expression) *CumeDist {
+	return &CumeDist{
+		&rankBase{
+			partitionStart: -1,
+			partitionEnd:   -1,
+			pos:            -1,
+			orderBy:        orderBy,
+		},
+	}
+}
+
+// Compute returns the number of elements before or peer with the current row, divided by the number of rows in the
+// partition.
+// ex: [1, 2, 2, 2, 3, 3, 3, 4, 5, 5, 6] => every 3 returns float64(7) / float64(11), because
+// there are 7 values less than or equal to 3, and there are 11 total rows in the list.
+func (a *CumeDist) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
+	if interval.End-interval.Start < 1 {
+		return nil
+	}
+	defer func() { a.pos++ }()
+	return float64(interval.End-a.partitionStart) / float64(a.partitionEnd-a.partitionStart)
+}
+
+type Ntile struct {
+	// buckets is the number of groups the partition is divided into
+	buckets                      int
+	partitionStart, partitionEnd int
+	pos                          int
+}
+
+func NewNtile(buckets int) *Ntile {
+	return &Ntile{
+		buckets:        buckets,
+		partitionStart: -1,
+		partitionEnd:   -1,
+		pos:            -1,
+	}
+}
+
+func (a *Ntile) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, error) {
+	return a, nil
+}
+
+func (a *Ntile) Dispose() {
+	return
+}
+
+// DefaultFramer returns a NewPartitionFramer
+func (a *Ntile) DefaultFramer() sql.WindowFramer {
+	return NewPartitionFramer()
+}
+
+func (a *Ntile) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) error {
+	a.Dispose()
+	a.partitionStart, a.partitionEnd = interval.Start, interval.End
+	a.pos = a.partitionStart
+	return nil
+}
+
+func (a *Ntile) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
+	panic("sliding window interface not implemented yet")
+}
+
+// Compute returns the number of the group of the current row, when the rows of the partition are divided into
+// [buckets] groups of sizes that differ by at most one, with the larger groups first.
+// ex: 10 rows in 4 buckets => [1, 1, 1, 2, 2, 2, 3, 3, 4, 4]
+func (a *Ntile) Compute(ctx *sql.Context, interval sql.WindowInterval, buf sql.WindowBuffer) interface{} {
+	if interval.End-interval.Start < 1 {
+		return nil
+	}
+	defer func() { a.pos++ }()
+	size := (a.partitionEnd - a.partitionStart) / a.buckets
+	larger := (a.partitionEnd - a.partitionStart) % a.buckets
+	i := a.pos - a.partitionStart
+	if i < larger*(size+1) {
+		return uint64(i/(size+1) + 1)
+	}
+	return uint64(larger + (i-larger*(size+1))/size + 1)
+}
+
+type NthValue struct {
+	partitionStart int
+	expr           sql.Expression
+	// n is the position of the value in the frame, starting at 1
+	n       int
+	orderBy []sql.Expression
+	framer  sql.WindowFramer
+}
+
+func NewNthValue(e sql.Expression, n int) *NthValue {
+	return &NthValue{
+		partitionStart: -1,
+		expr:           e,
+		n:              n,
+	}
+}
+
+func (a *NthValue) WithWindow(w *sql.WindowDefinition) (sql.WindowFunction, error) {
+	na := *a
+	if w != nil {
+		na.orderBy = w.OrderBy.ToExpressions()
+		if w.Frame != nil {
+			framer, err := w.Frame.NewFramer(w)
+			if err != nil {
+				return nil, err
+			}
+			na.framer = framer
+		}
+	}
+	return &na, nil
+}
+
+func (a *NthValue) Dispose() {
+	expression.Dispose(a.expr)
+}
+
+// DefaultFramer returns the framer of the window's frame if it has one. The default frame runs from the start of
+// the partition to the last peer of the current row, so without a frame the peer group of each row is framed, and
+// Compute extends it to the start of the partition.
+func (a *NthValue) DefaultFramer() sql.WindowFramer {
+	if a.framer != nil {
+		return a.framer
+	}
+	return NewPeerGroupFramer(a.orderBy)
+}
+
+func (a *NthValue) StartPartition(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) error {
+	a.Dispose()
+	a.partitionStart = interval.Start
+	return nil
+}
+
+func (a *NthValue) NewSlidingFrameInterval(added, dropped sql.WindowInterval) {
+	panic("sliding window interface not implemented yet")
+}
+
+// Compute returns the value of the [n]th row of the frame, or nil if the frame has fewer rows.
+func (a *NthValue) Compute(ctx *sql.Context, interval sql.WindowInterval, buffer sql.WindowBuffer) interface{} {
+	if a.framer == nil {
+		interval.Start = a.partitionStart
+	}
+	if interval.End-interval.Start < a.n {
+		return nil
+	}
+	v, err := a.expr.Eval(ctx, buffer[interval.Start+a.n-1])
+	if err != nil {
+		return err
+	}
+	return v
+}
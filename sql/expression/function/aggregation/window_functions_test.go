@@ -156,18 +156,18 @@ func TestGroupedAggFuncs(t *testing.T) {
 			Name: "json array null",
 			Agg:  NewJsonArrayAgg(expression.NewGetField(0, sql.LongText, "x", true)),
 			Expected: sql.Row{
-				sql.JSONDocument{Val: []interface{}{1, nil, 3, 4}},
-				sql.JSONDocument{Val: []interface{}{1, nil, 3, 4}},
-				sql.JSONDocument{Val: []interface{}{1, 2, nil, nil, 5, 6}},
+				sql.JSONDocument{Val: []interface{}{int64(1), nil, int64(3), int64(4)}},
+				sql.JSONDocument{Val: []interface{}{int64(1), nil, int64(3), int64(4)}},
+				sql.JSONDocument{Val: []interface{}{int64(1), int64(2), nil, nil, int64(5), int64(6)}},
 			},
 		},
 		{
 			Name: "json array int",
 			Agg:  NewJsonArrayAgg(expression.NewGetField(1, sql.LongText, "x", true)),
 			Expected: sql.Row{
-				sql.JSONDocument{Val: []interface{}{1, 2, 3, 4}},
-				sql.JSONDocument{Val: []interface{}{1, 2, 3, 4}},
-				sql.JSONDocument{Val: []interface{}{1, 2, 3, 4, 5, 6}},
+				sql.JSONDocument{Val: []interface{}{int64(1), int64(2), int64(3), int64(4)}},
+				sql.JSONDocument{Val: []interface{}{int64(1), int64(2), int64(3), int64(4)}},
+				sql.JSONDocument{Val: []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6)}},
 			},
 		},
 		{
@@ -188,9 +188,9 @@ func TestGroupedAggFuncs(t *testing.T) {
 				).(*JSONObjectAgg),
 			),
 			Expected: sql.Row{
-				sql.JSONDocument{Val: map[string]interface{}{"1": 1, "2": nil, "3": 3, "4": 4}},
-				sql.JSONDocument{Val: map[string]interface{}{"1": 1, "2": nil, "3": 3, "4": 4}},
-				sql.JSONDocument{Val: map[string]interface{}{"1": 1, "2": 2, "3": nil, "4": nil, "5": 5, "6": 6}},
+				sql.JSONDocument{Val: map[string]interface{}{"1": int64(1), "2": nil, "3": int64(3), "4": int64(4)}},
+				sql.JSONDocument{Val: map[string]interface{}{"1": int64(1), "2": nil, "3": int64(3), "4": int64(4)}},
+				sql.JSONDocument{Val: map[string]interface{}{"1": int64(1), "2": int64(2), "3": nil, "4": nil, "5": int64(5), "6": int64(6)}},
 			},
 		},
 		{
@@ -202,9 +202,9 @@ func TestGroupedAggFuncs(t *testing.T) {
 				).(*JSONObjectAgg),
 			),
 			Expected: sql.Row{
-				sql.JSONDocument{Val: map[string]interface{}{"1": 1, "2": nil, "3": 3, "4": 4}},
-				sql.JSONDocument{Val: map[string]interface{}{"1": 1, "2": nil, "3": 3, "4": 4}},
-				sql.JSONDocument{Val: map[string]interface{}{"1": 1, "2": 2, "3": nil, "4": nil, "5": 5, "6": 6}},
+				sql.JSONDocument{Val: map[string]interface{}{"1": int64(1), "2": nil, "3": int64(3), "4": int64(4)}},
+				sql.JSONDocument{Val: map[string]interface{}{"1": int64(1), "2": nil, "3": int64(3), "4": int64(4)}},
+				sql.JSONDocument{Val: map[string]interface{}{"1": int64(1), "2": int64(2), "3": nil, "4": nil, "5": int64(5), "6": int64(6)}},
 			},
 		},
 		{
@@ -340,6 +340,47 @@ func TestWindowedAggFuncs(t *testing.T) {
 				float64(0), float64(1) / float64(5), float64(1) / float64(5), float64(3) / float64(5), float64(3) / float64(5), float64(1),
 			},
 		},
+		{
+			Name: "cume dist no peers",
+			Agg:  NewCumeDist([]sql.Expression{}),
+			Expected: sql.Row{
+				float64(1), float64(1), float64(1), float64(1),
+				float64(1), float64(1), float64(1), float64(1),
+				float64(1), float64(1), float64(1), float64(1), float64(1), float64(1),
+			},
+		},
+		{
+			Name: "cume dist peer groups",
+			Agg:  NewCumeDist([]sql.Expression{expression.NewGetField(5, sql.LongText, "x", true)}),
+			Expected: sql.Row{
+				float64(2) / float64(4), float64(2) / float64(4), float64(3) / float64(4), float64(1),
+				float64(1) / float64(4), float64(3) / float64(4), float64(3) / float64(4), float64(1),
+				float64(1) / float64(6), float64(3) / float64(6), float64(3) / float64(6), float64(5) / float64(6), float64(5) / float64(6), float64(1),
+			},
+		},
+		{
+			Name:     "ntile",
+			Agg:      NewNtile(3),
+			Expected: sql.Row{uint64(1), uint64(1), uint64(2), uint64(3), uint64(1), uint64(1), uint64(2), uint64(3), uint64(1), uint64(1), uint64(2), uint64(2), uint64(3), uint64(3)},
+		},
+		{
+			Name:     "ntile more buckets than rows",
+			Agg:      NewNtile(5),
+			Expected: sql.Row{uint64(1), uint64(2), uint64(3), uint64(4), uint64(1), uint64(2), uint64(3), uint64(4), uint64(1), uint64(1), uint64(2), uint64(3), uint64(4), uint64(5)},
+		},
+		{
+			Name:     "nth value",
+			Agg:      NewNthValue(expression.NewGetField(0, sql.LongText, "x", true), 2),
+			Expected: sql.Row{nil, nil, nil, nil, nil, nil, nil, nil, 2, 2, 2, 2, 2, 2},
+		},
+		{
+			Name: "nth value peer groups",
+			Agg: mustWithWindow(
+				NewNthValue(expression.NewGetField(1, sql.LongText, "x", true), 2),
+				sql.NewWindowDefinition(nil, sql.SortFields{{Column: expression.NewGetField(5, sql.LongText, "x", true)}}, nil, "", ""),
+			),
+			Expected: sql.Row{2, 2, 2, 2, nil, 2, 2, 2, nil, 2, 2, 2, 2, 2},
+		},
 	}
 
 	buf := []sql.Row{
@@ -391,6 +432,14 @@ func TestWindowedAggFuncs(t *testing.T) {
 
 }
 
+func mustWithWindow(f sql.WindowFunction, w *sql.WindowDefinition) sql.WindowFunction {
+	f, err := f.WithWindow(w)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
 func mustNewGroupByConcat(distinct string, orderBy sql.SortFields, separator string, selectExprs []sql.Expression, maxLen int) *GroupConcat {
 	gc, err := NewGroupConcat(distinct, orderBy, separator, selectExprs, maxLen)
 	if err != nil {
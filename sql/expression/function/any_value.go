@@ -0,0 +1,67 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// AnyValue is a function that returns its argument unchanged. It is exempt from ONLY_FULL_GROUP_BY validation, so it
+// can select a column that is neither grouped nor functionally dependent on the grouped columns.
+type AnyValue struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*AnyValue)(nil)
+
+// NewAnyValue creates a new AnyValue expression.
+func NewAnyValue(e sql.Expression) sql.Expression {
+	return &AnyValue{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AnyValue) FunctionName() string {
+	return "any_value"
+}
+
+// Description implements sql.FunctionExpression
+func (a *AnyValue) Description() string {
+	return "returns its argument, suppressing ONLY_FULL_GROUP_BY validation for it."
+}
+
+// Eval implements the Expression interface.
+func (a *AnyValue) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return a.Child.Eval(ctx, row)
+}
+
+func (a *AnyValue) String() string {
+	return fmt.Sprintf("ANY_VALUE(%s)", a.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (a *AnyValue) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewAnyValue(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (a *AnyValue) Type() sql.Type {
+	return a.Child.Type()
+}
@@ -104,12 +104,9 @@ func (j *JSONArray) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			return nil, err
 		}
 
-		if json, ok := val.(sql.JSONValue); ok {
-			doc, err := json.(sql.JSONValue).Unmarshall(ctx)
-			if err != nil {
-				return nil, err
-			}
-			val = doc.Val
+		val, err = sql.JSONDocumentValue(ctx, val)
+		if err != nil {
+			return nil, err
 		}
 		resultArray[i] = val
 	}
@@ -60,12 +60,12 @@ func TestJSONArray(t *testing.T) {
 		err      error
 	}{
 		{f0, sql.Row{}, sql.JSONDocument{Val: []interface{}{}}, nil},
-		{f1, sql.Row{[]interface{}{1, 2}}, sql.JSONDocument{Val: []interface{}{[]interface{}{1, 2}}}, nil},
-		{f2, sql.Row{[]interface{}{1, 2}, "second item"}, sql.JSONDocument{Val: []interface{}{[]interface{}{1, 2}, "second item"}}, nil},
-		{f2, sql.Row{[]interface{}{1, 2}, map[string]interface{}{"name": "x"}}, sql.JSONDocument{Val: []interface{}{[]interface{}{1, 2}, map[string]interface{}{"name": "x"}}}, nil},
-		{f2, sql.Row{map[string]interface{}{"name": "x"}, map[string]interface{}{"id": 47}}, sql.JSONDocument{Val: []interface{}{map[string]interface{}{"name": "x"}, map[string]interface{}{"id": 47}}}, nil},
-		{f3, sql.Row{"foo", -44, "b"}, sql.JSONDocument{Val: []interface{}{"foo", -44, "b"}}, nil},
-		{f4, sql.Row{100, true, nil, "four"}, sql.JSONDocument{Val: []interface{}{100, true, nil, "four"}}, nil},
+		{f1, sql.Row{[]interface{}{1, 2}}, sql.JSONDocument{Val: []interface{}{[]interface{}{int64(1), int64(2)}}}, nil},
+		{f2, sql.Row{[]interface{}{1, 2}, "second item"}, sql.JSONDocument{Val: []interface{}{[]interface{}{int64(1), int64(2)}, "second item"}}, nil},
+		{f2, sql.Row{[]interface{}{1, 2}, map[string]interface{}{"name": "x"}}, sql.JSONDocument{Val: []interface{}{[]interface{}{int64(1), int64(2)}, map[string]interface{}{"name": "x"}}}, nil},
+		{f2, sql.Row{map[string]interface{}{"name": "x"}, map[string]interface{}{"id": 47}}, sql.JSONDocument{Val: []interface{}{map[string]interface{}{"name": "x"}, map[string]interface{}{"id": int64(47)}}}, nil},
+		{f3, sql.Row{"foo", -44, "b"}, sql.JSONDocument{Val: []interface{}{"foo", int64(-44), "b"}}, nil},
+		{f4, sql.Row{100, true, nil, "four"}, sql.JSONDocument{Val: []interface{}{int64(100), true, nil, "four"}}, nil},
 		{f4, sql.Row{100.44, `{"name":null,"id":{"number":998,"type":"A"}}`, nil, `four`},
 			sql.JSONDocument{Val: []interface{}{100.44, "{\"name\":null,\"id\":{\"number\":998,\"type\":\"A\"}}", nil, "four"}}, nil},
 	}
@@ -142,7 +142,7 @@ func (j *JSONContains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		}
 
 		result, err := target.Extract(ctx, path.(string))
-		if err != nil {
+		if err != nil || result == nil {
 			return nil, err
 		}
 
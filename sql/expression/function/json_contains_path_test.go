@@ -0,0 +1,74 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONContainsPath(t *testing.T) {
+	_, err := NewJSONContainsPath(
+		expression.NewGetField(0, sql.JSON, "doc", true),
+		expression.NewGetField(1, sql.LongText, "oneOrAll", true),
+	)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewJSONContainsPath(
+		expression.NewGetField(0, sql.JSON, "doc", true),
+		expression.NewGetField(1, sql.LongText, "oneOrAll", true),
+		expression.NewGetField(2, sql.LongText, "path", true),
+		expression.NewGetField(3, sql.LongText, "path", true),
+	)
+	require.NoError(t, err)
+
+	doc := `{"a": 1, "b": 2, "c": {"d": 4}}`
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{f, sql.Row{doc, "one", `$.a`, `$.e`}, true, nil},
+		{f, sql.Row{doc, "ALL", `$.a`, `$.e`}, false, nil},
+		{f, sql.Row{doc, "all", `$.a`, `$.c.d`}, true, nil},
+		{f, sql.Row{doc, "one", `$.e`, `$.c.e`}, false, nil},
+		{f, sql.Row{doc, "one", `$.*.d`, `$.e`}, true, nil},
+		{f, sql.Row{nil, "one", `$.a`, `$.e`}, nil, nil},
+		{f, sql.Row{doc, nil, `$.a`, `$.e`}, nil, nil},
+		{f, sql.Row{doc, "one", nil, `$.e`}, nil, nil},
+		{f, sql.Row{doc, "some", `$.a`, `$.e`}, nil, sql.ErrInvalidJSONOneOrAll},
+		{f, sql.Row{doc, "one", `$.a`, `$.`}, nil, sql.ErrInvalidJSONPath},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
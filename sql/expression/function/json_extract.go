@@ -75,7 +75,7 @@ func (j *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	defer span.End()
 
 	js, err := j.JSON.Eval(ctx, row)
-	if err != nil {
+	if err != nil || js == nil {
 		return nil, err
 	}
 
@@ -92,10 +92,11 @@ func (j *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
-	var results = make([]sql.JSONValue, len(j.Paths))
-	for i, p := range j.Paths {
+	// Paths that don't match any value are skipped, and the result is NULL if none match
+	var results = make([]sql.JSONValue, 0, len(j.Paths))
+	for _, p := range j.Paths {
 		path, err := p.Eval(ctx, row)
-		if err != nil {
+		if err != nil || path == nil {
 			return nil, err
 		}
 
@@ -104,13 +105,19 @@ func (j *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			return nil, err
 		}
 
-		results[i], err = searchable.Extract(ctx, path.(string))
+		result, err := searchable.Extract(ctx, path.(string))
 		if err != nil {
 			return nil, err
 		}
+		if result != nil {
+			results = append(results, result)
+		}
 	}
 
-	if len(results) == 1 {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	if len(j.Paths) == 1 {
 		return results[0], nil
 	}
 
@@ -47,14 +47,14 @@ func TestJSONExtract(t *testing.T) {
 	require.NoError(t, err)
 
 	json := map[string]interface{}{
-		"a": []interface{}{float64(1), float64(2), float64(3), float64(4)},
+		"a": []interface{}{int64(1), int64(2), int64(3), int64(4)},
 		"b": map[string]interface{}{
 			"c": "foo",
 			"d": true,
 		},
 		"e": []interface{}{
-			[]interface{}{float64(1), float64(2)},
-			[]interface{}{float64(3), float64(4)},
+			[]interface{}{int64(1), int64(2)},
+			[]interface{}{int64(3), int64(4)},
 		},
 		"f": map[string]interface{}{
 			`key.with.dots`:        0,
@@ -72,21 +72,24 @@ func TestJSONExtract(t *testing.T) {
 		err      error
 	}{
 		//{f2, sql.Row{json, "FOO"}, nil, errors.New("should start with '$'")},
-		{f2, sql.Row{nil, "$.b.c"}, sql.JSONDocument{Val: nil}, nil},
-		{f2, sql.Row{json, "$.foo"}, sql.JSONDocument{Val: nil}, nil},
+		{f2, sql.Row{nil, "$.b.c"}, nil, nil},
+		{f2, sql.Row{json, "$.foo"}, nil, nil},
+		{f2, sql.Row{map[string]interface{}{"foo": nil}, "$.foo"}, sql.JSONDocument{Val: nil}, nil},
+		{f3, sql.Row{json, "$.foo", "$.bar"}, nil, nil},
+		{f3, sql.Row{json, "$.foo", "$.b.c"}, sql.JSONDocument{Val: []interface{}{"foo"}}, nil},
 		{f2, sql.Row{json, "$.b.c"}, sql.JSONDocument{Val: "foo"}, nil},
 		{f3, sql.Row{json, "$.b.c", "$.b.d"}, sql.JSONDocument{Val: []interface{}{"foo", true}}, nil},
 		{f4, sql.Row{json, "$.b.c", "$.b.d", "$.e[0][*]"}, sql.JSONDocument{Val: []interface{}{
 			"foo",
 			true,
-			[]interface{}{1., 2.},
+			[]interface{}{int64(1), int64(2)},
 		}}, nil},
 
-		{f2, sql.Row{json, `$.f."key.with.dots"`}, sql.JSONDocument{Val: float64(0)}, nil},
-		{f2, sql.Row{json, `$.f."key with spaces"`}, sql.JSONDocument{Val: float64(1)}, nil},
-		{f2, sql.Row{json, `$.f.key with spaces`}, sql.JSONDocument{Val: float64(1)}, nil},
-		{f2, sql.Row{json, `$.f.key'with'squotes`}, sql.JSONDocument{Val: float64(3)}, nil},
-		{f2, sql.Row{json, `$.f."key'with'squotes"`}, sql.JSONDocument{Val: float64(3)}, nil},
+		{f2, sql.Row{json, `$.f."key.with.dots"`}, sql.JSONDocument{Val: int64(0)}, nil},
+		{f2, sql.Row{json, `$.f."key with spaces"`}, sql.JSONDocument{Val: int64(1)}, nil},
+		{f2, sql.Row{json, `$.f.key with spaces`}, sql.JSONDocument{Val: int64(1)}, nil},
+		{f2, sql.Row{json, `$.f.key'with'squotes`}, sql.JSONDocument{Val: int64(3)}, nil},
+		{f2, sql.Row{json, `$.f."key'with'squotes"`}, sql.JSONDocument{Val: int64(3)}, nil},
 
 		// TODO: Fix these. They work in mysql
 		//{f2, sql.Row{json, `$.f.key\\"with\\"dquotes`}, sql.JSONDocument{Val: 2}, nil},
@@ -0,0 +1,140 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSON_KEYS(json_doc[, path])
+//
+// JSONKeys Returns the keys from the top-level value of a JSON object as a JSON array, or, if a path argument is given,
+// the top-level keys from the selected path. Returns NULL if any argument is NULL, the json_doc argument is not an
+// object, or path, if given, does not locate an object. An error occurs if the json_doc argument is not a valid JSON
+// document or the path argument is not a valid path expression or contains a * or ** wildcard. The result array is
+// empty if the selected object is empty. If the top-level value has nested subobjects, the return value does not
+// include keys from those subobjects.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-keys
+type JSONKeys struct {
+	JSON sql.Expression
+	Path sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONKeys)(nil)
+
+// NewJSONKeys creates a new JSONKeys function.
+func NewJSONKeys(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &JSONKeys{JSON: args[0]}, nil
+	case 2:
+		return &JSONKeys{JSON: args[0], Path: args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_KEYS", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONKeys) FunctionName() string {
+	return "json_keys"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONKeys) Description() string {
+	return "array of keys from JSON document."
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONKeys) IsUnsupported() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (j *JSONKeys) Resolved() bool {
+	for _, child := range j.Children() {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Expression interface.
+func (j *JSONKeys) String() string {
+	children := j.Children()
+	parts := make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("JSON_KEYS(%s)", strings.Join(parts, ", "))
+}
+
+// Type implements the Expression interface.
+func (j *JSONKeys) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable implements the Expression interface.
+func (j *JSONKeys) IsNullable() bool {
+	// The result is NULL whenever the path doesn't select an object
+	return true
+}
+
+// Eval implements the Expression interface.
+func (j *JSONKeys) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, err := getSearchableJSONVal(ctx, row, j.JSON)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	path := "$"
+	if j.Path != nil {
+		p, err := j.Path.Eval(ctx, row)
+		if err != nil || p == nil {
+			return nil, err
+		}
+		p, err = sql.LongText.Convert(p)
+		if err != nil {
+			return nil, err
+		}
+		path = p.(string)
+	}
+
+	keys, err := doc.Keys(ctx, path)
+	if err != nil || keys == nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Children implements the Expression interface.
+func (j *JSONKeys) Children() []sql.Expression {
+	if j.Path != nil {
+		return []sql.Expression{j.JSON, j.Path}
+	}
+	return []sql.Expression{j.JSON}
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONKeys) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(j.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.Children()))
+	}
+	return NewJSONKeys(children...)
+}
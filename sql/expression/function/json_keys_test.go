@@ -0,0 +1,70 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONKeys(t *testing.T) {
+	_, err := NewJSONKeys()
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f1, err := NewJSONKeys(expression.NewGetField(0, sql.JSON, "doc", true))
+	require.NoError(t, err)
+	f2, err := NewJSONKeys(
+		expression.NewGetField(0, sql.JSON, "doc", true),
+		expression.NewGetField(1, sql.LongText, "path", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{f1, sql.Row{`{"b": 1, "a": {"c": 2}}`}, sql.MustJSON(`["a", "b"]`), nil},
+		{f1, sql.Row{`[1, 2]`}, nil, nil},
+		{f1, sql.Row{nil}, nil, nil},
+		{f1, sql.Row{`{"a": `}, nil, sql.ErrInvalidJSONText},
+		{f2, sql.Row{`{"b": 1, "a": {"c": 2}}`, `$.a`}, sql.MustJSON(`["c"]`), nil},
+		{f2, sql.Row{`{"b": 1, "a": {"c": 2}}`, `$.b`}, nil, nil},
+		{f2, sql.Row{`{"b": 1, "a": {"c": 2}}`, `$.d`}, nil, nil},
+		{f2, sql.Row{`{"b": 1, "a": {"c": 2}}`, nil}, nil, nil},
+		{f2, sql.Row{`{"b": 1, "a": {"c": 2}}`, `$.*`}, nil, sql.ErrInvalidJSONPathWildcard},
+		{f2, sql.Row{`{"b": 1, "a": {"c": 2}}`, `$.`}, nil, sql.ErrInvalidJSONPath},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
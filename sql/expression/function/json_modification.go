@@ -0,0 +1,558 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// jsonModifyFunc applies a single path, or path-value pair, to a JSON document.
+type jsonModifyFunc func(doc sql.JSONDocument, path sql.JSONPath, val interface{}) (sql.JSONDocument, error)
+
+// jsonModification is embedded by the JSON modification functions that take a JSON document followed by either paths
+// or path-value pairs. The pairs are applied left to right, each to the document produced by the previous one.
+type jsonModification struct {
+	name   string
+	args   []sql.Expression
+	values bool
+}
+
+func newJSONModification(name string, values bool, args []sql.Expression) (jsonModification, error) {
+	if values && (len(args) < 3 || len(args)%2 == 0) {
+		return jsonModification{}, sql.ErrInvalidArgumentNumber.New(strings.ToUpper(name), "an odd number of at least 3", len(args))
+	}
+	if !values && len(args) < 2 {
+		return jsonModification{}, sql.ErrInvalidArgumentNumber.New(strings.ToUpper(name), "2 or more", len(args))
+	}
+	return jsonModification{name: name, args: args, values: values}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *jsonModification) FunctionName() string {
+	return j.name
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *jsonModification) IsUnsupported() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (j *jsonModification) Resolved() bool {
+	for _, arg := range j.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Expression interface.
+func (j *jsonModification) String() string {
+	parts := make([]string, len(j.args))
+	for i, arg := range j.args {
+		parts[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(j.name), strings.Join(parts, ", "))
+}
+
+// Type implements the Expression interface.
+func (j *jsonModification) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable implements the Expression interface.
+func (j *jsonModification) IsNullable() bool {
+	for i, arg := range j.args {
+		// A NULL value is stored as JSON null, rather than making the result NULL
+		if j.values && i > 0 && i%2 == 0 {
+			continue
+		}
+		if arg.IsNullable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Children implements the Expression interface.
+func (j *jsonModification) Children() []sql.Expression {
+	return j.args
+}
+
+func (j *jsonModification) eval(ctx *sql.Context, row sql.Row, modify jsonModifyFunc) (interface{}, error) {
+	doc, err := evalJSONDocument(ctx, row, j.args[0])
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	result := sql.JSONDocument{Val: sql.DeepCopyJson(doc.Val)}
+
+	step := 1
+	if j.values {
+		step = 2
+	}
+	for i := 1; i < len(j.args); i += step {
+		path, err := evalJSONPath(ctx, row, j.args[i])
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		var val interface{}
+		if j.values {
+			val, err = j.args[i+1].Eval(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			val, err = sql.JSONDocumentValue(ctx, val)
+			if err != nil {
+				return nil, err
+			}
+			val = sql.DeepCopyJson(val)
+		}
+
+		result, err = modify(result, *path, val)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// evalJSONDocument evaluates the expression given as a JSON document. It returns nil if the expression is NULL.
+func evalJSONDocument(ctx *sql.Context, row sql.Row, e sql.Expression) (*sql.JSONDocument, error) {
+	val, err := getSearchableJSONVal(ctx, row, e)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	doc, err := val.Unmarshall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// evalJSONPath evaluates the expression given as a JSON path. It returns nil if the expression is NULL.
+func evalJSONPath(ctx *sql.Context, row sql.Row, e sql.Expression) (*sql.JSONPath, error) {
+	val, err := e.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	val, err = sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	path, err := sql.ParseJSONPath(val.(string))
+	if err != nil {
+		return nil, err
+	}
+	return &path, nil
+}
+
+// JSON_SET(json_doc, path, val[, path, val] ...)
+//
+// JSONSet Inserts or updates data in a JSON document and returns the result. Returns NULL if any argument is NULL or
+// path, if given, does not locate an object. An error occurs if the json_doc argument is not a valid JSON document or
+// any path argument is not a valid path expression or contains a * or ** wildcard. The path-value pairs are evaluated
+// left to right. The document produced by evaluating one pair becomes the new value against which the next pair is
+// evaluated. A path-value pair for an existing path in the document overwrites the existing document value with the
+// new value. A path-value pair for a non-existing path in the document adds the value to the document if the path
+// identifies one of these types of values:
+//   - A member not present in an existing object. The member is added to the object and associated with the new value.
+//   - A position past the end of an existing array. The array is extended with the new value. If the existing value is
+//     not an array, it is auto-wrapped as an array, then extended with the new value.
+//
+// Otherwise, a path-value pair for a non-existing path in the document is ignored and has no effect.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-set
+type JSONSet struct {
+	jsonModification
+}
+
+var _ sql.FunctionExpression = (*JSONSet)(nil)
+
+// NewJSONSet creates a new JSONSet function.
+func NewJSONSet(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModification("json_set", true, args)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSet{m}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONSet) Description() string {
+	return "inserts data into JSON document."
+}
+
+// Eval implements the Expression interface.
+func (j *JSONSet) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return j.eval(ctx, row, sql.JSONDocument.Set)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(j.args) != len(children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.args))
+	}
+	return NewJSONSet(children...)
+}
+
+// JSON_INSERT(json_doc, path, val[, path, val] ...)
+//
+// JSONInsert Inserts data into a JSON document and returns the result. Returns NULL if any argument is NULL. An error
+// occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path expression or
+// contains a * or ** wildcard. The path-value pairs are evaluated left to right. The document produced by evaluating
+// one pair becomes the new value against which the next pair is evaluated. A path-value pair for an existing path in
+// the document is ignored and does not overwrite the existing document value. A path-value pair for a nonexisting path
+// in the document adds the value to the document if the path identifies one of these types of values:
+//   - A member not present in an existing object. The member is added to the object and associated with the new value.
+//   - A position past the end of an existing array. The array is extended with the new value. If the existing value is
+//     not an array, it is autowrapped as an array, then extended with the new value.
+//
+// Otherwise, a path-value pair for a nonexisting path in the document is ignored and has no effect.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-insert
+type JSONInsert struct {
+	jsonModification
+}
+
+var _ sql.FunctionExpression = (*JSONInsert)(nil)
+
+// NewJSONInsert creates a new JSONInsert function.
+func NewJSONInsert(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModification("json_insert", true, args)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONInsert{m}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONInsert) Description() string {
+	return "inserts data into JSON document"
+}
+
+// Eval implements the Expression interface.
+func (j *JSONInsert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return j.eval(ctx, row, sql.JSONDocument.Insert)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONInsert) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(j.args) != len(children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.args))
+	}
+	return NewJSONInsert(children...)
+}
+
+// JSON_REPLACE(json_doc, path, val[, path, val] ...)
+//
+// JSONReplace Replaces existing values in a JSON document and returns the result. Returns NULL if any argument is NULL.
+// An error occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path
+// expression or contains a * or ** wildcard. The path-value pairs are evaluated left to right. The document produced by
+// evaluating one pair becomes the new value against which the next pair is evaluated. A path-value pair for an existing
+// path in the document overwrites the existing document value with the new value. A path-value pair for a non-existing
+// path in the document is ignored and has no effect.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-replace
+type JSONReplace struct {
+	jsonModification
+}
+
+var _ sql.FunctionExpression = (*JSONReplace)(nil)
+
+// NewJSONReplace creates a new JSONReplace function.
+func NewJSONReplace(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModification("json_replace", true, args)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONReplace{m}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONReplace) Description() string {
+	return "replaces values in JSON document."
+}
+
+// Eval implements the Expression interface.
+func (j *JSONReplace) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return j.eval(ctx, row, sql.JSONDocument.Replace)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONReplace) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(j.args) != len(children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.args))
+	}
+	return NewJSONReplace(children...)
+}
+
+// JSON_REMOVE(json_doc, path[, path] ...)
+//
+// JSONRemove Removes data from a JSON document and returns the result. Returns NULL if any argument is NULL. An error
+// occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path expression or
+// is $ or contains a * or ** wildcard. The path arguments are evaluated left to right. The document produced by
+// evaluating one path becomes the new value against which the next path is evaluated. It is not an error if the element
+// to be removed does not exist in the document; in that case, the path does not affect the document.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-remove
+type JSONRemove struct {
+	jsonModification
+}
+
+var _ sql.FunctionExpression = (*JSONRemove)(nil)
+
+// NewJSONRemove creates a new JSONRemove function.
+func NewJSONRemove(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModification("json_remove", false, args)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONRemove{m}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONRemove) Description() string {
+	return "removes data from JSON document."
+}
+
+// Eval implements the Expression interface.
+func (j *JSONRemove) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return j.eval(ctx, row, func(doc sql.JSONDocument, path sql.JSONPath, _ interface{}) (sql.JSONDocument, error) {
+		return doc.Remove(path)
+	})
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONRemove) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(j.args) != len(children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.args))
+	}
+	return NewJSONRemove(children...)
+}
+
+// JSON_ARRAY_APPEND(json_doc, path, val[, path, val] ...)
+//
+// JSONArrayAppend Appends values to the end of the indicated arrays within a JSON document and returns the result.
+// Returns NULL if any argument is NULL. An error occurs if the json_doc argument is not a valid JSON document or any
+// path argument is not a valid path expression or contains a * or ** wildcard. The path-value pairs are evaluated left
+// to right. The document produced by evaluating one pair becomes the new value against which the next pair is
+// evaluated. If a path selects a scalar or object value, that value is autowrapped within an array and the new value is
+// added to that array. Pairs for which the path does not identify any value in the JSON document are ignored.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-array-append
+type JSONArrayAppend struct {
+	jsonModification
+}
+
+var _ sql.FunctionExpression = (*JSONArrayAppend)(nil)
+
+// NewJSONArrayAppend creates a new JSONArrayAppend function.
+func NewJSONArrayAppend(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModification("json_array_append", true, args)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONArrayAppend{m}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONArrayAppend) Description() string {
+	return "appends data to JSON document."
+}
+
+// Eval implements the Expression interface.
+func (j *JSONArrayAppend) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return j.eval(ctx, row, sql.JSONDocument.ArrayAppend)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONArrayAppend) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(j.args) != len(children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.args))
+	}
+	return NewJSONArrayAppend(children...)
+}
+
+// JSON_ARRAY_INSERT(json_doc, path, val[, path, val] ...)
+//
+// JSONArrayInsert Updates a JSON document, inserting into an array within the document and returning the modified
+// document. Returns NULL if any argument is NULL. An error occurs if the json_doc argument is not a valid JSON document
+// or any path argument is not a valid path expression or contains a * or ** wildcard or does not end with an array
+// element identifier. The path-value pairs are evaluated left to right. The document produced by evaluating one pair
+// becomes the new value against which the next pair is evaluated. Pairs for which the path does not identify any array
+// in the JSON document are ignored. If a path identifies an array element, the corresponding value is inserted at that
+// element position, shifting any following values to the right. If a path identifies an array position past the end of
+// an array, the value is inserted at the end of the array.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-array-insert
+type JSONArrayInsert struct {
+	jsonModification
+}
+
+var _ sql.FunctionExpression = (*JSONArrayInsert)(nil)
+
+// NewJSONArrayInsert creates a new JSONArrayInsert function.
+func NewJSONArrayInsert(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModification("json_array_insert", true, args)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONArrayInsert{m}, nil
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONArrayInsert) Description() string {
+	return "inserts into JSON array."
+}
+
+// Eval implements the Expression interface.
+func (j *JSONArrayInsert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return j.eval(ctx, row, sql.JSONDocument.ArrayInsert)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONArrayInsert) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(j.args) != len(children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.args))
+	}
+	return NewJSONArrayInsert(children...)
+}
+
+// JSON_MERGE_PATCH(json_doc, json_doc[, json_doc] ...)
+//
+// JSONMergePatch Performs an RFC 7396 compliant merge of two or more JSON documents and returns the merged result,
+// without preserving members having duplicate keys. Raises an error if at least one of the documents passed as arguments
+// to this function is not valid. JSONMergePatch performs a merge as follows:
+//   - If the first argument is not an object, the result of the merge is the same as if an empty object had been merged
+//     with the second argument.
+//   - If the second argument is not an object, the result of the merge is the second argument.
+//   - If both arguments are objects, the result of the merge is an object with the following members:
+//   - All members of the first object which do not have a corresponding member with the same key in the second
+//     object.
+//   - All members of the second object which do not have a corresponding key in the first object, and whose value is
+//     not the JSON null literal.
+//   - All members with a key that exists in both the first and the second object, and whose value in the second
+//     object is not the JSON null literal. The values of these members are the results of recursively merging the
+//     value in the first object with the value in the second object.
+//
+// The behavior of JSONMergePatch is the same as that of JSONMergePreserve, with the following two exceptions:
+//   - JSONMergePatch removes any member in the first object with a matching key in the second object, provided that
+//     the value associated with the key in the second object is not JSON null.
+//   - If the second object has a member with a key matching a member in the first object, JSONMergePatch replaces
+//     the value in the first object with the value in the second object, whereas JSONMergePreserve appends the
+//     second value to the first value.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-merge-patch
+type JSONMergePatch struct {
+	JSONDocs []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONMergePatch)(nil)
+
+// NewJSONMergePatch creates a new JSONMergePatch function.
+func NewJSONMergePatch(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_MERGE_PATCH", "2 or more", len(args))
+	}
+
+	return &JSONMergePatch{JSONDocs: args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONMergePatch) FunctionName() string {
+	return "json_merge_patch"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONMergePatch) Description() string {
+	return "merges JSON documents, replacing values of duplicate keys"
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONMergePatch) IsUnsupported() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (j *JSONMergePatch) Resolved() bool {
+	for _, d := range j.JSONDocs {
+		if !d.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Expression interface.
+func (j *JSONMergePatch) String() string {
+	parts := make([]string, len(j.JSONDocs))
+	for i, d := range j.JSONDocs {
+		parts[i] = d.String()
+	}
+	return fmt.Sprintf("JSON_MERGE_PATCH(%s)", strings.Join(parts, ", "))
+}
+
+// Type implements the Expression interface.
+func (j *JSONMergePatch) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable implements the Expression interface.
+func (j *JSONMergePatch) IsNullable() bool {
+	for _, d := range j.JSONDocs {
+		if d.IsNullable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Eval implements the Expression interface.
+func (j *JSONMergePatch) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	var result sql.JSONDocument
+	for i, d := range j.JSONDocs {
+		doc, err := evalJSONDocument(ctx, row, d)
+		if err != nil || doc == nil {
+			return nil, err
+		}
+		// Every document is copied, since values of each patch end up in the result and may be merged into later
+		val := sql.DeepCopyJson(doc.Val)
+		if i == 0 {
+			result = sql.JSONDocument{Val: val}
+		} else {
+			result = result.MergePatch(sql.JSONDocument{Val: val})
+		}
+	}
+	return result, nil
+}
+
+// Children implements the Expression interface.
+func (j *JSONMergePatch) Children() []sql.Expression {
+	return j.JSONDocs
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONMergePatch) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(j.JSONDocs) != len(children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.JSONDocs))
+	}
+	return NewJSONMergePatch(children...)
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONModification(t *testing.T) {
+	doc := expression.NewGetField(0, sql.JSON, "doc", true)
+	path := func(i int) sql.Expression {
+		return expression.NewGetField(i, sql.LongText, "path", true)
+	}
+	val := func(i int) sql.Expression {
+		return expression.NewGetField(i, sql.LongText, "val", true)
+	}
+	mustNew := func(fn sql.CreateFuncNArgs, args ...sql.Expression) sql.Expression {
+		e, err := fn(args...)
+		require.NoError(t, err)
+		return e
+	}
+
+	set := mustNew(NewJSONSet, doc, path(1), val(2), path(3), val(4))
+	insert := mustNew(NewJSONInsert, doc, path(1), val(2), path(3), val(4))
+	replace := mustNew(NewJSONReplace, doc, path(1), val(2), path(3), val(4))
+	arrayAppend := mustNew(NewJSONArrayAppend, doc, path(1), val(2))
+	arrayInsert := mustNew(NewJSONArrayInsert, doc, path(1), val(2))
+	remove := mustNew(NewJSONRemove, doc, path(1), path(2))
+	mergePatch := mustNew(NewJSONMergePatch, doc, val(1), val(2))
+
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{set, sql.Row{`{"a": 1}`, `$.a`, "x", `$.b`, "y"}, sql.MustJSON(`{"a": "x", "b": "y"}`), nil},
+		{set, sql.Row{`{"a": 1}`, `$.a`, sql.MustJSON(`{}`), `$.a.b`, "y"}, sql.MustJSON(`{"a": {"b": "y"}}`), nil},
+		{set, sql.Row{`{"a": 1}`, `$.a`, nil, `$.b`, "y"}, sql.MustJSON(`{"a": null, "b": "y"}`), nil},
+		{set, sql.Row{nil, `$.a`, "x", `$.b`, "y"}, nil, nil},
+		{set, sql.Row{`{"a": 1}`, `$.a`, "x", nil, "y"}, nil, nil},
+		{set, sql.Row{`{"a": 1}`, `$.a`, "x", `$.*`, "y"}, nil, sql.ErrInvalidJSONPathWildcard},
+		{set, sql.Row{`{"a": 1}`, `$.a`, "x", `$.`, "y"}, nil, sql.ErrInvalidJSONPath},
+		{set, sql.Row{`{"a": `, `$.a`, "x", `$.b`, "y"}, nil, sql.ErrInvalidJSONText},
+		{insert, sql.Row{`{"a": 1}`, `$.a`, "x", `$.b`, "y"}, sql.MustJSON(`{"a": 1, "b": "y"}`), nil},
+		{replace, sql.Row{`{"a": 1}`, `$.a`, "x", `$.b`, "y"}, sql.MustJSON(`{"a": "x"}`), nil},
+		{arrayAppend, sql.Row{`{"a": [1]}`, `$.a`, "x"}, sql.MustJSON(`{"a": [1, "x"]}`), nil},
+		{arrayInsert, sql.Row{`{"a": [1]}`, `$.a[0]`, "x"}, sql.MustJSON(`{"a": ["x", 1]}`), nil},
+		{arrayInsert, sql.Row{`{"a": [1]}`, `$.a`, "x"}, nil, sql.ErrInvalidJSONPathArrayCell},
+		{remove, sql.Row{`{"a": [1, 2], "b": 3}`, `$.a[0]`, `$.b`}, sql.MustJSON(`{"a": [2]}`), nil},
+		{remove, sql.Row{`{"a": [1, 2], "b": 3}`, `$.a[0]`, `$`}, nil, sql.ErrJSONVacuousPath},
+		{mergePatch, sql.Row{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": 5, "d": 6, "b": null}`}, sql.MustJSON(`{"a": 5, "c": 4, "d": 6}`), nil},
+		{mergePatch, sql.Row{`{"a": 1}`, nil, `{"a": 5}`}, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
+
+func TestJSONModificationDoesNotModifyArguments(t *testing.T) {
+	require := require.New(t)
+	doc := sql.MustJSON(`{"a": [1, {"b": 2}]}`)
+	val := sql.MustJSON(`{"c": 3}`)
+
+	f, err := NewJSONSet(
+		expression.NewLiteral(doc, sql.JSON),
+		expression.NewLiteral("$.a[1].b", sql.LongText),
+		expression.NewLiteral(val, sql.JSON),
+		expression.NewLiteral("$.a[1].b.d", sql.LongText),
+		expression.NewLiteral(4, sql.Int64),
+	)
+	require.NoError(err)
+
+	result, err := f.Eval(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+	require.Equal(sql.JSONDocument{Val: map[string]interface{}{
+		"a": []interface{}{int64(1), map[string]interface{}{"b": map[string]interface{}{"c": int64(3), "d": int64(4)}}},
+	}}, result)
+	require.Equal(sql.MustJSON(`{"a": [1, {"b": 2}]}`), doc)
+	require.Equal(sql.MustJSON(`{"c": 3}`), val)
+}
+
+func TestJSONModificationArgumentCount(t *testing.T) {
+	arg := expression.NewLiteral("{}", sql.LongText)
+	for _, fn := range []sql.CreateFuncNArgs{NewJSONSet, NewJSONInsert, NewJSONReplace, NewJSONArrayAppend, NewJSONArrayInsert} {
+		_, err := fn(arg, arg)
+		require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+		_, err = fn(arg, arg, arg, arg)
+		require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+	}
+	_, err := NewJSONRemove(arg)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+	_, err = NewJSONMergePatch(arg)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
@@ -102,12 +102,9 @@ func (j JSONObject) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			}
 			key = val.(string)
 		} else {
-			if json, ok := val.(sql.JSONValue); ok {
-				doc, err := json.Unmarshall(ctx)
-				if err != nil {
-					return nil, err
-				}
-				val = doc.Val
+			val, err = sql.JSONDocumentValue(ctx, val)
+			if err != nil {
+				return nil, err
 			}
 			obj[key] = val
 		}
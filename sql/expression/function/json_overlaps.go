@@ -0,0 +1,98 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSON_OVERLAPS(json_doc1, json_doc2)
+//
+// JSONOverlaps Compares two JSON documents. Returns true (1) if the two document have any key-value pairs or array
+// elements in common. If both arguments are scalars, the function performs a simple equality test.
+//
+// This function serves as counterpart to JSON_CONTAINS(), which requires all elements of the array searched for to be
+// present in the array searched in. Thus, JSON_CONTAINS() performs an AND operation on search keys, while
+// JSON_OVERLAPS() performs an OR operation.
+//
+// Queries on JSON columns of InnoDB tables using JSON_OVERLAPS() in the WHERE clause can be optimized using
+// multi-valued indexes. Multi-Valued Indexes, provides detailed information and examples.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-overlaps
+type JSONOverlaps struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONOverlaps)(nil)
+
+// NewJSONOverlaps creates a new JSONOverlaps function.
+func NewJSONOverlaps(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_OVERLAPS", 2, len(args))
+	}
+
+	return &JSONOverlaps{expression.BinaryExpression{Left: args[0], Right: args[1]}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONOverlaps) FunctionName() string {
+	return "json_overlaps"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONOverlaps) Description() string {
+	return "compares two JSON documents, returns TRUE (1) if these have any key-value pairs or array elements in common, otherwise FALSE (0)."
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONOverlaps) IsUnsupported() bool {
+	return false
+}
+
+// String implements the Expression interface.
+func (j *JSONOverlaps) String() string {
+	return fmt.Sprintf("JSON_OVERLAPS(%s, %s)", j.Left, j.Right)
+}
+
+// Type implements the Expression interface.
+func (j *JSONOverlaps) Type() sql.Type {
+	return sql.Boolean
+}
+
+// Eval implements the Expression interface.
+func (j *JSONOverlaps) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, err := getSearchableJSONVal(ctx, row, j.Left)
+	if err != nil || left == nil {
+		return nil, err
+	}
+
+	right, err := getSearchableJSONVal(ctx, row, j.Right)
+	if err != nil || right == nil {
+		return nil, err
+	}
+
+	return left.Overlaps(ctx, right)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONOverlaps) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewJSONOverlaps(children...)
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONOverlaps(t *testing.T) {
+	f, err := NewJSONOverlaps(
+		expression.NewGetField(0, sql.JSON, "a", true),
+		expression.NewGetField(1, sql.JSON, "b", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{f, sql.Row{`[1, 3, 5, 7]`, `[2, 5, 7]`}, true, nil},
+		{f, sql.Row{`[1, 3, 5, 7]`, `[2, 6, 8]`}, false, nil},
+		{f, sql.Row{`{"a": 1, "b": 10}`, `{"c": 1, "b": 10}`}, true, nil},
+		{f, sql.Row{`{"a": 1}`, `{"a": 2}`}, false, nil},
+		{f, sql.Row{`5`, `[1, 5]`}, true, nil},
+		{f, sql.Row{`[[1, 2]]`, `[1, 2]`}, false, nil},
+		{f, sql.Row{nil, `[1]`}, nil, nil},
+		{f, sql.Row{`[1]`, nil}, nil, nil},
+		{f, sql.Row{`[1`, `[1]`}, nil, sql.ErrInvalidJSONText},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
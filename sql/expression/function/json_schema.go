@@ -0,0 +1,214 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// jsonSchemaValidator validates JSON documents against a JSON schema. The parsed schema is kept when it doesn't depend
+// on the row, as is usual in CHECK constraints.
+type jsonSchemaValidator struct {
+	expression.BinaryExpression
+	name string
+
+	once      sync.Once
+	cached    bool
+	schema    *sql.JSONSchema
+	schemaErr error
+}
+
+// validate validates the document against the schema. It returns nil if either is NULL.
+func (v *jsonSchemaValidator) validate(ctx *sql.Context, row sql.Row) (*sql.JSONSchemaValidation, error) {
+	var schema *sql.JSONSchema
+	var err error
+	v.once.Do(func() {
+		if v.cached = canBeCached(v.Left); v.cached {
+			v.schema, v.schemaErr = v.parseSchema(ctx, row)
+		}
+	})
+	if v.cached {
+		schema, err = v.schema, v.schemaErr
+	} else {
+		schema, err = v.parseSchema(ctx, row)
+	}
+	if err != nil || schema == nil {
+		return nil, err
+	}
+
+	doc, err := evalJSONDocument(ctx, row, v.Right)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	validation := schema.Validate(*doc)
+	return &validation, nil
+}
+
+func (v *jsonSchemaValidator) parseSchema(ctx *sql.Context, row sql.Row) (*sql.JSONSchema, error) {
+	doc, err := evalJSONDocument(ctx, row, v.Left)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	if _, ok := doc.Val.(map[string]interface{}); !ok {
+		return nil, sql.ErrInvalidJSONType.New(1, v.name, "object")
+	}
+	return sql.ParseJSONSchema(*doc)
+}
+
+// JSON_SCHEMA_VALID(schema,document)
+//
+// JSONSchemaValid Validates a JSON document against a JSON schema. Both schema and document are required. The schema
+// must be a valid JSON object; the document must be a valid JSON document. Provided that these conditions are met: If
+// the document validates against the schema, the function returns true (1); otherwise, it returns false (0).
+// https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-valid
+type JSONSchemaValid struct {
+	jsonSchemaValidator
+}
+
+var _ sql.FunctionExpression = (*JSONSchemaValid)(nil)
+
+// NewJSONSchemaValid creates a new JSONSchemaValid function.
+func NewJSONSchemaValid(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_SCHEMA_VALID", 2, len(args))
+	}
+	return &JSONSchemaValid{jsonSchemaValidator{
+		BinaryExpression: expression.BinaryExpression{Left: args[0], Right: args[1]},
+		name:             "json_schema_valid",
+	}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONSchemaValid) FunctionName() string {
+	return "json_schema_valid"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONSchemaValid) Description() string {
+	return "validates JSON document against JSON schema; returns TRUE/1 if document validates against schema, or FALSE/0 if it does not."
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONSchemaValid) IsUnsupported() bool {
+	return false
+}
+
+// String implements the Expression interface.
+func (j *JSONSchemaValid) String() string {
+	return fmt.Sprintf("JSON_SCHEMA_VALID(%s, %s)", j.Left, j.Right)
+}
+
+// Type implements the Expression interface.
+func (j *JSONSchemaValid) Type() sql.Type {
+	return sql.Boolean
+}
+
+// Eval implements the Expression interface.
+func (j *JSONSchemaValid) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	validation, err := j.validate(ctx, row)
+	if err != nil || validation == nil {
+		return nil, err
+	}
+	return validation.Valid, nil
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONSchemaValid) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewJSONSchemaValid(children...)
+}
+
+// JSON_SCHEMA_VALIDATION_REPORT(schema,document)
+//
+// JSONSchemaValidationReport Validates a JSON document against a JSON schema. Both schema and document are required.
+// As with JSONSchemaValid, the schema must be a valid JSON object, and the document must be a valid JSON document.
+// Provided that these conditions are met, the function returns a report, as a JSON document, on the outcome of the
+// validation. If the JSON document is considered valid according to the JSON Schema, the function returns a JSON object
+// with one property valid having the value "true". If the JSON document fails validation, the function returns a JSON
+// object which includes the properties listed here:
+//   - valid: Always "false" for a failed schema validation
+//   - reason: A human-readable string containing the reason for the failure
+//   - schema-location: A JSON pointer URI fragment identifier indicating where in the JSON schema the validation failed
+//     (see Note following this list)
+//   - document-location: A JSON pointer URI fragment identifier indicating where in the JSON document the validation
+//     failed (see Note following this list)
+//   - schema-failed-keyword: A string containing the name of the keyword or property in the JSON schema that was
+//     violated
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-validation-report
+type JSONSchemaValidationReport struct {
+	jsonSchemaValidator
+}
+
+var _ sql.FunctionExpression = (*JSONSchemaValidationReport)(nil)
+
+// NewJSONSchemaValidationReport creates a new JSONSchemaValidationReport function.
+func NewJSONSchemaValidationReport(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_SCHEMA_VALIDATION_REPORT", 2, len(args))
+	}
+	return &JSONSchemaValidationReport{jsonSchemaValidator{
+		BinaryExpression: expression.BinaryExpression{Left: args[0], Right: args[1]},
+		name:             "json_schema_validation_report",
+	}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONSchemaValidationReport) FunctionName() string {
+	return "json_schema_validation_report"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONSchemaValidationReport) Description() string {
+	return "validates JSON document against JSON schema; returns report in JSON format on outcome on validation including success or failure and reasons for failure."
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONSchemaValidationReport) IsUnsupported() bool {
+	return false
+}
+
+// String implements the Expression interface.
+func (j *JSONSchemaValidationReport) String() string {
+	return fmt.Sprintf("JSON_SCHEMA_VALIDATION_REPORT(%s, %s)", j.Left, j.Right)
+}
+
+// Type implements the Expression interface.
+func (j *JSONSchemaValidationReport) Type() sql.Type {
+	return sql.JSON
+}
+
+// Eval implements the Expression interface.
+func (j *JSONSchemaValidationReport) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	validation, err := j.validate(ctx, row)
+	if err != nil || validation == nil {
+		return nil, err
+	}
+	return validation.Report(), nil
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONSchemaValidationReport) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewJSONSchemaValidationReport(children...)
+}
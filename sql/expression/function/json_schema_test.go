@@ -0,0 +1,99 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONSchemaValidation(t *testing.T) {
+	_, err := NewJSONSchemaValid(expression.NewGetField(0, sql.JSON, "schema", true))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+	_, err = NewJSONSchemaValidationReport(expression.NewGetField(0, sql.JSON, "schema", true))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	schema := expression.NewGetField(0, sql.JSON, "schema", true)
+	doc := expression.NewGetField(1, sql.JSON, "doc", true)
+	valid, err := NewJSONSchemaValid(schema, doc)
+	require.NoError(t, err)
+	report, err := NewJSONSchemaValidationReport(schema, doc)
+	require.NoError(t, err)
+
+	latitude := `{"type": "object", "properties": {"latitude": {"type": "number", "minimum": -90, "maximum": 90}}, "required": ["latitude"]}`
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{valid, sql.Row{latitude, `{"latitude": 63.4}`}, true, nil},
+		{valid, sql.Row{latitude, `{"latitude": 91}`}, false, nil},
+		{valid, sql.Row{latitude, `{"longitude": 1}`}, false, nil},
+		{valid, sql.Row{nil, `{}`}, nil, nil},
+		{valid, sql.Row{latitude, nil}, nil, nil},
+		{valid, sql.Row{`[]`, `{}`}, nil, sql.ErrInvalidJSONType},
+		{valid, sql.Row{`{"type": "text"}`, `{}`}, nil, sql.ErrInvalidJSONSchema},
+		{valid, sql.Row{`{"type": `, `{}`}, nil, sql.ErrInvalidJSONText},
+		{valid, sql.Row{latitude, `{"latitude": `}, nil, sql.ErrInvalidJSONText},
+		{report, sql.Row{latitude, `{"latitude": 63.4}`}, sql.MustJSON(`{"valid": true}`), nil},
+		{report, sql.Row{latitude, `{"latitude": 91}`}, sql.MustJSON(`{
+			"valid": false,
+			"reason": "The JSON document location '#/latitude' failed requirement 'maximum' at JSON Schema location '#/properties/latitude'",
+			"schema-location": "#/properties/latitude",
+			"document-location": "#/latitude",
+			"schema-failed-keyword": "maximum"
+		}`), nil},
+		{report, sql.Row{nil, `{}`}, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
+
+func TestJSONSchemaValidCachesSchema(t *testing.T) {
+	require := require.New(t)
+	f, err := NewJSONSchemaValid(
+		expression.NewLiteral(`{"maximum": 1}`, sql.LongText),
+		expression.NewGetField(0, sql.JSON, "doc", true),
+	)
+	require.NoError(err)
+
+	for _, tt := range []struct {
+		doc      string
+		expected bool
+	}{{`1`, true}, {`2`, false}, {`0`, true}} {
+		result, err := f.Eval(sql.NewEmptyContext(), sql.Row{tt.doc})
+		require.NoError(err)
+		require.Equal(tt.expected, result)
+	}
+	require.NotNil(f.(*JSONSchemaValid).schema)
+}
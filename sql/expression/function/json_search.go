@@ -0,0 +1,212 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSON_SEARCH(json_doc, one_or_all, search_str[, escape_char[, path] ...])
+//
+// JSONSearch Returns the path to the given string within a JSON document. Returns NULL if any of the json_doc,
+// search_str, or path arguments are NULL; no path exists within the document; or search_str is not found. An error
+// occurs if the json_doc argument is not a valid JSON document, any path argument is not a valid path expression,
+// one_or_all is not 'one' or 'all', or escape_char is not a constant expression.
+// The one_or_all argument affects the search as follows:
+//   - 'one': The search terminates after the first match and returns one path string. It is undefined which match is
+//     considered first.
+//   - 'all': The search returns all matching path strings such that no duplicate paths are included. If there are
+//     multiple strings, they are autowrapped as an array. The order of the array elements is undefined.
+//
+// Within the search_str search string argument, the % and _ characters work as for the LIKE operator: % matches any
+// number of characters (including zero characters), and _ matches exactly one character.
+//
+// To specify a literal % or _ character in the search string, precede it by the escape character. The default is \ if
+// the escape_char argument is missing or NULL. Otherwise, escape_char must be a constant that is empty or one character.
+// For more information about matching and escape character behavior, see the description of LIKE in Section 12.8.1,
+// “String Comparison Functions and Operators”: https://dev.mysql.com/doc/refman/8.0/en/string-comparison-functions.html
+// For escape character handling, a difference from the LIKE behavior is that the escape character for JSON_SEARCH()
+// must evaluate to a constant at compile time, not just at execution time. For example, if JSON_SEARCH() is used in a
+// prepared statement and the escape_char argument is supplied using a ? parameter, the parameter value might be
+// constant at execution time, but is not at compile time.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-search
+type JSONSearch struct {
+	JSON     sql.Expression
+	OneOrAll sql.Expression
+	Search   sql.Expression
+	Escape   sql.Expression
+	Paths    []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONSearch)(nil)
+
+// NewJSONSearch creates a new NewJSONSearch function.
+func NewJSONSearch(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_SEARCH", "3 or more", len(args))
+	}
+
+	j := &JSONSearch{JSON: args[0], OneOrAll: args[1], Search: args[2]}
+	if len(args) > 3 {
+		j.Escape = args[3]
+		j.Paths = args[4:]
+	}
+	return j, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONSearch) FunctionName() string {
+	return "json_search"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONSearch) Description() string {
+	return "path to value within JSON document."
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONSearch) IsUnsupported() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (j *JSONSearch) Resolved() bool {
+	for _, child := range j.Children() {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Expression interface.
+func (j *JSONSearch) String() string {
+	children := j.Children()
+	parts := make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("JSON_SEARCH(%s)", strings.Join(parts, ", "))
+}
+
+// Type implements the Expression interface.
+func (j *JSONSearch) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable implements the Expression interface.
+func (j *JSONSearch) IsNullable() bool {
+	// The result is NULL whenever the search string isn't found
+	return true
+}
+
+// Eval implements the Expression interface.
+func (j *JSONSearch) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, err := getSearchableJSONVal(ctx, row, j.JSON)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	all, err := evalJSONOneOrAll(ctx, row, j.OneOrAll, j.FunctionName())
+	if err != nil || all == nil {
+		return nil, err
+	}
+
+	search, err := j.Search.Eval(ctx, row)
+	if err != nil || search == nil {
+		return nil, err
+	}
+	search, err = sql.LongText.Convert(search)
+	if err != nil {
+		return nil, err
+	}
+
+	escape, err := j.evalEscape(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]sql.JSONPath, len(j.Paths))
+	for i, p := range j.Paths {
+		path, err := evalJSONPath(ctx, row, p)
+		if err != nil || path == nil {
+			return nil, err
+		}
+		paths[i] = *path
+	}
+
+	// JSON strings are compared using their binary collation
+	matcher, err := expression.ConstructLikeMatcher(sql.Collation_utf8mb4_bin, search.(string), escape)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := doc.Search(ctx, matcher.Match, *all, paths...)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// evalEscape returns the escape character of the search string, which is a backslash unless another is given.
+func (j *JSONSearch) evalEscape(ctx *sql.Context, row sql.Row) (rune, error) {
+	if j.Escape == nil {
+		return '\\', nil
+	}
+	escape, err := j.Escape.Eval(ctx, row)
+	if err != nil {
+		return 0, err
+	}
+	if escape == nil {
+		return '\\', nil
+	}
+	escape, err = sql.LongText.Convert(escape)
+	if err != nil {
+		return 0, err
+	}
+
+	switch utf8.RuneCountInString(escape.(string)) {
+	case 0:
+		return '\\', nil
+	case 1:
+		r, _ := utf8.DecodeRuneInString(escape.(string))
+		return r, nil
+	default:
+		return 0, sql.ErrInvalidArgument.New("ESCAPE")
+	}
+}
+
+// Children implements the Expression interface.
+func (j *JSONSearch) Children() []sql.Expression {
+	children := []sql.Expression{j.JSON, j.OneOrAll, j.Search}
+	if j.Escape != nil {
+		children = append(children, j.Escape)
+	}
+	return append(children, j.Paths...)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONSearch) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(j.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), len(j.Children()))
+	}
+	return NewJSONSearch(children...)
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONSearch(t *testing.T) {
+	_, err := NewJSONSearch(
+		expression.NewGetField(0, sql.JSON, "doc", true),
+		expression.NewGetField(1, sql.LongText, "oneOrAll", true),
+	)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	doc := expression.NewGetField(0, sql.JSON, "doc", true)
+	oneOrAll := expression.NewGetField(1, sql.LongText, "oneOrAll", true)
+	search := expression.NewGetField(2, sql.LongText, "search", true)
+	f1, err := NewJSONSearch(doc, oneOrAll, search)
+	require.NoError(t, err)
+	f2, err := NewJSONSearch(doc, oneOrAll, search,
+		expression.NewGetField(3, sql.LongText, "escape", true),
+		expression.NewGetField(4, sql.LongText, "path", true),
+	)
+	require.NoError(t, err)
+
+	json := `["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]`
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{f1, sql.Row{json, "one", "abc"}, sql.JSONDocument{Val: "$[0]"}, nil},
+		{f1, sql.Row{json, "all", "abc"}, sql.MustJSON(`["$[0]", "$[2].x"]`), nil},
+		{f1, sql.Row{json, "all", "ghi"}, nil, nil},
+		{f1, sql.Row{json, "all", "10"}, sql.JSONDocument{Val: "$[1][0].k"}, nil},
+		{f1, sql.Row{json, "all", "%b%"}, sql.MustJSON(`["$[0]", "$[2].x", "$[3].y"]`), nil},
+		{f1, sql.Row{json, "one", "%b%"}, sql.JSONDocument{Val: "$[0]"}, nil},
+		{f1, sql.Row{json, "all", "_bc"}, sql.MustJSON(`["$[0]", "$[2].x"]`), nil},
+		{f1, sql.Row{json, "all", "ABC"}, nil, nil},
+		{f1, sql.Row{nil, "all", "abc"}, nil, nil},
+		{f1, sql.Row{json, nil, "abc"}, nil, nil},
+		{f1, sql.Row{json, "all", nil}, nil, nil},
+		{f1, sql.Row{json, "some", "abc"}, nil, sql.ErrInvalidJSONOneOrAll},
+		{f2, sql.Row{json, "all", "abc", nil, `$[2]`}, sql.JSONDocument{Val: "$[2].x"}, nil},
+		{f2, sql.Row{json, "all", "%b%", "", `$[*].y`}, sql.JSONDocument{Val: "$[3].y"}, nil},
+		{f2, sql.Row{`["a_c", "abc"]`, "all", "a|_c", "|", `$`}, sql.JSONDocument{Val: "$[0]"}, nil},
+		{f2, sql.Row{`["a_c", "abc"]`, "all", `a\_c`, nil, `$`}, sql.JSONDocument{Val: "$[0]"}, nil},
+		{f2, sql.Row{json, "all", "abc", "ab", `$`}, nil, sql.ErrInvalidArgument},
+		{f2, sql.Row{json, "all", "abc", nil, nil}, nil, nil},
+		{f2, sql.Row{json, "all", "abc", nil, `$.`}, nil, sql.ErrInvalidJSONPath},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSON_TYPE(json_val)
+//
+// Returns a utf8mb4 string indicating the type of a JSON value. This can be an object, an array, or a scalar type.
+// JSONType returns NULL if the argument is NULL. An error occurs if the argument is not a valid JSON value
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-attribute-functions.html#function_json-type
+type JSONType struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONType)(nil)
+
+// NewJSONType creates a new JSONType function.
+func NewJSONType(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_TYPE", "1", len(args))
+	}
+	return &JSONType{expression.UnaryExpression{Child: args[0]}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONType) FunctionName() string {
+	return "json_type"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONType) Description() string {
+	return "returns type of JSON value."
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONType) IsUnsupported() bool {
+	return false
+}
+
+// String implements the Expression interface.
+func (j *JSONType) String() string {
+	return fmt.Sprintf("JSON_TYPE(%s)", j.Child)
+}
+
+// Type implements the Expression interface.
+func (*JSONType) Type() sql.Type {
+	return sql.LongText
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONType) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONType(children...)
+}
+
+// Eval implements the Expression interface.
+func (j *JSONType) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	js, err := getSearchableJSONVal(ctx, row, j.Child)
+	if js == nil || err != nil {
+		return nil, err
+	}
+	doc, err := js.Unmarshall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sql.JSONTypeOf(doc.Val), nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONType(t *testing.T) {
+	_, err := NewJSONType()
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewJSONType(expression.NewGetField(0, sql.JSON, "doc", true))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{f, sql.Row{nil}, nil, nil},
+		{f, sql.Row{`null`}, "NULL", nil},
+		{f, sql.Row{`true`}, "BOOLEAN", nil},
+		{f, sql.Row{`"abc"`}, "STRING", nil},
+		{f, sql.Row{`[1, 2]`}, "ARRAY", nil},
+		{f, sql.Row{`{"a": 1}`}, "OBJECT", nil},
+		{f, sql.Row{`-1234567890123456789`}, "INTEGER", nil},
+		{f, sql.Row{`18446744073709551615`}, "UNSIGNED INTEGER", nil},
+		{f, sql.Row{`1.0`}, "DOUBLE", nil},
+		{f, sql.Row{`1e3`}, "DOUBLE", nil},
+		{f, sql.Row{sql.JSONDocument{Val: decimal.RequireFromString("1.50")}}, "DECIMAL", nil},
+		{f, sql.Row{`{"a": `}, nil, sql.ErrInvalidJSONText},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
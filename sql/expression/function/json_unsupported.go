@@ -27,203 +27,6 @@ var ErrUnsupportedJSONFunction = errors.NewKind("unsupported JSON function: %s")
 // JSON search functions //
 ///////////////////////////
 
-// JSON_CONTAINS_PATH(json_doc, one_or_all, path[, path] ...)
-//
-// JSONContainsPath Returns 0 or 1 to indicate whether a JSON document contains data at a given path or paths. Returns
-// NULL if any argument is NULL. An error occurs if the json_doc argument is not a valid JSON document, any path
-// argument is not a valid path expression, or one_or_all is not 'one' or 'all'. To check for a specific value at a
-// path, use JSON_CONTAINS() instead.
-//
-// The return value is 0 if no specified path exists within the document. Otherwise, the return value depends on the
-// one_or_all argument:
-//   - 'one': 1 if at least one path exists within the document, 0 otherwise.
-//   - 'all': 1 if all paths exist within the document, 0 otherwise.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-contains-path
-type JSONContainsPath struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONContainsPath{}
-
-// NewJSONContainsPath creates a new JSONContainsPath function.
-func NewJSONContainsPath(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONContainsPath{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONContainsPath) FunctionName() string {
-	return "json_contains_path"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONContainsPath) Description() string {
-	return "returns whether JSON document contains any data at path."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONContainsPath) IsUnsupported() bool {
-	return true
-}
-
-// JSON_KEYS(json_doc[, path])
-//
-// JSONKeys Returns the keys from the top-level value of a JSON object as a JSON array, or, if a path argument is given,
-// the top-level keys from the selected path. Returns NULL if any argument is NULL, the json_doc argument is not an
-// object, or path, if given, does not locate an object. An error occurs if the json_doc argument is not a valid JSON
-// document or the path argument is not a valid path expression or contains a * or ** wildcard. The result array is
-// empty if the selected object is empty. If the top-level value has nested subobjects, the return value does not
-// include keys from those subobjects.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-keys
-type JSONKeys struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONKeys{}
-
-// NewJSONKeys creates a new JSONKeys function.
-func NewJSONKeys(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONKeys{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONKeys) FunctionName() string {
-	return "json_keys"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONKeys) Description() string {
-	return "array of keys from JSON document."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONKeys) IsUnsupported() bool {
-	return true
-}
-
-// JSON_OVERLAPS(json_doc1, json_doc2)
-//
-// JSONOverlaps Compares two JSON documents. Returns true (1) if the two document have any key-value pairs or array
-// elements in common. If both arguments are scalars, the function performs a simple equality test.
-//
-// This function serves as counterpart to JSON_CONTAINS(), which requires all elements of the array searched for to be
-// present in the array searched in. Thus, JSON_CONTAINS() performs an AND operation on search keys, while
-// JSON_OVERLAPS() performs an OR operation.
-//
-// Queries on JSON columns of InnoDB tables using JSON_OVERLAPS() in the WHERE clause can be optimized using
-// multi-valued indexes. Multi-Valued Indexes, provides detailed information and examples.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-overlaps
-type JSONOverlaps struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONOverlaps{}
-
-// NewJSONOverlaps creates a new JSONOverlaps function.
-func NewJSONOverlaps(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONOverlaps{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONOverlaps) FunctionName() string {
-	return "json_overlaps"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONOverlaps) Description() string {
-	return "compares two JSON documents, returns TRUE (1) if these have any key-value pairs or array elements in common, otherwise FALSE (0)."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONOverlaps) IsUnsupported() bool {
-	return true
-}
-
-// JSON_SEARCH(json_doc, one_or_all, search_str[, escape_char[, path] ...])
-//
-// JSONSearch Returns the path to the given string within a JSON document. Returns NULL if any of the json_doc,
-// search_str, or path arguments are NULL; no path exists within the document; or search_str is not found. An error
-// occurs if the json_doc argument is not a valid JSON document, any path argument is not a valid path expression,
-// one_or_all is not 'one' or 'all', or escape_char is not a constant expression.
-// The one_or_all argument affects the search as follows:
-//   - 'one': The search terminates after the first match and returns one path string. It is undefined which match is
-//     considered first.
-//   - 'all': The search returns all matching path strings such that no duplicate paths are included. If there are
-//     multiple strings, they are autowrapped as an array. The order of the array elements is undefined.
-//
-// Within the search_str search string argument, the % and _ characters work as for the LIKE operator: % matches any
-// number of characters (including zero characters), and _ matches exactly one character.
-//
-// To specify a literal % or _ character in the search string, precede it by the escape character. The default is \ if
-// the escape_char argument is missing or NULL. Otherwise, escape_char must be a constant that is empty or one character.
-// For more information about matching and escape character behavior, see the description of LIKE in Section 12.8.1,
-// “String Comparison Functions and Operators”: https://dev.mysql.com/doc/refman/8.0/en/string-comparison-functions.html
-// For escape character handling, a difference from the LIKE behavior is that the escape character for JSON_SEARCH()
-// must evaluate to a constant at compile time, not just at execution time. For example, if JSON_SEARCH() is used in a
-// prepared statement and the escape_char argument is supplied using a ? parameter, the parameter value might be
-// constant at execution time, but is not at compile time.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-search
-type JSONSearch struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONSearch{}
-
-// NewJSONSearch creates a new NewJSONSearch function.
-func NewJSONSearch(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONSearch{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONSearch) FunctionName() string {
-	return "json_search"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONSearch) Description() string {
-	return "path to value within JSON document."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONSearch) IsUnsupported() bool {
-	return true
-}
-
-// JSON_VALUE(json_doc, path)
-//
-// JSONValue Extracts a value from a JSON document at the path given in the specified document, and returns the
-// extracted value, optionally converting it to a desired type.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
-type JSONValue struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONValue{}
-
-// NewJSONValue creates a new JSONValue function.
-func NewJSONValue(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONValue{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONValue) FunctionName() string {
-	return "json_value"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONValue) Description() string {
-	return "extract value from JSON document at location pointed to by path provided; return this value as VARCHAR(512) or specified type."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONValue) IsUnsupported() bool {
-	return true
-}
-
 // value MEMBER OF(json_array)
 //
 // Returns true (1) if value is an element of json_array, otherwise returns false (0). value must be a scalar or a JSON
@@ -278,172 +81,6 @@ func (j JSONQuote) IsUnsupported() bool {
 // JSON modification functions //
 /////////////////////////////////
 
-// JSON_ARRAY_APPEND(json_doc, path, val[, path, val] ...)
-//
-// JSONArrayAppend Appends values to the end of the indicated arrays within a JSON document and returns the result.
-// Returns NULL if any argument is NULL. An error occurs if the json_doc argument is not a valid JSON document or any
-// path argument is not a valid path expression or contains a * or ** wildcard. The path-value pairs are evaluated left
-// to right. The document produced by evaluating one pair becomes the new value against which the next pair is
-// evaluated. If a path selects a scalar or object value, that value is autowrapped within an array and the new value is
-// added to that array. Pairs for which the path does not identify any value in the JSON document are ignored.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-array-append
-type JSONArrayAppend struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONArrayAppend{}
-
-// NewJSONArrayAppend creates a new JSONArrayAppend function.
-func NewJSONArrayAppend(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONArrayAppend{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONArrayAppend) FunctionName() string {
-	return "json_array_append"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONArrayAppend) Description() string {
-	return "appends data to JSON document."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONArrayAppend) IsUnsupported() bool {
-	return true
-}
-
-// JSON_ARRAY_INSERT(json_doc, path, val[, path, val] ...)
-//
-// JSONArrayInsert Updates a JSON document, inserting into an array within the document and returning the modified
-// document. Returns NULL if any argument is NULL. An error occurs if the json_doc argument is not a valid JSON document
-// or any path argument is not a valid path expression or contains a * or ** wildcard or does not end with an array
-// element identifier. The path-value pairs are evaluated left to right. The document produced by evaluating one pair
-// becomes the new value against which the next pair is evaluated. Pairs for which the path does not identify any array
-// in the JSON document are ignored. If a path identifies an array element, the corresponding value is inserted at that
-// element position, shifting any following values to the right. If a path identifies an array position past the end of
-// an array, the value is inserted at the end of the array.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-array-insert
-type JSONArrayInsert struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONArrayInsert{}
-
-// NewJSONArrayInsert creates a new JSONArrayInsert function.
-func NewJSONArrayInsert(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONArrayInsert{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONArrayInsert) FunctionName() string {
-	return "json_array_insert"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONArrayInsert) Description() string {
-	return "inserts into JSON array."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONArrayInsert) IsUnsupported() bool {
-	return true
-}
-
-// JSON_INSERT(json_doc, path, val[, path, val] ...)
-//
-// JSONInsert Inserts data into a JSON document and returns the result. Returns NULL if any argument is NULL. An error
-// occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path expression or
-// contains a * or ** wildcard. The path-value pairs are evaluated left to right. The document produced by evaluating
-// one pair becomes the new value against which the next pair is evaluated. A path-value pair for an existing path in
-// the document is ignored and does not overwrite the existing document value. A path-value pair for a nonexisting path
-// in the document adds the value to the document if the path identifies one of these types of values:
-//   - A member not present in an existing object. The member is added to the object and associated with the new value.
-//   - A position past the end of an existing array. The array is extended with the new value. If the existing value is
-//     not an array, it is autowrapped as an array, then extended with the new value.
-//
-// Otherwise, a path-value pair for a nonexisting path in the document is ignored and has no effect.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-insert
-type JSONInsert struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONInsert{}
-
-// NewJSONInsert creates a new JSONInsert function.
-func NewJSONInsert(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONInsert{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONInsert) FunctionName() string {
-	return "json_insert"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONInsert) Description() string {
-	return "inserts data into JSON document"
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONInsert) IsUnsupported() bool {
-	return true
-}
-
-// JSON_MERGE_PATCH(json_doc, json_doc[, json_doc] ...)
-//
-// JSONMergePatch Performs an RFC 7396 compliant merge of two or more JSON documents and returns the merged result,
-// without preserving members having duplicate keys. Raises an error if at least one of the documents passed as arguments
-// to this function is not valid. JSONMergePatch performs a merge as follows:
-//   - If the first argument is not an object, the result of the merge is the same as if an empty object had been merged
-//     with the second argument.
-//   - If the second argument is not an object, the result of the merge is the second argument.
-//   - If both arguments are objects, the result of the merge is an object with the following members:
-//   - All members of the first object which do not have a corresponding member with the same key in the second
-//     object.
-//   - All members of the second object which do not have a corresponding key in the first object, and whose value is
-//     not the JSON null literal.
-//   - All members with a key that exists in both the first and the second object, and whose value in the second
-//     object is not the JSON null literal. The values of these members are the results of recursively merging the
-//     value in the first object with the value in the second object.
-//
-// The behavior of JSONMergePatch is the same as that of JSONMergePreserve, with the following two exceptions:
-//   - JSONMergePatch removes any member in the first object with a matching key in the second object, provided that
-//     the value associated with the key in the second object is not JSON null.
-//   - If the second object has a member with a key matching a member in the first object, JSONMergePatch replaces
-//     the value in the first object with the value in the second object, whereas JSONMergePreserve appends the
-//     second value to the first value.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-merge-patch
-type JSONMergePatch struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONMergePatch{}
-
-// NewJSONMergePatch creates a new JSONMergePatch function.
-func NewJSONMergePatch(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONMergePatch{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONMergePatch) FunctionName() string {
-	return "json_merge_patch"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONMergePatch) Description() string {
-	return "merges JSON documents, replacing values of duplicate keys"
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONMergePatch) IsUnsupported() bool {
-	return true
-}
-
 // JSON_MERGE(json_doc, json_doc[, json_doc] ...)
 //
 // JSONMerge Merges two or more JSON documents. Synonym for JSONMergePreserve(); deprecated in MySQL 8.0.3 and subject
@@ -454,119 +91,6 @@ type JSONMerge struct {
 	sql.Expression
 }
 
-// JSON_REMOVE(json_doc, path[, path] ...)
-//
-// JSONRemove Removes data from a JSON document and returns the result. Returns NULL if any argument is NULL. An error
-// occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path expression or
-// is $ or contains a * or ** wildcard. The path arguments are evaluated left to right. The document produced by
-// evaluating one path becomes the new value against which the next path is evaluated. It is not an error if the element
-// to be removed does not exist in the document; in that case, the path does not affect the document.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-remove
-type JSONRemove struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONRemove{}
-
-// NewJSONRemove creates a new JSONRemove function.
-func NewJSONRemove(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONRemove{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONRemove) FunctionName() string {
-	return "json_remove"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONRemove) Description() string {
-	return "removes data from JSON document."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONRemove) IsUnsupported() bool {
-	return true
-}
-
-// JSON_REPLACE(json_doc, path, val[, path, val] ...)
-//
-// JSONReplace Replaces existing values in a JSON document and returns the result. Returns NULL if any argument is NULL.
-// An error occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path
-// expression or contains a * or ** wildcard. The path-value pairs are evaluated left to right. The document produced by
-// evaluating one pair becomes the new value against which the next pair is evaluated. A path-value pair for an existing
-// path in the document overwrites the existing document value with the new value. A path-value pair for a non-existing
-// path in the document is ignored and has no effect.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-replace
-type JSONReplace struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONReplace{}
-
-// NewJSONReplace creates a new JSONReplace function.
-func NewJSONReplace(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONReplace{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONReplace) FunctionName() string {
-	return "json_replace"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONReplace) Description() string {
-	return "replaces values in JSON document."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONReplace) IsUnsupported() bool {
-	return true
-}
-
-// JSON_SET(json_doc, path, val[, path, val] ...)
-//
-// JSONSet Inserts or updates data in a JSON document and returns the result. Returns NULL if any argument is NULL or
-// path, if given, does not locate an object. An error occurs if the json_doc argument is not a valid JSON document or
-// any path argument is not a valid path expression or contains a * or ** wildcard. The path-value pairs are evaluated
-// left to right. The document produced by evaluating one pair becomes the new value against which the next pair is
-// evaluated. A path-value pair for an existing path in the document overwrites the existing document value with the
-// new value. A path-value pair for a non-existing path in the document adds the value to the document if the path
-// identifies one of these types of values:
-//   - A member not present in an existing object. The member is added to the object and associated with the new value.
-//   - A position past the end of an existing array. The array is extended with the new value. If the existing value is
-//     not an array, it is auto-wrapped as an array, then extended with the new value.
-//
-// Otherwise, a path-value pair for a non-existing path in the document is ignored and has no effect.
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-set
-type JSONSet struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONSet{}
-
-// NewJSONSet creates a new JSONSet function.
-func NewJSONSet(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONSet{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONSet) FunctionName() string {
-	return "json_set"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONSet) Description() string {
-	return "inserts data into JSON document."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONSet) IsUnsupported() bool {
-	return true
-}
-
 //////////////////////////////
 // JSON attribute functions //
 //////////////////////////////
@@ -643,38 +167,6 @@ func (j JSONLength) IsUnsupported() bool {
 	return true
 }
 
-// JSON_TYPE(json_val)
-//
-// Returns a utf8mb4 string indicating the type of a JSON value. This can be an object, an array, or a scalar type.
-// JSONType returns NULL if the argument is NULL. An error occurs if the argument is not a valid JSON value
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-attribute-functions.html#function_json-type
-type JSONType struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONType{}
-
-// NewJSONType creates a new JSONType function.
-func NewJSONType(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONType{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONType) FunctionName() string {
-	return "json_type"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONType) Description() string {
-	return "returns type of JSON value."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONType) IsUnsupported() bool {
-	return true
-}
-
 // JSON_VALID(val)
 //
 // Returns 0 or 1 to indicate whether a value is valid JSON. Returns NULL if the argument is NULL.
@@ -742,86 +234,6 @@ func (j JSONTable) IsUnsupported() bool {
 	return true
 }
 
-///////////////////////////////
-// JSON validation functions //
-///////////////////////////////
-
-// JSON_SCHEMA_VALID(schema,document)
-//
-// JSONSchemaValid Validates a JSON document against a JSON schema. Both schema and document are required. The schema
-// must be a valid JSON object; the document must be a valid JSON document. Provided that these conditions are met: If
-// the document validates against the schema, the function returns true (1); otherwise, it returns false (0).
-// https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-valid
-type JSONSchemaValid struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONSchemaValid{}
-
-// NewJSONSchemaValid creates a new JSONSchemaValid function.
-func NewJSONSchemaValid(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONSchemaValid{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONSchemaValid) FunctionName() string {
-	return "json_schema_valid"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONSchemaValid) Description() string {
-	return "validates JSON document against JSON schema; returns TRUE/1 if document validates against schema, or FALSE/0 if it does not."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONSchemaValid) IsUnsupported() bool {
-	return true
-}
-
-// JSON_SCHEMA_VALIDATION_REPORT(schema,document)
-//
-// JSONSchemaValidationReport Validates a JSON document against a JSON schema. Both schema and document are required.
-// As with JSONSchemaValid, the schema must be a valid JSON object, and the document must be a valid JSON document.
-// Provided that these conditions are met, the function returns a report, as a JSON document, on the outcome of the
-// validation. If the JSON document is considered valid according to the JSON Schema, the function returns a JSON object
-// with one property valid having the value "true". If the JSON document fails validation, the function returns a JSON
-// object which includes the properties listed here:
-//   - valid: Always "false" for a failed schema validation
-//   - reason: A human-readable string containing the reason for the failure
-//   - schema-location: A JSON pointer URI fragment identifier indicating where in the JSON schema the validation failed
-//     (see Note following this list)
-//   - document-location: A JSON pointer URI fragment identifier indicating where in the JSON document the validation
-//     failed (see Note following this list)
-//   - schema-failed-keyword: A string containing the name of the keyword or property in the JSON schema that was
-//     violated
-//
-// https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-validation-report
-type JSONSchemaValidationReport struct {
-	sql.Expression
-}
-
-var _ sql.FunctionExpression = JSONSchemaValidationReport{}
-
-// NewJSONSchemaValidationReport creates a new JSONSchemaValidationReport function.
-func NewJSONSchemaValidationReport(args ...sql.Expression) (sql.Expression, error) {
-	return nil, ErrUnsupportedJSONFunction.New(JSONSchemaValidationReport{}.FunctionName())
-}
-
-// FunctionName implements sql.FunctionExpression
-func (j JSONSchemaValidationReport) FunctionName() string {
-	return "json_schema_validation_report"
-}
-
-// Description implements sql.FunctionExpression
-func (j JSONSchemaValidationReport) Description() string {
-	return "validates JSON document against JSON schema; returns report in JSON format on outcome on validation including success or failure and reasons for failure."
-}
-
-// IsUnsupported implements sql.UnsupportedFunctionStub
-func (j JSONSchemaValidationReport) IsUnsupported() bool {
-	return true
-}
-
 ////////////////////////////
 // JSON utility functions //
 ////////////////////////////
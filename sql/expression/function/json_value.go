@@ -0,0 +1,135 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// jsonValueType is the type JSON_VALUE returns. The parser doesn't support the RETURNING clause yet, which would
+// choose another type.
+var jsonValueType = sql.MustCreateStringWithDefaults(sqltypes.VarChar, 512)
+
+// JSON_VALUE(json_doc, path)
+//
+// JSONValue Extracts a value from a JSON document at the path given in the specified document, and returns the
+// extracted value as a string. A path that selects nothing, an array or an object returns NULL.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+type JSONValue struct {
+	JSON sql.Expression
+	Path sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONValue)(nil)
+
+// NewJSONValue creates a new JSONValue function.
+func NewJSONValue(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_VALUE", 2, len(args))
+	}
+	return &JSONValue{JSON: args[0], Path: args[1]}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONValue) FunctionName() string {
+	return "json_value"
+}
+
+// Description implements sql.FunctionExpression
+func (j *JSONValue) Description() string {
+	return "extract value from JSON document at location pointed to by path provided; return this value as VARCHAR(512)."
+}
+
+// IsUnsupported implements sql.UnsupportedFunctionStub
+func (j *JSONValue) IsUnsupported() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (j *JSONValue) Resolved() bool {
+	return j.JSON.Resolved() && j.Path.Resolved()
+}
+
+// String implements the Expression interface.
+func (j *JSONValue) String() string {
+	return fmt.Sprintf("JSON_VALUE(%s, %s)", j.JSON, j.Path)
+}
+
+// Type implements the Expression interface.
+func (j *JSONValue) Type() sql.Type {
+	return jsonValueType
+}
+
+// IsNullable implements the Expression interface.
+func (j *JSONValue) IsNullable() bool {
+	return true
+}
+
+// Eval implements the Expression interface.
+func (j *JSONValue) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, err := evalJSONDocument(ctx, row, j.JSON)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	path, err := evalJSONPath(ctx, row, j.Path)
+	if err != nil || path == nil {
+		return nil, err
+	}
+	if path.ContainsWildcard() {
+		return nil, sql.ErrInvalidJSONPathWildcard.New()
+	}
+
+	vals := doc.Find(*path)
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	switch v := vals[0].(type) {
+	case nil, map[string]interface{}, []interface{}:
+		return nil, nil
+	case bool:
+		// JSON booleans are returned as the text of the literal
+		if v {
+			return "true", nil
+		}
+		return "false", nil
+	default:
+		val, err := jsonValueType.Convert(v)
+		if err != nil {
+			// Values that can't be returned are NULL, as with MySQL's default of NULL ON ERROR
+			return nil, nil
+		}
+		return val, nil
+	}
+}
+
+// Children implements the Expression interface.
+func (j *JSONValue) Children() []sql.Expression {
+	return []sql.Expression{j.JSON, j.Path}
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONValue) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewJSONValue(children...)
+}
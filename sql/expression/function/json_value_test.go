@@ -0,0 +1,69 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONValue(t *testing.T) {
+	_, err := NewJSONValue(expression.NewGetField(0, sql.JSON, "doc", true))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	doc := expression.NewGetField(0, sql.JSON, "doc", true)
+	path := expression.NewGetField(1, sql.LongText, "path", true)
+	_, err = NewJSONValue(doc, path, expression.NewLiteral(int8(1), sql.Int8))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	plain, err := NewJSONValue(doc, path)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{plain, sql.Row{`{"a": "x", "b": 1.5}`, `$.a`}, "x", nil},
+		{plain, sql.Row{`{"a": "x", "b": 1.5}`, `$.b`}, "1.5", nil},
+		{plain, sql.Row{`{"a": true}`, `$.a`}, "true", nil},
+		{plain, sql.Row{`{"a": null}`, `$.a`}, nil, nil},
+		{plain, sql.Row{`{"a": "x"}`, `$.b`}, nil, nil},
+		{plain, sql.Row{`{"a": [1]}`, `$.a`}, nil, nil},
+		{plain, sql.Row{nil, `$.a`}, nil, nil},
+		{plain, sql.Row{`{"a": "x"}`, nil}, nil, nil},
+		{plain, sql.Row{`{"a": "x"}`, `$.*`}, nil, sql.ErrInvalidJSONPathWildcard},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err == nil {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			} else {
+				require.Error(err)
+				require.True(tt.err.Is(err), err.Error())
+			}
+		})
+	}
+}
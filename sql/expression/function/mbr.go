@@ -0,0 +1,186 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// mbrRelation evaluates the two geometry arguments of an MBR function and applies the relation given to their
+// minimum bounding rectangles. Empty geometries have no bounding rectangle, and satisfy no relation.
+func mbrRelation(ctx *sql.Context, row sql.Row, name string, left, right sql.Expression, relation func(a, b planarGeometry) bool) (interface{}, error) {
+	g1, g2, err := evalGeometryArgs(ctx, row, name, left, right)
+	if err != nil {
+		return nil, err
+	}
+	if g1 == nil || g2 == nil {
+		return nil, nil
+	}
+	box1, ok1 := sql.BoundingBox(g1)
+	box2, ok2 := sql.BoundingBox(g2)
+	if !ok1 || !ok2 {
+		return false, nil
+	}
+	srid := g1.GetSRID()
+	return relation(newPlanarGeometry(envelope(srid, box1)), newPlanarGeometry(envelope(srid, box2))), nil
+}
+
+// MBRContains is a function that returns whether the minimum bounding rectangle of the first geometry contains that
+// of the second.
+type MBRContains struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*MBRContains)(nil)
+
+// NewMBRContains creates a new MBRContains expression.
+func NewMBRContains(g1, g2 sql.Expression) sql.Expression {
+	return &MBRContains{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MBRContains) FunctionName() string {
+	return "mbrcontains"
+}
+
+// Description implements sql.FunctionExpression
+func (m *MBRContains) Description() string {
+	return "returns 1 or 0 to indicate whether the minimum bounding rectangle of g1 contains the minimum bounding rectangle of g2."
+}
+
+// Type implements the sql.Expression interface.
+func (m *MBRContains) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (m *MBRContains) String() string {
+	return fmt.Sprintf("MBRCONTAINS(%s,%s)", m.Left, m.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (m *MBRContains) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 2)
+	}
+	return NewMBRContains(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (m *MBRContains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return mbrRelation(ctx, row, m.FunctionName(), m.Left, m.Right, contains)
+}
+
+// MBRWithin is a function that returns whether the minimum bounding rectangle of the first geometry is within that
+// of the second.
+type MBRWithin struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*MBRWithin)(nil)
+
+// NewMBRWithin creates a new MBRWithin expression.
+func NewMBRWithin(g1, g2 sql.Expression) sql.Expression {
+	return &MBRWithin{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MBRWithin) FunctionName() string {
+	return "mbrwithin"
+}
+
+// Description implements sql.FunctionExpression
+func (m *MBRWithin) Description() string {
+	return "returns 1 or 0 to indicate whether the minimum bounding rectangle of g1 is within the minimum bounding rectangle of g2."
+}
+
+// Type implements the sql.Expression interface.
+func (m *MBRWithin) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (m *MBRWithin) String() string {
+	return fmt.Sprintf("MBRWITHIN(%s,%s)", m.Left, m.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (m *MBRWithin) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 2)
+	}
+	return NewMBRWithin(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (m *MBRWithin) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return mbrRelation(ctx, row, m.FunctionName(), m.Left, m.Right, func(a, b planarGeometry) bool {
+		return contains(b, a)
+	})
+}
+
+// MBRIntersects is a function that returns whether the minimum bounding rectangles of two geometries intersect.
+type MBRIntersects struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*MBRIntersects)(nil)
+
+// NewMBRIntersects creates a new MBRIntersects expression.
+func NewMBRIntersects(g1, g2 sql.Expression) sql.Expression {
+	return &MBRIntersects{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MBRIntersects) FunctionName() string {
+	return "mbrintersects"
+}
+
+// Description implements sql.FunctionExpression
+func (m *MBRIntersects) Description() string {
+	return "returns 1 or 0 to indicate whether the minimum bounding rectangles of g1 and g2 intersect."
+}
+
+// Type implements the sql.Expression interface.
+func (m *MBRIntersects) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (m *MBRIntersects) String() string {
+	return fmt.Sprintf("MBRINTERSECTS(%s,%s)", m.Left, m.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (m *MBRIntersects) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 2)
+	}
+	return NewMBRIntersects(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (m *MBRIntersects) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g1, g2, err := evalGeometryArgs(ctx, row, m.FunctionName(), m.Left, m.Right)
+	if err != nil {
+		return nil, err
+	}
+	if g1 == nil || g2 == nil {
+		return nil, nil
+	}
+	box1, ok1 := sql.BoundingBox(g1)
+	box2, ok2 := sql.BoundingBox(g2)
+	return ok1 && ok2 && box1.Intersects(box2), nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestMBRRelations(t *testing.T) {
+	sq := square(0, 0, 4)
+	diagonal := sql.LineString{Points: []sql.Point{{X: 1, Y: 1}, {X: 3, Y: 3}}}
+	edge := sql.LineString{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 4}}}
+	far := sql.Point{X: 10, Y: 10}
+
+	tests := []struct {
+		name     string
+		f        func(g1, g2 sql.Expression) sql.Expression
+		g1, g2   sql.GeometryValue
+		expected interface{}
+	}{
+		{"rectangle contains inner line", NewMBRContains, sq, diagonal, true},
+		{"rectangle doesn't contain its edge", NewMBRContains, sq, edge, false},
+		{"rectangle doesn't contain far point", NewMBRContains, sq, far, false},
+		{"line within rectangle", NewMBRWithin, diagonal, sq, true},
+		{"rectangle not within line", NewMBRWithin, sq, diagonal, false},
+		{"point within itself", NewMBRWithin, far, far, true},
+		{"rectangle intersects its edge", NewMBRIntersects, sq, edge, true},
+		{"rectangles touching at a corner intersect", NewMBRIntersects, sq, square(0, 4, 6), true},
+		{"far point doesn't intersect", NewMBRIntersects, sq, far, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := tt.f(expression.NewLiteral(tt.g1, sql.GeometryType{}), expression.NewLiteral(tt.g2, sql.GeometryType{}))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	t.Run("null argument", func(t *testing.T) {
+		require := require.New(t)
+		f := NewMBRIntersects(expression.NewLiteral(sq, sql.PolygonType{}), expression.NewLiteral(nil, sql.Null))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+
+	t.Run("different srids", func(t *testing.T) {
+		require := require.New(t)
+		f := NewMBRContains(expression.NewLiteral(square(4326, 0, 4), sql.PolygonType{}), expression.NewLiteral(sq, sql.PolygonType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrDiffSRIDs.Is(err))
+	})
+}
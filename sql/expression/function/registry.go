@@ -33,6 +33,7 @@ var BuiltIns = []sql.Function{
 	// elt, find_in_set, insert, load_file, locate
 	sql.Function1{Name: "abs", Fn: NewAbsVal},
 	sql.Function1{Name: "acos", Fn: NewAcos},
+	sql.Function1{Name: "any_value", Fn: NewAnyValue},
 	sql.Function1{Name: "ascii", Fn: NewAscii},
 	sql.Function1{Name: "asin", Fn: NewAsin},
 	sql.Function1{Name: "atan", Fn: NewAtan},
@@ -152,6 +153,9 @@ var BuiltIns = []sql.Function{
 	sql.FunctionN{Name: "lpad", Fn: NewLeftPad},
 	sql.Function1{Name: "ltrim", Fn: NewLeftTrim},
 	sql.Function1{Name: "max", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewMax(e) }},
+	sql.Function2{Name: "mbrcontains", Fn: NewMBRContains},
+	sql.Function2{Name: "mbrintersects", Fn: NewMBRIntersects},
+	sql.Function2{Name: "mbrwithin", Fn: NewMBRWithin},
 	sql.Function1{Name: "md5", Fn: NewMD5},
 	sql.Function1{Name: "microsecond", Fn: NewMicrosecond},
 	sql.FunctionN{Name: "mid", Fn: NewSubstring},
@@ -185,6 +189,9 @@ var BuiltIns = []sql.Function{
 	sql.Function0{Name: "dense_rank", Fn: window.NewDenseRank},
 	sql.Function1{Name: "first_value", Fn: window.NewFirstValue},
 	sql.Function1{Name: "last_value", Fn: window.NewLastValue},
+	sql.Function0{Name: "cume_dist", Fn: window.NewCumeDist},
+	sql.FunctionN{Name: "ntile", Fn: window.NewNtile},
+	sql.FunctionN{Name: "nth_value", Fn: window.NewNthValue},
 	sql.FunctionN{Name: "rpad", Fn: NewRightPad},
 	sql.Function1{Name: "rtrim", Fn: NewRightTrim},
 	sql.Function0{Name: "schema", Fn: NewDatabase},
@@ -204,7 +211,16 @@ var BuiltIns = []sql.Function{
 	sql.Function1{Name: "st_aswkb", Fn: NewAsWKB},
 	sql.Function1{Name: "st_aswkt", Fn: NewAsWKT},
 	sql.Function1{Name: "st_astext", Fn: NewAsWKT},
+	sql.Function2{Name: "st_buffer", Fn: NewSTBuffer},
+	sql.Function1{Name: "st_centroid", Fn: NewSTCentroid},
+	sql.Function2{Name: "st_contains", Fn: NewSTContains},
+	sql.Function1{Name: "st_convexhull", Fn: NewSTConvexHull},
 	sql.Function1{Name: "st_dimension", Fn: NewDimension},
+	sql.Function2{Name: "st_disjoint", Fn: NewSTDisjoint},
+	sql.Function2{Name: "st_distance", Fn: NewSTDistance},
+	sql.FunctionN{Name: "st_distance_sphere", Fn: NewSTDistanceSphere},
+	sql.Function1{Name: "st_envelope", Fn: NewSTEnvelope},
+	sql.Function2{Name: "st_equals", Fn: NewSTEquals},
 	sql.FunctionN{Name: "st_geomcollfromtext", Fn: NewGeomCollFromText},
 	sql.FunctionN{Name: "st_geomcollfromtxt", Fn: NewGeomCollFromText},
 	sql.FunctionN{Name: "st_geomcollfromwkb", Fn: NewGeomCollFromWKB},
@@ -215,6 +231,7 @@ var BuiltIns = []sql.Function{
 	sql.FunctionN{Name: "st_geomfromtext", Fn: NewGeomFromText},
 	sql.FunctionN{Name: "st_geometryfromwkb", Fn: NewGeomFromWKB},
 	sql.FunctionN{Name: "st_geomfromwkb", Fn: NewGeomFromWKB},
+	sql.Function2{Name: "st_intersects", Fn: NewSTIntersects},
 	sql.FunctionN{Name: "st_length", Fn: NewSTLength},
 	sql.FunctionN{Name: "st_longitude", Fn: NewLongitude},
 	sql.FunctionN{Name: "st_linefromtext", Fn: NewLineFromText},
@@ -242,6 +259,8 @@ var BuiltIns = []sql.Function{
 	sql.FunctionN{Name: "st_polygonfromwkb", Fn: NewPolyFromWKB},
 	sql.FunctionN{Name: "st_srid", Fn: NewSRID},
 	sql.Function1{Name: "st_swapxy", Fn: NewSwapXY},
+	sql.Function2{Name: "st_touches", Fn: NewSTTouches},
+	sql.Function2{Name: "st_within", Fn: NewSTWithin},
 	sql.FunctionN{Name: "st_x", Fn: NewSTX},
 	sql.FunctionN{Name: "st_y", Fn: NewSTY},
 	sql.FunctionN{Name: "substr", Fn: NewSubstring},
@@ -0,0 +1,659 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"math"
+	"sort"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrDiffSRIDs is returned when a function of two geometries is given geometries with different SRIDs.
+var ErrDiffSRIDs = errors.NewKind("Binary geometry function %s given two geometries of different srids: %v and %v, which should have been identical.")
+
+// ErrNotImplementedForGeographic is returned when a function is given a geometry in a geographic spatial reference
+// system, but is only implemented for cartesian ones.
+var ErrNotImplementedForGeographic = errors.NewKind("%s has not been implemented for geographic spatial reference systems.")
+
+// ErrUnsupportedSpatialArgument is returned when a function is given a geometry of a type it doesn't accept.
+var ErrUnsupportedSpatialArgument = errors.NewKind("Calling geometry function %s with unsupported types of arguments.")
+
+// spatialEpsilon is the relative tolerance used to decide whether three points are collinear.
+const spatialEpsilon = 1e-12
+
+// planarGeometry is a geometry flattened into its points, linestrings and polygons, on which spatial relations and
+// measurements are computed. Coordinates are treated as cartesian, whatever the SRID of the geometry.
+type planarGeometry struct {
+	points   []sql.Point
+	lines    []sql.LineString
+	polygons []sql.Polygon
+}
+
+// segment is a straight line between two points.
+type segment struct {
+	a, b sql.Point
+}
+
+// newPlanarGeometry flattens the geometry given, including multi geometries and geometry collections.
+func newPlanarGeometry(g sql.GeometryValue) planarGeometry {
+	var pg planarGeometry
+	pg.add(g)
+	return pg
+}
+
+func (g *planarGeometry) add(v sql.GeometryValue) {
+	switch v := v.(type) {
+	case sql.Point:
+		g.points = append(g.points, v)
+	case sql.LineString:
+		if len(v.Points) > 0 {
+			g.lines = append(g.lines, v)
+		}
+	case sql.Polygon:
+		if len(v.Lines) > 0 && len(v.Lines[0].Points) > 0 {
+			g.polygons = append(g.polygons, v)
+		}
+	case sql.MultiPoint:
+		g.points = append(g.points, v.Points...)
+	case sql.MultiLineString:
+		for _, l := range v.Lines {
+			g.add(l)
+		}
+	case sql.MultiPolygon:
+		for _, p := range v.Polygons {
+			g.add(p)
+		}
+	case sql.GeomColl:
+		for _, c := range v.Geoms {
+			g.add(c)
+		}
+	}
+}
+
+// isEmpty returns whether the geometry has no points at all.
+func (g planarGeometry) isEmpty() bool {
+	return len(g.points) == 0 && len(g.lines) == 0 && len(g.polygons) == 0
+}
+
+// vertices returns every point of the geometry, including the vertices of its linestrings and polygons.
+func (g planarGeometry) vertices() []sql.Point {
+	points := append([]sql.Point(nil), g.points...)
+	for _, l := range g.lines {
+		points = append(points, l.Points...)
+	}
+	for _, p := range g.polygons {
+		for _, l := range p.Lines {
+			points = append(points, l.Points...)
+		}
+	}
+	return points
+}
+
+// lineSegments returns the segments of the linestrings of the geometry.
+func (g planarGeometry) lineSegments() []segment {
+	var segs []segment
+	for _, l := range g.lines {
+		segs = appendSegments(segs, l)
+	}
+	return segs
+}
+
+// ringSegments returns the segments of the rings of the polygons of the geometry.
+func (g planarGeometry) ringSegments() []segment {
+	var segs []segment
+	for _, p := range g.polygons {
+		for _, l := range p.Lines {
+			segs = appendSegments(segs, l)
+		}
+	}
+	return segs
+}
+
+// segments returns the segments of both the linestrings and the polygon rings of the geometry.
+func (g planarGeometry) segments() []segment {
+	return append(g.lineSegments(), g.ringSegments()...)
+}
+
+func appendSegments(segs []segment, l sql.LineString) []segment {
+	for i := 0; i < len(l.Points)-1; i++ {
+		if !samePoint(l.Points[i], l.Points[i+1]) {
+			segs = append(segs, segment{l.Points[i], l.Points[i+1]})
+		}
+	}
+	return segs
+}
+
+// lineBoundary returns the boundary of the linestrings of the geometry: the endpoints shared by an odd number of
+// linestrings that aren't closed.
+func (g planarGeometry) lineBoundary() []sql.Point {
+	var ends []sql.Point
+	for _, l := range g.lines {
+		first, last := l.Points[0], l.Points[len(l.Points)-1]
+		if !samePoint(first, last) {
+			ends = append(ends, first, last)
+		}
+	}
+
+	var boundary []sql.Point
+	for i, p := range ends {
+		count := 0
+		seen := false
+		for j, q := range ends {
+			if samePoint(p, q) {
+				count++
+				seen = seen || j < i
+			}
+		}
+		if count%2 == 1 && !seen {
+			boundary = append(boundary, p)
+		}
+	}
+	return boundary
+}
+
+// locate returns whether the point given lies in the closure of the geometry, and whether it lies in its interior.
+func (g planarGeometry) locate(p sql.Point) (inClosure bool, inInterior bool) {
+	for _, q := range g.points {
+		if samePoint(p, q) {
+			return true, true
+		}
+	}
+
+	for _, s := range g.lineSegments() {
+		if pointOnSegment(p, s) {
+			inClosure = true
+			inInterior = true
+			for _, b := range g.lineBoundary() {
+				if samePoint(p, b) {
+					inInterior = false
+				}
+			}
+			break
+		}
+	}
+
+	for _, poly := range g.polygons {
+		switch pointInPolygon(p, poly) {
+		case 0:
+			inClosure = true
+		case 1:
+			return true, true
+		}
+	}
+	return inClosure, inInterior
+}
+
+// inClosure returns whether the point given lies in the closure of the geometry.
+func (g planarGeometry) inClosure(p sql.Point) bool {
+	inClosure, _ := g.locate(p)
+	return inClosure
+}
+
+// inPolygonInterior returns whether the point given lies strictly inside one of the polygons of the geometry.
+func (g planarGeometry) inPolygonInterior(p sql.Point) bool {
+	for _, poly := range g.polygons {
+		if pointInPolygon(p, poly) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// intersects returns whether the two geometries share any point.
+func (g planarGeometry) intersects(o planarGeometry) bool {
+	for _, p := range g.points {
+		if o.inClosure(p) {
+			return true
+		}
+	}
+	for _, p := range o.points {
+		if g.inClosure(p) {
+			return true
+		}
+	}
+
+	oSegs := o.segments()
+	for _, s := range g.segments() {
+		for _, t := range oSegs {
+			if len(segmentIntersections(s, t)) > 0 {
+				return true
+			}
+		}
+	}
+
+	// Without crossing boundaries, a linestring or polygon can only be entirely inside a polygon of the other geometry
+	for _, p := range append(g.firstVertices(), o.firstVertices()...) {
+		if g.inClosure(p) && o.inClosure(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstVertices returns the first point of every linestring and polygon of the geometry.
+func (g planarGeometry) firstVertices() []sql.Point {
+	var points []sql.Point
+	for _, l := range g.lines {
+		points = append(points, l.Points[0])
+	}
+	for _, p := range g.polygons {
+		points = append(points, p.Lines[0].Points[0])
+	}
+	return points
+}
+
+// covers returns whether every point of the other geometry lies in the closure of this one.
+func (g planarGeometry) covers(o planarGeometry) bool {
+	if o.isEmpty() || g.isEmpty() {
+		return false
+	}
+
+	for _, p := range o.points {
+		if !g.inClosure(p) {
+			return false
+		}
+	}
+
+	segs := g.segments()
+	for _, s := range o.segments() {
+		if !g.inClosure(s.a) || !g.inClosure(s.b) {
+			return false
+		}
+		for _, piece := range splitSegment(s, segs) {
+			if !g.inClosure(midpoint(piece)) {
+				return false
+			}
+		}
+	}
+
+	for _, poly := range o.polygons {
+		if !g.inClosure(polygonInteriorPoint(poly)) {
+			return false
+		}
+		// Any part of a boundary of this geometry strictly inside the polygon leaves part of the polygon uncovered
+		polySegs := newPlanarGeometry(poly).segments()
+		for _, s := range g.ringSegments() {
+			for _, piece := range splitSegment(s, polySegs) {
+				if pointInPolygon(midpoint(piece), poly) != 1 {
+					continue
+				}
+				// Rings shared by two adjacent polygons have this geometry on both sides
+				left, right := sidePoints(piece)
+				if !g.inClosure(left) || !g.inClosure(right) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// interiorsIntersect returns whether the interiors of the two geometries share any point.
+func (g planarGeometry) interiorsIntersect(o planarGeometry) bool {
+	candidates := append(g.points, o.points...)
+	for _, l := range append(g.lines, o.lines...) {
+		candidates = append(candidates, l.Points...)
+	}
+	for _, p := range append(g.polygons, o.polygons...) {
+		candidates = append(candidates, polygonInteriorPoint(p))
+	}
+
+	gSegs, oSegs := g.segments(), o.segments()
+	for _, s := range gSegs {
+		for _, t := range oSegs {
+			candidates = append(candidates, segmentIntersections(s, t)...)
+		}
+	}
+	for _, s := range g.lineSegments() {
+		for _, piece := range splitSegment(s, oSegs) {
+			candidates = append(candidates, midpoint(piece))
+		}
+	}
+	for _, s := range o.lineSegments() {
+		for _, piece := range splitSegment(s, gSegs) {
+			candidates = append(candidates, midpoint(piece))
+		}
+	}
+
+	for _, c := range candidates {
+		if _, in := g.locate(c); in {
+			if _, in := o.locate(c); in {
+				return true
+			}
+		}
+	}
+
+	// A polygon boundary passing through the interior of the other polygon has the interior of its own polygon on one
+	// side, so the interiors intersect next to it
+	for _, s := range g.ringSegments() {
+		for _, piece := range splitSegment(s, oSegs) {
+			if o.inPolygonInterior(midpoint(piece)) {
+				return true
+			}
+		}
+	}
+	for _, s := range o.ringSegments() {
+		for _, piece := range splitSegment(s, gSegs) {
+			if g.inPolygonInterior(midpoint(piece)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// distance returns the smallest distance between the two geometries.
+func (g planarGeometry) distance(o planarGeometry) float64 {
+	if g.intersects(o) {
+		return 0
+	}
+
+	dist := math.Inf(1)
+	gSegs, oSegs := g.segments(), o.segments()
+	for _, p := range g.points {
+		for _, q := range o.points {
+			dist = math.Min(dist, pointDistance(p, q))
+		}
+		for _, t := range oSegs {
+			dist = math.Min(dist, pointSegmentDistance(p, t))
+		}
+	}
+	for _, s := range gSegs {
+		for _, q := range o.points {
+			dist = math.Min(dist, pointSegmentDistance(q, s))
+		}
+		for _, t := range oSegs {
+			dist = math.Min(dist, math.Min(
+				math.Min(pointSegmentDistance(s.a, t), pointSegmentDistance(s.b, t)),
+				math.Min(pointSegmentDistance(t.a, s), pointSegmentDistance(t.b, s)),
+			))
+		}
+	}
+	return dist
+}
+
+func samePoint(p, q sql.Point) bool {
+	return p.X == q.X && p.Y == q.Y
+}
+
+func midpoint(s segment) sql.Point {
+	return sql.Point{X: (s.a.X + s.b.X) / 2, Y: (s.a.Y + s.b.Y) / 2}
+}
+
+// sidePoints returns two points right next to the middle of the segment given, one on each side of it.
+func sidePoints(s segment) (sql.Point, sql.Point) {
+	m := midpoint(s)
+	dx, dy := (s.b.X-s.a.X)*1e-6, (s.b.Y-s.a.Y)*1e-6
+	return sql.Point{X: m.X - dy, Y: m.Y + dx}, sql.Point{X: m.X + dy, Y: m.Y - dx}
+}
+
+func pointDistance(p, q sql.Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+func pointSegmentDistance(p sql.Point, s segment) float64 {
+	t := segmentParam(p, s)
+	t = math.Max(0, math.Min(1, t))
+	return pointDistance(p, sql.Point{X: s.a.X + t*(s.b.X-s.a.X), Y: s.a.Y + t*(s.b.Y-s.a.Y)})
+}
+
+// segmentParam returns the position of the projection of the point given on the line through the segment, where 0 is
+// the start of the segment and 1 is its end.
+func segmentParam(p sql.Point, s segment) float64 {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	return ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / (dx*dx + dy*dy)
+}
+
+// orientation returns 1 if the points given turn counterclockwise, -1 if they turn clockwise and 0 if they are
+// collinear.
+func orientation(a, b, c sql.Point) int {
+	cross := (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+	scale := (math.Abs(b.X-a.X) + math.Abs(b.Y-a.Y)) * (math.Abs(c.X-a.X) + math.Abs(c.Y-a.Y))
+	switch {
+	case math.Abs(cross) <= spatialEpsilon*scale:
+		return 0
+	case cross > 0:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// pointOnSegment returns whether the point given lies on the segment given.
+func pointOnSegment(p sql.Point, s segment) bool {
+	return orientation(s.a, s.b, p) == 0 && inSegmentBox(p, s)
+}
+
+func inSegmentBox(p sql.Point, s segment) bool {
+	return p.X >= math.Min(s.a.X, s.b.X) && p.X <= math.Max(s.a.X, s.b.X) &&
+		p.Y >= math.Min(s.a.Y, s.b.Y) && p.Y <= math.Max(s.a.Y, s.b.Y)
+}
+
+// segmentIntersections returns the points the two segments share: none, the single point where they meet, or the
+// ends of the part they share if they overlap.
+func segmentIntersections(s, t segment) []sql.Point {
+	o1, o2 := orientation(s.a, s.b, t.a), orientation(s.a, s.b, t.b)
+	o3, o4 := orientation(t.a, t.b, s.a), orientation(t.a, t.b, s.b)
+
+	if o1 == 0 && o2 == 0 {
+		var points []sql.Point
+		for _, p := range []sql.Point{t.a, t.b} {
+			if inSegmentBox(p, s) {
+				points = appendUniquePoint(points, p)
+			}
+		}
+		for _, p := range []sql.Point{s.a, s.b} {
+			if inSegmentBox(p, t) {
+				points = appendUniquePoint(points, p)
+			}
+		}
+		return points
+	}
+
+	if o1 == o2 || o3 == o4 {
+		return nil
+	}
+	switch {
+	case o1 == 0:
+		return []sql.Point{t.a}
+	case o2 == 0:
+		return []sql.Point{t.b}
+	case o3 == 0:
+		return []sql.Point{s.a}
+	case o4 == 0:
+		return []sql.Point{s.b}
+	}
+
+	sx, sy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	tx, ty := t.b.X-t.a.X, t.b.Y-t.a.Y
+	u := ((t.a.X-s.a.X)*ty - (t.a.Y-s.a.Y)*tx) / (sx*ty - sy*tx)
+	return []sql.Point{{X: s.a.X + u*sx, Y: s.a.Y + u*sy}}
+}
+
+func appendUniquePoint(points []sql.Point, p sql.Point) []sql.Point {
+	for _, q := range points {
+		if samePoint(p, q) {
+			return points
+		}
+	}
+	return append(points, p)
+}
+
+// splitSegment splits the segment given at every point it shares with the other segments.
+func splitSegment(s segment, others []segment) []segment {
+	params := []float64{0, 1}
+	for _, t := range others {
+		for _, p := range segmentIntersections(s, t) {
+			params = append(params, segmentParam(p, s))
+		}
+	}
+	sort.Float64s(params)
+
+	var pieces []segment
+	for i := 0; i < len(params)-1; i++ {
+		if params[i+1]-params[i] <= spatialEpsilon {
+			continue
+		}
+		pieces = append(pieces, segment{pointAt(s, params[i]), pointAt(s, params[i+1])})
+	}
+	return pieces
+}
+
+func pointAt(s segment, t float64) sql.Point {
+	switch t {
+	case 0:
+		return s.a
+	case 1:
+		return s.b
+	}
+	return sql.Point{X: s.a.X + t*(s.b.X-s.a.X), Y: s.a.Y + t*(s.b.Y-s.a.Y)}
+}
+
+// pointInRing returns 1 if the point given lies inside the ring given, 0 if it lies on the ring and -1 otherwise.
+func pointInRing(p sql.Point, ring sql.LineString) int {
+	inside := false
+	for i := 0; i < len(ring.Points)-1; i++ {
+		a, b := ring.Points[i], ring.Points[i+1]
+		if pointOnSegment(p, segment{a, b}) {
+			return 0
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) {
+			x := a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if p.X < x {
+				inside = !inside
+			}
+		}
+	}
+	if inside {
+		return 1
+	}
+	return -1
+}
+
+// pointInPolygon returns 1 if the point given lies inside the polygon given, 0 if it lies on its boundary and -1
+// otherwise.
+func pointInPolygon(p sql.Point, poly sql.Polygon) int {
+	loc := pointInRing(p, poly.Lines[0])
+	if loc <= 0 {
+		return loc
+	}
+	for _, hole := range poly.Lines[1:] {
+		switch pointInRing(p, hole) {
+		case 0:
+			return 0
+		case 1:
+			return -1
+		}
+	}
+	return 1
+}
+
+// polygonInteriorPoint returns a point that lies inside the polygon given, in the middle of the widest span of the
+// polygon along a horizontal line through its middle.
+func polygonInteriorPoint(poly sql.Polygon) sql.Point {
+	var ys []float64
+	for _, l := range poly.Lines {
+		for _, p := range l.Points {
+			ys = append(ys, p.Y)
+		}
+	}
+	sort.Float64s(ys)
+	var distinct []float64
+	for i, y := range ys {
+		if i == 0 || y != ys[i-1] {
+			distinct = append(distinct, y)
+		}
+	}
+	if len(distinct) < 2 {
+		return poly.Lines[0].Points[0]
+	}
+	mid := len(distinct) / 2
+	y := (distinct[mid-1] + distinct[mid]) / 2
+
+	var xs []float64
+	for _, l := range poly.Lines {
+		for i := 0; i < len(l.Points)-1; i++ {
+			a, b := l.Points[i], l.Points[i+1]
+			if (a.Y > y) != (b.Y > y) {
+				xs = append(xs, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+			}
+		}
+	}
+	sort.Float64s(xs)
+	if len(xs) < 2 {
+		return poly.Lines[0].Points[0]
+	}
+
+	best := 0
+	for i := 0; i+1 < len(xs); i += 2 {
+		if xs[i+1]-xs[i] > xs[best+1]-xs[best] {
+			best = i
+		}
+	}
+	return sql.Point{X: (xs[best] + xs[best+1]) / 2, Y: y}
+}
+
+// evalGeometryArgs evaluates the two geometry arguments of the function with the name given, returning nil values if
+// either is NULL. Both geometries must have the same SRID.
+func evalGeometryArgs(ctx *sql.Context, row sql.Row, name string, left, right sql.Expression) (sql.GeometryValue, sql.GeometryValue, error) {
+	l, err := left.Eval(ctx, row)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := right.Eval(ctx, row)
+	if err != nil {
+		return nil, nil, err
+	}
+	if l == nil || r == nil {
+		return nil, nil, nil
+	}
+
+	g1, ok := l.(sql.GeometryValue)
+	if !ok {
+		return nil, nil, sql.ErrIllegalGISValue.New(l)
+	}
+	g2, ok := r.(sql.GeometryValue)
+	if !ok {
+		return nil, nil, sql.ErrIllegalGISValue.New(r)
+	}
+	if g1.GetSRID() != g2.GetSRID() {
+		return nil, nil, ErrDiffSRIDs.New(name, g1.GetSRID(), g2.GetSRID())
+	}
+	return g1, g2, nil
+}
+
+// evalCartesianGeometryArg evaluates the geometry argument of the function with the name given, returning nil if it
+// is NULL. Geometries in the geographic SRID are rejected.
+func evalCartesianGeometryArg(ctx *sql.Context, row sql.Row, name string, arg sql.Expression) (sql.GeometryValue, error) {
+	v, err := arg.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	g, ok := v.(sql.GeometryValue)
+	if !ok {
+		return nil, sql.ErrIllegalGISValue.New(v)
+	}
+	if g.GetSRID() == sql.GeoSpatialSRID {
+		return nil, ErrNotImplementedForGeographic.New(name)
+	}
+	return g, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// bufferPointsPerCircle is the number of points used to approximate a circle around a point.
+const bufferPointsPerCircle = 32
+
+// STBuffer is a function that returns a geometry representing all the points within a distance of a geometry.
+type STBuffer struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STBuffer)(nil)
+
+// NewSTBuffer creates a new STBuffer expression.
+func NewSTBuffer(g, d sql.Expression) sql.Expression {
+	return &STBuffer{expression.BinaryExpression{Left: g, Right: d}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STBuffer) FunctionName() string {
+	return "st_buffer"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STBuffer) Description() string {
+	return "returns a geometry that represents all points whose distance from the given geometry is less than or equal to the given distance."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STBuffer) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (s *STBuffer) String() string {
+	return fmt.Sprintf("ST_BUFFER(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STBuffer) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTBuffer(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STBuffer) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := evalCartesianGeometryArg(ctx, row, s.FunctionName(), s.Left)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	d, err := s.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, nil
+	}
+	d, err = sql.Float64.Convert(d)
+	if err != nil {
+		return nil, err
+	}
+	dist := d.(float64)
+
+	if dist == 0 {
+		return g, nil
+	}
+
+	srid := g.GetSRID()
+	pg := newPlanarGeometry(g)
+	if dist < 0 {
+		// Shrinking a geometry without area leaves nothing
+		if len(pg.polygons) == 0 {
+			return sql.GeomColl{SRID: srid, Geoms: []sql.GeometryValue{}}, nil
+		}
+		return nil, sql.ErrUnsupportedFeature.New("st_buffer with a negative distance on a polygon")
+	}
+
+	switch {
+	case len(pg.lines) == 0 && len(pg.polygons) == 0:
+		return bufferPoints(srid, pg.points, dist)
+	case isConvexGeometry(pg):
+		var points []sql.Point
+		for _, p := range pg.vertices() {
+			points = append(points, circlePoints(p, dist)...)
+		}
+		return hullGeometry(srid, convexHull(points)), nil
+	}
+	return nil, sql.ErrUnsupportedFeature.New("st_buffer on non-convex geometries")
+}
+
+// bufferPoints returns the buffer of the points given: a polygon for a single point, or a multipolygon of the circles
+// around every point if none of them overlap.
+func bufferPoints(srid uint32, points []sql.Point, dist float64) (interface{}, error) {
+	if len(points) == 0 {
+		return sql.GeomColl{SRID: srid, Geoms: []sql.GeometryValue{}}, nil
+	}
+
+	polygons := make([]sql.Polygon, len(points))
+	for i, p := range points {
+		for _, q := range points[:i] {
+			if pointDistance(p, q) <= 2*dist {
+				return nil, sql.ErrUnsupportedFeature.New("st_buffer on multipoints with overlapping buffers")
+			}
+		}
+		circle := circlePoints(p, dist)
+		for j := range circle {
+			circle[j].SRID = srid
+		}
+		circle = append(circle, circle[0])
+		polygons[i] = sql.Polygon{SRID: srid, Lines: []sql.LineString{{SRID: srid, Points: circle}}}
+	}
+
+	if len(polygons) == 1 {
+		return polygons[0], nil
+	}
+	return sql.MultiPolygon{SRID: srid, Polygons: polygons}, nil
+}
+
+// circlePoints returns the points of the polygon approximating the circle of the radius given around the point
+// given, clockwise starting from the point to its right.
+func circlePoints(p sql.Point, radius float64) []sql.Point {
+	points := make([]sql.Point, bufferPointsPerCircle)
+	for i := range points {
+		angle := -2 * math.Pi * float64(i) / bufferPointsPerCircle
+		sin, cos := math.Sincos(angle)
+		points[i] = sql.Point{X: p.X + radius*cos, Y: p.Y + radius*sin}
+	}
+	return points
+}
+
+// isConvexGeometry returns whether the geometry given is a single segment or a single convex polygon without holes,
+// whose buffer is the convex hull of the circles around its vertices.
+func isConvexGeometry(g planarGeometry) bool {
+	if len(g.points) > 0 || len(g.lines)+len(g.polygons) != 1 {
+		return false
+	}
+	if len(g.lines) == 1 {
+		return len(g.lineSegments()) <= 1
+	}
+	if len(g.polygons[0].Lines) != 1 {
+		return false
+	}
+
+	segs := g.ringSegments()
+	turn := 0
+	for i := range segs {
+		next := segs[(i+1)%len(segs)]
+		o := orientation(segs[i].a, segs[i].b, next.b)
+		if o == 0 {
+			continue
+		}
+		if turn != 0 && o != turn {
+			return false
+		}
+		turn = o
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSTBuffer(t *testing.T) {
+	t.Run("buffer of point", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTBuffer(expression.NewLiteral(sql.Point{X: 1, Y: 1}, sql.PointType{}), expression.NewLiteral(2, sql.Int64))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		polygon, ok := v.(sql.Polygon)
+		require.True(ok)
+		require.Len(polygon.Lines, 1)
+		points := polygon.Lines[0].Points
+		require.Len(points, bufferPointsPerCircle+1)
+		require.Equal(sql.Point{X: 3, Y: 1}, points[0])
+		require.Equal(points[0], points[len(points)-1])
+		for _, p := range points {
+			require.InDelta(2, pointDistance(p, sql.Point{X: 1, Y: 1}), 1e-12)
+		}
+	})
+
+	t.Run("buffer of square", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTBuffer(expression.NewLiteral(square(0, 0, 4), sql.PolygonType{}), expression.NewLiteral(1, sql.Int64))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		buffer := newPlanarGeometry(v.(sql.Polygon))
+		require.True(buffer.covers(newPlanarGeometry(square(0, -0.5, 4.5))))
+		require.True(buffer.inClosure(sql.Point{X: 2, Y: -0.99}))
+		require.False(buffer.inClosure(sql.Point{X: 2, Y: -1.01}))
+		require.False(buffer.inClosure(sql.Point{X: -0.9, Y: -0.9}))
+	})
+
+	t.Run("zero distance", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTBuffer(expression.NewLiteral(sql.Point{X: 1, Y: 1}, sql.PointType{}), expression.NewLiteral(0, sql.Int64))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 1}, v)
+	})
+
+	t.Run("negative distance on linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.LineString{Points: []sql.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+		f := NewSTBuffer(expression.NewLiteral(line, sql.LineStringType{}), expression.NewLiteral(-1, sql.Int64))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.GeomColl{Geoms: []sql.GeometryValue{}}, v)
+	})
+
+	t.Run("buffer of concave polygon", func(t *testing.T) {
+		require := require.New(t)
+		polygon := sql.Polygon{Lines: []sql.LineString{{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}, {X: 2, Y: 4}, {X: 0, Y: 0}}}}}
+		f := NewSTBuffer(expression.NewLiteral(polygon, sql.PolygonType{}), expression.NewLiteral(1, sql.Int64))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(sql.ErrUnsupportedFeature.Is(err))
+	})
+}
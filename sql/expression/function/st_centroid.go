@@ -0,0 +1,140 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// STCentroid is a function that returns the centroid of a geometry.
+type STCentroid struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*STCentroid)(nil)
+
+// NewSTCentroid creates a new STCentroid expression.
+func NewSTCentroid(arg sql.Expression) sql.Expression {
+	return &STCentroid{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STCentroid) FunctionName() string {
+	return "st_centroid"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STCentroid) Description() string {
+	return "returns the mathematical centroid of the given geometry as a point."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STCentroid) Type() sql.Type {
+	return sql.PointType{}
+}
+
+func (s *STCentroid) String() string {
+	return fmt.Sprintf("ST_CENTROID(%s)", s.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STCentroid) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSTCentroid(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STCentroid) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := evalCartesianGeometryArg(ctx, row, s.FunctionName(), s.Child)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	c, ok := centroid(newPlanarGeometry(g))
+	if !ok {
+		return nil, nil
+	}
+	c.SRID = g.GetSRID()
+	return c, nil
+}
+
+// centroid returns the centroid of the components of the highest dimension in the geometry given: the area weighted
+// centroid of its polygons, or else the length weighted centroid of its linestrings, or else the average of its
+// points. It returns false if the geometry is empty.
+func centroid(g planarGeometry) (sql.Point, bool) {
+	var x, y, weight float64
+	for _, poly := range g.polygons {
+		for i, l := range poly.Lines {
+			// Holes have the opposite sign of the exterior ring, whatever their orientation
+			sign := 1.0
+			if i > 0 {
+				sign = -1
+			}
+			area, cx, cy := ringCentroid(l)
+			x += sign * area * cx
+			y += sign * area * cy
+			weight += sign * area
+		}
+	}
+	if weight != 0 {
+		return sql.Point{X: x / weight, Y: y / weight}, true
+	}
+
+	for _, s := range g.lineSegments() {
+		length := pointDistance(s.a, s.b)
+		m := midpoint(s)
+		x += length * m.X
+		y += length * m.Y
+		weight += length
+	}
+	if weight != 0 {
+		return sql.Point{X: x / weight, Y: y / weight}, true
+	}
+
+	points := g.vertices()
+	if len(points) == 0 {
+		return sql.Point{}, false
+	}
+	for _, p := range points {
+		x += p.X
+		y += p.Y
+	}
+	return sql.Point{X: x / float64(len(points)), Y: y / float64(len(points))}, true
+}
+
+// ringCentroid returns the unsigned area of the ring given and the coordinates of its centroid.
+func ringCentroid(l sql.LineString) (float64, float64, float64) {
+	var area, cx, cy float64
+	for i := 0; i < len(l.Points)-1; i++ {
+		p1, p2 := l.Points[i], l.Points[i+1]
+		cross := p1.X*p2.Y - p2.X*p1.Y
+		area += cross
+		cx += (p1.X + p2.X) * cross
+		cy += (p1.Y + p2.Y) * cross
+	}
+	if area == 0 {
+		return 0, 0, 0
+	}
+	cx, cy = cx/(3*area), cy/(3*area)
+	if area < 0 {
+		area = -area
+	}
+	return area / 2, cx, cy
+}
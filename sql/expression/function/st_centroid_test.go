@@ -0,0 +1,78 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSTCentroid(t *testing.T) {
+	t.Run("centroid of square", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTCentroid(expression.NewLiteral(square(0, 0, 4), sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 2, Y: 2}, v)
+	})
+
+	t.Run("centroid of square with hole", func(t *testing.T) {
+		require := require.New(t)
+		polygon := sql.Polygon{Lines: []sql.LineString{square(0, 0, 4).Lines[0], square(0, 0, 2).Lines[0]}}
+		f := NewSTCentroid(expression.NewLiteral(polygon, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 7.0 / 3, Y: 7.0 / 3}, v)
+	})
+
+	t.Run("centroid of linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.LineString{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}}}
+		f := NewSTCentroid(expression.NewLiteral(line, sql.LineStringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 8.0 / 3, Y: 1.0 / 3}, v)
+	})
+
+	t.Run("centroid of geometry collection ignores lower dimensions", func(t *testing.T) {
+		require := require.New(t)
+		gc := sql.GeomColl{Geoms: []sql.GeometryValue{sql.Point{X: 100, Y: 100}, square(0, 0, 4)}}
+		f := NewSTCentroid(expression.NewLiteral(gc, sql.GeomCollType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 2, Y: 2}, v)
+	})
+
+	t.Run("centroid of multipoint", func(t *testing.T) {
+		require := require.New(t)
+		mp := sql.MultiPoint{SRID: 3857, Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 4}}}
+		f := NewSTCentroid(expression.NewLiteral(mp, sql.MultiPointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{SRID: 3857, X: 1, Y: 2}, v)
+	})
+
+	t.Run("centroid of empty geometry collection", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTCentroid(expression.NewLiteral(sql.GeomColl{}, sql.GeomCollType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// STConvexHull is a function that returns the convex hull of a geometry.
+type STConvexHull struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*STConvexHull)(nil)
+
+// NewSTConvexHull creates a new STConvexHull expression.
+func NewSTConvexHull(arg sql.Expression) sql.Expression {
+	return &STConvexHull{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STConvexHull) FunctionName() string {
+	return "st_convexhull"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STConvexHull) Description() string {
+	return "returns the convex hull of the given geometry."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STConvexHull) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (s *STConvexHull) String() string {
+	return fmt.Sprintf("ST_CONVEXHULL(%s)", s.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STConvexHull) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSTConvexHull(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STConvexHull) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := evalCartesianGeometryArg(ctx, row, s.FunctionName(), s.Child)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	hull := convexHull(newPlanarGeometry(g).vertices())
+	if len(hull) == 0 {
+		return nil, nil
+	}
+	return hullGeometry(g.GetSRID(), hull), nil
+}
+
+// convexHull returns the vertices of the convex hull of the points given, in clockwise order starting from the
+// lowest of the leftmost points, using Andrew's monotone chain algorithm. Collinear points are left out.
+func convexHull(points []sql.Point) []sql.Point {
+	sorted := make([]sql.Point, 0, len(points))
+	for _, p := range points {
+		sorted = append(sorted, sql.Point{X: p.X, Y: p.Y})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+
+	var distinct []sql.Point
+	for _, p := range sorted {
+		if len(distinct) == 0 || !samePoint(p, distinct[len(distinct)-1]) {
+			distinct = append(distinct, p)
+		}
+	}
+	if len(distinct) <= 2 {
+		return distinct
+	}
+
+	// The upper hull goes from left to right, then the lower hull from right to left, each turning clockwise only
+	chain := func(hull []sql.Point, p sql.Point, start int) []sql.Point {
+		for len(hull) >= start+2 && orientation(hull[len(hull)-2], hull[len(hull)-1], p) >= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		return append(hull, p)
+	}
+	var hull []sql.Point
+	for _, p := range distinct {
+		hull = chain(hull, p, 0)
+	}
+	upper := len(hull)
+	for i := len(distinct) - 2; i >= 0; i-- {
+		hull = chain(hull, distinct[i], upper-1)
+	}
+	// The last point is the first one again
+	hull = hull[:len(hull)-1]
+	if len(hull) == 2 {
+		return []sql.Point{distinct[0], distinct[len(distinct)-1]}
+	}
+	return hull
+}
+
+// hullGeometry returns the geometry with the SRID given that has the vertices of a convex hull: a point, a
+// linestring or a polygon.
+func hullGeometry(srid uint32, hull []sql.Point) sql.GeometryValue {
+	for i := range hull {
+		hull[i].SRID = srid
+	}
+	switch len(hull) {
+	case 1:
+		return hull[0]
+	case 2:
+		return sql.LineString{SRID: srid, Points: hull}
+	}
+	ring := append(hull, hull[0])
+	return sql.Polygon{SRID: srid, Lines: []sql.LineString{{SRID: srid, Points: ring}}}
+}
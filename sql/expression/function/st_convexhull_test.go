@@ -0,0 +1,61 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSTConvexHull(t *testing.T) {
+	t.Run("convex hull of multipoint", func(t *testing.T) {
+		require := require.New(t)
+		mp := sql.MultiPoint{Points: []sql.Point{{X: 5, Y: 0}, {X: 25, Y: 0}, {X: 15, Y: 10}, {X: 15, Y: 25}}}
+		f := NewSTConvexHull(expression.NewLiteral(mp, sql.MultiPointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.LineString{{Points: []sql.Point{{X: 5, Y: 0}, {X: 15, Y: 25}, {X: 25, Y: 0}, {X: 5, Y: 0}}}}}, v)
+	})
+
+	t.Run("convex hull of collinear points", func(t *testing.T) {
+		require := require.New(t)
+		mp := sql.MultiPoint{Points: []sql.Point{{X: 2, Y: 2}, {X: 0, Y: 0}, {X: 1, Y: 1}}}
+		f := NewSTConvexHull(expression.NewLiteral(mp, sql.MultiPointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.LineString{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 2}}}, v)
+	})
+
+	t.Run("convex hull of point", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTConvexHull(expression.NewLiteral(sql.Point{X: 1, Y: 1}, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 1}, v)
+	})
+
+	t.Run("convex hull of concave polygon", func(t *testing.T) {
+		require := require.New(t)
+		polygon := sql.Polygon{Lines: []sql.LineString{{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 0}, {X: 2, Y: 4}, {X: 0, Y: 0}}}}}
+		f := NewSTConvexHull(expression.NewLiteral(polygon, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.LineString{{Points: []sql.Point{{X: 0, Y: 0}, {X: 2, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 0}}}}}, v)
+	})
+}
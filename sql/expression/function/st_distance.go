@@ -0,0 +1,276 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// The semi-major axis and flattening of the WGS 84 ellipsoid, which SRID 4326 is defined on.
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+)
+
+// defaultSphereRadius is the radius of the sphere ST_DISTANCE_SPHERE uses when none is given, in meters.
+const defaultSphereRadius = 6370986.0
+
+// STDistance is a function that returns the minimum distance between two geometries.
+type STDistance struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STDistance)(nil)
+
+// NewSTDistance creates a new STDistance expression.
+func NewSTDistance(g1, g2 sql.Expression) sql.Expression {
+	return &STDistance{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STDistance) FunctionName() string {
+	return "st_distance"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STDistance) Description() string {
+	return "returns the distance between g1 and g2. For geographic geometries, the distance is in meters."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STDistance) Type() sql.Type {
+	return sql.Float64
+}
+
+func (s *STDistance) String() string {
+	return fmt.Sprintf("ST_DISTANCE(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STDistance) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTDistance(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STDistance) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g1, g2, err := evalGeometryArgs(ctx, row, s.FunctionName(), s.Left, s.Right)
+	if err != nil {
+		return nil, err
+	}
+	if g1 == nil || g2 == nil {
+		return nil, nil
+	}
+
+	p1, p2 := newPlanarGeometry(g1), newPlanarGeometry(g2)
+	if p1.isEmpty() || p2.isEmpty() {
+		return nil, nil
+	}
+
+	if g1.GetSRID() != sql.GeoSpatialSRID {
+		return p1.distance(p2), nil
+	}
+
+	// TODO: geographic distances involving linestrings and polygons
+	points1, ok1 := onlyPoints(p1)
+	points2, ok2 := onlyPoints(p2)
+	if !ok1 || !ok2 {
+		return nil, ErrNotImplementedForGeographic.New(s.FunctionName())
+	}
+	if err := validateGeographicPoints(s.FunctionName(), append(points1, points2...)); err != nil {
+		return nil, err
+	}
+	return minPointDistance(points1, points2, ellipsoidDistance), nil
+}
+
+// STDistanceSphere is a function that returns the minimum distance on a sphere between two points or multipoints.
+type STDistanceSphere struct {
+	expression.NaryExpression
+}
+
+var _ sql.FunctionExpression = (*STDistanceSphere)(nil)
+
+// NewSTDistanceSphere creates a new STDistanceSphere expression.
+func NewSTDistanceSphere(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_DISTANCE_SPHERE", "2 or 3", len(args))
+	}
+	return &STDistanceSphere{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STDistanceSphere) FunctionName() string {
+	return "st_distance_sphere"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STDistanceSphere) Description() string {
+	return "returns the minimum distance on a sphere between two points or multipoints, in meters. If given a radius argument, will use a sphere of that radius"
+}
+
+// Type implements the sql.Expression interface.
+func (s *STDistanceSphere) Type() sql.Type {
+	return sql.Float64
+}
+
+func (s *STDistanceSphere) String() string {
+	var args = make([]string, len(s.ChildExpressions))
+	for i, arg := range s.ChildExpressions {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("ST_DISTANCE_SPHERE(%s)", strings.Join(args, ","))
+}
+
+// WithChildren implements the Expression interface.
+func (s *STDistanceSphere) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewSTDistanceSphere(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STDistanceSphere) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g1, g2, err := evalGeometryArgs(ctx, row, s.FunctionName(), s.ChildExpressions[0], s.ChildExpressions[1])
+	if err != nil {
+		return nil, err
+	}
+	if g1 == nil || g2 == nil {
+		return nil, nil
+	}
+
+	radius := defaultSphereRadius
+	if len(s.ChildExpressions) == 3 {
+		r, err := s.ChildExpressions[2].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if r == nil {
+			return nil, nil
+		}
+		r, err = sql.Float64.Convert(r)
+		if err != nil {
+			return nil, err
+		}
+		radius = r.(float64)
+		if radius <= 0 {
+			return nil, sql.ErrInvalidArgument.New(s.FunctionName())
+		}
+	}
+
+	points1, ok1 := onlyPoints(newPlanarGeometry(g1))
+	points2, ok2 := onlyPoints(newPlanarGeometry(g2))
+	if !ok1 || !ok2 {
+		return nil, ErrUnsupportedSpatialArgument.New(s.FunctionName())
+	}
+	if len(points1) == 0 || len(points2) == 0 {
+		return nil, nil
+	}
+	if err := validateGeographicPoints(s.FunctionName(), append(points1, points2...)); err != nil {
+		return nil, err
+	}
+	return minPointDistance(points1, points2, func(p, q sql.Point) float64 {
+		return haversineDistance(p, q, radius)
+	}), nil
+}
+
+// onlyPoints returns the points of the geometry given, and whether the geometry consists of points only.
+func onlyPoints(g planarGeometry) ([]sql.Point, bool) {
+	return g.points, len(g.lines) == 0 && len(g.polygons) == 0
+}
+
+// validateGeographicPoints checks that the points given, whose X is a longitude and Y a latitude, are in range.
+func validateGeographicPoints(name string, points []sql.Point) error {
+	for _, p := range points {
+		if p.X <= -180 || p.X > 180 {
+			return ErrLongitudeOutOfRange.New(p.X, name)
+		}
+		if p.Y < -90 || p.Y > 90 {
+			return ErrLatitudeOutOfRange.New(p.Y, name)
+		}
+	}
+	return nil
+}
+
+func minPointDistance(points1, points2 []sql.Point, dist func(p, q sql.Point) float64) float64 {
+	min := math.Inf(1)
+	for _, p := range points1 {
+		for _, q := range points2 {
+			min = math.Min(min, dist(p, q))
+		}
+	}
+	return min
+}
+
+// haversineDistance returns the great circle distance between two points on a sphere of the radius given. The X of
+// the points is a longitude and their Y a latitude, both in degrees.
+func haversineDistance(p, q sql.Point, radius float64) float64 {
+	lat1, lat2 := p.Y*math.Pi/180, q.Y*math.Pi/180
+	dLat, dLon := lat2-lat1, (q.X-p.X)*math.Pi/180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// ellipsoidDistance returns the geodesic distance in meters between two points on the WGS 84 ellipsoid, using
+// Vincenty's inverse formula: https://en.wikipedia.org/wiki/Vincenty%27s_formulae. The X of the points is a longitude
+// and their Y a latitude, both in degrees.
+func ellipsoidDistance(p, q sql.Point) float64 {
+	if samePoint(p, q) {
+		return 0
+	}
+
+	a, f := wgs84SemiMajorAxis, wgs84Flattening
+	b := a * (1 - f)
+	l := (q.X - p.X) * math.Pi / 180
+	u1 := math.Atan((1 - f) * math.Tan(p.Y*math.Pi/180))
+	u2 := math.Atan((1 - f) * math.Tan(q.Y*math.Pi/180))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		c := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			break
+		}
+	}
+
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	bigA := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	bigB := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	return b * bigA * (sigma - deltaSigma)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSTDistance(t *testing.T) {
+	t.Run("distance between points", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTDistance(expression.NewLiteral(sql.Point{X: 0, Y: 0}, sql.PointType{}), expression.NewLiteral(sql.Point{X: 3, Y: 4}, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(5.0, v)
+	})
+
+	t.Run("distance between point and linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.LineString{Points: []sql.Point{{X: 1, Y: -1}, {X: 1, Y: 1}}}
+		f := NewSTDistance(expression.NewLiteral(sql.Point{X: 0, Y: 0}, sql.PointType{}), expression.NewLiteral(line, sql.LineStringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(1.0, v)
+	})
+
+	t.Run("distance between intersecting geometries", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTDistance(expression.NewLiteral(square(0, 0, 4), sql.PolygonType{}), expression.NewLiteral(sql.Point{X: 1, Y: 1}, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(0.0, v)
+	})
+
+	t.Run("geographic distance between points", func(t *testing.T) {
+		require := require.New(t)
+		p1 := sql.Point{SRID: sql.GeoSpatialSRID, X: 0, Y: 0}
+		p2 := sql.Point{SRID: sql.GeoSpatialSRID, X: 0, Y: 1}
+		f := NewSTDistance(expression.NewLiteral(p1, sql.PointType{}), expression.NewLiteral(p2, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.InDelta(110574.389, v, 1e-3)
+	})
+
+	t.Run("geographic distance to linestring", func(t *testing.T) {
+		require := require.New(t)
+		p := sql.Point{SRID: sql.GeoSpatialSRID, X: 0, Y: 0}
+		line := sql.LineString{SRID: sql.GeoSpatialSRID, Points: []sql.Point{{SRID: sql.GeoSpatialSRID, X: 1, Y: 1}, {SRID: sql.GeoSpatialSRID, X: 2, Y: 1}}}
+		f := NewSTDistance(expression.NewLiteral(p, sql.PointType{}), expression.NewLiteral(line, sql.LineStringType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrNotImplementedForGeographic.Is(err))
+	})
+}
+
+func TestSTDistanceSphere(t *testing.T) {
+	t.Run("distance on the default sphere", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewSTDistanceSphere(expression.NewLiteral(sql.Point{X: 0, Y: 0}, sql.PointType{}), expression.NewLiteral(sql.Point{X: 0, Y: 90}, sql.PointType{}))
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.InDelta(defaultSphereRadius*1.5707963267948966, v, 1e-6)
+	})
+
+	t.Run("distance on a unit sphere", func(t *testing.T) {
+		require := require.New(t)
+		mp := sql.MultiPoint{Points: []sql.Point{{X: 90, Y: 0}, {X: 180, Y: 0}}}
+		f, err := NewSTDistanceSphere(
+			expression.NewLiteral(sql.Point{X: 0, Y: 0}, sql.PointType{}),
+			expression.NewLiteral(mp, sql.MultiPointType{}),
+			expression.NewLiteral(1, sql.Int64),
+		)
+		require.NoError(err)
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.InDelta(1.5707963267948966, v, 1e-12)
+	})
+
+	t.Run("longitude out of range", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewSTDistanceSphere(expression.NewLiteral(sql.Point{X: 200, Y: 0}, sql.PointType{}), expression.NewLiteral(sql.Point{X: 0, Y: 0}, sql.PointType{}))
+		require.NoError(err)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrLongitudeOutOfRange.Is(err))
+	})
+
+	t.Run("linestring argument", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.LineString{Points: []sql.Point{{X: 1, Y: 1}, {X: 2, Y: 1}}}
+		f, err := NewSTDistanceSphere(expression.NewLiteral(line, sql.LineStringType{}), expression.NewLiteral(sql.Point{X: 0, Y: 0}, sql.PointType{}))
+		require.NoError(err)
+		_, err = f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrUnsupportedSpatialArgument.Is(err))
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		require := require.New(t)
+		_, err := NewSTDistanceSphere(expression.NewLiteral(sql.Point{}, sql.PointType{}))
+		require.Error(err)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// STEnvelope is a function that returns the minimum bounding rectangle of a geometry.
+type STEnvelope struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*STEnvelope)(nil)
+
+// NewSTEnvelope creates a new STEnvelope expression.
+func NewSTEnvelope(arg sql.Expression) sql.Expression {
+	return &STEnvelope{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STEnvelope) FunctionName() string {
+	return "st_envelope"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STEnvelope) Description() string {
+	return "returns the minimum bounding rectangle of the given geometry."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STEnvelope) Type() sql.Type {
+	return sql.GeometryType{}
+}
+
+func (s *STEnvelope) String() string {
+	return fmt.Sprintf("ST_ENVELOPE(%s)", s.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STEnvelope) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSTEnvelope(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STEnvelope) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := evalCartesianGeometryArg(ctx, row, s.FunctionName(), s.Child)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	box, ok := sql.BoundingBox(g)
+	if !ok {
+		return nil, nil
+	}
+	return envelope(g.GetSRID(), box), nil
+}
+
+// envelope returns the geometry with the SRID given that covers exactly the box given: a point or a linestring if
+// the box is degenerate, and a polygon otherwise.
+func envelope(srid uint32, box sql.SpatialBox) sql.GeometryValue {
+	min := sql.Point{SRID: srid, X: box.MinX, Y: box.MinY}
+	max := sql.Point{SRID: srid, X: box.MaxX, Y: box.MaxY}
+	switch {
+	case box.MinX == box.MaxX && box.MinY == box.MaxY:
+		return min
+	case box.MinX == box.MaxX || box.MinY == box.MaxY:
+		return sql.LineString{SRID: srid, Points: []sql.Point{min, max}}
+	}
+	return sql.Polygon{SRID: srid, Lines: []sql.LineString{{SRID: srid, Points: []sql.Point{
+		min,
+		{SRID: srid, X: box.MaxX, Y: box.MinY},
+		max,
+		{SRID: srid, X: box.MinX, Y: box.MaxY},
+		min,
+	}}}}
+}
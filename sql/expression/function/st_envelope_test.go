@@ -0,0 +1,59 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSTEnvelope(t *testing.T) {
+	t.Run("envelope of linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.LineString{Points: []sql.Point{{X: 1, Y: 1}, {X: 3, Y: 5}, {X: 2, Y: 0}}}
+		f := NewSTEnvelope(expression.NewLiteral(line, sql.LineStringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Polygon{Lines: []sql.LineString{{Points: []sql.Point{{X: 1, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 5}, {X: 1, Y: 5}, {X: 1, Y: 0}}}}}, v)
+	})
+
+	t.Run("envelope of point", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTEnvelope(expression.NewLiteral(sql.Point{X: 1, Y: 2}, sql.PointType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.Point{X: 1, Y: 2}, v)
+	})
+
+	t.Run("envelope of vertical linestring", func(t *testing.T) {
+		require := require.New(t)
+		line := sql.LineString{Points: []sql.Point{{X: 1, Y: 5}, {X: 1, Y: 1}}}
+		f := NewSTEnvelope(expression.NewLiteral(line, sql.LineStringType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal(sql.LineString{Points: []sql.Point{{X: 1, Y: 1}, {X: 1, Y: 5}}}, v)
+	})
+
+	t.Run("envelope of geographic geometry", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTEnvelope(expression.NewLiteral(sql.Point{SRID: sql.GeoSpatialSRID}, sql.PointType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrNotImplementedForGeographic.Is(err))
+	})
+}
@@ -0,0 +1,315 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// spatialRelation evaluates the two geometry arguments of a spatial relation function and applies the relation given
+// to them. The relation is computed on the coordinates of the geometries, also for geographic SRIDs.
+func spatialRelation(ctx *sql.Context, row sql.Row, name string, left, right sql.Expression, relation func(a, b planarGeometry) bool) (interface{}, error) {
+	g1, g2, err := evalGeometryArgs(ctx, row, name, left, right)
+	if err != nil {
+		return nil, err
+	}
+	if g1 == nil || g2 == nil {
+		return nil, nil
+	}
+	return relation(newPlanarGeometry(g1), newPlanarGeometry(g2)), nil
+}
+
+func contains(a, b planarGeometry) bool {
+	return a.covers(b) && a.interiorsIntersect(b)
+}
+
+// STContains is a function that returns whether the first geometry contains the second.
+type STContains struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STContains)(nil)
+
+// NewSTContains creates a new STContains expression.
+func NewSTContains(g1, g2 sql.Expression) sql.Expression {
+	return &STContains{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STContains) FunctionName() string {
+	return "st_contains"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STContains) Description() string {
+	return "returns 1 or 0 to indicate whether g1 completely contains g2."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STContains) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *STContains) String() string {
+	return fmt.Sprintf("ST_CONTAINS(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STContains) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTContains(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STContains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return spatialRelation(ctx, row, s.FunctionName(), s.Left, s.Right, contains)
+}
+
+// STWithin is a function that returns whether the first geometry is within the second.
+type STWithin struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STWithin)(nil)
+
+// NewSTWithin creates a new STWithin expression.
+func NewSTWithin(g1, g2 sql.Expression) sql.Expression {
+	return &STWithin{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STWithin) FunctionName() string {
+	return "st_within"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STWithin) Description() string {
+	return "returns 1 or 0 to indicate whether g1 is spatially within g2."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STWithin) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *STWithin) String() string {
+	return fmt.Sprintf("ST_WITHIN(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STWithin) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTWithin(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STWithin) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return spatialRelation(ctx, row, s.FunctionName(), s.Left, s.Right, func(a, b planarGeometry) bool {
+		return contains(b, a)
+	})
+}
+
+// STIntersects is a function that returns whether two geometries share any point.
+type STIntersects struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STIntersects)(nil)
+
+// NewSTIntersects creates a new STIntersects expression.
+func NewSTIntersects(g1, g2 sql.Expression) sql.Expression {
+	return &STIntersects{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STIntersects) FunctionName() string {
+	return "st_intersects"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STIntersects) Description() string {
+	return "returns 1 or 0 to indicate whether g1 spatially intersects g2."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STIntersects) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *STIntersects) String() string {
+	return fmt.Sprintf("ST_INTERSECTS(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STIntersects) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTIntersects(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STIntersects) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return spatialRelation(ctx, row, s.FunctionName(), s.Left, s.Right, planarGeometry.intersects)
+}
+
+// STDisjoint is a function that returns whether two geometries share no point.
+type STDisjoint struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STDisjoint)(nil)
+
+// NewSTDisjoint creates a new STDisjoint expression.
+func NewSTDisjoint(g1, g2 sql.Expression) sql.Expression {
+	return &STDisjoint{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STDisjoint) FunctionName() string {
+	return "st_disjoint"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STDisjoint) Description() string {
+	return "returns 1 or 0 to indicate whether g1 is spatially disjoint from g2."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STDisjoint) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *STDisjoint) String() string {
+	return fmt.Sprintf("ST_DISJOINT(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STDisjoint) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTDisjoint(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STDisjoint) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return spatialRelation(ctx, row, s.FunctionName(), s.Left, s.Right, func(a, b planarGeometry) bool {
+		return !a.intersects(b)
+	})
+}
+
+// STTouches is a function that returns whether two geometries touch: they share points, but their interiors don't.
+type STTouches struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STTouches)(nil)
+
+// NewSTTouches creates a new STTouches expression.
+func NewSTTouches(g1, g2 sql.Expression) sql.Expression {
+	return &STTouches{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STTouches) FunctionName() string {
+	return "st_touches"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STTouches) Description() string {
+	return "returns 1 or 0 to indicate whether g1 spatially touches g2."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STTouches) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *STTouches) String() string {
+	return fmt.Sprintf("ST_TOUCHES(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STTouches) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTTouches(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STTouches) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return spatialRelation(ctx, row, s.FunctionName(), s.Left, s.Right, func(a, b planarGeometry) bool {
+		// Two points have no boundary, so they can never touch
+		if len(a.lines) == 0 && len(a.polygons) == 0 && len(b.lines) == 0 && len(b.polygons) == 0 {
+			return false
+		}
+		return a.intersects(b) && !a.interiorsIntersect(b)
+	})
+}
+
+// STEquals is a function that returns whether two geometries are spatially equal.
+type STEquals struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*STEquals)(nil)
+
+// NewSTEquals creates a new STEquals expression.
+func NewSTEquals(g1, g2 sql.Expression) sql.Expression {
+	return &STEquals{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STEquals) FunctionName() string {
+	return "st_equals"
+}
+
+// Description implements sql.FunctionExpression
+func (s *STEquals) Description() string {
+	return "returns 1 or 0 to indicate whether g1 is spatially equal to g2."
+}
+
+// Type implements the sql.Expression interface.
+func (s *STEquals) Type() sql.Type {
+	return sql.Boolean
+}
+
+func (s *STEquals) String() string {
+	return fmt.Sprintf("ST_EQUALS(%s,%s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *STEquals) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSTEquals(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (s *STEquals) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return spatialRelation(ctx, row, s.FunctionName(), s.Left, s.Right, func(a, b planarGeometry) bool {
+		return a.covers(b) && b.covers(a)
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func square(srid uint32, min, max float64) sql.Polygon {
+	return sql.Polygon{SRID: srid, Lines: []sql.LineString{{SRID: srid, Points: []sql.Point{
+		{SRID: srid, X: min, Y: min}, {SRID: srid, X: min, Y: max}, {SRID: srid, X: max, Y: max}, {SRID: srid, X: max, Y: min}, {SRID: srid, X: min, Y: min},
+	}}}}
+}
+
+func TestSpatialRelations(t *testing.T) {
+	sq := square(0, 0, 4)
+	inner := square(0, 1, 2)
+	adjacent := sql.Polygon{Lines: []sql.LineString{{Points: []sql.Point{{X: 4, Y: 0}, {X: 4, Y: 4}, {X: 8, Y: 4}, {X: 8, Y: 0}, {X: 4, Y: 0}}}}}
+	holed := sql.Polygon{Lines: []sql.LineString{sq.Lines[0], inner.Lines[0]}}
+	diagonal := sql.LineString{Points: []sql.Point{{X: 0, Y: 0}, {X: 4, Y: 4}}}
+	edge := sql.LineString{Points: []sql.Point{{X: 0, Y: 0}, {X: 0, Y: 4}}}
+	crossing := sql.LineString{Points: []sql.Point{{X: 0, Y: 4}, {X: 4, Y: 0}}}
+	far := sql.LineString{Points: []sql.Point{{X: 10, Y: 10}, {X: 11, Y: 10}}}
+
+	tests := []struct {
+		name     string
+		f        func(g1, g2 sql.Expression) sql.Expression
+		g1, g2   sql.GeometryValue
+		expected interface{}
+	}{
+		{"polygon contains interior point", NewSTContains, sq, sql.Point{X: 1, Y: 1}, true},
+		{"polygon doesn't contain boundary point", NewSTContains, sq, sql.Point{X: 0, Y: 1}, false},
+		{"polygon contains smaller polygon", NewSTContains, sq, inner, true},
+		{"polygon doesn't contain polygon over its hole", NewSTContains, holed, square(0, 0, 3), false},
+		{"polygon contains diagonal", NewSTContains, sq, diagonal, true},
+		{"polygon doesn't contain its edge", NewSTContains, sq, edge, false},
+		{"point within polygon", NewSTWithin, sql.Point{X: 1, Y: 1}, sq, true},
+		{"polygon not within smaller polygon", NewSTWithin, sq, inner, false},
+		{"crossing lines intersect", NewSTIntersects, diagonal, crossing, true},
+		{"polygon intersects polygon inside it", NewSTIntersects, sq, inner, true},
+		{"polygon doesn't intersect point in its hole", NewSTIntersects, holed, sql.Point{X: 1.5, Y: 1.5}, false},
+		{"far line is disjoint", NewSTDisjoint, sq, far, true},
+		{"adjacent polygons aren't disjoint", NewSTDisjoint, sq, adjacent, false},
+		{"adjacent polygons touch", NewSTTouches, sq, adjacent, true},
+		{"line touches its endpoint", NewSTTouches, diagonal, sql.Point{X: 0, Y: 0}, true},
+		{"line doesn't touch interior point", NewSTTouches, diagonal, sql.Point{X: 1, Y: 1}, false},
+		{"points don't touch", NewSTTouches, sql.Point{X: 1, Y: 1}, sql.Point{X: 1, Y: 1}, false},
+		{"lines with different vertices are equal", NewSTEquals, diagonal, sql.LineString{Points: []sql.Point{{X: 4, Y: 4}, {X: 2, Y: 2}, {X: 0, Y: 0}}}, true},
+		{"different points aren't equal", NewSTEquals, sql.Point{X: 1, Y: 1}, sql.Point{X: 1, Y: 2}, false},
+		{"multipolygon contains polygon across its parts", NewSTContains, sql.MultiPolygon{Polygons: []sql.Polygon{sq, adjacent}}, sql.Polygon{Lines: []sql.LineString{{Points: []sql.Point{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 6, Y: 2}, {X: 6, Y: 1}, {X: 1, Y: 1}}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := tt.f(expression.NewLiteral(tt.g1, sql.GeometryType{}), expression.NewLiteral(tt.g2, sql.GeometryType{}))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	t.Run("null argument", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTContains(expression.NewLiteral(nil, sql.Null), expression.NewLiteral(sq, sql.PolygonType{}))
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Nil(v)
+	})
+
+	t.Run("different srids", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTIntersects(expression.NewLiteral(square(4326, 0, 4), sql.PolygonType{}), expression.NewLiteral(sq, sql.PolygonType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.True(ErrDiffSRIDs.Is(err))
+	})
+
+	t.Run("non geometry argument", func(t *testing.T) {
+		require := require.New(t)
+		f := NewSTWithin(expression.NewLiteral(1, sql.Int64), expression.NewLiteral(sq, sql.PolygonType{}))
+		_, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.Error(err)
+	})
+}
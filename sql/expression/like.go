@@ -30,6 +30,9 @@ func newDefaultLikeMatcher(likeStr string) (regex.DisposableMatcher, error) {
 	return regex.NewDisposableMatcher("go", likeStr)
 }
 
+// noLikeEscape is the escape character of patterns that have none, as it never matches a character of the pattern.
+const noLikeEscape rune = -1
+
 // Like performs pattern matching against two strings.
 type Like struct {
 	BinaryExpression
@@ -171,6 +174,10 @@ func (l *Like) evalRight(ctx *sql.Context, row sql.Row) (right *string, escape r
 	}
 
 	rightStr := rightVal.(string)
+	// An empty escape means that the pattern has no escape character
+	if escapeVal.(string) == "" {
+		return &rightStr, noLikeEscape, nil
+	}
 	return &rightStr, []rune(escapeVal.(string))[0], nil
 }
 
@@ -0,0 +1,168 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Match is a MATCH (...) AGAINST (...) expression, which evaluates to the relevance of a row to a full-text search of
+// its columns. The columns must be exactly the columns of a FULLTEXT index, which the analyzer finds and assigns with
+// WithIndex before the expression can be evaluated.
+type Match struct {
+	Columns []sql.Expression
+	Against sql.Expression
+	Mode    sql.FulltextMode
+	Index   sql.FulltextIndex
+	// ordinals holds the position among Columns of each expression of Index
+	ordinals []int
+}
+
+var _ sql.Expression = (*Match)(nil)
+
+// NewMatch returns a new Match expression.
+func NewMatch(columns []sql.Expression, against sql.Expression, mode sql.FulltextMode) *Match {
+	return &Match{
+		Columns: columns,
+		Against: against,
+		Mode:    mode,
+	}
+}
+
+// WithIndex returns a copy of this expression that scores rows with the index given. It returns false if the columns
+// of the expression aren't exactly the columns of the index, in any order.
+func (m *Match) WithIndex(idx sql.FulltextIndex) (*Match, bool) {
+	exprs := idx.Expressions()
+	if len(exprs) != len(m.Columns) {
+		return nil, false
+	}
+	ordinals := make([]int, len(exprs))
+	used := make([]bool, len(m.Columns))
+IndexExpressions:
+	for i, expr := range exprs {
+		name := expr
+		if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+			name = name[dot+1:]
+		}
+		for j, col := range m.Columns {
+			gf, ok := col.(*GetField)
+			if ok && !used[j] && strings.EqualFold(gf.Name(), name) {
+				used[j] = true
+				ordinals[i] = j
+				continue IndexExpressions
+			}
+		}
+		return nil, false
+	}
+	nm := *m
+	nm.Index = idx
+	nm.ordinals = ordinals
+	return &nm, true
+}
+
+// Search returns the search of this expression, which is only known before evaluation if the query is a literal.
+func (m *Match) Search() (sql.FulltextSearch, bool) {
+	lit, ok := m.Against.(*Literal)
+	if !ok {
+		return sql.FulltextSearch{}, false
+	}
+	query, err := sql.LongText.Convert(lit.Value())
+	if err != nil || query == nil {
+		return sql.FulltextSearch{}, false
+	}
+	return sql.FulltextSearch{Query: query.(string), Mode: m.Mode}, true
+}
+
+// Resolved implements the Expression interface.
+func (m *Match) Resolved() bool {
+	for _, col := range m.Columns {
+		if !col.Resolved() {
+			return false
+		}
+	}
+	return m.Against.Resolved()
+}
+
+// IsNullable implements the Expression interface.
+func (m *Match) IsNullable() bool {
+	return false
+}
+
+// Type implements the Expression interface.
+func (m *Match) Type() sql.Type {
+	return sql.Float64
+}
+
+// Children implements the Expression interface.
+func (m *Match) Children() []sql.Expression {
+	return append(append([]sql.Expression(nil), m.Columns...), m.Against)
+}
+
+// WithChildren implements the Expression interface.
+func (m *Match) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(m.Columns)+1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), len(m.Columns)+1)
+	}
+	nm := *m
+	nm.Columns = children[:len(m.Columns)]
+	nm.Against = children[len(m.Columns)]
+	return &nm, nil
+}
+
+// Eval implements the Expression interface.
+func (m *Match) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if m.Index == nil {
+		return nil, sql.ErrNoFulltextIndex.New()
+	}
+	query, err := m.Against.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if query == nil {
+		return float64(0), nil
+	}
+	query, err = sql.LongText.Convert(query)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(sql.Row, len(m.ordinals))
+	for i, ord := range m.ordinals {
+		values[i], err = m.Columns[ord].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m.Index.Relevance(ctx, sql.FulltextSearch{Query: query.(string), Mode: m.Mode}, values)
+}
+
+func (m *Match) String() string {
+	columns := make([]string, len(m.Columns))
+	for i, col := range m.Columns {
+		columns[i] = col.String()
+	}
+	return fmt.Sprintf("MATCH (%s) AGAINST (%s %s)", strings.Join(columns, ", "), m.Against, m.Mode)
+}
+
+func (m *Match) DebugString() string {
+	columns := make([]string, len(m.Columns))
+	for i, col := range m.Columns {
+		columns[i] = sql.DebugString(col)
+	}
+	return fmt.Sprintf("MATCH (%s) AGAINST (%s %s)", strings.Join(columns, ", "), sql.DebugString(m.Against), m.Mode)
+}
@@ -21,10 +21,34 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// ProcedureParamReference contains the references to the parameters for a single CALL statement.
+// ProcedureParamReference contains the references to the parameters, declared variables, cursors, and handlers for a
+// single CALL statement. Each BEGIN/END block within the stored procedure pushes a new scope, so that declarations are
+// only visible within the block that declared them (along with any nested blocks).
 type ProcedureParamReference struct {
-	nameToParam map[string]*procedureParamReferenceValue
+	innermostScope  *procedureScope
+	runningHandlers []runningHandler
 }
+
+// runningHandler represents a handler whose statement is executing. While it executes, the handler and the other
+// handlers of its scope are not active, so that only the handlers of the outer scopes, along with the handlers that
+// the statement declares itself, may handle the errors raised by the statement.
+type runningHandler struct {
+	// raisedScope is the innermost scope when the condition that the handler handles was raised.
+	raisedScope *procedureScope
+	// declaredScope is the scope that the handler was declared in.
+	declaredScope *procedureScope
+}
+
+// procedureScope represents a single BEGIN/END block's declarations. The outermost scope holds the procedure's
+// parameters.
+type procedureScope struct {
+	parent    *procedureScope
+	height    int
+	variables map[string]*procedureParamReferenceValue
+	cursors   map[string]*procedureCursorReferenceValue
+	handlers  []ProcedureHandler
+}
+
 type procedureParamReferenceValue struct {
 	Name       string
 	Value      interface{}
@@ -32,14 +56,59 @@ type procedureParamReferenceValue struct {
 	HasBeenSet bool
 }
 
+type procedureCursorReferenceValue struct {
+	Name       string
+	SelectStmt sql.Node
+}
+
+// ProcedureHandler is a handler that has been declared within a stored procedure, which is invoked when a statement
+// returns an error that the handler matches.
+type ProcedureHandler interface {
+	// MatchesError returns whether the handler should be invoked for the given error.
+	MatchesError(err error) bool
+}
+
+// ProcedureReferencable is a node that needs a *ProcedureParamReference to execute, and is therefore given the
+// reference that belongs to the CALL statement that is executing it.
+type ProcedureReferencable interface {
+	WithParamReference(pRef *ProcedureParamReference) sql.Node
+}
+
+func newProcedureScope(parent *procedureScope) *procedureScope {
+	height := 0
+	if parent != nil {
+		height = parent.height + 1
+	}
+	return &procedureScope{
+		parent:    parent,
+		height:    height,
+		variables: make(map[string]*procedureParamReferenceValue),
+		cursors:   make(map[string]*procedureCursorReferenceValue),
+	}
+}
+
 // Initialize sets the initial value for the parameter.
 func (ppr *ProcedureParamReference) Initialize(name string, sqlType sql.Type, val interface{}) error {
+	root := ppr.innermostScope
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root.initializeVariable(name, sqlType, val)
+}
+
+// InitializeVariable declares a variable in the innermost scope, setting its initial value.
+func (ppr *ProcedureParamReference) InitializeVariable(name string, sqlType sql.Type, val interface{}) error {
+	return ppr.innermostScope.initializeVariable(name, sqlType, val)
+}
+
+// initializeVariable sets the variable on this scope.
+func (ps *procedureScope) initializeVariable(name string, sqlType sql.Type, val interface{}) error {
 	name = strings.ToLower(name)
 	convertedVal, err := sqlType.Convert(val)
 	if err != nil {
 		return err
 	}
-	ppr.nameToParam[name] = &procedureParamReferenceValue{
+	ps.variables[name] = &procedureParamReferenceValue{
 		Name:       name,
 		Value:      convertedVal,
 		SqlType:    sqlType,
@@ -48,34 +117,56 @@ func (ppr *ProcedureParamReference) Initialize(name string, sqlType sql.Type, va
 	return nil
 }
 
-// Get returns the value of the given parameter. Name is case-insensitive.
-func (ppr *ProcedureParamReference) Get(name string) (interface{}, error) {
+// InitializeCursor declares a cursor in the innermost scope.
+func (ppr *ProcedureParamReference) InitializeCursor(name string, selectStmt sql.Node) {
 	name = strings.ToLower(name)
-	paramRefVal, ok := ppr.nameToParam[name]
+	ppr.innermostScope.cursors[name] = &procedureCursorReferenceValue{
+		Name:       name,
+		SelectStmt: selectStmt,
+	}
+}
+
+// InitializeHandler declares a handler in the innermost scope.
+func (ppr *ProcedureParamReference) InitializeHandler(handler ProcedureHandler) {
+	ppr.innermostScope.handlers = append(ppr.innermostScope.handlers, handler)
+}
+
+// getVariable returns the variable with the given name, searching from the innermost scope outward.
+func (ppr *ProcedureParamReference) getVariable(name string) (*procedureParamReferenceValue, bool) {
+	name = strings.ToLower(name)
+	for scope := ppr.innermostScope; scope != nil; scope = scope.parent {
+		if paramRefVal, ok := scope.variables[name]; ok {
+			return paramRefVal, true
+		}
+	}
+	return nil, false
+}
+
+// Get returns the value of the given parameter or variable. Name is case-insensitive.
+func (ppr *ProcedureParamReference) Get(name string) (interface{}, error) {
+	paramRefVal, ok := ppr.getVariable(name)
 	if !ok {
 		return nil, fmt.Errorf("cannot find value for parameter `%s`", name)
 	}
 	return paramRefVal.Value, nil
 }
 
-// GetType returns the type of the given parameter. Name is case-insensitive. Returns the NULL type if the type cannot
-// be found.
+// GetType returns the type of the given parameter or variable. Name is case-insensitive. Returns the NULL type if the
+// type cannot be found.
 func (ppr *ProcedureParamReference) GetType(name string) sql.Type {
 	if ppr == nil {
 		return sql.Null
 	}
-	name = strings.ToLower(name)
-	paramRefVal, ok := ppr.nameToParam[name]
+	paramRefVal, ok := ppr.getVariable(name)
 	if !ok {
 		return sql.Null
 	}
 	return paramRefVal.SqlType
 }
 
-// Set updates the value of the given parameter. Name is case-insensitive.
+// Set updates the value of the given parameter or variable. Name is case-insensitive.
 func (ppr *ProcedureParamReference) Set(name string, val interface{}, valType sql.Type) error {
-	name = strings.ToLower(name)
-	paramRefVal, ok := ppr.nameToParam[name]
+	paramRefVal, ok := ppr.getVariable(name)
 	if !ok {
 		return fmt.Errorf("cannot find value for parameter `%s`", name)
 	}
@@ -91,16 +182,74 @@ func (ppr *ProcedureParamReference) Set(name string, val interface{}, valType sq
 
 // HasBeenSet returns whether the parameter has had its value altered from the initial value.
 func (ppr *ProcedureParamReference) HasBeenSet(name string) bool {
-	name = strings.ToLower(name)
-	paramRefVal, ok := ppr.nameToParam[name]
+	paramRefVal, ok := ppr.getVariable(name)
 	if !ok {
 		return false
 	}
 	return paramRefVal.HasBeenSet
 }
 
+// FindHandler returns the handler that matches the given error, searching from the innermost scope outward. Also
+// returns the height of the scope that the handler was declared in, which is needed by EXIT handlers. Returns a nil
+// handler if none match.
+func (ppr *ProcedureParamReference) FindHandler(err error) (ProcedureHandler, int) {
+	if ppr == nil {
+		return nil, 0
+	}
+	for scope := ppr.innermostScope; scope != nil; scope = scope.parent {
+		// The scopes from where a running handler's condition was raised up to where the handler was declared are
+		// skipped, as their handlers are inactive while the running handler executes
+		for i := len(ppr.runningHandlers) - 1; i >= 0 && scope != nil; i-- {
+			if ppr.runningHandlers[i].raisedScope == scope {
+				scope = ppr.runningHandlers[i].declaredScope.parent
+				i = len(ppr.runningHandlers)
+			}
+		}
+		if scope == nil {
+			break
+		}
+		for _, handler := range scope.handlers {
+			if handler.MatchesError(err) {
+				return handler, scope.height
+			}
+		}
+	}
+	return nil, 0
+}
+
+// StartHandler marks the handler declared in the scope with the given height as running, which deactivates the
+// handlers of that scope and of the scopes within it until EndHandler is called.
+func (ppr *ProcedureParamReference) StartHandler(scopeHeight int) {
+	declaredScope := ppr.innermostScope
+	for declaredScope.parent != nil && declaredScope.height != scopeHeight {
+		declaredScope = declaredScope.parent
+	}
+	ppr.runningHandlers = append(ppr.runningHandlers, runningHandler{
+		raisedScope:   ppr.innermostScope,
+		declaredScope: declaredScope,
+	})
+}
+
+// EndHandler marks the most recently started handler as no longer running.
+func (ppr *ProcedureParamReference) EndHandler() {
+	ppr.runningHandlers = ppr.runningHandlers[:len(ppr.runningHandlers)-1]
+}
+
+// PushScope creates a new scope for declarations, returning the height of the new scope.
+func (ppr *ProcedureParamReference) PushScope() int {
+	ppr.innermostScope = newProcedureScope(ppr.innermostScope)
+	return ppr.innermostScope.height
+}
+
+// PopScope removes the innermost scope.
+func (ppr *ProcedureParamReference) PopScope() {
+	if ppr.innermostScope.parent != nil {
+		ppr.innermostScope = ppr.innermostScope.parent
+	}
+}
+
 func NewProcedureParamReference() *ProcedureParamReference {
-	return &ProcedureParamReference{make(map[string]*procedureParamReferenceValue)}
+	return &ProcedureParamReference{innermostScope: newProcedureScope(nil)}
 }
 
 // ProcedureParam represents the parameter of a stored procedure or stored function.
@@ -0,0 +1,58 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import "fmt"
+
+// FulltextMode is the search modifier of a MATCH ... AGAINST expression.
+type FulltextMode byte
+
+const (
+	// FulltextNaturalLanguageMode searches for any of the words of the query, which is interpreted as free text.
+	FulltextNaturalLanguageMode FulltextMode = iota
+	// FulltextBooleanMode searches for rows that satisfy the operators of the query, such as required words (+),
+	// excluded words (-), prefixes (*) and phrases ("").
+	FulltextBooleanMode
+)
+
+func (m FulltextMode) String() string {
+	switch m {
+	case FulltextBooleanMode:
+		return "IN BOOLEAN MODE"
+	default:
+		return "IN NATURAL LANGUAGE MODE"
+	}
+}
+
+// FulltextSearch is a search of a FULLTEXT index, as given by the AGAINST clause of a MATCH expression.
+type FulltextSearch struct {
+	Query string
+	Mode  FulltextMode
+}
+
+func (s FulltextSearch) String() string {
+	return fmt.Sprintf("AGAINST ('%s' %s)", s.Query, s.Mode)
+}
+
+// FulltextIndex is an extension of |Index| for FULLTEXT indexes, which index the words of one or more text columns.
+// A FULLTEXT index can't be used for range lookups. Instead, it's given IndexLookups with a Fulltext search, for
+// which it must return exactly the rows that match the search, and it scores the relevance of rows to a search for
+// MATCH expressions.
+type FulltextIndex interface {
+	Index
+	// Relevance returns the relevance of a row to the search, given the values of the indexed columns of the row in
+	// the order of Expressions(). The relevance of rows that don't match the search is zero.
+	Relevance(ctx *Context, search FulltextSearch, values Row) (float64, error)
+}
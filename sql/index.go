@@ -58,6 +58,10 @@ type IndexLookup struct {
 	// exact equality.
 	IsPointLookup bool
 	IsEmptyRange  bool
+	// Fulltext is the search of a lookup on a FulltextIndex, which has no ranges. It is nil for every other lookup.
+	Fulltext *FulltextSearch
+	// Spatial is the bounding box of a lookup on a SpatialIndex, which has no ranges. It is nil for every other lookup.
+	Spatial *SpatialBox
 }
 
 var emptyLookup = IndexLookup{}
@@ -69,6 +73,14 @@ func (il IndexLookup) IsEmpty() bool {
 func (il IndexLookup) String() string {
 	pr := NewTreePrinter()
 	_ = pr.WriteNode("IndexLookup")
+	if il.Fulltext != nil {
+		pr.WriteChildren(fmt.Sprintf("index: %s", il.Index), fmt.Sprintf("search: %s", il.Fulltext))
+		return pr.String()
+	}
+	if il.Spatial != nil {
+		pr.WriteChildren(fmt.Sprintf("index: %s", il.Index), fmt.Sprintf("box: %s", il.Spatial))
+		return pr.String()
+	}
 	pr.WriteChildren(fmt.Sprintf("index: %s", il.Index), fmt.Sprintf("ranges: %s", il.Ranges.String()))
 	return pr.String()
 }
@@ -76,6 +88,14 @@ func (il IndexLookup) String() string {
 func (il IndexLookup) DebugString() string {
 	pr := NewTreePrinter()
 	_ = pr.WriteNode("IndexLookup")
+	if il.Fulltext != nil {
+		pr.WriteChildren(fmt.Sprintf("index: %s", il.Index), fmt.Sprintf("search: %s", il.Fulltext))
+		return pr.String()
+	}
+	if il.Spatial != nil {
+		pr.WriteChildren(fmt.Sprintf("index: %s", il.Index), fmt.Sprintf("box: %s", il.Spatial))
+		return pr.String()
+	}
 	pr.WriteChildren(fmt.Sprintf("index: %s", il.Index), fmt.Sprintf("ranges: %s", il.Ranges.DebugString()))
 	return pr.String()
 }
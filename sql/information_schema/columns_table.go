@@ -240,6 +240,10 @@ func (c *ColumnsTable) columnsRowIter(ctx *sql.Context) (sql.RowIter, error) {
 				tableName := t.Name()
 
 				columnDefault := getColumnDefault(ctx, col.Default)
+				genExpr := ""
+				if col.Generated != nil {
+					genExpr = col.Generated.Expression.String()
+				}
 				rows = append(rows, sql.Row{
 					"def",            // table_catalog
 					db.Name(),        // table_schema
@@ -261,7 +265,7 @@ func (c *ColumnsTable) columnsRowIter(ctx *sql.Context) (sql.RowIter, error) {
 					col.Extra,        // extra
 					"select",         // privileges
 					col.Comment,      // column_comment
-					"",               // generation_expression
+					genExpr,          // generation_expression
 					srsId,            // srs_id
 				})
 			}
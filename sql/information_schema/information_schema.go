@@ -962,58 +962,60 @@ func statisticsRowIter(ctx *Context, c Catalog) (RowIter, error) {
 					// Create a Row for each column this index refers too.
 					i := 0
 					for _, expr := range index.Expressions() {
-						col := plan.GetColumnFromIndexExpr(expr, tbl)
-						if col != nil {
-							i += 1
-							var (
-								collation   string
-								nullable    string
-								cardinality int64
-							)
-
-							seqInIndex := i
-							colName := strings.Replace(col.Name, "`", "", -1) // get rid of backticks
-
-							// collation is "A" for ASC ; "D" for DESC ; "NULL" for not sorted
-							collation = "A"
-
-							// TODO : cardinality should be an estimate of the number of unique values in the index.
-							// it is currently set to total number of rows in the table
-							if st, ok := tbl.(StatisticsTable); ok {
-								cardinality, err = getTotalNumRows(ctx, st)
-								if err != nil {
-									return nil, err
-								}
-							}
-
+						i += 1
+						var (
+							collation   string
+							nullable    string
+							cardinality int64
+							colName     interface{}
+							expression  interface{}
+						)
+
+						seqInIndex := i
+						// functional key parts have no column, but an expression instead
+						if col := plan.GetColumnFromIndexExpr(expr, tbl); col != nil {
+							colName = strings.Replace(col.Name, "`", "", -1) // get rid of backticks
 							// if nullable, 'YES'; if not, ''
 							if col.Nullable {
 								nullable = "YES"
-							} else {
-								nullable = ""
 							}
+						} else {
+							expression = plan.IndexExpressionString(expr, tbl)
+							nullable = "YES"
+						}
 
-							rows = append(rows, Row{
-								"def",        // table_catalog
-								db.Name(),    // table_schema
-								tbl.Name(),   // table_name
-								nonUnique,    // non_unique		NOT NULL
-								db.Name(),    // index_schema
-								indexName,    // index_name
-								seqInIndex,   // seq_in_index	NOT NULL
-								colName,      // column_name
-								collation,    // collation
-								cardinality,  // cardinality
-								nil,          // sub_part
-								nil,          // packed
-								nullable,     // is_nullable	NOT NULL
-								indexType,    // index_type		NOT NULL
-								comment,      // comment		NOT NULL
-								indexComment, // index_comment	NOT NULL
-								isVisible,    // is_visible		NOT NULL
-								nil,          // expression
-							})
+						// collation is "A" for ASC ; "D" for DESC ; "NULL" for not sorted
+						collation = "A"
+
+						// TODO : cardinality should be an estimate of the number of unique values in the index.
+						// it is currently set to total number of rows in the table
+						if st, ok := tbl.(StatisticsTable); ok {
+							cardinality, err = getTotalNumRows(ctx, st)
+							if err != nil {
+								return nil, err
+							}
 						}
+
+						rows = append(rows, Row{
+							"def",        // table_catalog
+							db.Name(),    // table_schema
+							tbl.Name(),   // table_name
+							nonUnique,    // non_unique		NOT NULL
+							db.Name(),    // index_schema
+							indexName,    // index_name
+							seqInIndex,   // seq_in_index	NOT NULL
+							colName,      // column_name
+							collation,    // collation
+							cardinality,  // cardinality
+							nil,          // sub_part
+							nil,          // packed
+							nullable,     // is_nullable	NOT NULL
+							indexType,    // index_type		NOT NULL
+							comment,      // comment		NOT NULL
+							indexComment, // index_comment	NOT NULL
+							isVisible,    // is_visible		NOT NULL
+							expression,   // expression
+						})
 					}
 				}
 			}
@@ -15,6 +15,7 @@
 package sql
 
 import (
+	"bytes"
 	"encoding/json"
 	"reflect"
 
@@ -61,22 +62,28 @@ func (t jsonType) Convert(v interface{}) (doc interface{}, err error) {
 		if int64(len(v)) > MaxJsonFieldByteLength {
 			return nil, ErrLengthTooLarge.New(len(v), MaxJsonFieldByteLength)
 		}
-		err = json.Unmarshal(v, &doc)
+		doc, err = unmarshalJSON(v)
 	case string:
 		charsetMaxLength := Collation_Default.CharacterSet().MaxLength()
 		length := int64(len(v)) * charsetMaxLength
 		if length > MaxJsonFieldByteLength {
 			return nil, ErrLengthTooLarge.New(length, MaxJsonFieldByteLength)
 		}
-		err = json.Unmarshal([]byte(v), &doc)
+		doc, err = unmarshalJSON([]byte(v))
+	case nil, bool, []interface{}, map[string]interface{}:
+		doc = normalizeJSONNumbers(v)
 	default:
+		if isJSONNumber(jsonScalar(v)) {
+			doc = jsonScalar(v)
+			break
+		}
 		// if |v| can be marshalled, it contains
 		// a valid JSON document representation
 		if b, berr := json.Marshal(v); berr == nil {
 			if int64(len(b)) > MaxJsonFieldByteLength {
 				return nil, ErrLengthTooLarge.New(len(b), MaxJsonFieldByteLength)
 			}
-			err = json.Unmarshal(b, &doc)
+			doc, err = unmarshalJSON(b)
 		}
 	}
 	if err != nil {
@@ -85,6 +92,21 @@ func (t jsonType) Convert(v interface{}) (doc interface{}, err error) {
 	return JSONDocument{Val: doc}, nil
 }
 
+// unmarshalJSON parses JSON text, keeping the precision of its numbers (see json_number.go).
+func unmarshalJSON(data []byte) (interface{}, error) {
+	var doc interface{}
+	if !json.Valid(data) {
+		// Unmarshal reports the syntax error
+		return nil, json.Unmarshal(data, &doc)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return decodeJSONNumbers(doc)
+}
+
 // Equals implements the Type interface.
 func (t jsonType) Equals(otherType Type) bool {
 	_, ok := otherType.(jsonType)
@@ -167,11 +189,8 @@ func DeepCopyJson(v interface{}) interface{} {
 			newArray[i] = DeepCopyJson(doc)
 		}
 		return newArray
-	case bool, string, float64, float32,
-		int, int8, int16, int32, int64,
-		uint, uint8, uint16, uint32, uint64:
-		return v
 	default:
-		return nil
+		// Scalars are immutable, so they can be shared
+		return v
 	}
 }
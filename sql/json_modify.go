@@ -0,0 +1,216 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// The methods in this file implement the JSON modification functions. They modify the document in place wherever
+// they can, so callers must pass a copy (see DeepCopyJson) of any document they don't own. None of them accept a path
+// that contains a wildcard or an array range.
+
+// Set sets the value at the path given, adding it to the document if the path doesn't exist, and returns the
+// resulting document. A new value is only added as a member of an existing object or past the end of an existing
+// array, autowrapping a non-array value as an array as needed.
+func (doc JSONDocument) Set(path JSONPath, val interface{}) (JSONDocument, error) {
+	return doc.updateAt(path, func(_ interface{}, _ bool) (interface{}, bool) {
+		return val, true
+	})
+}
+
+// Insert adds the value at the path given if the path doesn't exist, and returns the resulting document. Existing
+// values are left unchanged.
+func (doc JSONDocument) Insert(path JSONPath, val interface{}) (JSONDocument, error) {
+	return doc.updateAt(path, func(_ interface{}, exists bool) (interface{}, bool) {
+		return val, !exists
+	})
+}
+
+// Replace replaces the value at the path given if the path exists, and returns the resulting document.
+func (doc JSONDocument) Replace(path JSONPath, val interface{}) (JSONDocument, error) {
+	return doc.updateAt(path, func(_ interface{}, exists bool) (interface{}, bool) {
+		return val, exists
+	})
+}
+
+// ArrayAppend appends the value to the array at the path given, and returns the resulting document. A non-array value
+// at the path is autowrapped as an array first.
+func (doc JSONDocument) ArrayAppend(path JSONPath, val interface{}) (JSONDocument, error) {
+	return doc.updateAt(path, func(cur interface{}, exists bool) (interface{}, bool) {
+		if !exists {
+			return nil, false
+		}
+		if arr, ok := cur.([]interface{}); ok {
+			return append(arr, val), true
+		}
+		return []interface{}{cur, val}, true
+	})
+}
+
+// ArrayInsert inserts the value into an array at the position the path given ends in, shifting the following elements
+// to the right, and returns the resulting document. A position past the end of the array appends to it.
+func (doc JSONDocument) ArrayInsert(path JSONPath, val interface{}) (JSONDocument, error) {
+	if path.ContainsWildcard() {
+		return doc, ErrInvalidJSONPathWildcard.New()
+	}
+	if path.IsRoot() || path.legs[len(path.legs)-1].typ != jsonPathArrayIndex {
+		return doc, ErrInvalidJSONPathArrayCell.New()
+	}
+	idx := path.legs[len(path.legs)-1].index
+	parent := JSONPath{legs: path.legs[:len(path.legs)-1]}
+	return doc.updateAt(parent, func(cur interface{}, exists bool) (interface{}, bool) {
+		arr, ok := cur.([]interface{})
+		if !exists || !ok {
+			return nil, false
+		}
+		i := idx.resolve(len(arr))
+		if i < 0 {
+			i = 0
+		}
+		if i >= len(arr) {
+			return append(arr, val), true
+		}
+		arr = append(arr, nil)
+		copy(arr[i+1:], arr[i:])
+		arr[i] = val
+		return arr, true
+	})
+}
+
+// Remove removes the value at the path given if it exists, and returns the resulting document.
+func (doc JSONDocument) Remove(path JSONPath) (JSONDocument, error) {
+	if path.ContainsWildcard() {
+		return doc, ErrInvalidJSONPathWildcard.New()
+	}
+	if path.IsRoot() {
+		return doc, ErrJSONVacuousPath.New()
+	}
+	last := path.legs[len(path.legs)-1]
+	parent := JSONPath{legs: path.legs[:len(path.legs)-1]}
+	return doc.updateAt(parent, func(cur interface{}, exists bool) (interface{}, bool) {
+		if !exists {
+			return nil, false
+		}
+		switch cur := cur.(type) {
+		case map[string]interface{}:
+			if last.typ != jsonPathMember {
+				return nil, false
+			}
+			delete(cur, last.key)
+			return cur, true
+		case []interface{}:
+			if last.typ != jsonPathArrayIndex {
+				return nil, false
+			}
+			i := last.index.resolve(len(cur))
+			if i < 0 || i >= len(cur) {
+				return nil, false
+			}
+			return append(cur[:i], cur[i+1:]...), true
+		default:
+			return nil, false
+		}
+	})
+}
+
+// MergePatch merges the patch given into the document as described by RFC 7396, and returns the resulting document.
+// Members of the patch whose value is JSON null are removed from the document, and all others replace the matching
+// members of the document, recursively merging objects.
+func (doc JSONDocument) MergePatch(patch JSONDocument) JSONDocument {
+	return JSONDocument{Val: mergePatchJSON(doc.Val, patch.Val)}
+}
+
+func mergePatchJSON(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{}, len(patchObj))
+	}
+	for key, val := range patchObj {
+		if val == nil {
+			delete(targetObj, key)
+		} else {
+			targetObj[key] = mergePatchJSON(targetObj[key], val)
+		}
+	}
+	return targetObj
+}
+
+// jsonUpdateFunc computes the new value for a location in a document from its current value, if it exists. It returns
+// false if the document shouldn't be changed.
+type jsonUpdateFunc func(cur interface{}, exists bool) (interface{}, bool)
+
+// updateAt calls the update function given for the location the path identifies, and returns the document with the
+// location set to the value it returns.
+func (doc JSONDocument) updateAt(path JSONPath, update jsonUpdateFunc) (JSONDocument, error) {
+	if path.ContainsWildcard() {
+		return doc, ErrInvalidJSONPathWildcard.New()
+	}
+	return JSONDocument{Val: updateJSON(doc.Val, path.legs, update)}, nil
+}
+
+func updateJSON(cur interface{}, legs []jsonPathLeg, update jsonUpdateFunc) interface{} {
+	if len(legs) == 0 {
+		if val, ok := update(cur, true); ok {
+			return val
+		}
+		return cur
+	}
+
+	leg := legs[0]
+	switch leg.typ {
+	case jsonPathMember:
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return cur
+		}
+		if child, ok := obj[leg.key]; ok {
+			obj[leg.key] = updateJSON(child, legs[1:], update)
+		} else if len(legs) == 1 {
+			if val, ok := update(nil, false); ok {
+				obj[leg.key] = val
+			}
+		}
+		return obj
+
+	case jsonPathArrayIndex:
+		// A value that isn't an array is treated as an array holding just that value
+		arr, isArray := cur.([]interface{})
+		if !isArray {
+			arr = []interface{}{cur}
+		}
+		i := leg.index.resolve(len(arr))
+		if i < 0 {
+			return cur
+		}
+		if i < len(arr) {
+			child := updateJSON(arr[i], legs[1:], update)
+			if !isArray {
+				return child
+			}
+			arr[i] = child
+			return arr
+		}
+		if len(legs) == 1 {
+			if val, ok := update(nil, false); ok {
+				return append(arr, val)
+			}
+		}
+		return cur
+
+	default:
+		return cur
+	}
+}
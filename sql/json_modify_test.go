@@ -0,0 +1,139 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDocumentModify(t *testing.T) {
+	type modifyFunc func(doc JSONDocument, path JSONPath, val interface{}) (JSONDocument, error)
+	remove := func(doc JSONDocument, path JSONPath, _ interface{}) (JSONDocument, error) {
+		return doc.Remove(path)
+	}
+
+	tests := []struct {
+		name     string
+		fn       modifyFunc
+		doc      string
+		path     string
+		val      interface{}
+		expected string
+	}{
+		{"set member", JSONDocument.Set, `{"a": 1}`, `$.a`, "x", `{"a": "x"}`},
+		{"set new member", JSONDocument.Set, `{"a": 1}`, `$.b`, "x", `{"a": 1, "b": "x"}`},
+		{"set nested missing member", JSONDocument.Set, `{"a": 1}`, `$.b.c`, "x", `{"a": 1}`},
+		{"set member of scalar", JSONDocument.Set, `{"a": 1}`, `$.a.b`, "x", `{"a": 1}`},
+		{"set root", JSONDocument.Set, `{"a": 1}`, `$`, "x", `"x"`},
+		{"set array element", JSONDocument.Set, `[1, 2]`, `$[1]`, "x", `[1, "x"]`},
+		{"set last", JSONDocument.Set, `[1, 2]`, `$[last]`, "x", `[1, "x"]`},
+		{"set past end", JSONDocument.Set, `[1, 2]`, `$[5]`, "x", `[1, 2, "x"]`},
+		{"set before start", JSONDocument.Set, `[1, 2]`, `$[last-2]`, "x", `[1, 2]`},
+		{"set autowrapped scalar", JSONDocument.Set, `{"a": 1}`, `$.a[0]`, "x", `{"a": "x"}`},
+		{"set past autowrapped scalar", JSONDocument.Set, `{"a": 1}`, `$.a[1]`, "x", `{"a": [1, "x"]}`},
+		{"set through autowrapped object", JSONDocument.Set, `{"a": 1}`, `$[0].b`, "x", `{"a": 1, "b": "x"}`},
+		{"insert existing", JSONDocument.Insert, `{"a": 1}`, `$.a`, "x", `{"a": 1}`},
+		{"insert new", JSONDocument.Insert, `{"a": 1}`, `$.b`, "x", `{"a": 1, "b": "x"}`},
+		{"insert root", JSONDocument.Insert, `{"a": 1}`, `$`, "x", `{"a": 1}`},
+		{"insert past end", JSONDocument.Insert, `[1]`, `$[3]`, "x", `[1, "x"]`},
+		{"insert past autowrapped scalar", JSONDocument.Insert, `1`, `$[1]`, "x", `[1, "x"]`},
+		{"replace existing", JSONDocument.Replace, `{"a": 1}`, `$.a`, "x", `{"a": "x"}`},
+		{"replace new", JSONDocument.Replace, `{"a": 1}`, `$.b`, "x", `{"a": 1}`},
+		{"replace past end", JSONDocument.Replace, `[1]`, `$[1]`, "x", `[1]`},
+		{"append to array", JSONDocument.ArrayAppend, `{"a": [1]}`, `$.a`, "x", `{"a": [1, "x"]}`},
+		{"append to scalar", JSONDocument.ArrayAppend, `{"a": 1}`, `$.a`, "x", `{"a": [1, "x"]}`},
+		{"append to object", JSONDocument.ArrayAppend, `{"a": 1}`, `$`, "x", `[{"a": 1}, "x"]`},
+		{"append to missing", JSONDocument.ArrayAppend, `{"a": 1}`, `$.b`, "x", `{"a": 1}`},
+		{"array insert", JSONDocument.ArrayInsert, `[1, 2]`, `$[1]`, "x", `[1, "x", 2]`},
+		{"array insert first", JSONDocument.ArrayInsert, `[1, 2]`, `$[0]`, "x", `["x", 1, 2]`},
+		{"array insert last", JSONDocument.ArrayInsert, `[1, 2]`, `$[last]`, "x", `[1, "x", 2]`},
+		{"array insert past end", JSONDocument.ArrayInsert, `[1, 2]`, `$[9]`, "x", `[1, 2, "x"]`},
+		{"array insert into scalar", JSONDocument.ArrayInsert, `{"a": 1}`, `$.a[0]`, "x", `{"a": 1}`},
+		{"array insert nested", JSONDocument.ArrayInsert, `{"a": [[1]]}`, `$.a[0][0]`, "x", `{"a": [["x", 1]]}`},
+		{"remove member", remove, `{"a": 1, "b": 2}`, `$.a`, nil, `{"b": 2}`},
+		{"remove missing member", remove, `{"a": 1}`, `$.b`, nil, `{"a": 1}`},
+		{"remove element", remove, `[1, 2, 3]`, `$[1]`, nil, `[1, 3]`},
+		{"remove last", remove, `[1, 2, 3]`, `$[last]`, nil, `[1, 2]`},
+		{"remove past end", remove, `[1, 2, 3]`, `$[3]`, nil, `[1, 2, 3]`},
+		{"remove nested", remove, `{"a": [{"b": 1}]}`, `$.a[0].b`, nil, `{"a": [{}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := MustJSON(test.doc)
+			result, err := test.fn(doc, MustParseJSONPath(test.path), test.val)
+			require.NoError(t, err)
+			assert.Equal(t, MustJSON(test.expected), result)
+		})
+	}
+}
+
+func TestJSONDocumentModifyErrors(t *testing.T) {
+	doc := MustJSON(`{"a": [1, 2]}`)
+
+	_, err := doc.Set(MustParseJSONPath(`$.*`), 1)
+	assert.True(t, ErrInvalidJSONPathWildcard.Is(err))
+	_, err = doc.Insert(MustParseJSONPath(`$**.a`), 1)
+	assert.True(t, ErrInvalidJSONPathWildcard.Is(err))
+	_, err = doc.Replace(MustParseJSONPath(`$.a[*]`), 1)
+	assert.True(t, ErrInvalidJSONPathWildcard.Is(err))
+	_, err = doc.ArrayAppend(MustParseJSONPath(`$.a[0 to 1]`), 1)
+	assert.True(t, ErrInvalidJSONPathWildcard.Is(err))
+	_, err = doc.ArrayInsert(MustParseJSONPath(`$.a[*]`), 1)
+	assert.True(t, ErrInvalidJSONPathWildcard.Is(err))
+	_, err = doc.Remove(MustParseJSONPath(`$.a[*]`))
+	assert.True(t, ErrInvalidJSONPathWildcard.Is(err))
+
+	_, err = doc.ArrayInsert(MustParseJSONPath(`$.a`), 1)
+	assert.True(t, ErrInvalidJSONPathArrayCell.Is(err))
+	_, err = doc.ArrayInsert(MustParseJSONPath(`$`), 1)
+	assert.True(t, ErrInvalidJSONPathArrayCell.Is(err))
+	_, err = doc.Remove(MustParseJSONPath(`$`))
+	assert.True(t, ErrJSONVacuousPath.Is(err))
+}
+
+func TestJSONDocumentMergePatch(t *testing.T) {
+	tests := []struct {
+		doc      string
+		patch    string
+		expected string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": ["b"]}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "c"}`, `{"a": ["b"]}`, `{"a": ["b"]}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`{"a": [{"b": "c"}]}`, `{"a": [1]}`, `{"a": [1]}`},
+		{`["a", "b"]`, `["c", "d"]`, `["c", "d"]`},
+		{`{"a": "b"}`, `["c"]`, `["c"]`},
+		{`{"a": "foo"}`, `null`, `null`},
+		{`{"a": "foo"}`, `"bar"`, `"bar"`},
+		{`{"e": null}`, `{"a": 1}`, `{"e": null, "a": 1}`},
+		{`[1, 2]`, `{"a": "b", "c": null}`, `{"a": "b"}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.doc+" "+test.patch, func(t *testing.T) {
+			result := MustJSON(test.doc).MergePatch(MustJSON(test.patch))
+			assert.Equal(t, MustJSON(test.expected), result)
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// JSON documents hold numbers with the JSON type they have in MySQL, so that they keep their precision: INTEGER as
+// int64, UNSIGNED INTEGER as uint64, DOUBLE as float64 and DECIMAL as decimal.Decimal. Numbers parsed from JSON text
+// are integers if they have neither a fraction nor an exponent and fit in 64 bits, and doubles otherwise. DECIMAL
+// values only come from SQL values, such as the arguments of JSON_ARRAY.
+
+// JSONDocumentValue returns the SQL value given as a value of a JSON document. JSON values are replaced with the
+// value of their document, and numbers with the type that holds their JSON type.
+func JSONDocumentValue(ctx *Context, v interface{}) (interface{}, error) {
+	if js, ok := v.(JSONValue); ok {
+		doc, err := js.Unmarshall(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return doc.Val, nil
+	}
+	return normalizeJSONNumbers(v), nil
+}
+
+// JSONTypeOf returns the name of the JSON type of a value of a JSON document, as returned by JSON_TYPE.
+func JSONTypeOf(v interface{}) string {
+	switch jsonScalar(v).(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		return "BOOLEAN"
+	case string:
+		return "STRING"
+	case []interface{}:
+		return "ARRAY"
+	case map[string]interface{}:
+		return "OBJECT"
+	case int64:
+		return "INTEGER"
+	case uint64:
+		return "UNSIGNED INTEGER"
+	case float64:
+		return "DOUBLE"
+	case decimal.Decimal:
+		return "DECIMAL"
+	case time.Time:
+		return "DATETIME"
+	default:
+		return "OPAQUE"
+	}
+}
+
+// jsonScalar returns the number given as the type that holds its JSON type. Other values are returned as they are.
+func jsonScalar(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint8:
+		return uint64(v)
+	case uint16:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case float32:
+		return float64(v)
+	case decimal.NullDecimal:
+		if !v.Valid {
+			return nil
+		}
+		return v.Decimal
+	default:
+		return v
+	}
+}
+
+// normalizeJSONNumbers returns the value given with the numbers it holds replaced by the type that holds their JSON
+// type. Arrays and objects are copied.
+func normalizeJSONNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, val := range v {
+			arr[i] = normalizeJSONNumbers(val)
+		}
+		return arr
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			obj[key] = normalizeJSONNumbers(val)
+		}
+		return obj
+	default:
+		return jsonScalar(v)
+	}
+}
+
+// parseJSONNumber parses a number of JSON text.
+func parseJSONNumber(s string) (interface{}, error) {
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return u, nil
+		}
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// decodeJSONNumbers replaces the json.Number values held by the value given, as decoded by a json.Decoder using
+// numbers, with the type that holds their JSON type. Arrays and objects are modified in place.
+func decodeJSONNumbers(v interface{}) (interface{}, error) {
+	var err error
+	switch v := v.(type) {
+	case json.Number:
+		return parseJSONNumber(string(v))
+	case []interface{}:
+		for i := range v {
+			if v[i], err = decodeJSONNumbers(v[i]); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]interface{}:
+		for key, val := range v {
+			if v[key], err = decodeJSONNumbers(val); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// isJSONNumber returns whether the value is a number of a JSON document.
+func isJSONNumber(v interface{}) bool {
+	switch v.(type) {
+	case int64, uint64, float64, decimal.Decimal, int, int8, int16, int32, uint, uint8, uint16, uint32, float32:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonNumberDecimal returns the number of a JSON document given as a decimal.
+func jsonNumberDecimal(v interface{}) (decimal.Decimal, bool) {
+	switch v := jsonScalar(v).(type) {
+	case int64:
+		return decimal.NewFromInt(v), true
+	case uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(v), 0), true
+	case float64:
+		return decimal.NewFromFloat(v), true
+	case decimal.Decimal:
+		return v, true
+	default:
+		return decimal.Decimal{}, false
+	}
+}
+
+// compareJSONNumbers compares two numbers of JSON documents exactly, whatever their JSON types. It returns false if
+// either value isn't a number.
+func compareJSONNumbers(a, b interface{}) (int, bool) {
+	a, b = jsonScalar(a), jsonScalar(b)
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return compareInts(a, b), true
+		case uint64:
+			if a < 0 {
+				return -1, true
+			}
+			return compareUints(uint64(a), b), true
+		}
+	case uint64:
+		switch b := b.(type) {
+		case uint64:
+			return compareUints(a, b), true
+		case int64:
+			if b < 0 {
+				return 1, true
+			}
+			return compareUints(a, uint64(b)), true
+		}
+	case float64:
+		if b, ok := b.(float64); ok && !math.IsNaN(a) && !math.IsNaN(b) {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+
+	// Numbers of different types are compared as decimals, which hold any of them exactly
+	ad, ok := jsonNumberDecimal(a)
+	if !ok {
+		return 0, false
+	}
+	bd, ok := jsonNumberDecimal(b)
+	if !ok {
+		return 0, false
+	}
+	return ad.Cmp(bd), true
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// marshallableJSON returns a copy of the value given with the numbers that json.Marshal wouldn't write as MySQL does
+// replaced with a json.Number: decimals, which decimal.Decimal marshals as strings, and doubles without a fraction,
+// which are written with one so that they're read back as doubles.
+func marshallableJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case decimal.Decimal:
+		// Decimals are written with their scale, as String drops trailing zeros
+		if v.Exponent() < 0 {
+			return json.Number(v.StringFixed(-v.Exponent()))
+		}
+		return json.Number(v.String())
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e21 {
+			return json.Number(strconv.FormatFloat(v, 'f', -1, 64) + ".0")
+		}
+		return v
+	case float32:
+		return marshallableJSON(float64(v))
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, val := range v {
+			arr[i] = marshallableJSON(val)
+		}
+		return arr
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			obj[key] = marshallableJSON(val)
+		}
+		return obj
+	default:
+		return v
+	}
+}
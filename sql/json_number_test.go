@@ -0,0 +1,141 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalJSONNumbers(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected interface{}
+	}{
+		{`0`, int64(0)},
+		{`-12`, int64(-12)},
+		{`1234567890123456789`, int64(1234567890123456789)},
+		{`-9223372036854775808`, int64(math.MinInt64)},
+		{`9223372036854775808`, uint64(math.MaxInt64 + 1)},
+		{`18446744073709551615`, uint64(math.MaxUint64)},
+		{`18446744073709551616`, float64(18446744073709551616)},
+		{`1.5`, 1.5},
+		{`1.0`, 1.0},
+		{`1e2`, 100.0},
+		{`[1, 2.5, {"a": 3}]`, []interface{}{int64(1), 2.5, map[string]interface{}{"a": int64(3)}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			val, err := unmarshalJSON([]byte(test.json))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, val)
+		})
+	}
+}
+
+func TestJSONNumberToString(t *testing.T) {
+	tests := []struct {
+		val      interface{}
+		expected string
+	}{
+		{int64(1234567890123456789), `1234567890123456789`},
+		{uint64(math.MaxUint64), `18446744073709551615`},
+		{1.5, `1.5`},
+		{100.0, `100.0`},
+		{-3.0, `-3.0`},
+		{1e300, `1e+300`},
+		{decimal.RequireFromString("12345678901234.5678"), `12345678901234.5678`},
+		{decimal.RequireFromString("1.0000"), `1.0000`},
+		{decimal.RequireFromString("-20"), `-20`},
+		{[]interface{}{int64(1), 2.0, uint64(math.MaxUint64)}, `[1,2.0,18446744073709551615]`},
+		{map[string]interface{}{"a": []interface{}{2.0}}, `{"a":[2.0]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			str, err := JSONDocument{Val: test.val}.ToString(NewEmptyContext())
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, str)
+
+			// Integers and doubles are read back with the same type, decimals as doubles
+			if _, ok := test.val.(decimal.Decimal); !ok {
+				doc, err := JSON.Convert(str)
+				require.NoError(t, err)
+				assert.Equal(t, JSONDocument{Val: test.val}, doc)
+			}
+		})
+	}
+}
+
+func TestCompareJSONNumbers(t *testing.T) {
+	tests := []struct {
+		left  interface{}
+		right interface{}
+		cmp   int
+	}{
+		{int64(1), int64(2), -1},
+		{int64(-1), uint64(0), -1},
+		{uint64(math.MaxUint64), int64(math.MaxInt64), 1},
+		{uint64(5), int64(5), 0},
+		{int64(9007199254740993), float64(9007199254740992), 1},
+		{float64(9007199254740992), int64(9007199254740993), -1},
+		{uint64(math.MaxUint64), float64(math.MaxUint64), -1},
+		{decimal.RequireFromString("1.50"), 1.5, 0},
+		{decimal.RequireFromString("12345678901234.5678"), 12345678901234.5678, -1},
+		{decimal.RequireFromString("3"), int64(3), 0},
+		{int32(3), uint8(4), -1},
+	}
+
+	for _, test := range tests {
+		cmp, ok := compareJSONNumbers(test.left, test.right)
+		require.True(t, ok)
+		assert.Equal(t, test.cmp, cmp, "%v <=> %v", test.left, test.right)
+	}
+
+	_, ok := compareJSONNumbers(int64(1), "1")
+	assert.False(t, ok)
+}
+
+func TestJSONTypeOf(t *testing.T) {
+	tests := []struct {
+		val      interface{}
+		expected string
+	}{
+		{nil, "NULL"},
+		{true, "BOOLEAN"},
+		{"a", "STRING"},
+		{[]interface{}{}, "ARRAY"},
+		{map[string]interface{}{}, "OBJECT"},
+		{int64(1), "INTEGER"},
+		{int8(1), "INTEGER"},
+		{uint64(1), "UNSIGNED INTEGER"},
+		{uint32(1), "UNSIGNED INTEGER"},
+		{1.5, "DOUBLE"},
+		{float32(1.5), "DOUBLE"},
+		{decimal.RequireFromString("1.5"), "DECIMAL"},
+		{time.Now(), "DATETIME"},
+		{struct{}{}, "OPAQUE"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, JSONTypeOf(test.val), "%#v", test.val)
+	}
+}
@@ -0,0 +1,445 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// JSONPath is a parsed MySQL JSON path expression, such as $.a[2].b or $**.c[last-1]. Paths are parsed according to
+// https://dev.mysql.com/doc/refman/8.0/en/json.html#json-path-syntax.
+type JSONPath struct {
+	legs []jsonPathLeg
+}
+
+type jsonPathLegType byte
+
+const (
+	// jsonPathMember selects the member of an object with the leg's key
+	jsonPathMember jsonPathLegType = iota
+	// jsonPathMemberWildcard selects every member of an object
+	jsonPathMemberWildcard
+	// jsonPathArrayIndex selects the element of an array at the leg's index
+	jsonPathArrayIndex
+	// jsonPathArrayRange selects the elements of an array between the leg's index and end, inclusive
+	jsonPathArrayRange
+	// jsonPathArrayWildcard selects every element of an array
+	jsonPathArrayWildcard
+	// jsonPathDoubleWildcard selects every value nested anywhere in the current value, including itself
+	jsonPathDoubleWildcard
+)
+
+type jsonPathLeg struct {
+	typ   jsonPathLegType
+	key   string
+	index jsonArrayIndex
+	end   jsonArrayIndex
+}
+
+// jsonArrayIndex is an array position in a path, either counted from the start of the array or, for last and last-N,
+// back from its end.
+type jsonArrayIndex struct {
+	n        int
+	fromLast bool
+}
+
+// resolve returns the position this index refers to in an array of the given length. The result is negative if the
+// index is before the start of the array.
+func (i jsonArrayIndex) resolve(length int) int {
+	if i.fromLast {
+		return length - 1 - i.n
+	}
+	return i.n
+}
+
+func (i jsonArrayIndex) String() string {
+	if !i.fromLast {
+		return strconv.Itoa(i.n)
+	}
+	if i.n == 0 {
+		return "last"
+	}
+	return "last-" + strconv.Itoa(i.n)
+}
+
+// ParseJSONPath parses the MySQL JSON path expression given.
+func ParseJSONPath(path string) (JSONPath, error) {
+	p := &jsonPathParser{path: path}
+	p.skipSpace()
+	if !p.consume('$') {
+		return JSONPath{}, p.err()
+	}
+
+	var legs []jsonPathLeg
+	for {
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		leg, err := p.parseLeg()
+		if err != nil {
+			return JSONPath{}, err
+		}
+		legs = append(legs, leg)
+	}
+
+	// A path can't end in **, since it must select something beneath it
+	if len(legs) > 0 && legs[len(legs)-1].typ == jsonPathDoubleWildcard {
+		return JSONPath{}, ErrInvalidJSONPath.New(len(path))
+	}
+	return JSONPath{legs: legs}, nil
+}
+
+// MustParseJSONPath parses the JSON path given, panicking if it is invalid.
+func MustParseJSONPath(path string) JSONPath {
+	p, err := ParseJSONPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// IsRoot returns whether this path is $, selecting the entire document.
+func (p JSONPath) IsRoot() bool {
+	return len(p.legs) == 0
+}
+
+// ContainsWildcard returns whether this path contains a *, a ** or an array range, and so may select more than one
+// value.
+func (p JSONPath) ContainsWildcard() bool {
+	for _, leg := range p.legs {
+		switch leg.typ {
+		case jsonPathMemberWildcard, jsonPathArrayRange, jsonPathArrayWildcard, jsonPathDoubleWildcard:
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the canonical form of this path.
+func (p JSONPath) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("$")
+	for _, leg := range p.legs {
+		sb.WriteString(leg.String())
+	}
+	return sb.String()
+}
+
+func (l jsonPathLeg) String() string {
+	switch l.typ {
+	case jsonPathMember:
+		if isJSONPathIdentifier(l.key) {
+			return "." + l.key
+		}
+		b, _ := json.Marshal(l.key)
+		return "." + string(b)
+	case jsonPathMemberWildcard:
+		return ".*"
+	case jsonPathArrayIndex:
+		return "[" + l.index.String() + "]"
+	case jsonPathArrayRange:
+		return "[" + l.index.String() + " to " + l.end.String() + "]"
+	case jsonPathArrayWildcard:
+		return "[*]"
+	default:
+		return "**"
+	}
+}
+
+// isJSONPathIdentifier returns whether the key given can be written in a path without quotes.
+func isJSONPathIdentifier(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, r := range key {
+		if !isJSONPathIdentifierRune(r, i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func isJSONPathIdentifierRune(r rune, first bool) bool {
+	if r == '$' || r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+	return !first && (unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Mc, r) || unicode.Is(unicode.Pc, r))
+}
+
+type jsonPathParser struct {
+	path string
+	pos  int
+}
+
+func (p *jsonPathParser) done() bool {
+	return p.pos >= len(p.path)
+}
+
+func (p *jsonPathParser) err() error {
+	return ErrInvalidJSONPath.New(p.pos)
+}
+
+func (p *jsonPathParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.path[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *jsonPathParser) consume(c byte) bool {
+	if !p.done() && p.path[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) parseLeg() (jsonPathLeg, error) {
+	switch {
+	case p.consume('.'):
+		p.skipSpace()
+		if p.consume('*') {
+			return jsonPathLeg{typ: jsonPathMemberWildcard}, nil
+		}
+		key, err := p.parseKey()
+		if err != nil {
+			return jsonPathLeg{}, err
+		}
+		return jsonPathLeg{typ: jsonPathMember, key: key}, nil
+
+	case p.consume('['):
+		p.skipSpace()
+		var leg jsonPathLeg
+		if p.consume('*') {
+			leg.typ = jsonPathArrayWildcard
+		} else {
+			idx, err := p.parseIndex()
+			if err != nil {
+				return jsonPathLeg{}, err
+			}
+			leg.typ, leg.index = jsonPathArrayIndex, idx
+			p.skipSpace()
+			if p.consumeWord("to") {
+				p.skipSpace()
+				end, err := p.parseIndex()
+				if err != nil {
+					return jsonPathLeg{}, err
+				}
+				// A range whose bounds are both known must not be backwards
+				if idx.fromLast == end.fromLast && ((!idx.fromLast && end.n < idx.n) || (idx.fromLast && end.n > idx.n)) {
+					return jsonPathLeg{}, p.err()
+				}
+				leg.typ, leg.end = jsonPathArrayRange, end
+			}
+		}
+		p.skipSpace()
+		if !p.consume(']') {
+			return jsonPathLeg{}, p.err()
+		}
+		return leg, nil
+
+	case p.consume('*'):
+		if !p.consume('*') {
+			return jsonPathLeg{}, p.err()
+		}
+		return jsonPathLeg{typ: jsonPathDoubleWildcard}, nil
+
+	default:
+		return jsonPathLeg{}, p.err()
+	}
+}
+
+// consumeWord consumes the keyword given if it appears at the current position and isn't followed by more letters.
+func (p *jsonPathParser) consumeWord(word string) bool {
+	if !strings.HasPrefix(p.path[p.pos:], word) {
+		return false
+	}
+	end := p.pos + len(word)
+	if end < len(p.path) && unicode.IsLetter(rune(p.path[end])) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *jsonPathParser) parseIndex() (jsonArrayIndex, error) {
+	if p.consumeWord("last") {
+		p.skipSpace()
+		if !p.consume('-') {
+			return jsonArrayIndex{fromLast: true}, nil
+		}
+		p.skipSpace()
+		n, err := p.parseNumber()
+		if err != nil {
+			return jsonArrayIndex{}, err
+		}
+		return jsonArrayIndex{n: n, fromLast: true}, nil
+	}
+	n, err := p.parseNumber()
+	if err != nil {
+		return jsonArrayIndex{}, err
+	}
+	return jsonArrayIndex{n: n}, nil
+}
+
+func (p *jsonPathParser) parseNumber() (int, error) {
+	start := p.pos
+	for !p.done() && p.path[p.pos] >= '0' && p.path[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, p.err()
+	}
+	n, err := strconv.Atoi(p.path[start:p.pos])
+	if err != nil {
+		return 0, p.err()
+	}
+	return n, nil
+}
+
+// parseKey parses an object member name, which is either an ECMAScript identifier or a double-quoted JSON string.
+func (p *jsonPathParser) parseKey() (string, error) {
+	if p.consume('"') {
+		start := p.pos - 1
+		for !p.done() {
+			switch p.path[p.pos] {
+			case '\\':
+				p.pos += 2
+			case '"':
+				p.pos++
+				var key string
+				if err := json.Unmarshal([]byte(p.path[start:p.pos]), &key); err != nil {
+					return "", ErrInvalidJSONPath.New(start)
+				}
+				return key, nil
+			default:
+				p.pos++
+			}
+		}
+		return "", ErrInvalidJSONPath.New(len(p.path))
+	}
+
+	start := p.pos
+	for i, r := range p.path[p.pos:] {
+		if !isJSONPathIdentifierRune(r, i == 0) {
+			break
+		}
+		p.pos = start + i + len(string(r))
+	}
+	if start == p.pos {
+		return "", p.err()
+	}
+	return p.path[start:p.pos], nil
+}
+
+// visitJSONPath calls visit for every value the path legs given select beneath the value given, along with the legs of
+// a path without wildcards that selects just that value. The legs passed to visit are only valid during the call. It
+// stops early, returning false, if visit returns false.
+func visitJSONPath(val interface{}, legs []jsonPathLeg, at []jsonPathLeg, visit func(at []jsonPathLeg, val interface{}) bool) bool {
+	if len(legs) == 0 {
+		return visit(at, val)
+	}
+
+	leg, rest := legs[0], legs[1:]
+	switch leg.typ {
+	case jsonPathMember:
+		if obj, ok := val.(map[string]interface{}); ok {
+			if child, ok := obj[leg.key]; ok {
+				return visitJSONPath(child, rest, append(at, leg), visit)
+			}
+		}
+		return true
+
+	case jsonPathMemberWildcard:
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			return true
+		}
+		for _, key := range sortedJSONKeys(obj) {
+			if !visitJSONPath(obj[key], rest, append(at, jsonPathLeg{typ: jsonPathMember, key: key}), visit) {
+				return false
+			}
+		}
+		return true
+
+	case jsonPathArrayIndex:
+		arr, ok := val.([]interface{})
+		if !ok {
+			// A value that isn't an array is treated as an array holding just that value
+			if leg.index.resolve(1) == 0 {
+				return visitJSONPath(val, rest, at, visit)
+			}
+			return true
+		}
+		i := leg.index.resolve(len(arr))
+		if i < 0 || i >= len(arr) {
+			return true
+		}
+		return visitJSONPath(arr[i], rest, append(at, jsonPathLeg{typ: jsonPathArrayIndex, index: jsonArrayIndex{n: i}}), visit)
+
+	case jsonPathArrayRange, jsonPathArrayWildcard:
+		arr, ok := val.([]interface{})
+		if !ok {
+			return true
+		}
+		start, end := 0, len(arr)-1
+		if leg.typ == jsonPathArrayRange {
+			start, end = leg.index.resolve(len(arr)), leg.end.resolve(len(arr))
+		}
+		if start < 0 {
+			start = 0
+		}
+		for i := start; i <= end && i < len(arr); i++ {
+			if !visitJSONPath(arr[i], rest, append(at, jsonPathLeg{typ: jsonPathArrayIndex, index: jsonArrayIndex{n: i}}), visit) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		// ** selects the value itself and every value nested within it
+		if !visitJSONPath(val, rest, at, visit) {
+			return false
+		}
+		switch v := val.(type) {
+		case map[string]interface{}:
+			for _, key := range sortedJSONKeys(v) {
+				if !visitJSONPath(v[key], legs, append(at, jsonPathLeg{typ: jsonPathMember, key: key}), visit) {
+					return false
+				}
+			}
+		case []interface{}:
+			for i, child := range v {
+				if !visitJSONPath(child, legs, append(at, jsonPathLeg{typ: jsonPathArrayIndex, index: jsonArrayIndex{n: i}}), visit) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+func sortedJSONKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
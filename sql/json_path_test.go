@@ -0,0 +1,90 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+		wildcard bool
+	}{
+		{`$`, `$`, false},
+		{` $ `, `$`, false},
+		{`$.a`, `$.a`, false},
+		{`$.a.b_2.$c`, `$.a.b_2.$c`, false},
+		{`$."a b"`, `$."a b"`, false},
+		{`$."a"`, `$.a`, false},
+		{`$."a\"b"`, `$."a\"b"`, false},
+		{`$[0]`, `$[0]`, false},
+		{`$[ 12 ]`, `$[12]`, false},
+		{`$[last]`, `$[last]`, false},
+		{`$[last - 2]`, `$[last-2]`, false},
+		{`$.a[1].b[last].c`, `$.a[1].b[last].c`, false},
+		{`$.*`, `$.*`, true},
+		{`$[*]`, `$[*]`, true},
+		{`$**.a`, `$**.a`, true},
+		{`$[1 to 3]`, `$[1 to 3]`, true},
+		{`$[last-3 to last]`, `$[last-3 to last]`, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			p, err := ParseJSONPath(test.path)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, p.String())
+			assert.Equal(t, test.wildcard, p.ContainsWildcard())
+			assert.Equal(t, test.expected == "$", p.IsRoot())
+		})
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		pos  int
+	}{
+		{``, 0},
+		{`a`, 0},
+		{`$.`, 2},
+		{`$a`, 1},
+		{`$.a.`, 4},
+		{`$.1a`, 2},
+		{`$[`, 2},
+		{`$[a]`, 2},
+		{`$[1`, 3},
+		{`$[-1]`, 2},
+		{`$[3 to 1]`, 8},
+		{`$[last-]`, 7},
+		{`$."a`, 4},
+		{`$*`, 2},
+		{`$.a**`, 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			_, err := ParseJSONPath(test.path)
+			require.Error(t, err)
+			assert.True(t, ErrInvalidJSONPath.Is(err))
+			assert.Equal(t, ErrInvalidJSONPath.New(test.pos).Error(), err.Error())
+		})
+	}
+}
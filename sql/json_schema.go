@@ -0,0 +1,705 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// JSONSchema is a JSON Schema of draft 4, the dialect MySQL validates JSON documents against. Schemas may reference
+// parts of themselves with $ref, but not other schemas.
+//
+// http://json-schema.org/draft-04/json-schema-validation.html
+type JSONSchema struct {
+	root *jsonSchema
+}
+
+// JSONSchemaValidation is the outcome of validating a JSON document against a JSON Schema. The locations of a failed
+// validation are JSON pointer URI fragments, such as #/properties/a, of the schema whose keyword failed and of the
+// value in the document that failed it.
+type JSONSchemaValidation struct {
+	Valid            bool
+	SchemaLocation   string
+	DocumentLocation string
+	FailedKeyword    string
+}
+
+// ParseJSONSchema parses the JSON document given as a JSON Schema.
+func ParseJSONSchema(doc JSONDocument) (*JSONSchema, error) {
+	if _, ok := doc.Val.(map[string]interface{}); !ok {
+		return nil, ErrInvalidJSONSchema.New("a JSON Schema must be an object")
+	}
+	c := &jsonSchemaCompiler{doc: doc.Val, compiled: make(map[string]*jsonSchema)}
+	root, err := c.compile(doc.Val, "#", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// References are resolved once every schema they may point to has been compiled. Resolving a reference may
+	// compile more schemas, with references of their own.
+	for len(c.refs) > 0 {
+		s := c.refs[0]
+		c.refs = c.refs[1:]
+		if s.refSchema, err = c.resolve(s.ref, s.location); err != nil {
+			return nil, err
+		}
+	}
+	return &JSONSchema{root: root}, nil
+}
+
+// Validate validates the JSON document given against the schema.
+func (s *JSONSchema) Validate(doc JSONDocument) JSONSchemaValidation {
+	// References may loop, so the schemas being validated against each value are tracked
+	failure := s.root.validate(doc.Val, "#", make(map[jsonSchemaVisit]struct{}))
+	if failure == nil {
+		return JSONSchemaValidation{Valid: true}
+	}
+	return *failure
+}
+
+// Reason returns a description of why validation failed, or an empty string if it didn't.
+func (v JSONSchemaValidation) Reason() string {
+	if v.Valid {
+		return ""
+	}
+	return fmt.Sprintf("The JSON document location '%s' failed requirement '%s' at JSON Schema location '%s'",
+		v.DocumentLocation, v.FailedKeyword, v.SchemaLocation)
+}
+
+// Report returns the validation as the JSON object returned by JSON_SCHEMA_VALIDATION_REPORT.
+func (v JSONSchemaValidation) Report() JSONDocument {
+	if v.Valid {
+		return JSONDocument{Val: map[string]interface{}{"valid": true}}
+	}
+	return JSONDocument{Val: map[string]interface{}{
+		"valid":                 false,
+		"reason":                v.Reason(),
+		"schema-location":       v.SchemaLocation,
+		"document-location":     v.DocumentLocation,
+		"schema-failed-keyword": v.FailedKeyword,
+	}}
+}
+
+// jsonSchema is a compiled schema object, found at location within the root schema.
+type jsonSchema struct {
+	location string
+
+	// ref is the reference of a $ref keyword, which makes every other keyword ignored
+	ref       string
+	refSchema *jsonSchema
+
+	types []string
+	enum  []interface{}
+
+	multipleOf       *decimal.Decimal
+	maximum          *decimal.Decimal
+	exclusiveMaximum bool
+	minimum          *decimal.Decimal
+	exclusiveMinimum bool
+
+	maxLength *int
+	minLength *int
+	pattern   *regexp.Regexp
+
+	items             *jsonSchema
+	itemsList         []*jsonSchema
+	additionalItems   *jsonSchema
+	noAdditionalItems bool
+	maxItems          *int
+	minItems          *int
+	uniqueItems       bool
+
+	maxProperties          *int
+	minProperties          *int
+	required               []string
+	properties             map[string]*jsonSchema
+	patternProperties      []jsonSchemaPattern
+	additionalProperties   *jsonSchema
+	noAdditionalProperties bool
+	dependencies           []jsonSchemaDependency
+
+	allOf []*jsonSchema
+	anyOf []*jsonSchema
+	oneOf []*jsonSchema
+	not   *jsonSchema
+}
+
+// jsonSchemaPattern is a schema of patternProperties, which applies to members whose names match the pattern.
+type jsonSchemaPattern struct {
+	pattern *regexp.Regexp
+	schema  *jsonSchema
+}
+
+// jsonSchemaDependency is a dependency of an object member, either on other members or on a schema.
+type jsonSchemaDependency struct {
+	member string
+	names  []string
+	schema *jsonSchema
+}
+
+// jsonSchemaTypes are the names of the primitive types of a JSON Schema.
+var jsonSchemaTypes = map[string]struct{}{
+	"array": {}, "boolean": {}, "integer": {}, "null": {}, "number": {}, "object": {}, "string": {},
+}
+
+type jsonSchemaCompiler struct {
+	doc interface{}
+	// compiled are the schemas compiled so far, by location
+	compiled map[string]*jsonSchema
+	// refs are the compiled schemas whose references haven't been resolved
+	refs []*jsonSchema
+}
+
+// compile compiles the schema at the location given. The keyword is the one of the parent schema whose value holds
+// the schema, which is reported along with the parent's location if the schema isn't an object.
+func (c *jsonSchemaCompiler) compile(val interface{}, location, keyword string) (*jsonSchema, error) {
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, invalidJSONSchemaKeyword(keyword, location[:strings.LastIndex(location, "/")])
+	}
+	if s, ok := c.compiled[location]; ok {
+		return s, nil
+	}
+
+	s := &jsonSchema{location: location}
+	c.compiled[location] = s
+	invalid := func(keyword string) error {
+		return invalidJSONSchemaKeyword(keyword, location)
+	}
+
+	if ref, ok := obj["$ref"]; ok {
+		if s.ref, ok = ref.(string); !ok {
+			return nil, invalid("$ref")
+		}
+		c.refs = append(c.refs, s)
+		return s, nil
+	}
+
+	// Every subschema is compiled, so that references to them can be resolved
+	for _, keyword := range []string{"definitions", "properties"} {
+		if defs, ok := obj[keyword]; ok {
+			m, ok := defs.(map[string]interface{})
+			if !ok {
+				return nil, invalid(keyword)
+			}
+			schemas := make(map[string]*jsonSchema, len(m))
+			for _, name := range sortedJSONKeys(m) {
+				sub, err := c.compile(m[name], location+"/"+keyword+"/"+escapeJSONPointer(name), keyword)
+				if err != nil {
+					return nil, err
+				}
+				schemas[name] = sub
+			}
+			if keyword == "properties" {
+				s.properties = schemas
+			}
+		}
+	}
+
+	if t, ok := obj["type"]; ok {
+		names, ok := jsonSchemaStrings(t)
+		if name, isString := t.(string); isString {
+			names, ok = []string{name}, true
+		}
+		if !ok || len(names) == 0 {
+			return nil, invalid("type")
+		}
+		for _, name := range names {
+			if _, ok := jsonSchemaTypes[name]; !ok {
+				return nil, invalid("type")
+			}
+		}
+		s.types = names
+	}
+	if e, ok := obj["enum"]; ok {
+		if s.enum, ok = e.([]interface{}); !ok || len(s.enum) == 0 {
+			return nil, invalid("enum")
+		}
+	}
+
+	for keyword, field := range map[string]**decimal.Decimal{"multipleOf": &s.multipleOf, "maximum": &s.maximum, "minimum": &s.minimum} {
+		if v, ok := obj[keyword]; ok {
+			d, ok := jsonNumberDecimal(v)
+			if !ok || (keyword == "multipleOf" && !d.IsPositive()) {
+				return nil, invalid(keyword)
+			}
+			*field = &d
+		}
+	}
+	for keyword, field := range map[string]*bool{"exclusiveMaximum": &s.exclusiveMaximum, "exclusiveMinimum": &s.exclusiveMinimum, "uniqueItems": &s.uniqueItems} {
+		if v, ok := obj[keyword]; ok {
+			if *field, ok = v.(bool); !ok {
+				return nil, invalid(keyword)
+			}
+		}
+	}
+	for keyword, field := range map[string]**int{
+		"maxLength": &s.maxLength, "minLength": &s.minLength,
+		"maxItems": &s.maxItems, "minItems": &s.minItems,
+		"maxProperties": &s.maxProperties, "minProperties": &s.minProperties,
+	} {
+		if v, ok := obj[keyword]; ok {
+			n, ok := jsonSchemaCount(v)
+			if !ok {
+				return nil, invalid(keyword)
+			}
+			*field = &n
+		}
+	}
+
+	if p, ok := obj["pattern"]; ok {
+		var err error
+		if s.pattern, err = compileJSONSchemaPattern(p); err != nil {
+			return nil, invalid("pattern")
+		}
+	}
+
+	if items, ok := obj["items"]; ok {
+		var err error
+		if list, ok := items.([]interface{}); ok {
+			s.itemsList = make([]*jsonSchema, len(list))
+			for i, item := range list {
+				if s.itemsList[i], err = c.compile(item, location+"/items/"+strconv.Itoa(i), "items"); err != nil {
+					return nil, err
+				}
+			}
+		} else if s.items, err = c.compile(items, location+"/items", "items"); err != nil {
+			return nil, err
+		}
+	}
+	if additional, ok := obj["additionalItems"]; ok {
+		var err error
+		if s.additionalItems, s.noAdditionalItems, err = c.compileAdditional(additional, location, "additionalItems"); err != nil {
+			return nil, err
+		}
+	}
+
+	if r, ok := obj["required"]; ok {
+		if s.required, ok = jsonSchemaStrings(r); !ok || len(s.required) == 0 {
+			return nil, invalid("required")
+		}
+	}
+	if pp, ok := obj["patternProperties"]; ok {
+		m, ok := pp.(map[string]interface{})
+		if !ok {
+			return nil, invalid("patternProperties")
+		}
+		for _, p := range sortedJSONKeys(m) {
+			re, err := compileJSONSchemaPattern(p)
+			if err != nil {
+				return nil, invalid("patternProperties")
+			}
+			sub, err := c.compile(m[p], location+"/patternProperties/"+escapeJSONPointer(p), "patternProperties")
+			if err != nil {
+				return nil, err
+			}
+			s.patternProperties = append(s.patternProperties, jsonSchemaPattern{pattern: re, schema: sub})
+		}
+	}
+	if additional, ok := obj["additionalProperties"]; ok {
+		var err error
+		if s.additionalProperties, s.noAdditionalProperties, err = c.compileAdditional(additional, location, "additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if deps, ok := obj["dependencies"]; ok {
+		m, ok := deps.(map[string]interface{})
+		if !ok {
+			return nil, invalid("dependencies")
+		}
+		for _, name := range sortedJSONKeys(m) {
+			if names, ok := jsonSchemaStrings(m[name]); ok {
+				s.dependencies = append(s.dependencies, jsonSchemaDependency{member: name, names: names})
+				continue
+			}
+			sub, err := c.compile(m[name], location+"/dependencies/"+escapeJSONPointer(name), "dependencies")
+			if err != nil {
+				return nil, err
+			}
+			s.dependencies = append(s.dependencies, jsonSchemaDependency{member: name, schema: sub})
+		}
+	}
+
+	for keyword, field := range map[string]*[]*jsonSchema{"allOf": &s.allOf, "anyOf": &s.anyOf, "oneOf": &s.oneOf} {
+		if v, ok := obj[keyword]; ok {
+			list, ok := v.([]interface{})
+			if !ok || len(list) == 0 {
+				return nil, invalid(keyword)
+			}
+			*field = make([]*jsonSchema, len(list))
+			for i, item := range list {
+				sub, err := c.compile(item, location+"/"+keyword+"/"+strconv.Itoa(i), keyword)
+				if err != nil {
+					return nil, err
+				}
+				(*field)[i] = sub
+			}
+		}
+	}
+	if not, ok := obj["not"]; ok {
+		var err error
+		if s.not, err = c.compile(not, location+"/not", "not"); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// compileAdditional compiles the value of additionalItems or additionalProperties, which is either a schema or a
+// boolean. It returns whether additional values are forbidden.
+func (c *jsonSchemaCompiler) compileAdditional(val interface{}, location, keyword string) (*jsonSchema, bool, error) {
+	if allowed, ok := val.(bool); ok {
+		return nil, !allowed, nil
+	}
+	s, err := c.compile(val, location+"/"+keyword, keyword)
+	return s, false, err
+}
+
+// resolve returns the schema the reference given, found in the schema at location, points to.
+func (c *jsonSchemaCompiler) resolve(ref, location string) (*jsonSchema, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, ErrUnsupportedFeature.New("references to other JSON schemas")
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil || (pointer != "" && !strings.HasPrefix(pointer, "/")) {
+		return nil, invalidJSONSchemaKeyword("$ref", location)
+	}
+
+	val := c.doc
+	target := "#"
+	if pointer != "" {
+		for _, token := range strings.Split(pointer[1:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			var ok bool
+			switch v := val.(type) {
+			case map[string]interface{}:
+				val, ok = v[token]
+			case []interface{}:
+				i, err := strconv.Atoi(token)
+				if ok = err == nil && i >= 0 && i < len(v); ok {
+					val = v[i]
+				}
+			}
+			if !ok {
+				return nil, invalidJSONSchemaKeyword("$ref", location)
+			}
+			target += "/" + escapeJSONPointer(token)
+		}
+	}
+	if _, ok := val.(map[string]interface{}); !ok {
+		return nil, invalidJSONSchemaKeyword("$ref", location)
+	}
+	return c.compile(val, target, "")
+}
+
+// jsonSchemaVisit is the validation of the value at a location of a document against a schema with a reference.
+type jsonSchemaVisit struct {
+	schema *jsonSchema
+	at     string
+}
+
+// validate validates the value at the location given against the schema, returning the first failure, if any.
+func (s *jsonSchema) validate(val interface{}, at string, visiting map[jsonSchemaVisit]struct{}) *JSONSchemaValidation {
+	if s.refSchema != nil {
+		// A reference that leads back to itself without moving through the document can't fail
+		visit := jsonSchemaVisit{schema: s, at: at}
+		if _, ok := visiting[visit]; ok {
+			return nil
+		}
+		visiting[visit] = struct{}{}
+		defer delete(visiting, visit)
+		return s.refSchema.validate(val, at, visiting)
+	}
+
+	if len(s.types) > 0 && !s.hasType(val) {
+		return s.fail(at, "type")
+	}
+
+	var failure *JSONSchemaValidation
+	switch v := val.(type) {
+	case string:
+		failure = s.validateString(v, at)
+	case []interface{}:
+		failure = s.validateArray(v, at, visiting)
+	case map[string]interface{}:
+		failure = s.validateObject(v, at, visiting)
+	default:
+		if d, ok := jsonNumberDecimal(val); ok {
+			failure = s.validateNumber(d, at)
+		}
+	}
+	if failure != nil {
+		return failure
+	}
+
+	if s.enum != nil && !jsonSchemaContains(s.enum, val) {
+		return s.fail(at, "enum")
+	}
+	for _, sub := range s.allOf {
+		if sub.validate(val, at, visiting) != nil {
+			return s.fail(at, "allOf")
+		}
+	}
+	if len(s.anyOf) > 0 {
+		valid := false
+		for _, sub := range s.anyOf {
+			if sub.validate(val, at, visiting) == nil {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return s.fail(at, "anyOf")
+		}
+	}
+	if len(s.oneOf) > 0 {
+		valid := 0
+		for _, sub := range s.oneOf {
+			if sub.validate(val, at, visiting) == nil {
+				valid++
+			}
+		}
+		if valid != 1 {
+			return s.fail(at, "oneOf")
+		}
+	}
+	if s.not != nil && s.not.validate(val, at, visiting) == nil {
+		return s.fail(at, "not")
+	}
+	return nil
+}
+
+// hasType returns whether the value is of one of the types of the schema.
+func (s *jsonSchema) hasType(val interface{}) bool {
+	for _, typ := range s.types {
+		switch typ {
+		case "null":
+			if val == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := val.(bool); ok {
+				return true
+			}
+		case "string":
+			if _, ok := val.(string); ok {
+				return true
+			}
+		case "array":
+			if _, ok := val.([]interface{}); ok {
+				return true
+			}
+		case "object":
+			if _, ok := val.(map[string]interface{}); ok {
+				return true
+			}
+		case "number":
+			if _, ok := jsonNumberDecimal(val); ok {
+				return true
+			}
+		case "integer":
+			switch JSONTypeOf(val) {
+			case "INTEGER", "UNSIGNED INTEGER":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *jsonSchema) validateNumber(d decimal.Decimal, at string) *JSONSchemaValidation {
+	if s.multipleOf != nil && !d.Mod(*s.multipleOf).IsZero() {
+		return s.fail(at, "multipleOf")
+	}
+	if s.maximum != nil {
+		if cmp := d.Cmp(*s.maximum); cmp > 0 || (cmp == 0 && s.exclusiveMaximum) {
+			return s.fail(at, "maximum")
+		}
+	}
+	if s.minimum != nil {
+		if cmp := d.Cmp(*s.minimum); cmp < 0 || (cmp == 0 && s.exclusiveMinimum) {
+			return s.fail(at, "minimum")
+		}
+	}
+	return nil
+}
+
+func (s *jsonSchema) validateString(str string, at string) *JSONSchemaValidation {
+	length := utf8.RuneCountInString(str)
+	if s.maxLength != nil && length > *s.maxLength {
+		return s.fail(at, "maxLength")
+	}
+	if s.minLength != nil && length < *s.minLength {
+		return s.fail(at, "minLength")
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return s.fail(at, "pattern")
+	}
+	return nil
+}
+
+func (s *jsonSchema) validateArray(arr []interface{}, at string, visiting map[jsonSchemaVisit]struct{}) *JSONSchemaValidation {
+	for i, item := range arr {
+		var sub *jsonSchema
+		switch {
+		case s.items != nil:
+			sub = s.items
+		case i < len(s.itemsList):
+			sub = s.itemsList[i]
+		case s.itemsList != nil && s.noAdditionalItems:
+			return s.fail(at, "additionalItems")
+		case s.itemsList != nil:
+			sub = s.additionalItems
+		}
+		if sub != nil {
+			if failure := sub.validate(item, at+"/"+strconv.Itoa(i), visiting); failure != nil {
+				return failure
+			}
+		}
+	}
+
+	if s.maxItems != nil && len(arr) > *s.maxItems {
+		return s.fail(at, "maxItems")
+	}
+	if s.minItems != nil && len(arr) < *s.minItems {
+		return s.fail(at, "minItems")
+	}
+	if s.uniqueItems {
+		for i := range arr {
+			if jsonSchemaContains(arr[:i], arr[i]) {
+				return s.fail(at, "uniqueItems")
+			}
+		}
+	}
+	return nil
+}
+
+func (s *jsonSchema) validateObject(obj map[string]interface{}, at string, visiting map[jsonSchemaVisit]struct{}) *JSONSchemaValidation {
+	for _, key := range sortedJSONKeys(obj) {
+		memberAt := at + "/" + escapeJSONPointer(key)
+		var subs []*jsonSchema
+		if sub, ok := s.properties[key]; ok {
+			subs = append(subs, sub)
+		}
+		for _, p := range s.patternProperties {
+			if p.pattern.MatchString(key) {
+				subs = append(subs, p.schema)
+			}
+		}
+		if len(subs) == 0 {
+			if s.noAdditionalProperties {
+				return s.fail(at, "additionalProperties")
+			}
+			if s.additionalProperties != nil {
+				subs = append(subs, s.additionalProperties)
+			}
+		}
+		for _, sub := range subs {
+			if failure := sub.validate(obj[key], memberAt, visiting); failure != nil {
+				return failure
+			}
+		}
+	}
+
+	if s.maxProperties != nil && len(obj) > *s.maxProperties {
+		return s.fail(at, "maxProperties")
+	}
+	if s.minProperties != nil && len(obj) < *s.minProperties {
+		return s.fail(at, "minProperties")
+	}
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			return s.fail(at, "required")
+		}
+	}
+	for _, dep := range s.dependencies {
+		if _, ok := obj[dep.member]; !ok {
+			continue
+		}
+		for _, name := range dep.names {
+			if _, ok := obj[name]; !ok {
+				return s.fail(at, "dependencies")
+			}
+		}
+		if dep.schema != nil && dep.schema.validate(obj, at, visiting) != nil {
+			return s.fail(at, "dependencies")
+		}
+	}
+	return nil
+}
+
+// fail returns the failure of the keyword given for the value at the location given.
+func (s *jsonSchema) fail(at, keyword string) *JSONSchemaValidation {
+	return &JSONSchemaValidation{SchemaLocation: s.location, DocumentLocation: at, FailedKeyword: keyword}
+}
+
+// jsonSchemaCount returns the value given as an int if it's a non-negative integer.
+func jsonSchemaCount(val interface{}) (int, bool) {
+	d, ok := jsonNumberDecimal(val)
+	if !ok || d.IsNegative() || !d.Equal(d.Truncate(0)) || d.GreaterThan(decimal.NewFromInt(math.MaxInt32)) {
+		return 0, false
+	}
+	return int(d.IntPart()), true
+}
+
+// jsonSchemaStrings returns the value given as a slice if it's an array of strings.
+func jsonSchemaStrings(val interface{}) ([]string, bool) {
+	arr, ok := val.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, len(arr))
+	for i, v := range arr {
+		if strs[i], ok = v.(string); !ok {
+			return nil, false
+		}
+	}
+	return strs, true
+}
+
+// jsonSchemaContains returns whether any of the values in the list is equal to the value given.
+func jsonSchemaContains(list []interface{}, val interface{}) bool {
+	for _, v := range list {
+		if cmp, err := compareJSON(v, val); err == nil && cmp == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func compileJSONSchemaPattern(val interface{}) (*regexp.Regexp, error) {
+	p, ok := val.(string)
+	if !ok {
+		return nil, fmt.Errorf("pattern must be a string")
+	}
+	return regexp.Compile(p)
+}
+
+// escapeJSONPointer escapes the characters of a JSON pointer token that have a special meaning.
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func invalidJSONSchemaKeyword(keyword, location string) error {
+	return ErrInvalidJSONSchema.New(fmt.Sprintf("the value of '%s' at '%s' is invalid", keyword, location))
+}
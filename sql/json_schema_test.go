@@ -0,0 +1,131 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		doc      string
+		expected JSONSchemaValidation
+	}{
+		{"empty schema", `{}`, `[1, "a", {"b": null}]`, JSONSchemaValidation{Valid: true}},
+		{"type", `{"type": "string"}`, `"a"`, JSONSchemaValidation{Valid: true}},
+		{"wrong type", `{"type": "string"}`, `1`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "type"}},
+		{"types", `{"type": ["null", "boolean"]}`, `false`, JSONSchemaValidation{Valid: true}},
+		{"integer", `{"type": "integer"}`, `3`, JSONSchemaValidation{Valid: true}},
+		{"not integer", `{"type": "integer"}`, `3.5`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "type"}},
+		{"double is not integer", `{"type": "integer"}`, `3.0`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "type"}},
+		{"unsigned integer", `{"type": "integer", "minimum": 0}`, `18446744073709551615`, JSONSchemaValidation{Valid: true}},
+		{"enum", `{"enum": [1, "a", [2]]}`, `[2]`, JSONSchemaValidation{Valid: true}},
+		{"not in enum", `{"enum": [1, "a", [2]]}`, `"b"`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "enum"}},
+		{"multipleOf", `{"multipleOf": 0.1}`, `0.3`, JSONSchemaValidation{Valid: true}},
+		{"not multipleOf", `{"multipleOf": 2}`, `3`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "multipleOf"}},
+		{"maximum", `{"maximum": 3}`, `3`, JSONSchemaValidation{Valid: true}},
+		{"exclusiveMaximum", `{"maximum": 3, "exclusiveMaximum": true}`, `3`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "maximum"}},
+		{"minimum", `{"minimum": 3}`, `2.5`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "minimum"}},
+		{"minimum of string", `{"minimum": 3}`, `"a"`, JSONSchemaValidation{Valid: true}},
+		{"maxLength", `{"maxLength": 2}`, `"äö"`, JSONSchemaValidation{Valid: true}},
+		{"over maxLength", `{"maxLength": 2}`, `"abc"`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "maxLength"}},
+		{"minLength", `{"minLength": 2}`, `"a"`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "minLength"}},
+		{"pattern", `{"pattern": "^a+$"}`, `"aab"`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "pattern"}},
+		{"items", `{"items": {"type": "number"}}`, `[1, 2, "a"]`, JSONSchemaValidation{SchemaLocation: "#/items", DocumentLocation: "#/2", FailedKeyword: "type"}},
+		{"items list", `{"items": [{"type": "number"}, {"type": "string"}]}`, `[1, "a", null]`, JSONSchemaValidation{Valid: true}},
+		{"additionalItems", `{"items": [{"type": "number"}], "additionalItems": false}`, `[1, 2]`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "additionalItems"}},
+		{"additionalItems schema", `{"items": [{}], "additionalItems": {"type": "string"}}`, `[1, 2]`, JSONSchemaValidation{SchemaLocation: "#/additionalItems", DocumentLocation: "#/1", FailedKeyword: "type"}},
+		{"maxItems", `{"maxItems": 1}`, `[1, 2]`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "maxItems"}},
+		{"minItems", `{"minItems": 1}`, `[]`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "minItems"}},
+		{"uniqueItems", `{"uniqueItems": true}`, `[{"a": 1}, {"a": 1.0}]`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "uniqueItems"}},
+		{"properties", `{"properties": {"a/b": {"properties": {"c": {"maximum": 1}}}}}`, `{"a/b": {"c": 2}}`, JSONSchemaValidation{SchemaLocation: "#/properties/a~1b/properties/c", DocumentLocation: "#/a~1b/c", FailedKeyword: "maximum"}},
+		{"patternProperties", `{"patternProperties": {"^x": {"type": "string"}}}`, `{"a": 1, "xa": 2}`, JSONSchemaValidation{SchemaLocation: "#/patternProperties/^x", DocumentLocation: "#/xa", FailedKeyword: "type"}},
+		{"additionalProperties", `{"properties": {"a": {}}, "patternProperties": {"^x": {}}, "additionalProperties": false}`, `{"a": 1, "xa": 2, "b": 3}`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "additionalProperties"}},
+		{"additionalProperties schema", `{"properties": {"a": {}}, "additionalProperties": {"type": "string"}}`, `{"a": 1, "b": "c"}`, JSONSchemaValidation{Valid: true}},
+		{"maxProperties", `{"maxProperties": 1}`, `{"a": 1, "b": 2}`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "maxProperties"}},
+		{"minProperties", `{"minProperties": 1}`, `{}`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "minProperties"}},
+		{"required", `{"required": ["a", "b"]}`, `{"a": 1}`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "required"}},
+		{"dependencies", `{"dependencies": {"a": ["b"]}}`, `{"a": 1}`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "dependencies"}},
+		{"schema dependencies", `{"dependencies": {"a": {"required": ["b"]}}}`, `{"c": 1}`, JSONSchemaValidation{Valid: true}},
+		{"allOf", `{"allOf": [{"minimum": 1}, {"maximum": 2}]}`, `3`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "allOf"}},
+		{"anyOf", `{"anyOf": [{"type": "string"}, {"maximum": 2}]}`, `1`, JSONSchemaValidation{Valid: true}},
+		{"not anyOf", `{"anyOf": [{"type": "string"}, {"maximum": 2}]}`, `3`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "anyOf"}},
+		{"oneOf", `{"oneOf": [{"type": "number"}, {"maximum": 2}]}`, `1`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "oneOf"}},
+		{"not", `{"not": {"type": "null"}}`, `null`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#", FailedKeyword: "not"}},
+		{"ref", `{"definitions": {"pos": {"minimum": 0}}, "items": {"$ref": "#/definitions/pos"}}`, `[1, -1]`, JSONSchemaValidation{SchemaLocation: "#/definitions/pos", DocumentLocation: "#/1", FailedKeyword: "minimum"}},
+		{"recursive ref", `{"type": "object", "properties": {"next": {"$ref": "#"}}}`, `{"next": {"next": 1}}`, JSONSchemaValidation{SchemaLocation: "#", DocumentLocation: "#/next/next", FailedKeyword: "type"}},
+		{"looping ref", `{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`, `1`, JSONSchemaValidation{Valid: true}},
+		{"unknown keywords", `{"format": "email", "title": "x"}`, `"a"`, JSONSchemaValidation{Valid: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema, err := ParseJSONSchema(MustJSON(test.schema))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, schema.Validate(MustJSON(test.doc)))
+		})
+	}
+}
+
+func TestParseJSONSchemaErrors(t *testing.T) {
+	tests := []string{
+		`[]`,
+		`{"type": "text"}`,
+		`{"type": []}`,
+		`{"enum": []}`,
+		`{"minimum": "1"}`,
+		`{"multipleOf": 0}`,
+		`{"maxLength": -1}`,
+		`{"minItems": 1.5}`,
+		`{"pattern": "("}`,
+		`{"items": 1}`,
+		`{"required": "a"}`,
+		`{"properties": {"a": 1}}`,
+		`{"additionalProperties": "a"}`,
+		`{"anyOf": {}}`,
+		`{"$ref": "#/definitions/a"}`,
+		`{"$ref": "#/type", "type": "string"}`,
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseJSONSchema(MustJSON(test))
+			require.Error(t, err)
+			assert.True(t, ErrInvalidJSONSchema.Is(err), err.Error())
+		})
+	}
+
+	_, err := ParseJSONSchema(MustJSON(`{"$ref": "http://example.com/schema"}`))
+	assert.True(t, ErrUnsupportedFeature.Is(err))
+}
+
+func TestJSONSchemaValidationReport(t *testing.T) {
+	assert.Equal(t, MustJSON(`{"valid": true}`), JSONSchemaValidation{Valid: true}.Report())
+
+	schema, err := ParseJSONSchema(MustJSON(`{"properties": {"latitude": {"maximum": 90}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, MustJSON(`{
+		"valid": false,
+		"reason": "The JSON document location '#/latitude' failed requirement 'maximum' at JSON Schema location '#/properties/latitude'",
+		"schema-location": "#/properties/latitude",
+		"document-location": "#/latitude",
+		"schema-failed-keyword": "maximum"
+	}`), schema.Validate(MustJSON(`{"latitude": 91}`)).Report())
+}
@@ -16,10 +16,12 @@ package sql
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
 	querypb "github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/shopspring/decimal"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,7 +33,7 @@ func TestJsonCompare(t *testing.T) {
 		right string
 		cmp   int
 	}{
-		// type precedence hierarchy: BOOLEAN, ARRAY, OBJECT, STRING, DOUBLE, NULL
+		// type precedence hierarchy: BOOLEAN, ARRAY, OBJECT, STRING, INTEGER, DOUBLE, NULL
 		{`true`, `[0]`, 1},
 		{`[0]`, `{"a": 0}`, 1},
 		{`{"a": 0}`, `"a"`, 1},
@@ -57,6 +59,12 @@ func TestJsonCompare(t *testing.T) {
 		{`0`, `0.0`, 0},
 		{`0`, `-1`, 1},
 		{`0`, `3.14`, -1},
+		{`9223372036854775806`, `9223372036854775807`, -1},
+		{`18446744073709551615`, `18446744073709551614`, 1},
+		{`18446744073709551615`, `-1`, 1},
+		{`9007199254740993`, `9007199254740992`, 1},
+		{`9007199254740993`, `9007199254740992.0`, 1},
+		{`9223372036854775807`, `9.223372036854776e18`, -1},
 
 		// arrays
 		{`[1,2]`, `[1,2]`, 0},
@@ -113,6 +121,12 @@ func TestJsonConvert(t *testing.T) {
 		{MustJSON(`{"field":"test"}`), MustJSON(`{"field":"test"}`), false},
 		{[]string{}, MustJSON(`[]`), false},
 		{[]string{`555-555-5555`}, MustJSON(`["555-555-5555"]`), false},
+		{int32(3), JSONDocument{Val: int64(3)}, false},
+		{uint64(math.MaxUint64), JSONDocument{Val: uint64(math.MaxUint64)}, false},
+		{decimal.RequireFromString("1.50"), JSONDocument{Val: decimal.RequireFromString("1.50")}, false},
+		{`1234567890123456789`, JSONDocument{Val: int64(1234567890123456789)}, false},
+		{`{"a": `, nil, true},
+		{`1 2`, nil, true},
 	}
 
 	for _, test := range tests {
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/oliveagle/jsonpath"
+	"github.com/shopspring/decimal"
 )
 
 // JSONValue is an integrator specific implementation of a JSON field value.
@@ -41,14 +42,15 @@ type SearchableJSONValue interface {
 
 	// Contains is value-specific implementation of JSON_Contains()
 	Contains(ctx *Context, candidate JSONValue) (val interface{}, err error)
-	// Extract is value-specific implementation of JSON_Extract()
+	// Extract is value-specific implementation of JSON_Extract(). It returns nil if the path doesn't match any value.
 	Extract(ctx *Context, path string) (val JSONValue, err error)
-	// Keys is value-specific implementation of JSON_Keys()
+	// Keys is value-specific implementation of JSON_Keys(). It returns nil if the path doesn't select an object.
 	Keys(ctx *Context, path string) (val JSONValue, err error)
 	// Overlaps is value-specific implementation of JSON_Overlaps()
 	Overlaps(ctx *Context, val SearchableJSONValue) (ok bool, err error)
-	// Search is value-specific implementation of JSON_Search()
-	Search(ctx *Context) (path string, err error)
+	// Search is value-specific implementation of JSON_Search(). It returns the paths to the strings selected by any of
+	// the paths given that match, or just the first of them if all is false. It returns nil if no string matches.
+	Search(ctx *Context, match func(string) bool, all bool, paths ...JSONPath) (val JSONValue, err error)
 }
 
 type JSONDocument struct {
@@ -70,7 +72,7 @@ func (doc JSONDocument) Compare(ctx *Context, v JSONValue) (int, error) {
 }
 
 func (doc JSONDocument) ToString(_ *Context) (string, error) {
-	bb, err := json.Marshal(doc.Val)
+	bb, err := json.Marshal(marshallableJSON(doc.Val))
 	return string(bb), err
 }
 
@@ -92,22 +94,95 @@ func (doc JSONDocument) Extract(ctx *Context, path string) (JSONValue, error) {
 		return nil, err
 	}
 
-	// TODO(andy) handle error
-	val, _ := c.Lookup(doc.Val) // err ignored
+	val, err := c.Lookup(doc.Val)
+	if err != nil {
+		// The path doesn't match any value of the document
+		return nil, nil
+	}
 
 	return JSONDocument{Val: val}, nil
 }
 
-func (doc JSONDocument) Keys(ctx *Context, path string) (val JSONValue, err error) {
-	panic("not implemented")
+func (doc JSONDocument) Keys(ctx *Context, path string) (JSONValue, error) {
+	p, err := ParseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if p.ContainsWildcard() {
+		return nil, ErrInvalidJSONPathWildcard.New()
+	}
+
+	vals := doc.Find(p)
+	if len(vals) == 0 {
+		return nil, nil
+	}
+	obj, ok := vals[0].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	keys := make([]interface{}, 0, len(obj))
+	for _, key := range sortedJSONKeys(obj) {
+		keys = append(keys, key)
+	}
+	return JSONDocument{Val: keys}, nil
 }
 
-func (doc JSONDocument) Overlaps(ctx *Context, val SearchableJSONValue) (ok bool, err error) {
-	panic("not implemented")
+func (doc JSONDocument) Overlaps(ctx *Context, val SearchableJSONValue) (bool, error) {
+	other, err := val.Unmarshall(ctx)
+	if err != nil {
+		return false, err
+	}
+	return overlapsJSON(doc.Val, other.Val)
 }
 
-func (doc JSONDocument) Search(ctx *Context) (path string, err error) {
-	panic("not implemented")
+func (doc JSONDocument) Search(ctx *Context, match func(string) bool, all bool, paths ...JSONPath) (JSONValue, error) {
+	if len(paths) == 0 {
+		paths = []JSONPath{{}}
+	}
+
+	var found []interface{}
+	seen := make(map[string]struct{})
+	for _, p := range paths {
+		visitJSONPath(doc.Val, p.legs, nil, func(at []jsonPathLeg, val interface{}) bool {
+			// Every string within the selected value is searched
+			return visitJSONPath(val, []jsonPathLeg{{typ: jsonPathDoubleWildcard}}, at, func(at []jsonPathLeg, val interface{}) bool {
+				s, ok := val.(string)
+				if !ok || !match(s) {
+					return true
+				}
+				path := JSONPath{legs: at}.String()
+				if _, ok := seen[path]; !ok {
+					seen[path] = struct{}{}
+					found = append(found, path)
+				}
+				return all
+			})
+		})
+		if len(found) > 0 && !all {
+			break
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return JSONDocument{Val: found[0]}, nil
+	default:
+		return JSONDocument{Val: found}, nil
+	}
+}
+
+// Find returns the values the path given selects in the document, in document order. A path without wildcards
+// selects at most one value.
+func (doc JSONDocument) Find(path JSONPath) []interface{} {
+	var vals []interface{}
+	visitJSONPath(doc.Val, path.legs, nil, func(_ []jsonPathLeg, val interface{}) bool {
+		vals = append(vals, val)
+		return true
+	})
+	return vals
 }
 
 func ConcatenateJSONValues(ctx *Context, vals ...JSONValue) (JSONValue, error) {
@@ -136,13 +211,61 @@ func containsJSON(a, b interface{}) (interface{}, error) {
 		return containsJSONBool(a, b)
 	case string:
 		return containsJSONString(a, b)
-	case float64:
+	case int64, uint64, float64, decimal.Decimal:
 		return containsJSONNumber(a, b)
 	default:
 		return false, ErrInvalidType.New(a)
 	}
 }
 
+// overlapsJSON returns whether a and b have any key-value pairs or array elements in common. A value that isn't an
+// array is treated as an array holding just that value, unless both values are objects.
+func overlapsJSON(a, b interface{}) (bool, error) {
+	aObj, aIsObj := a.(map[string]interface{})
+	bObj, bIsObj := b.(map[string]interface{})
+	if aIsObj && bIsObj {
+		for key, aVal := range aObj {
+			bVal, ok := bObj[key]
+			if !ok {
+				continue
+			}
+			cmp, err := compareJSON(aVal, bVal)
+			if err != nil {
+				return false, err
+			}
+			if cmp == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	aArr, aIsArr := a.([]interface{})
+	bArr, bIsArr := b.([]interface{})
+	if !aIsArr && !bIsArr && (aIsObj || bIsObj) {
+		// An object never overlaps a scalar
+		return false, nil
+	}
+	if !aIsArr {
+		aArr = []interface{}{a}
+	}
+	if !bIsArr {
+		bArr = []interface{}{b}
+	}
+	for _, aVal := range aArr {
+		for _, bVal := range bArr {
+			cmp, err := compareJSON(aVal, bVal)
+			if err != nil {
+				return false, err
+			}
+			if cmp == 0 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 func containsJSONBool(a bool, b interface{}) (bool, error) {
 	switch b := b.(type) {
 	case bool:
@@ -240,13 +363,9 @@ func containsJSONString(a string, b interface{}) (bool, error) {
 	}
 }
 
-func containsJSONNumber(a float64, b interface{}) (bool, error) {
-	switch b := b.(type) {
-	case float64:
-		return a == b, nil
-	default:
-		return false, nil
-	}
+func containsJSONNumber(a, b interface{}) (bool, error) {
+	cmp, ok := compareJSONNumbers(a, b)
+	return ok && cmp == 0, nil
 }
 
 // JSON values can be compared using the =, <, <=, >, >=, <>, !=, and <=> operators. BETWEEN IN() GREATEST() LEAST() are
@@ -262,7 +381,7 @@ func containsJSONNumber(a float64, b interface{}) (bool, error) {
 //
 //			BLOB, BIT, OPAQUE, DATETIME, TIME, DATE, BOOLEAN, ARRAY, OBJECT, STRING, INTEGER, DOUBLE, NULL
 //			TODO(andy): implement BLOB BIT OPAQUE DATETIME TIME DATE
-//	     current precedence: BOOLEAN, ARRAY, OBJECT, STRING, DOUBLE, NULL
+//	     current precedence: BOOLEAN, ARRAY, OBJECT, STRING, INTEGER, DOUBLE, NULL
 //
 // For JSON values of the same precedence, the comparison rules are type specific:
 //
@@ -290,7 +409,7 @@ func containsJSONNumber(a float64, b interface{}) (bool, error) {
 //     binary collation, comparison of JSON values is case-sensitive:
 //     e.g.   "A" < "a"
 //
-//   - DOUBLE
+//   - INTEGER, DOUBLE
 //     JSON values can contain exact-value numbers and approximate-value numbers. For a general discussion of these
 //     types of numbers, see Section 9.1.2, “Numeric Literals”. The rules for comparing native MySQL numeric types are
 //     discussed in Section 12.3, “Type Conversion in Expression Evaluation”, but the rules for comparing numbers
@@ -310,7 +429,7 @@ func containsJSONNumber(a float64, b interface{}) (bool, error) {
 //   - NULL
 //     For comparison of any JSON value to SQL NULL, the result is UNKNOWN.
 //
-//     TODO(andy): BLOB, BIT, OPAQUE, DATETIME, TIME, DATE, INTEGER
+//     TODO(andy): BLOB, BIT, OPAQUE, DATETIME, TIME, DATE
 //
 // https://dev.mysql.com/doc/refman/8.0/en/json.html#json-comparison
 func compareJSON(a, b interface{}) (int, error) {
@@ -327,7 +446,7 @@ func compareJSON(a, b interface{}) (int, error) {
 		return compareJSONObject(a, b)
 	case string:
 		return compareJSONString(a, b)
-	case float64:
+	case int64, uint64, float64, decimal.Decimal:
 		return compareJSONNumber(a, b)
 	default:
 		return 0, ErrInvalidType.New(a)
@@ -442,8 +561,8 @@ func compareJSONString(a string, b interface{}) (int, error) {
 	}
 }
 
-func compareJSONNumber(a float64, b interface{}) (int, error) {
-	switch b := b.(type) {
+func compareJSONNumber(a, b interface{}) (int, error) {
+	switch b.(type) {
 	case
 		bool,
 		[]interface{},
@@ -452,14 +571,9 @@ func compareJSONNumber(a float64, b interface{}) (int, error) {
 		// a is lower precedence
 		return -1, nil
 
-	case float64:
-		if a > b {
-			return 1, nil
-		}
-		if a < b {
-			return -1, nil
-		}
-		return 0, nil
+	case int64, uint64, float64, decimal.Decimal:
+		cmp, _ := compareJSONNumbers(a, b)
+		return cmp, nil
 
 	default:
 		// a is higher precedence
@@ -0,0 +1,145 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDocumentFind(t *testing.T) {
+	doc := MustJSON(`{"a": [1, {"b": 2}], "c": {"b": 3}}`)
+
+	tests := []struct {
+		path     string
+		expected []interface{}
+	}{
+		{`$`, []interface{}{doc.Val}},
+		{`$.a[0]`, []interface{}{int64(1)}},
+		{`$.a[last].b`, []interface{}{int64(2)}},
+		{`$.c[0].b`, []interface{}{int64(3)}},
+		{`$.c[1]`, nil},
+		{`$.d`, nil},
+		{`$.a[*]`, []interface{}{int64(1), map[string]interface{}{"b": int64(2)}}},
+		{`$**.b`, []interface{}{int64(2), int64(3)}},
+		{`$.*.b`, []interface{}{int64(3)}},
+		{`$.a[1 to 5]`, []interface{}{map[string]interface{}{"b": int64(2)}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, doc.Find(MustParseJSONPath(test.path)))
+		})
+	}
+}
+
+func TestJSONDocumentKeys(t *testing.T) {
+	ctx := NewEmptyContext()
+	doc := MustJSON(`{"b": 1, "a": {"c": [1], "b": 2}}`)
+
+	keys, err := doc.Keys(ctx, `$`)
+	require.NoError(t, err)
+	assert.Equal(t, MustJSON(`["a", "b"]`), keys)
+
+	keys, err = doc.Keys(ctx, `$.a`)
+	require.NoError(t, err)
+	assert.Equal(t, MustJSON(`["b", "c"]`), keys)
+
+	keys, err = doc.Keys(ctx, `$.a.c`)
+	require.NoError(t, err)
+	assert.Nil(t, keys)
+
+	keys, err = doc.Keys(ctx, `$.d`)
+	require.NoError(t, err)
+	assert.Nil(t, keys)
+
+	_, err = doc.Keys(ctx, `$.*`)
+	assert.True(t, ErrInvalidJSONPathWildcard.Is(err))
+}
+
+func TestJSONDocumentOverlaps(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{`[1, 3, 5, 7]`, `[2, 5, 7]`, true},
+		{`[1, 3, 5, 7]`, `[2, 6, 8]`, false},
+		{`[[1, 2], [3, 4], 5]`, `[1, [2, 3], [4, 5]]`, false},
+		{`[[1, 2], 3]`, `[[1, 2]]`, true},
+		{`{"a": 1, "b": 10, "d": 10}`, `{"c": 1, "e": 10, "f": 1, "d": 10}`, true},
+		{`{"a": 1, "b": 10, "d": 10}`, `{"a": 5, "e": 10, "f": 1, "d": 20}`, false},
+		{`{"a": 1}`, `1`, false},
+		{`[{"a": 1}]`, `{"a": 1}`, true},
+		{`5`, `[1, 5]`, true},
+		{`"a"`, `"a"`, true},
+		{`5`, `6`, false},
+	}
+
+	ctx := NewEmptyContext()
+	for _, test := range tests {
+		t.Run(test.a+" "+test.b, func(t *testing.T) {
+			result, err := MustJSON(test.a).Overlaps(ctx, MustJSON(test.b))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+			result, err = MustJSON(test.b).Overlaps(ctx, MustJSON(test.a))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestJSONDocumentSearch(t *testing.T) {
+	doc := MustJSON(`["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]`)
+	prefix := func(p string) func(string) bool {
+		return func(s string) bool { return strings.HasPrefix(s, p) }
+	}
+
+	tests := []struct {
+		name     string
+		match    func(string) bool
+		all      bool
+		paths    []string
+		expected interface{}
+	}{
+		{"one", prefix("abc"), false, nil, JSONDocument{Val: "$[0]"}},
+		{"all", prefix("abc"), true, nil, MustJSON(`["$[0]", "$[2].x"]`)},
+		{"no match", prefix("ghi"), true, nil, nil},
+		{"nested", prefix("1"), true, nil, JSONDocument{Val: "$[1][0].k"}},
+		{"path", prefix("abc"), true, []string{`$[2]`}, JSONDocument{Val: "$[2].x"}},
+		{"wildcard path", prefix(""), true, []string{`$[*].y`}, JSONDocument{Val: "$[3].y"}},
+		{"overlapping paths", prefix("abc"), true, []string{`$`, `$[0]`, `$[2]`}, MustJSON(`["$[0]", "$[2].x"]`)},
+	}
+
+	ctx := NewEmptyContext()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var paths []JSONPath
+			for _, p := range test.paths {
+				paths = append(paths, MustParseJSONPath(p))
+			}
+			result, err := doc.Search(ctx, test.match, test.all, paths...)
+			require.NoError(t, err)
+			if test.expected == nil {
+				assert.Nil(t, result)
+			} else {
+				assert.Equal(t, test.expected, result)
+			}
+		})
+	}
+}
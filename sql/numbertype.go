@@ -971,6 +971,89 @@ func convertToUint64(t numberTypeImpl, v interface{}) (uint64, error) {
 	}
 }
 
+// ClampNumber returns the value of the number or decimal type given that is closest to |v|, which is outside the
+// type's range. This is the value that is written in place of an out of range value when strict mode is not enabled.
+// Returns false if the type is not a number or decimal type or the value is not a number.
+func ClampNumber(t Type, v interface{}) (interface{}, bool) {
+	if dt, ok := t.(decimalType); ok {
+		return dt.clamp(v)
+	}
+	nt, ok := t.(numberTypeImpl)
+	if !ok {
+		return nil, false
+	}
+	f, err := convertToFloat64(nt, v)
+	if err != nil {
+		return nil, false
+	}
+	negative := f < 0
+
+	switch nt.baseType {
+	case sqltypes.Int8:
+		if negative {
+			return int8(math.MinInt8), true
+		}
+		return int8(math.MaxInt8), true
+	case sqltypes.Uint8:
+		if negative {
+			return uint8(0), true
+		}
+		return uint8(math.MaxUint8), true
+	case sqltypes.Int16:
+		if negative {
+			return int16(math.MinInt16), true
+		}
+		return int16(math.MaxInt16), true
+	case sqltypes.Uint16:
+		if negative {
+			return uint16(0), true
+		}
+		return uint16(math.MaxUint16), true
+	case sqltypes.Int24:
+		if negative {
+			return int32(-1 << 23), true
+		}
+		return int32(1<<23 - 1), true
+	case sqltypes.Uint24:
+		if negative {
+			return uint32(0), true
+		}
+		return uint32(1<<24 - 1), true
+	case sqltypes.Int32:
+		if negative {
+			return int32(math.MinInt32), true
+		}
+		return int32(math.MaxInt32), true
+	case sqltypes.Uint32:
+		if negative {
+			return uint32(0), true
+		}
+		return uint32(math.MaxUint32), true
+	case sqltypes.Int64:
+		if negative {
+			return int64(math.MinInt64), true
+		}
+		return int64(math.MaxInt64), true
+	case sqltypes.Uint64:
+		if negative {
+			return uint64(0), true
+		}
+		return uint64(math.MaxUint64), true
+	case sqltypes.Float32:
+		if negative {
+			return float32(-math.MaxFloat32), true
+		}
+		return float32(math.MaxFloat32), true
+	case sqltypes.Float64:
+		if negative {
+			return -math.MaxFloat64, true
+		}
+		return math.MaxFloat64, true
+	default:
+		return nil, false
+	}
+}
+
 func convertToFloat64(t numberTypeImpl, v interface{}) (float64, error) {
 	switch v := v.(type) {
 	case int:
@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -283,3 +284,28 @@ func TestNumberString(t *testing.T) {
 		})
 	}
 }
+
+func TestClampNumber(t *testing.T) {
+	clamped, ok := ClampNumber(Int8, 300)
+	assert.True(t, ok)
+	assert.Equal(t, int8(127), clamped)
+
+	clamped, ok = ClampNumber(Uint16, -3)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0), clamped)
+
+	clamped, ok = ClampNumber(Int24, "-1e10")
+	assert.True(t, ok)
+	assert.Equal(t, int32(-1<<23), clamped)
+
+	clamped, ok = ClampNumber(MustCreateDecimalType(4, 1), 999.99)
+	assert.True(t, ok)
+	assert.Equal(t, "999.9", clamped.(decimal.Decimal).String())
+
+	clamped, ok = ClampNumber(MustCreateDecimalType(4, 1), "-12345")
+	assert.True(t, ok)
+	assert.Equal(t, "-999.9", clamped.(decimal.Decimal).String())
+
+	_, ok = ClampNumber(LongText, 300)
+	assert.False(t, ok)
+}
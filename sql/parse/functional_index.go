@@ -0,0 +1,137 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/vterrors"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// The parser only supports columns as the key parts of indexes, so before parsing, every functional key part, which
+// is an expression in parentheses, is replaced with a quoted identifier holding the expression behind a marker. The
+// expression is parsed again when the key part is converted.
+
+// functionalKeyPartMarker starts the identifiers that replace functional key parts. MySQL identifiers can't hold
+// this character, so it can't be confused with the name of a column.
+const functionalKeyPartMarker = "\x00"
+
+// indexKeywords are the keywords that may be followed by the key parts of an index, possibly after its name, its
+// type and the table it's created on.
+var indexKeywords = []string{"INDEX", "KEY", "UNIQUE", "FULLTEXT", "SPATIAL"}
+
+// rewriteFunctionalKeyParts replaces the functional key parts of the indexes declared by the CREATE or ALTER
+// statement given with identifiers holding their expressions. Along with the rewritten query, it returns a function
+// that maps an offset of the rewritten query to the offset of the original query it came from.
+func rewriteFunctionalKeyParts(query string) (string, func(int) int) {
+	lower := strings.ToLower(query)
+	if !strings.Contains(lower, "key") && !strings.Contains(lower, "index") && !strings.Contains(lower, "unique") &&
+		!strings.Contains(lower, "fulltext") && !strings.Contains(lower, "spatial") {
+		return query, noQueryEdits
+	}
+
+	tokens := scanQueryTokens(query)
+	if len(tokens) == 0 || !(tokenIs(tokens[0], "CREATE") || tokenIs(tokens[0], "ALTER")) {
+		return query, noQueryEdits
+	}
+
+	var edits []queryEdit
+	for i := 0; i < len(tokens); i++ {
+		if !isIndexKeyword(tokens, i) {
+			continue
+		}
+		open := keyPartsStart(tokens, i)
+		if open < 0 {
+			continue
+		}
+		close := matchingParen(tokens, open)
+		if close < 0 {
+			continue
+		}
+
+		for j := open + 1; j < close; j++ {
+			if !tokenIs(tokens[j], "(") || !isKeyPartStart(tokens, j, open) {
+				continue
+			}
+			end := matchingParen(tokens, j)
+			expr := query[tokens[j].end:tokens[end].start]
+			edits = append(edits, queryEdit{
+				start: tokens[j].start,
+				end:   tokens[end].end,
+				text:  "`" + functionalKeyPartMarker + strings.ReplaceAll(expr, "`", "``") + "`",
+			})
+			j = end
+		}
+		i = close
+	}
+
+	if len(edits) == 0 {
+		return query, noQueryEdits
+	}
+	return applyQueryEdits(query, edits)
+}
+
+// isIndexKeyword returns whether the token at the index given is a keyword that may be followed by the key parts of
+// an index. The columns of foreign keys are never expressions, so they are skipped.
+func isIndexKeyword(tokens []queryToken, i int) bool {
+	for _, keyword := range indexKeywords {
+		if tokenIs(tokens[i], keyword) {
+			return i == 0 || !tokenIs(tokens[i-1], "FOREIGN")
+		}
+	}
+	return false
+}
+
+// keyPartsStart returns the index of the parenthesis that opens the key parts following the keyword at the index
+// given, or -1 if the keyword isn't followed by key parts. Only names may come between the keyword and the key parts.
+func keyPartsStart(tokens []queryToken, i int) int {
+	for i++; i < len(tokens); i++ {
+		switch t := tokens[i]; {
+		case isNameToken(t), tokenIs(t, "."):
+		case tokenIs(t, "("):
+			return i
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// isKeyPartStart returns whether the token at the index given starts a key part of the list opened at the index
+// given, that is whether it directly follows the opening parenthesis or a comma.
+func isKeyPartStart(tokens []queryToken, i, open int) bool {
+	return i-1 == open || tokenIs(tokens[i-1], ",")
+}
+
+// functionalKeyPart returns the expression of the key part with the name given, or nil if the key part is a column.
+func functionalKeyPart(ctx *sql.Context, name string) (sql.Expression, error) {
+	if !strings.HasPrefix(name, functionalKeyPartMarker) {
+		return nil, nil
+	}
+	def, err := StringToColumnDefaultValue(ctx, strings.TrimPrefix(name, functionalKeyPartMarker))
+	if se, ok := vterrors.AsSyntaxError(err); ok {
+		// The key part was hidden from the parser, so its syntax errors are reported like those of the statement
+		return nil, sql.ErrSyntaxError.New(se.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	if _, ok := def.Expression.(*expression.UnresolvedColumn); ok {
+		return nil, sql.ErrFunctionalIndexOnField.New()
+	}
+	return def.Expression, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteFunctionalKeyParts(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"CREATE INDEX idx ON t (a, b(10))", "CREATE INDEX idx ON t (a, b(10))"},
+		{"CREATE INDEX idx ON t ((lower(a)))", "CREATE INDEX idx ON t (`\x00lower(a)`)"},
+		{"create unique index idx using btree on db.t (a, (a + `b``c`) desc)", "create unique index idx using btree on db.t (a, `\x00a + ``b````c``` desc)"},
+		{"CREATE TABLE t (a int, KEY ((a + 1)), UNIQUE KEY u ((a * 2)))", "CREATE TABLE t (a int, KEY (`\x00a + 1`), UNIQUE KEY u (`\x00a * 2`))"},
+		{"CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES p (a), CHECK ((a > 0)))", "CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES p (a), CHECK ((a > 0)))"},
+		{"ALTER TABLE t ADD INDEX ((a + 1)), ADD COLUMN b int DEFAULT (1)", "ALTER TABLE t ADD INDEX (`\x00a + 1`), ADD COLUMN b int DEFAULT (1)"},
+		{"SELECT * FROM t USE INDEX ((a))", "SELECT * FROM t USE INDEX ((a))"},
+		{"CREATE INDEX idx ON t ((concat(a, ' key (x)')))", "CREATE INDEX idx ON t (`\x00concat(a, ' key (x)')`)"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			rewritten, _ := rewriteFunctionalKeyParts(tt.query)
+			require.Equal(t, tt.expected, rewritten)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// maxExecutionTimeHintRegex matches the MAX_EXECUTION_TIME optimizer hint, capturing the timeout in milliseconds.
+var maxExecutionTimeHintRegex = regexp.MustCompile(`(?i)\bmax_execution_time\s*\(\s*(\d+)\s*\)`)
+
+// maxExecutionTimeHint returns whether the statement given is a SELECT statement, which are the only statements that
+// MySQL limits the execution time of, along with the timeout in milliseconds given by its MAX_EXECUTION_TIME optimizer
+// hint. As in MySQL, the hint must follow the first SELECT keyword of the statement, and hints with an out of range
+// timeout are ignored. The last result is false if no valid hint was given.
+func maxExecutionTimeHint(stmt sqlparser.Statement) (isSelect bool, timeout int64, ok bool) {
+	ss, isSelect := stmt.(sqlparser.SelectStatement)
+	if !isSelect {
+		return false, 0, false
+	}
+	sel := firstSelect(ss)
+	if sel == nil {
+		return true, 0, false
+	}
+	for _, comment := range sel.Comments {
+		if !strings.HasPrefix(string(comment), "/*+") {
+			continue
+		}
+		if hint := maxExecutionTimeHintRegex.FindSubmatch(comment); hint != nil {
+			if timeout, err := strconv.ParseUint(string(hint[1]), 10, 32); err == nil {
+				return true, int64(timeout), true
+			}
+		}
+	}
+	return true, 0, false
+}
+
+// firstSelect returns the SELECT that comes first in the statement given, or nil if there isn't one.
+func firstSelect(ss sqlparser.SelectStatement) *sqlparser.Select {
+	switch n := ss.(type) {
+	case *sqlparser.Select:
+		return n
+	case *sqlparser.Union:
+		return firstSelect(n.Left)
+	case *sqlparser.ParenSelect:
+		return firstSelect(n.Select)
+	default:
+		return nil
+	}
+}
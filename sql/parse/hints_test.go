@@ -0,0 +1,56 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestMaxExecutionTimeHint(t *testing.T) {
+	testCases := []struct {
+		query    string
+		isSelect bool
+		timeout  int64
+		ok       bool
+	}{
+		{"SELECT 1", true, 0, false},
+		{"SELECT /*+ MAX_EXECUTION_TIME(100) */ 1", true, 100, true},
+		{"select /*+ JOIN_ORDER(a, b) max_execution_time( 20 ) */ * from a join b", true, 20, true},
+		{"/* comment */ SELECT /*+ MAX_EXECUTION_TIME(100) */ 1;", true, 100, true},
+		{"SELECT /* MAX_EXECUTION_TIME(100) */ 1", true, 0, false},
+		{"SELECT /*+ MAX_EXECUTION_TIME(4294967296) */ 1", true, 0, false},
+		{"WITH cte AS (SELECT 1) SELECT /*+ MAX_EXECUTION_TIME(100) */ * FROM cte", true, 100, true},
+		{"SELECT /*+ MAX_EXECUTION_TIME(100) */ 1 UNION SELECT 2", true, 100, true},
+		{"SELECT 1 UNION SELECT /*+ MAX_EXECUTION_TIME(100) */ 2", true, 0, false},
+		{"INSERT INTO a SELECT /*+ MAX_EXECUTION_TIME(100) */ 1", false, 0, false},
+		{"SET @x = 1", false, 0, false},
+		{"SELEC 1", false, 0, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			_, _ = Parse(ctx, tt.query)
+			isSelect, timeout, ok := ctx.MaxExecutionTimeHint()
+			require.Equal(t, tt.isSelect, isSelect)
+			require.Equal(t, tt.timeout, timeout)
+			require.Equal(t, tt.ok, ok)
+		})
+	}
+}
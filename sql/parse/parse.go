@@ -51,7 +51,7 @@ var (
 	tableCollationOptionRegex = regexp.MustCompile(`(?i)(DEFAULT)?\s+COLLATE((\s*=?\s*)|\s+)([A-Za-z0-9_]+)`)
 )
 
-var describeSupportedFormats = []string{"tree"}
+var describeSupportedFormats = []string{"tree", "json"}
 
 // These constants aren't exported from vitess for some reason. This could be removed if we changed this.
 const (
@@ -75,6 +75,9 @@ func ParseOne(ctx *sql.Context, query string) (sql.Node, string, string, error)
 }
 
 func parse(ctx *sql.Context, query string, multi bool) (sql.Node, string, string, error) {
+	// The hint is recorded on the context given, rather than on the one for the span, so that the engine can find it
+	ctx.SetMaxExecutionTimeHint(false, 0, false)
+	queryCtx := ctx
 	span, ctx := ctx.Span("parse", trace.WithAttributes(attribute.String("query", query)))
 	defer span.End()
 
@@ -89,12 +92,17 @@ func parse(ctx *sql.Context, query string, multi bool) (sql.Node, string, string
 	var remainder string
 
 	parsed = s
+	rewritten, originalOffset, err := rewriteQuery(s)
+	if err != nil {
+		return nil, parsed, remainder, err
+	}
 	if !multi {
-		stmt, err = sqlparser.Parse(s)
+		stmt, err = sqlparser.Parse(rewritten)
 	} else {
 		var ri int
-		stmt, ri, err = sqlparser.ParseOne(s)
-		if ri != 0 && ri < len(s) {
+		stmt, ri, err = sqlparser.ParseOne(rewritten)
+		if ri != 0 && ri < len(rewritten) {
+			ri = originalOffset(ri)
 			parsed = s[:ri]
 			parsed = strings.TrimSpace(parsed)
 			if strings.HasSuffix(parsed, ";") {
@@ -112,11 +120,33 @@ func parse(ctx *sql.Context, query string, multi bool) (sql.Node, string, string
 		return nil, parsed, remainder, sql.ErrSyntaxError.New(err.Error())
 	}
 
+	if rewritten != s {
+		restoreInputExpressions(stmt, rewritten, s, originalOffset)
+		restoreSubStatementPositions(stmt, originalOffset)
+	}
+
+	queryCtx.SetMaxExecutionTimeHint(maxExecutionTimeHint(stmt))
+
 	node, err := convert(ctx, stmt, s)
 
 	return node, parsed, remainder, err
 }
 
+// rewriteQuery rewrites the query given into one that the parser accepts, returning the rewritten query along with
+// the function that maps offsets in it back to offsets in the original query.
+func rewriteQuery(s string) (string, func(int) int, error) {
+	rewritten, originalOffset := rewriteFunctionalKeyParts(s)
+	withoutModifiers, windowOffset, err := rewriteWindowFunctions(rewritten)
+	if err != nil {
+		return "", nil, err
+	}
+	if withoutModifiers != rewritten {
+		keyPartOffset := originalOffset
+		rewritten, originalOffset = withoutModifiers, func(i int) int { return keyPartOffset(windowOffset(i)) }
+	}
+	return rewritten, originalOffset, nil
+}
+
 // ParseColumnTypeString will return a SQL type for the given string that represents a column type.
 // For example, giving the string `VARCHAR(255)` will return the string SQL type with the internal type set to Varchar
 // and the length set to 255 with the default collation.
@@ -360,6 +390,8 @@ func convertExplain(ctx *sql.Context, n *sqlparser.Explain) (sql.Node, error) {
 	// tree format, do nothing
 	case "debug":
 		explainFmt = "debug"
+	case "json":
+		explainFmt = "json"
 	default:
 		return nil, errInvalidDescribeFormat.New(
 			n.ExplainFormat,
@@ -367,6 +399,16 @@ func convertExplain(ctx *sql.Context, n *sqlparser.Explain) (sql.Node, error) {
 		)
 	}
 
+	if _, ok := n.Statement.(sqlparser.SelectStatement); !ok && explainFmt == "json" {
+		return nil, sql.ErrUnsupportedFeature.New("EXPLAIN with JSON format for statements other than SELECT")
+	}
+
+	if n.Analyze {
+		if explainFmt == "json" {
+			return nil, sql.ErrUnsupportedFeature.New("EXPLAIN ANALYZE with JSON format")
+		}
+		return plan.NewExplainAnalyze(explainFmt, child), nil
+	}
 	return plan.NewDescribeQuery(explainFmt, child), nil
 }
 
@@ -966,8 +1008,11 @@ func ctesToWith(ctx *sql.Context, with *sqlparser.With, node sql.Node) (sql.Node
 }
 
 func intoToInto(ctx *sql.Context, into *sqlparser.Into, node sql.Node) (sql.Node, error) {
-	if into.Outfile != "" || into.Dumpfile != "" {
-		return nil, sql.ErrUnsupportedSyntax.New("select into files is not supported yet")
+	if into.Outfile != "" {
+		return plan.NewIntoOutfile(node, into.Outfile), nil
+	}
+	if into.Dumpfile != "" {
+		return plan.NewIntoDumpfile(node, into.Dumpfile), nil
 	}
 
 	vars := make([]sql.Expression, len(into.Variables))
@@ -1243,6 +1288,12 @@ func convertCall(ctx *sql.Context, c *sqlparser.Call) (sql.Node, error) {
 func convertDeclare(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
 	if d.Condition != nil {
 		return convertDeclareCondition(ctx, d)
+	} else if d.Variables != nil {
+		return convertDeclareVariables(ctx, d)
+	} else if d.Cursor != nil {
+		return convertDeclareCursor(ctx, d)
+	} else if d.Handler != nil {
+		return convertDeclareHandler(ctx, d)
 	}
 	return nil, sql.ErrUnsupportedSyntax.New(sqlparser.String(d))
 }
@@ -1262,12 +1313,101 @@ func convertDeclareCondition(ctx *sql.Context, d *sqlparser.Declare) (sql.Node,
 			// We use our own error instead
 			return nil, fmt.Errorf("invalid value '%s' for MySQL error code", string(dc.MysqlErrorCode.Val))
 		}
-		//TODO: implement MySQL error code support
-		return nil, sql.ErrUnsupportedSyntax.New(sqlparser.String(d))
+		return plan.NewDeclareCondition(strings.ToLower(dc.Name), int64(number), ""), nil
 	}
 	return plan.NewDeclareCondition(strings.ToLower(dc.Name), 0, dc.SqlStateValue), nil
 }
 
+func convertDeclareVariables(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
+	dVars := d.Variables
+	names := make([]string, len(dVars.Names))
+	for i, variable := range dVars.Names {
+		names[i] = variable.String()
+	}
+	typ, err := sql.ColumnTypeToType(&dVars.VarType)
+	if err != nil {
+		return nil, err
+	}
+	var defaultVal sql.Expression
+	if dVars.VarType.Default != nil {
+		defaultVal, err = ExprToExpression(ctx, dVars.VarType.Default)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plan.NewDeclareVariables(names, typ, defaultVal), nil
+}
+
+func convertDeclareCursor(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
+	dCursor := d.Cursor
+	selectStmt, err := convertSelectStatement(ctx, dCursor.SelectStmt)
+	if err != nil {
+		return nil, err
+	}
+	return plan.NewDeclareCursor(dCursor.Name, selectStmt), nil
+}
+
+func convertDeclareHandler(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
+	dHandler := d.Handler
+	var action plan.DeclareHandlerAction
+	switch dHandler.Action {
+	case sqlparser.DeclareHandlerAction_Continue:
+		action = plan.DeclareHandlerAction_Continue
+	case sqlparser.DeclareHandlerAction_Exit:
+		action = plan.DeclareHandlerAction_Exit
+	case sqlparser.DeclareHandlerAction_Undo:
+		action = plan.DeclareHandlerAction_Undo
+	default:
+		return nil, fmt.Errorf("unknown DECLARE ... HANDLER action: %v", dHandler.Action)
+	}
+
+	conditions := make([]plan.DeclareHandlerCondition, len(dHandler.ConditionValues))
+	for i, condition := range dHandler.ConditionValues {
+		switch condition.ValueType {
+		case sqlparser.DeclareHandlerCondition_MysqlErrorCode:
+			number, err := strconv.ParseUint(string(condition.MysqlErrorCode.Val), 10, 64)
+			if err != nil || number == 0 {
+				// We use our own error instead
+				return nil, fmt.Errorf("invalid value '%s' for MySQL error code", string(condition.MysqlErrorCode.Val))
+			}
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:         plan.DeclareHandlerConditionType_MysqlErrorCode,
+				MysqlErrCode: int64(number),
+			}
+		case sqlparser.DeclareHandlerCondition_SqlState:
+			if len(condition.String) != 5 {
+				return nil, fmt.Errorf("SQLSTATE VALUE must be a string with length 5 consisting of only integers")
+			}
+			if condition.String[0:2] == "00" {
+				return nil, fmt.Errorf("invalid SQLSTATE VALUE: '%s'", condition.String)
+			}
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:          plan.DeclareHandlerConditionType_SqlState,
+				SqlStateValue: condition.String,
+			}
+		case sqlparser.DeclareHandlerCondition_ConditionName:
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:          plan.DeclareHandlerConditionType_ConditionName,
+				ConditionName: strings.ToLower(condition.String),
+			}
+		case sqlparser.DeclareHandlerCondition_SqlWarning:
+			conditions[i] = plan.DeclareHandlerCondition{Type: plan.DeclareHandlerConditionType_SqlWarning}
+		case sqlparser.DeclareHandlerCondition_NotFound:
+			conditions[i] = plan.DeclareHandlerCondition{Type: plan.DeclareHandlerConditionType_NotFound}
+		case sqlparser.DeclareHandlerCondition_SqlException:
+			conditions[i] = plan.DeclareHandlerCondition{Type: plan.DeclareHandlerConditionType_SqlException}
+		default:
+			return nil, fmt.Errorf("unknown DECLARE ... HANDLER condition: %v", condition.ValueType)
+		}
+	}
+
+	statement, err := convert(ctx, dHandler.Statement, sqlparser.String(dHandler.Statement))
+	if err != nil {
+		return nil, err
+	}
+	return plan.NewDeclareHandler(action, conditions, statement)
+}
+
 func convertSignal(ctx *sql.Context, s *sqlparser.Signal) (sql.Node, error) {
 	// https://dev.mysql.com/doc/refman/8.0/en/signal.html#signal-condition-information-items
 	var err error
@@ -1487,7 +1627,7 @@ func convertAlterIndex(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
 		case sqlparser.UniqueStr:
 			constraint = sql.IndexConstraint_Unique
 		case sqlparser.FulltextStr:
-			return nil, sql.ErrUnsupportedFeature.New("fulltext keys are unsupported")
+			constraint = sql.IndexConstraint_Fulltext
 		case sqlparser.SpatialStr:
 			constraint = sql.IndexConstraint_Spatial
 		case sqlparser.PrimaryStr:
@@ -1496,7 +1636,7 @@ func convertAlterIndex(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
 			constraint = sql.IndexConstraint_None
 		}
 
-		columns, err := gatherIndexColumns(ddl.IndexSpec.Columns)
+		columns, err := gatherIndexColumns(ctx, ddl.IndexSpec.Columns, constraint)
 		if err != nil {
 			return nil, err
 		}
@@ -1529,11 +1669,27 @@ func convertAlterIndex(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
 	}
 }
 
-func gatherIndexColumns(cols []*sqlparser.IndexColumn) ([]sql.IndexColumn, error) {
+func gatherIndexColumns(ctx *sql.Context, cols []*sqlparser.IndexColumn, constraint sql.IndexConstraint) ([]sql.IndexColumn, error) {
 	out := make([]sql.IndexColumn, len(cols))
 	var length int64
-	var err error
 	for i, col := range cols {
+		expr, err := functionalKeyPart(ctx, col.Column.String())
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			switch constraint {
+			case sql.IndexConstraint_Primary:
+				return nil, sql.ErrFunctionalIndexPrimaryKey.New()
+			case sql.IndexConstraint_Fulltext:
+				return nil, sql.ErrFulltextFunctionalIndex.New()
+			case sql.IndexConstraint_Spatial:
+				return nil, sql.ErrSpatialFunctionalIndex.New()
+			}
+			out[i] = sql.IndexColumn{Expression: expr}
+			continue
+		}
+
 		if col.Length != nil {
 			if col.Length.Type == sqlparser.IntVal {
 				length, err = strconv.ParseInt(string(col.Length.Val), 10, 64)
@@ -1704,11 +1860,10 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 		} else if idxDef.Info.Spatial {
 			constraint = sql.IndexConstraint_Spatial
 		} else if idxDef.Info.Fulltext {
-			// TODO: We do not support FULLTEXT indexes or keys
-			return nil, sql.ErrUnsupportedFeature.New("fulltext keys are unsupported")
+			constraint = sql.IndexConstraint_Fulltext
 		}
 
-		columns, err := gatherIndexColumns(idxDef.Columns)
+		columns, err := gatherIndexColumns(ctx, idxDef.Columns, constraint)
 		if err != nil {
 			return nil, err
 		}
@@ -1730,7 +1885,16 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 
 	for _, colDef := range c.TableSpec.Columns {
 		if colDef.Type.KeyOpt == colKeyFulltextKey {
-			return nil, sql.ErrUnsupportedFeature.New("fulltext keys are unsupported")
+			idxDefs = append(idxDefs, &plan.IndexDefinition{
+				IndexName:  "",
+				Using:      sql.IndexUsing_Default,
+				Constraint: sql.IndexConstraint_Fulltext,
+				Comment:    "",
+				Columns: []sql.IndexColumn{{
+					Name:   colDef.Name.String(),
+					Length: 0,
+				}},
+			})
 		}
 		if colDef.Type.KeyOpt == colKeyUnique || colDef.Type.KeyOpt == colKeyUniqueKey {
 			idxDefs = append(idxDefs, &plan.IndexDefinition{
@@ -2151,6 +2315,25 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 		extra = "auto_increment"
 	}
 
+	var generated *sql.ColumnDefaultValue
+	if cd.Type.GeneratedExpr != nil {
+		if defaultVal != nil {
+			return nil, sql.ErrGeneratedColumnWrongUsage.New("DEFAULT")
+		}
+		if cd.Type.Autoincrement {
+			return nil, sql.ErrGeneratedColumnWrongUsage.New("AUTO_INCREMENT")
+		}
+		generated, err = convertGeneratedExpression(ctx, cd.Type.GeneratedExpr, internalTyp)
+		if err != nil {
+			return nil, err
+		}
+		if cd.Type.Stored {
+			extra = "STORED GENERATED"
+		} else {
+			extra = "VIRTUAL GENERATED"
+		}
+	}
+
 	if cd.Type.SRID != nil {
 		sridVal, sErr := strconv.ParseInt(string(cd.Type.SRID.Val), 10, 32)
 		if sErr != nil {
@@ -2175,9 +2358,21 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 		AutoIncrement: bool(cd.Type.Autoincrement),
 		Comment:       comment,
 		Extra:         extra,
+		Generated:     generated,
+		Virtual:       generated != nil && !bool(cd.Type.Stored),
 	}, nil
 }
 
+// convertGeneratedExpression returns the expression of a generated column as a column default value, which is how
+// generated expressions are resolved and evaluated.
+func convertGeneratedExpression(ctx *sql.Context, generatedExpr sqlparser.Expr, typ sql.Type) (*sql.ColumnDefaultValue, error) {
+	parsedExpr, err := ExprToExpression(ctx, generatedExpr)
+	if err != nil {
+		return nil, err
+	}
+	return sql.NewColumnDefaultValue(parsedExpr, typ, false, true, true)
+}
+
 func convertDefaultExpression(ctx *sql.Context, defaultExpr sqlparser.Expr) (*sql.ColumnDefaultValue, error) {
 	if defaultExpr == nil {
 		return nil, nil
@@ -2707,12 +2902,6 @@ func tableExprToTable(
 }
 
 func joinTableExpr(ctx *sql.Context, t *sqlparser.JoinTableExpr) (sql.Node, error) {
-	// TODO: add support for using, once we have proper table
-	// qualification of fields
-	if len(t.Condition.Using) > 0 {
-		return nil, sql.ErrUnsupportedFeature.New("USING clause on join")
-	}
-
 	left, err := tableExprToTable(ctx, t.LeftExpr)
 	if err != nil {
 		return nil, err
@@ -2727,6 +2916,20 @@ func joinTableExpr(ctx *sql.Context, t *sqlparser.JoinTableExpr) (sql.Node, erro
 		return plan.NewNaturalJoin(left, right), nil
 	}
 
+	if len(t.Condition.Using) > 0 {
+		using := columnsToStrings(t.Condition.Using)
+		switch strings.ToLower(t.Join) {
+		case sqlparser.JoinStr:
+			return plan.NewUsingJoin(left, right, plan.JoinTypeInner, using), nil
+		case sqlparser.LeftJoinStr:
+			return plan.NewUsingJoin(left, right, plan.JoinTypeLeftOuter, using), nil
+		case sqlparser.RightJoinStr:
+			return plan.NewUsingJoin(left, right, plan.JoinTypeRightOuter, using), nil
+		default:
+			return nil, sql.ErrUnsupportedFeature.New("USING clause on " + t.Join)
+		}
+	}
+
 	if t.Condition.On == nil {
 		return plan.NewCrossJoin(left, right), nil
 	}
@@ -3021,8 +3224,13 @@ func selectExprsToExpressions(ctx *sql.Context, se sqlparser.SelectExprs) ([]sql
 
 // StringToColumnDefaultValue takes in a string representing a default value and returns the equivalent Expression.
 func StringToColumnDefaultValue(ctx *sql.Context, exprStr string) (*sql.ColumnDefaultValue, error) {
-	// all valid default expressions will parse correctly with SELECT prepended, as the parser will not parse raw expressions
-	stmt, err := sqlparser.Parse("SELECT " + exprStr)
+	// all valid default expressions will parse correctly with SELECT prepended, as the parser will not parse raw expressions.
+	// Stored expressions are rewritten the same way as the queries they were written in.
+	query, _, err := rewriteQuery("SELECT " + exprStr)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := sqlparser.Parse(query)
 	if err != nil {
 		return nil, err
 	}
@@ -3175,7 +3383,11 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		if err != nil {
 			return nil, err
 		}
-		return expression.NewUnresolvedFunction(v.Name.Lowered(),
+		name := v.Name.Lowered()
+		if name == "nth_value" {
+			name, exprs = removeWindowFunctionMarkers(exprs)
+		}
+		return expression.NewUnresolvedFunction(name,
 			isAggregateFunc(v), over, exprs...), nil
 	case *sqlparser.GroupConcatExpr:
 		exprs, err := selectExprsToExpressions(ctx, v.Exprs)
@@ -3298,6 +3510,8 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		return caseExprToExpression(ctx, v)
 	case *sqlparser.IntervalExpr:
 		return intervalExprToExpression(ctx, v)
+	case *sqlparser.MatchExpr:
+		return matchExprToExpression(ctx, v)
 	case *sqlparser.CollateExpr:
 		return handleCollateExpr(ctx, ctx.GetCharacterSet(), v)
 	case *sqlparser.ValuesFuncExpr:
@@ -3540,6 +3754,9 @@ func comparisonExprToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr) (
 		if err != nil {
 			return nil, err
 		}
+	} else if sql.LoadSqlMode(ctx).ModeEnabled(sql.SqlModeNoBackslashEscapes) {
+		// With NO_BACKSLASH_ESCAPES, LIKE patterns have no escape character unless one is given
+		escape = expression.NewLiteral("", sql.LongText)
 	}
 
 	switch strings.ToLower(c.Operator) {
@@ -3772,7 +3989,25 @@ func binaryExprToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Exp
 	case
 		sqlparser.JSONExtractOp,
 		sqlparser.JSONUnquoteExtractOp:
-		return nil, sql.ErrUnsupportedFeature.New(fmt.Sprintf("(%s) JSON operators not supported", be.Operator))
+		// col->path is shorthand for JSON_EXTRACT(col, path), and col->>path for JSON_UNQUOTE(JSON_EXTRACT(col, path))
+		l, err := ExprToExpression(ctx, be.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := ExprToExpression(ctx, be.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		extract, err := function.NewJSONExtract(l, r)
+		if err != nil {
+			return nil, err
+		}
+		if be.Operator == sqlparser.JSONUnquoteExtractOp {
+			return function.NewJSONUnquote(extract), nil
+		}
+		return extract, nil
 
 	default:
 		return nil, sql.ErrUnsupportedFeature.New(be.Operator)
@@ -3830,6 +4065,40 @@ func intervalExprToExpression(ctx *sql.Context, e *sqlparser.IntervalExpr) (sql.
 	return expression.NewInterval(expr, e.Unit), nil
 }
 
+func matchExprToExpression(ctx *sql.Context, e *sqlparser.MatchExpr) (sql.Expression, error) {
+	var mode sql.FulltextMode
+	switch e.Option {
+	case "", sqlparser.NaturalLanguageModeStr:
+		mode = sql.FulltextNaturalLanguageMode
+	case sqlparser.BooleanModeStr:
+		mode = sql.FulltextBooleanMode
+	default:
+		return nil, sql.ErrUnsupportedFeature.New("WITH QUERY EXPANSION")
+	}
+
+	columns := make([]sql.Expression, len(e.Columns))
+	for i, selectExpr := range e.Columns {
+		aliased, ok := selectExpr.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, sql.ErrUnsupportedSyntax.New(sqlparser.String(e))
+		}
+		if _, ok := aliased.Expr.(*sqlparser.ColName); !ok {
+			return nil, sql.ErrUnsupportedSyntax.New(sqlparser.String(e))
+		}
+		col, err := ExprToExpression(ctx, aliased.Expr)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = col
+	}
+
+	against, err := ExprToExpression(ctx, e.Expr)
+	if err != nil {
+		return nil, err
+	}
+	return expression.NewMatch(columns, against, mode), nil
+}
+
 func setExprsToExpressions(ctx *sql.Context, e sqlparser.SetVarExprs) ([]sql.Expression, error) {
 	res := make([]sql.Expression, len(e))
 	for i, setExpr := range e {
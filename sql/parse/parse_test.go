@@ -31,6 +31,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
@@ -333,7 +334,7 @@ func TestParse(t *testing.T) {
 							IndexName:  "",
 							Using:      sql.IndexUsing_Default,
 							Constraint: sql.IndexConstraint_None,
-							Columns:    []sql.IndexColumn{{"b", 0}},
+							Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 							Comment:    "",
 						},
 					},
@@ -363,7 +364,7 @@ func TestParse(t *testing.T) {
 						IndexName:  "idx_name",
 						Using:      sql.IndexUsing_Default,
 						Constraint: sql.IndexConstraint_None,
-						Columns:    []sql.IndexColumn{{"b", 0}},
+						Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 						Comment:    "",
 					}},
 				},
@@ -392,7 +393,7 @@ func TestParse(t *testing.T) {
 						IndexName:  "idx_name",
 						Using:      sql.IndexUsing_Default,
 						Constraint: sql.IndexConstraint_None,
-						Columns:    []sql.IndexColumn{{"b", 0}},
+						Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 						Comment:    "hi",
 					}},
 				},
@@ -421,7 +422,7 @@ func TestParse(t *testing.T) {
 						IndexName:  "",
 						Using:      sql.IndexUsing_Default,
 						Constraint: sql.IndexConstraint_Unique,
-						Columns:    []sql.IndexColumn{{"b", 0}},
+						Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 						Comment:    "",
 					}},
 				},
@@ -450,7 +451,7 @@ func TestParse(t *testing.T) {
 						IndexName:  "",
 						Using:      sql.IndexUsing_Default,
 						Constraint: sql.IndexConstraint_Unique,
-						Columns:    []sql.IndexColumn{{"b", 0}},
+						Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 						Comment:    "",
 					}},
 				},
@@ -479,7 +480,7 @@ func TestParse(t *testing.T) {
 						IndexName:  "",
 						Using:      sql.IndexUsing_Default,
 						Constraint: sql.IndexConstraint_None,
-						Columns:    []sql.IndexColumn{{"b", 0}, {"a", 0}},
+						Columns:    []sql.IndexColumn{{Name: "b", Length: 0}, {Name: "a", Length: 0}},
 						Comment:    "",
 					}},
 				},
@@ -508,13 +509,13 @@ func TestParse(t *testing.T) {
 						IndexName:  "",
 						Using:      sql.IndexUsing_Default,
 						Constraint: sql.IndexConstraint_None,
-						Columns:    []sql.IndexColumn{{"b", 0}},
+						Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 						Comment:    "",
 					}, {
 						IndexName:  "",
 						Using:      sql.IndexUsing_Default,
 						Constraint: sql.IndexConstraint_None,
-						Columns:    []sql.IndexColumn{{"b", 0}, {"a", 0}},
+						Columns:    []sql.IndexColumn{{Name: "b", Length: 0}, {Name: "a", Length: 0}},
 						Comment:    "",
 					}},
 				},
@@ -1253,7 +1254,7 @@ CREATE TABLE t2
 				"",
 				sql.IndexUsing_BTree,
 				sql.IndexConstraint_None,
-				[]sql.IndexColumn{{"v1", 0}},
+				[]sql.IndexColumn{{Name: "v1", Length: 0}},
 				"",
 			),
 		},
@@ -1542,6 +1543,22 @@ CREATE TABLE t2
 					plan.NewUnresolvedTable("foo", "")),
 			),
 		},
+		{
+			input: "EXPLAIN FORMAT=`JSON` SELECT * FROM foo",
+			plan: plan.NewDescribeQuery(
+				"json", plan.NewProject(
+					[]sql.Expression{expression.NewStar()},
+					plan.NewUnresolvedTable("foo", "")),
+			),
+		},
+		{
+			input: "EXPLAIN ANALYZE SELECT * FROM foo",
+			plan: plan.NewExplainAnalyze(
+				"tree", plan.NewProject(
+					[]sql.Expression{expression.NewStar()},
+					plan.NewUnresolvedTable("foo", "")),
+			),
+		},
 		{
 			input: `SELECT foo, bar FROM foo;`,
 			plan: plan.NewProject(
@@ -1621,6 +1638,25 @@ CREATE TABLE t2
 				),
 			),
 		},
+		{
+			input: `SELECT foo FROM foo WHERE MATCH (foo, bar) AGAINST ('+baz' IN BOOLEAN MODE);`,
+			plan: plan.NewProject(
+				[]sql.Expression{
+					expression.NewUnresolvedColumn("foo"),
+				},
+				plan.NewFilter(
+					expression.NewMatch(
+						[]sql.Expression{
+							expression.NewUnresolvedColumn("foo"),
+							expression.NewUnresolvedColumn("bar"),
+						},
+						expression.NewLiteral("+baz", sql.LongText),
+						sql.FulltextBooleanMode,
+					),
+					plan.NewUnresolvedTable("foo", ""),
+				),
+			),
+		},
 		{
 			input: `SELECT foo, bar FROM foo WHERE foo = 'bar';`,
 			plan: plan.NewProject(
@@ -1763,6 +1799,27 @@ CREATE TABLE t2
 				),
 			),
 		},
+		{
+			input: `SELECT foo FROM foo WHERE bar->'$.a' = bar->>'$.b';`,
+			plan: plan.NewProject(
+				[]sql.Expression{
+					expression.NewUnresolvedColumn("foo"),
+				},
+				plan.NewFilter(
+					expression.NewEquals(
+						mustNewJSONExtract(
+							expression.NewUnresolvedColumn("bar"),
+							expression.NewLiteral("$.a", sql.LongText),
+						),
+						function.NewJSONUnquote(mustNewJSONExtract(
+							expression.NewUnresolvedColumn("bar"),
+							expression.NewLiteral("$.b", sql.LongText),
+						)),
+					),
+					plan.NewUnresolvedTable("foo", ""),
+				),
+			),
+		},
 		{
 			input: `SELECT foo, bar FROM foo WHERE foo = :var;`,
 			plan: plan.NewProject(
@@ -2819,7 +2876,22 @@ CREATE TABLE t2
 				sql.IndexUsing_BTree,
 				sql.IndexConstraint_None,
 				[]sql.IndexColumn{
-					{"bar", 0},
+					{Name: "bar", Length: 0},
+				},
+				"",
+			),
+		},
+		{
+			input: `CREATE INDEX idx ON foo ((lower(bar)), baz)`,
+			plan: plan.NewAlterCreateIndex(
+				sql.UnresolvedDatabase(""),
+				plan.NewUnresolvedTable("foo", ""),
+				"idx",
+				sql.IndexUsing_BTree,
+				sql.IndexConstraint_None,
+				[]sql.IndexColumn{
+					{Expression: expression.NewUnresolvedFunction("lower", false, nil, expression.NewUnresolvedColumn("bar"))},
+					{Name: "baz", Length: 0},
 				},
 				"",
 			),
@@ -2833,7 +2905,7 @@ CREATE TABLE t2
 				sql.IndexUsing_BTree,
 				sql.IndexConstraint_None,
 				[]sql.IndexColumn{
-					{"bar", 0},
+					{Name: "bar", Length: 0},
 				},
 				"",
 			),
@@ -2848,6 +2920,18 @@ CREATE TABLE t2
 				),
 			),
 		},
+		{
+			input: `SELECT * FROM foo LEFT JOIN bar USING (a, b)`,
+			plan: plan.NewProject(
+				[]sql.Expression{expression.NewStar()},
+				plan.NewUsingJoin(
+					plan.NewUnresolvedTable("foo", ""),
+					plan.NewUnresolvedTable("bar", ""),
+					plan.JoinTypeLeftOuter,
+					[]string{"a", "b"},
+				),
+			),
+		},
 		{
 			input: `SELECT * FROM foo NATURAL JOIN bar NATURAL JOIN baz`,
 			plan: plan.NewProject(
@@ -4967,6 +5051,14 @@ func TestParseCreateTrigger(t *testing.T) {
 	}
 }
 
+func mustNewJSONExtract(args ...sql.Expression) sql.Expression {
+	e, err := function.NewJSONExtract(args...)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
 // assertNodesEqualWithDiff asserts the two nodes given to be equal and prints any diff according to their DebugString
 // methods.
 func assertNodesEqualWithDiff(t *testing.T, expected, actual sql.Node) bool {
@@ -5009,26 +5101,30 @@ func assertNodesEqualWithDiff(t *testing.T, expected, actual sql.Node) bool {
 }
 
 var fixturesErrors = map[string]*errors.Kind{
-	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                      sql.ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                      sql.ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                      sql.ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                      sql.ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                    sql.ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`:   sql.ErrUnsupportedSyntax,
-	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                  errInvalidDescribeFormat,
-	`CREATE TABLE test (pk int null primary key)`:               ErrPrimaryKeyOnNullField,
-	`CREATE TABLE test (pk int not null null primary key)`:      ErrPrimaryKeyOnNullField,
-	`CREATE TABLE test (pk int null, primary key(pk))`:          ErrPrimaryKeyOnNullField,
-	`CREATE TABLE test (pk int not null null, primary key(pk))`: ErrPrimaryKeyOnNullField,
-	`SELECT i, row_number() over (order by a) group by 1`:       sql.ErrUnsupportedFeature,
-	`SHOW COUNT(*) WARNINGS`:                                    sql.ErrUnsupportedFeature,
-	`SHOW ERRORS`:                                               sql.ErrUnsupportedFeature,
-	`SHOW VARIABLES WHERE Value = ''`:                           sql.ErrUnsupportedFeature,
-	`SHOW SESSION VARIABLES WHERE Value IS NOT NULL`:            sql.ErrUnsupportedFeature,
-	`KILL CONNECTION 4294967296`:                                sql.ErrUnsupportedFeature,
-	`DROP TABLE IF EXISTS curdb.foo, otherdb.bar`:               sql.ErrUnsupportedFeature,
-	`DROP TABLE curdb.t1, t2`:                                   sql.ErrUnsupportedFeature,
-	`CREATE TABLE test (i int fulltext key)`:                    sql.ErrUnsupportedFeature,
+	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                               sql.ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                               sql.ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                               sql.ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                               sql.ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                             sql.ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`:            sql.ErrUnsupportedSyntax,
+	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                           errInvalidDescribeFormat,
+	`CREATE TABLE test (pk int null primary key)`:                        ErrPrimaryKeyOnNullField,
+	`CREATE TABLE test (pk int not null null primary key)`:               ErrPrimaryKeyOnNullField,
+	`CREATE TABLE test (pk int null, primary key(pk))`:                   ErrPrimaryKeyOnNullField,
+	`CREATE TABLE test (pk int not null null, primary key(pk))`:          ErrPrimaryKeyOnNullField,
+	`SELECT i, row_number() over (order by a) group by 1`:                sql.ErrUnsupportedFeature,
+	`SHOW COUNT(*) WARNINGS`:                                             sql.ErrUnsupportedFeature,
+	`SHOW ERRORS`:                                                        sql.ErrUnsupportedFeature,
+	`SHOW VARIABLES WHERE Value = ''`:                                    sql.ErrUnsupportedFeature,
+	`SHOW SESSION VARIABLES WHERE Value IS NOT NULL`:                     sql.ErrUnsupportedFeature,
+	`KILL CONNECTION 4294967296`:                                         sql.ErrUnsupportedFeature,
+	`DROP TABLE IF EXISTS curdb.foo, otherdb.bar`:                        sql.ErrUnsupportedFeature,
+	`DROP TABLE curdb.t1, t2`:                                            sql.ErrUnsupportedFeature,
+	`SELECT * FROM t WHERE MATCH (a) AGAINST ('x' WITH QUERY EXPANSION)`: sql.ErrUnsupportedFeature,
+	"EXPLAIN FORMAT=`JSON` INSERT INTO foo VALUES (1)":                   sql.ErrUnsupportedFeature,
+	`CREATE INDEX idx ON foo ((bar))`:                                    sql.ErrFunctionalIndexOnField,
+	`CREATE TABLE foo (a int, PRIMARY KEY ((a + 1)))`:                    sql.ErrFunctionalIndexPrimaryKey,
+	`ALTER TABLE foo ADD FULLTEXT INDEX ((lower(a)))`:                    sql.ErrFulltextFunctionalIndex,
 }
 
 func TestParseOne(t *testing.T) {
@@ -0,0 +1,180 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// Some of the syntax MySQL accepts isn't supported by the parser yet, so before parsing, queries are rewritten into
+// syntax it supports. The rewrites scan the query with the parser's tokenizer, and keep track of where each part of
+// the rewritten query came from, so that the text the parser takes from the query is taken from the original one.
+
+// queryToken is a token of a query, as scanned by the parser's tokenizer.
+type queryToken struct {
+	typ int
+	// text is the token as written in the query
+	text string
+	// start and end are the offsets of the token in the query
+	start, end int
+}
+
+// scanQueryTokens returns the tokens of the query given, without its comments. Scanning stops at the first token that
+// the tokenizer rejects.
+func scanQueryTokens(query string) []queryToken {
+	var tokens []queryToken
+	tokenizer := sqlparser.NewStringTokenizer(query)
+	prevEnd := 0
+	for {
+		typ, _ := tokenizer.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			return tokens
+		}
+		// The tokenizer has read one character past the token
+		end := tokenizer.Position - 1
+		if end > len(query) {
+			end = len(query)
+		}
+		start := prevEnd
+		for start < end && strings.IndexByte(" \n\r\t", query[start]) >= 0 {
+			start++
+		}
+		if start > end {
+			start = end
+		}
+		prevEnd = end
+		if typ != sqlparser.COMMENT {
+			tokens = append(tokens, queryToken{typ: typ, text: query[start:end], start: start, end: end})
+		}
+	}
+}
+
+// tokenIs returns whether the token given is the keyword or the punctuation given.
+func tokenIs(t queryToken, text string) bool {
+	return strings.EqualFold(t.text, text)
+}
+
+// isNameToken returns whether the token given is an identifier or a keyword.
+func isNameToken(t queryToken) bool {
+	return t.typ == sqlparser.ID || sqlparser.KeywordString(t.typ) != ""
+}
+
+// matchingParen returns the index of the parenthesis that closes the one at the index given, or -1 if it's never
+// closed.
+func matchingParen(tokens []queryToken, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch {
+		case tokenIs(tokens[i], "("):
+			depth++
+		case tokenIs(tokens[i], ")"):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// queryEdit replaces the text of a query between two offsets.
+type queryEdit struct {
+	start, end int
+	text       string
+}
+
+// noQueryEdits maps the offsets of a query that wasn't rewritten.
+func noQueryEdits(i int) int {
+	return i
+}
+
+// applyQueryEdits returns the query given with the edits given, which must be in order and not overlap, along with a
+// function that maps an offset of the rewritten query to the offset of the original query it came from. Offsets in
+// the text of an edit are mapped into the text it replaced.
+func applyQueryEdits(query string, edits []queryEdit) (string, func(int) int) {
+	var sb strings.Builder
+	// rewrittenStarts holds the offsets of the edits in the rewritten query, along with the offsets of the original
+	// text following each edit
+	rewrittenStarts := make([]int, 0, 2*len(edits))
+	prev := 0
+	for _, edit := range edits {
+		sb.WriteString(query[prev:edit.start])
+		rewrittenStarts = append(rewrittenStarts, sb.Len())
+		sb.WriteString(edit.text)
+		rewrittenStarts = append(rewrittenStarts, sb.Len())
+		prev = edit.end
+	}
+	sb.WriteString(query[prev:])
+
+	return sb.String(), func(i int) int {
+		j := sort.Search(len(rewrittenStarts), func(j int) bool { return rewrittenStarts[j] > i }) - 1
+		switch {
+		case j < 0:
+			return i
+		case j%2 == 0:
+			edit := edits[j/2]
+			if offset := edit.start + i - rewrittenStarts[j]; offset < edit.end {
+				return offset
+			}
+			return edit.end
+		default:
+			return edits[j/2].end + i - rewrittenStarts[j]
+		}
+	}
+}
+
+// restoreInputExpressions replaces the verbatim select expressions of the statement parsed from the rewritten query
+// with the text they came from in the original query, since they are used as the names of the columns.
+func restoreInputExpressions(stmt sqlparser.Statement, rewritten, original string, originalOffset func(int) int) {
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		ae, ok := node.(*sqlparser.AliasedExpr)
+		if !ok || ae.InputExpression == "" || ae.EndParsePos > len(rewritten) {
+			return true, nil
+		}
+		start, end := originalOffset(ae.StartParsePos), originalOffset(ae.EndParsePos)
+		if start >= end {
+			return true, nil
+		}
+		input := strings.TrimLeft(original[start:end], " \n\t")
+		// The parser strips the quotes around expressions that are a single string
+		if ae.InputExpression != strings.TrimLeft(rewritten[ae.StartParsePos:ae.EndParsePos], " \n\t") && len(input) >= 2 {
+			input = input[1 : len(input)-1]
+		}
+		ae.InputExpression = input
+		return true, nil
+	}, stmt)
+}
+
+// restoreSubStatementPositions maps the positions of the sub statements of the DDL statement given, which are offsets
+// in the rewritten query, back to offsets in the original query, so that the definitions of views, triggers and
+// procedures are stored as they were written.
+func restoreSubStatementPositions(stmt sqlparser.Statement, originalOffset func(int) int) {
+	var ddls []*sqlparser.DDL
+	switch n := stmt.(type) {
+	case *sqlparser.DDL:
+		ddls = []*sqlparser.DDL{n}
+	case *sqlparser.MultiAlterDDL:
+		ddls = n.Statements
+	}
+	for _, ddl := range ddls {
+		if ddl.SubStatementPositionEnd > ddl.SubStatementPositionStart {
+			ddl.SubStatementPositionStart = originalOffset(ddl.SubStatementPositionStart)
+			ddl.SubStatementPositionEnd = originalOffset(ddl.SubStatementPositionEnd)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanQueryTokens(t *testing.T) {
+	query := "SELECT `a b`, 'c''d' /* e */ FROM\tt -- f\nWHERE x>=1"
+	var texts []string
+	for _, token := range scanQueryTokens(query) {
+		require.Equal(t, token.text, query[token.start:token.end])
+		texts = append(texts, token.text)
+	}
+	require.Equal(t, []string{"SELECT", "`a b`", ",", "'c''d'", "FROM", "t", "WHERE", "x", ">=", "1"}, texts)
+}
+
+func TestApplyQueryEdits(t *testing.T) {
+	require := require.New(t)
+
+	query := "SELECT a FROM b; SELECT 1"
+	rewritten, originalOffset := applyQueryEdits(query, []queryEdit{
+		{start: 7, end: 8, text: "abc"},
+		{start: 14, end: 14, text: "x"},
+	})
+	require.Equal("SELECT abc FROM xb; SELECT 1", rewritten)
+
+	require.Equal(0, originalOffset(0))
+	require.Equal(7, originalOffset(len("SELECT ")))
+	require.Equal(8, originalOffset(len("SELECT ab")))
+	require.Equal(8, originalOffset(len("SELECT abc")))
+	require.Equal(14, originalOffset(len("SELECT abc FROM x")))
+	require.Equal(len("SELECT a FROM b;"), originalOffset(len("SELECT abc FROM xb;")))
+	require.Equal(len(query), originalOffset(len(rewritten)))
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// The parser only supports NTILE without arguments, and doesn't support the FROM FIRST|LAST and RESPECT|IGNORE NULLS
+// modifiers of NTH_VALUE. Before parsing, NTILE(N) is replaced with NTH_VALUE called with a marker followed by N. The
+// marker is a quoted identifier, which is removed when the function is converted. As in MySQL, only the default
+// modifiers FROM FIRST and RESPECT NULLS are accepted, so they are removed, while FROM LAST and IGNORE NULLS are
+// rejected.
+
+// rewriteMarker starts the identifiers that mark rewritten syntax. MySQL identifiers can't hold this character, so it
+// can't be confused with the name of a column.
+const rewriteMarker = "\x00"
+
+const ntileMarker = "ntile"
+
+// rewriteWindowFunctions rewrites the calls of NTILE with arguments in the query given into calls of NTH_VALUE with a
+// marker, and removes the modifiers of the calls of NTH_VALUE. Along with the rewritten query, it returns a function
+// that maps an offset of the rewritten query to the offset of the original query it came from. It returns an error if
+// a call of NTH_VALUE has a modifier that isn't supported.
+func rewriteWindowFunctions(query string) (string, func(int) int, error) {
+	lower := strings.ToLower(query)
+	if !strings.Contains(lower, "ntile") && !strings.Contains(lower, "nth_value") {
+		return query, noQueryEdits, nil
+	}
+
+	tokens := scanQueryTokens(query)
+	var edits []queryEdit
+	for i := 0; i < len(tokens); i++ {
+		isNtile, isNthValue := tokenIs(tokens[i], "NTILE"), tokenIs(tokens[i], "NTH_VALUE")
+		open := i + 1
+		if !isNtile && !isNthValue || open >= len(tokens) || !tokenIs(tokens[open], "(") {
+			continue
+		}
+		close := matchingParen(tokens, open)
+		if close < 0 {
+			continue
+		}
+
+		if isNtile {
+			if close == open+1 {
+				continue
+			}
+			edits = append(edits,
+				queryEdit{start: tokens[i].start, end: tokens[i].end, text: "NTH_VALUE"},
+				queryEdit{start: tokens[open].end, end: tokens[open].end, text: "`" + rewriteMarker + ntileMarker + "`, "})
+			i = open
+			continue
+		}
+
+		over, err := nthValueModifiers(tokens, close)
+		if err != nil {
+			return "", nil, err
+		}
+		if over < 0 {
+			continue
+		}
+		edits = append(edits, queryEdit{start: tokens[close].end, end: tokens[over].start, text: " "})
+		i = over
+	}
+
+	if len(edits) == 0 {
+		return query, noQueryEdits, nil
+	}
+	rewritten, originalOffset := applyQueryEdits(query, edits)
+	return rewritten, originalOffset, nil
+}
+
+// nthValueModifiers returns the index of the OVER keyword that follows the modifiers after the arguments of NTH_VALUE
+// that end at the index given. If there are no modifiers, or they aren't followed by OVER, the index returned is -1.
+// It returns an error for the modifiers MySQL doesn't support, FROM LAST and IGNORE NULLS.
+func nthValueModifiers(tokens []queryToken, close int) (int, error) {
+	i := close + 1
+	if i < len(tokens) && tokenIs(tokens[i], "FROM") {
+		switch {
+		case i+1 < len(tokens) && tokenIs(tokens[i+1], "FIRST"):
+		case i+1 < len(tokens) && tokenIs(tokens[i+1], "LAST"):
+			return -1, sql.ErrNotSupportedYet.New("FROM LAST")
+		default:
+			return -1, nil
+		}
+		i += 2
+	}
+	if i < len(tokens) && (tokenIs(tokens[i], "RESPECT") || tokenIs(tokens[i], "IGNORE")) {
+		if i+1 >= len(tokens) || !tokenIs(tokens[i+1], "NULLS") {
+			return -1, nil
+		}
+		if tokenIs(tokens[i], "IGNORE") {
+			return -1, sql.ErrNotSupportedYet.New("IGNORE NULLS")
+		}
+		i += 2
+	}
+	if i == close+1 || i >= len(tokens) || !tokenIs(tokens[i], "OVER") {
+		return -1, nil
+	}
+	return i, nil
+}
+
+// removeWindowFunctionMarkers removes the marker left by rewriteWindowFunctions from the arguments of a call of
+// NTH_VALUE, returning the name of the function that was written along with its arguments.
+func removeWindowFunctionMarkers(exprs []sql.Expression) (string, []sql.Expression) {
+	if len(exprs) == 0 {
+		return "nth_value", exprs
+	}
+	col, ok := exprs[0].(*expression.UnresolvedColumn)
+	if !ok || col.Table() != "" || col.Name() != rewriteMarker+ntileMarker {
+		return "nth_value", exprs
+	}
+	return "ntile", exprs[1:]
+}
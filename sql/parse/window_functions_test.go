@@ -0,0 +1,72 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestRewriteWindowFunctions(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT NTILE() OVER w FROM a", "SELECT NTILE() OVER w FROM a"},
+		{"SELECT ntile(4) OVER w FROM a", "SELECT NTH_VALUE(`\x00ntile`, 4) OVER w FROM a"},
+		{"SELECT NTH_VALUE(x, 2) OVER w FROM a", "SELECT NTH_VALUE(x, 2) OVER w FROM a"},
+		{"SELECT NTH_VALUE(x, 2) FROM FIRST RESPECT NULLS OVER w FROM a", "SELECT NTH_VALUE(x, 2) OVER w FROM a"},
+		{"SELECT nth_value(x, 2) from first over w FROM a", "SELECT nth_value(x, 2) over w FROM a"},
+		{"SELECT NTH_VALUE(f(x), 2) RESPECT NULLS OVER (ORDER BY y) FROM a", "SELECT NTH_VALUE(f(x), 2) OVER (ORDER BY y) FROM a"},
+		{"SELECT NTH_VALUE(x, 2) FROM a", "SELECT NTH_VALUE(x, 2) FROM a"},
+		{"SELECT 'ntile(4)', `nth_value` FROM a", "SELECT 'ntile(4)', `nth_value` FROM a"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			rewritten, _, err := rewriteWindowFunctions(tt.query)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, rewritten)
+		})
+	}
+}
+
+func TestRewriteWindowFunctionsUnsupportedModifiers(t *testing.T) {
+	for _, query := range []string{
+		"SELECT nth_value(x, 2) from last over w FROM a",
+		"SELECT NTH_VALUE(x, 2) IGNORE NULLS OVER w FROM a",
+		"SELECT NTH_VALUE(x, 2) FROM FIRST IGNORE NULLS OVER w FROM a",
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, _, err := rewriteWindowFunctions(query)
+			require.True(t, sql.ErrNotSupportedYet.Is(err), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestRewriteWindowFunctionsOffsets(t *testing.T) {
+	require := require.New(t)
+
+	query := "SELECT NTH_VALUE(x, 2) FROM FIRST OVER w FROM a"
+	rewritten, originalOffset, err := rewriteWindowFunctions(query)
+	require.NoError(err)
+	require.Equal("SELECT NTH_VALUE(x, 2) OVER w FROM a", rewritten)
+
+	require.Equal(len("SELECT NTH_VALUE(x, 2) FROM FIRST "), originalOffset(len("SELECT NTH_VALUE(x, 2) ")))
+	require.Equal(len(query), originalOffset(len(rewritten)))
+}
@@ -143,6 +143,9 @@ func (p *AlterIndex) Execute(ctx *sql.Context) error {
 			seenCols[col.Name] = false
 		}
 		for _, indexCol := range p.Columns {
+			if indexCol.IsFunctional() {
+				continue
+			}
 			if seen, ok := seenCols[indexCol.Name]; ok {
 				if !seen {
 					seenCols[indexCol.Name] = true
@@ -329,15 +332,7 @@ func (p AlterIndex) String() string {
 		case sql.IndexUsing_Hash:
 			children = append(children, "Using(HASH)")
 		}
-		cols := make([]string, len(p.Columns))
-		for i, col := range p.Columns {
-			if col.Length == 0 {
-				cols[i] = col.Name
-			} else {
-				cols[i] = fmt.Sprintf("%s(%v)", col.Name, col.Length)
-			}
-		}
-		children = append(children, fmt.Sprintf("Columns(%s)", strings.Join(cols, ", ")))
+		children = append(children, fmt.Sprintf("Columns(%s)", indexColumnsString(p.Columns)))
 		children = append(children, fmt.Sprintf("Comment(%s)", p.Comment))
 		_ = pr.WriteChildren(children...)
 	case IndexAction_Drop:
@@ -357,7 +352,29 @@ func (p AlterIndex) String() string {
 }
 
 func (p *AlterIndex) Resolved() bool {
-	return p.Table.Resolved() && p.ddlNode.Resolved()
+	if !p.Table.Resolved() || !p.ddlNode.Resolved() {
+		return false
+	}
+	for _, expr := range functionalIndexExpressions(p.Columns) {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface, returning the expressions of the functional key parts.
+func (p *AlterIndex) Expressions() []sql.Expression {
+	return functionalIndexExpressions(p.Columns)
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (p AlterIndex) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if length := len(functionalIndexExpressions(p.Columns)); len(exprs) != length {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(exprs), length)
+	}
+	p.Columns, _ = withFunctionalIndexExpressions(p.Columns, exprs)
+	return &p, nil
 }
 
 // Children implements the sql.Node interface.
@@ -367,9 +384,5 @@ func (p *AlterIndex) Children() []sql.Node {
 
 // ColumnNames returns each column's name without the length property.
 func (p *AlterIndex) columnNames() []string {
-	colNames := make([]string, len(p.Columns))
-	for i, col := range p.Columns {
-		colNames[i] = col.Name
-	}
-	return colNames
+	return indexColumnNames(p.Columns)
 }
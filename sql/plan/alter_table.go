@@ -17,6 +17,7 @@ package plan
 import (
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/dolthub/vitess/go/sqltypes"
@@ -328,17 +329,25 @@ func applyDefaults(ctx *sql.Context, tblSch sql.Schema, col int, row sql.Row, cd
 }
 
 func (a *AddColumn) Expressions() []sql.Expression {
-	return append(transform.WrappedColumnDefaults(a.targetSch), expression.WrapExpressions(a.column.Default)...)
+	exprs := append(transform.WrappedColumnDefaults(a.targetSch), expression.WrapExpressions(a.column.Default)...)
+	if a.column.Generated != nil {
+		exprs = append(exprs, a.column.Generated)
+	}
+	return exprs
 }
 
 func (a AddColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != 1+len(a.targetSch) {
-		return nil, sql.ErrInvalidChildrenNumber.New(a, len(exprs), 1+len(a.targetSch))
+	length := 1 + len(a.targetSch)
+	if a.column.Generated != nil {
+		length++
+	}
+	if len(exprs) != length {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(exprs), length)
 	}
 
 	a.targetSch = transform.SchemaWithDefaults(a.targetSch, exprs[:len(a.targetSch)])
 
-	unwrappedColDefVal, ok := exprs[len(exprs)-1].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
+	unwrappedColDefVal, ok := exprs[len(a.targetSch)].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
 
 	// *sql.Column is a reference type, make a copy before we modify it so we don't affect the original node
 	a.column = a.column.Copy()
@@ -347,12 +356,20 @@ func (a AddColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 	} else { // nil fails type check
 		a.column.Default = nil
 	}
+
+	if a.column.Generated != nil {
+		generated, ok := exprs[len(exprs)-1].(*sql.ColumnDefaultValue)
+		if !ok {
+			return nil, fmt.Errorf("expected generated column expression, found %T", exprs[len(exprs)-1])
+		}
+		a.column.Generated = generated
+	}
 	return &a, nil
 }
 
 // Resolved implements the Resolvable interface.
 func (a *AddColumn) Resolved() bool {
-	if !(a.ddlNode.Resolved() && a.Table.Resolved() && a.column.Default.Resolved()) {
+	if !(a.ddlNode.Resolved() && a.Table.Resolved() && a.column.Default.Resolved() && a.column.Generated.Resolved()) {
 		return false
 	}
 
@@ -839,6 +856,13 @@ func (d *DropColumn) validate(ctx *sql.Context, tbl sql.Table) error {
 		return sql.ErrTableColumnNotFound.New(tbl.Name(), d.Column)
 	}
 
+	if err := validateGeneratedColumnDependency(d.targetSchema, d.Column); err != nil {
+		return err
+	}
+	if err := validateFunctionalIndexDependency(ctx, tbl, d.Column); err != nil {
+		return err
+	}
+
 	for _, col := range d.targetSchema {
 		if col.Default == nil {
 			continue
@@ -1047,6 +1071,15 @@ func (r *RenameColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 		return nil, sql.ErrTableColumnNotFound.New(tbl.Name(), r.ColumnName)
 	}
 
+	if !strings.EqualFold(r.ColumnName, r.NewColumnName) {
+		if err := validateGeneratedColumnDependency(r.targetSchema, r.ColumnName); err != nil {
+			return nil, err
+		}
+		if err := validateFunctionalIndexDependency(ctx, tbl, r.ColumnName); err != nil {
+			return nil, err
+		}
+	}
+
 	nc := *r.targetSchema[idx]
 	nc.Name = r.NewColumnName
 	col := &nc
@@ -1175,6 +1208,14 @@ func (m *ModifyColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 	if err := m.validateDefaultPosition(m.targetSchema); err != nil {
 		return nil, err
 	}
+	if !strings.EqualFold(m.columnName, m.column.Name) {
+		if err := validateGeneratedColumnDependency(m.targetSchema, m.columnName); err != nil {
+			return nil, err
+		}
+		if err := validateFunctionalIndexDependency(ctx, tbl, m.columnName); err != nil {
+			return nil, err
+		}
+	}
 	// MySQL assigns the column's type (which contains the collation) at column creation/modification. If a column has
 	// an invalid collation, then one has not been assigned at this point, so we assign it the table's collation. This
 	// does not create a reference to the table's collation, which may change at any point, and therefore will have no
@@ -1552,28 +1593,44 @@ func (m *ModifyColumn) CheckPrivileges(ctx *sql.Context, opChecker sql.Privilege
 }
 
 func (m *ModifyColumn) Expressions() []sql.Expression {
-	return append(transform.WrappedColumnDefaults(m.targetSchema), expression.WrapExpressions(m.column.Default)...)
+	exprs := append(transform.WrappedColumnDefaults(m.targetSchema), expression.WrapExpressions(m.column.Default)...)
+	if m.column.Generated != nil {
+		exprs = append(exprs, m.column.Generated)
+	}
+	return exprs
 }
 
 func (m ModifyColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != 1+len(m.targetSchema) {
-		return nil, sql.ErrInvalidChildrenNumber.New(m, len(exprs), 1+len(m.targetSchema))
+	length := 1 + len(m.targetSchema)
+	if m.column.Generated != nil {
+		length++
+	}
+	if len(exprs) != length {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(exprs), length)
 	}
 
 	m.targetSchema = transform.SchemaWithDefaults(m.targetSchema, exprs[:len(m.targetSchema)])
 
-	unwrappedColDefVal, ok := exprs[len(exprs)-1].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
+	unwrappedColDefVal, ok := exprs[len(m.targetSchema)].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
 	if ok {
 		m.column.Default = unwrappedColDefVal
 	} else { // nil fails type check
 		m.column.Default = nil
 	}
+
+	if m.column.Generated != nil {
+		generated, ok := exprs[len(exprs)-1].(*sql.ColumnDefaultValue)
+		if !ok {
+			return nil, fmt.Errorf("expected generated column expression, found %T", exprs[len(exprs)-1])
+		}
+		m.column.Generated = generated
+	}
 	return &m, nil
 }
 
 // Resolved implements the Resolvable interface.
 func (m *ModifyColumn) Resolved() bool {
-	if !(m.Table.Resolved() && m.column.Default.Resolved() && m.ddlNode.Resolved()) {
+	if !(m.Table.Resolved() && m.column.Default.Resolved() && m.column.Generated.Resolved() && m.ddlNode.Resolved()) {
 		return false
 	}
 
@@ -1635,6 +1692,54 @@ func (m *ModifyColumn) validateDefaultPosition(tblSch sql.Schema) error {
 	return nil
 }
 
+// validateGeneratedColumnDependency returns an error if the expression of any generated column of the schema refers to
+// the column given, which is about to be dropped or renamed.
+func validateGeneratedColumnDependency(schema sql.Schema, column string) error {
+	for _, col := range schema {
+		if col.Generated == nil || strings.EqualFold(col.Name, column) {
+			continue
+		}
+		found := false
+		sql.Inspect(col.Generated, func(expr sql.Expression) bool {
+			switch expr := expr.(type) {
+			case *expression.GetField:
+				found = found || strings.EqualFold(expr.Name(), column)
+			case *expression.UnresolvedColumn:
+				found = found || strings.EqualFold(expr.Name(), column)
+			}
+			return !found
+		})
+		if found {
+			return sql.ErrDependentByGeneratedColumn.New(column)
+		}
+	}
+	return nil
+}
+
+// validateFunctionalIndexDependency returns an error if a functional key part of any index of the table refers to the
+// column given, which is about to be dropped or renamed. Index expressions refer to the columns of their table in the
+// form "table.column".
+func validateFunctionalIndexDependency(ctx *sql.Context, tbl sql.Table, column string) error {
+	indexed, ok := tbl.(sql.IndexAddressable)
+	if !ok {
+		return nil
+	}
+	indexes, err := indexed.GetIndexes(ctx)
+	if err != nil {
+		return err
+	}
+
+	ref := regexp.MustCompile(`(?i)(^|[^\w.])` + regexp.QuoteMeta(tbl.Name()+"."+column) + `($|\W)`)
+	for _, index := range indexes {
+		for _, expr := range index.Expressions() {
+			if GetColumnFromIndexExpr(expr, tbl) == nil && ref.MatchString(expr) {
+				return sql.ErrDependentByFunctionalIndex.New(column)
+			}
+		}
+	}
+	return nil
+}
+
 // updateDefaultsOnColumnRename updates each column that references the old column name within its default value.
 func updateDefaultsOnColumnRename(ctx *sql.Context, tbl sql.AlterableTable, schema sql.Schema, oldName, newName string) error {
 	if oldName == newName {
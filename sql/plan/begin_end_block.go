@@ -16,6 +16,7 @@ package plan
 
 import (
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // BeginEndBlock represents a BEGIN/END block.
@@ -32,6 +33,7 @@ func NewBeginEndBlock(block *Block) *BeginEndBlock {
 
 var _ sql.Node = (*BeginEndBlock)(nil)
 var _ sql.DebugStringer = (*BeginEndBlock)(nil)
+var _ expression.ProcedureReferencable = (*BeginEndBlock)(nil)
 
 // String implements the sql.Node interface.
 func (b *BeginEndBlock) String() string {
@@ -59,10 +61,39 @@ func (b *BeginEndBlock) DebugString() string {
 
 // WithChildren implements the sql.Node interface.
 func (b *BeginEndBlock) WithChildren(children ...sql.Node) (sql.Node, error) {
-	return NewBeginEndBlock(NewBlock(children)), nil
+	newBlock, err := b.Block.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return NewBeginEndBlock(newBlock.(*Block)), nil
+}
+
+// WithParamReference implements the expression.ProcedureReferencable interface.
+func (b *BeginEndBlock) WithParamReference(pRef *expression.ProcedureParamReference) sql.Node {
+	return NewBeginEndBlock(b.Block.WithParamReference(pRef).(*Block))
 }
 
 // CheckPrivileges implements the interface sql.Node.
 func (b *BeginEndBlock) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
 	return b.Block.CheckPrivileges(ctx, opChecker)
 }
+
+// RowIter implements the sql.Node interface.
+func (b *BeginEndBlock) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	// Outside of stored procedures (such as with triggers), there are no declarations to scope.
+	if b.pRef == nil {
+		return b.Block.RowIter(ctx, row)
+	}
+
+	scopeHeight := b.pRef.PushScope()
+	iter, err := b.Block.RowIter(ctx, row)
+	b.pRef.PopScope()
+	if err != nil {
+		// An EXIT handler declared in this block ends the block without an error.
+		if exitErr, ok := err.(exitBlockError); ok && int(exitErr) == scopeHeight {
+			return sql.RowsToRowIter(), nil
+		}
+		return nil, err
+	}
+	return iter, nil
+}
@@ -15,19 +15,23 @@
 package plan
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // Block represents a collection of statements that should be executed in sequence.
 type Block struct {
 	statements []sql.Node
 	rowIterSch sql.Schema // This is set during RowIter, as the schema is unknown until iterating over the statements.
+	pRef       *expression.ProcedureParamReference
 }
 
 var _ sql.Node = (*Block)(nil)
 var _ sql.DebugStringer = (*Block)(nil)
+var _ expression.ProcedureReferencable = (*Block)(nil)
 
 // NewBlock creates a new *Block node.
 func NewBlock(statements []sql.Node) *Block {
@@ -80,7 +84,16 @@ func (b *Block) Children() []sql.Node {
 
 // WithChildren implements the sql.Node interface.
 func (b *Block) WithChildren(children ...sql.Node) (sql.Node, error) {
-	return NewBlock(children), nil
+	nb := *b
+	nb.statements = children
+	return &nb, nil
+}
+
+// WithParamReference implements the expression.ProcedureReferencable interface.
+func (b *Block) WithParamReference(pRef *expression.ProcedureParamReference) sql.Node {
+	nb := *b
+	nb.pRef = pRef
+	return &nb
 }
 
 // CheckPrivileges implements the interface sql.Node.
@@ -148,7 +161,9 @@ func (b *Block) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 			return nil
 		}()
 		if err != nil {
-			return nil, err
+			if err = b.handleError(ctx, row, err); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -160,6 +175,55 @@ func (b *Block) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	}, nil
 }
 
+// handleError runs the handler that matches the given error, if one has been declared in an enclosing BEGIN/END block.
+// Returns nil if execution should continue with the next statement, or an error if execution of the block should stop.
+func (b *Block) handleError(ctx *sql.Context, row sql.Row, err error) error {
+	if b.pRef == nil || isControlFlowError(err) {
+		return err
+	}
+	handler, scopeHeight := b.pRef.FindHandler(err)
+	if handler == nil {
+		return err
+	}
+	declareHandler, ok := handler.(*DeclareHandler)
+	if !ok {
+		return err
+	}
+	b.pRef.StartHandler(scopeHeight)
+	defer b.pRef.EndHandler()
+	handlerIter, err := declareHandler.Statement.RowIter(ctx, row)
+	if err != nil {
+		return err
+	}
+	if _, err = sql.RowIterToRows(ctx, nil, handlerIter); err != nil {
+		return err
+	}
+	if declareHandler.Action == DeclareHandlerAction_Exit {
+		return exitBlockError(scopeHeight)
+	}
+	return nil
+}
+
+// exitBlockError is returned when an EXIT handler has run, and contains the height of the scope that declared the
+// handler. The BEGIN/END block that owns that scope stops executing its statements without returning an error.
+type exitBlockError int
+
+// Error implements the error interface.
+func (e exitBlockError) Error() string {
+	return fmt.Sprintf("exiting block at scope height %d", int(e))
+}
+
+// isControlFlowError returns whether the error is used to move execution within a stored procedure, rather than
+// representing an actual error. Handlers do not apply to these errors.
+func isControlFlowError(err error) bool {
+	switch err.(type) {
+	case exitBlockError:
+		return true
+	default:
+		return false
+	}
+}
+
 // blockIter is a sql.RowIter that iterates over the given rows.
 type blockIter struct {
 	internalIter sql.RowIter
@@ -76,13 +76,67 @@ func (i *IndexDefinition) String() string {
 
 // ColumnNames returns each column's name without the length property.
 func (i *IndexDefinition) ColumnNames() []string {
-	colNames := make([]string, len(i.Columns))
-	for i, col := range i.Columns {
-		colNames[i] = col.Name
+	return indexColumnNames(i.Columns)
+}
+
+// functionalIndexName is the name given to functional key parts when generating the name of an index.
+const functionalIndexName = "functional_index"
+
+// indexColumnNames returns the name of each key part given, without the length property. Functional key parts are
+// named functionalIndexName.
+func indexColumnNames(cols []sql.IndexColumn) []string {
+	colNames := make([]string, len(cols))
+	for i, col := range cols {
+		if col.IsFunctional() {
+			colNames[i] = functionalIndexName
+		} else {
+			colNames[i] = col.Name
+		}
 	}
 	return colNames
 }
 
+// indexColumnsString returns the key parts given as they're written in an index definition.
+func indexColumnsString(cols []sql.IndexColumn) string {
+	strs := make([]string, len(cols))
+	for i, col := range cols {
+		if col.IsFunctional() {
+			strs[i] = fmt.Sprintf("(%s)", col.Expression.String())
+		} else if col.Length == 0 {
+			strs[i] = col.Name
+		} else {
+			strs[i] = fmt.Sprintf("%s(%v)", col.Name, col.Length)
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
+// functionalIndexExpressions returns the expressions of the functional key parts given.
+func functionalIndexExpressions(cols []sql.IndexColumn) []sql.Expression {
+	var exprs []sql.Expression
+	for _, col := range cols {
+		if col.IsFunctional() {
+			exprs = append(exprs, col.Expression)
+		}
+	}
+	return exprs
+}
+
+// withFunctionalIndexExpressions returns a copy of the key parts given with the expressions of the functional ones
+// replaced by the expressions given, in order, along with the number of expressions used.
+func withFunctionalIndexExpressions(cols []sql.IndexColumn, exprs []sql.Expression) ([]sql.IndexColumn, int) {
+	newCols := make([]sql.IndexColumn, len(cols))
+	i := 0
+	for j, col := range cols {
+		if col.IsFunctional() {
+			col.Expression = exprs[i]
+			i++
+		}
+		newCols[j] = col
+	}
+	return newCols, i
+}
+
 // TableSpec is a node describing the schema of a table.
 type TableSpec struct {
 	Schema    sql.PrimaryKeySchema
@@ -219,7 +273,7 @@ func (c *CreateTable) Resolved() bool {
 	}
 
 	for _, col := range c.CreateSchema.Schema {
-		if !col.Default.Resolved() {
+		if !col.Default.Resolved() || !col.Generated.Resolved() {
 			return false
 		}
 	}
@@ -230,6 +284,14 @@ func (c *CreateTable) Resolved() bool {
 		}
 	}
 
+	for _, idxDef := range c.idxDefs {
+		for _, expr := range functionalIndexExpressions(idxDef.Columns) {
+			if !expr.Resolved() {
+				return false
+			}
+		}
+	}
+
 	if c.like != nil {
 		if !c.like.Resolved() {
 			return false
@@ -580,16 +642,24 @@ func (c *CreateTable) schemaDebugString() string {
 	return p.String()
 }
 
+// Expressions implements the sql.Expressioner interface. Column defaults are returned wrapped, one for every column,
+// followed by the expressions of any generated columns, the check constraint expressions and then the expressions of
+// any functional key parts.
 func (c *CreateTable) Expressions() []sql.Expression {
-	exprs := make([]sql.Expression, len(c.CreateSchema.Schema)+len(c.chDefs))
-	i := 0
+	exprs := make([]sql.Expression, 0, len(c.CreateSchema.Schema)+len(c.chDefs))
 	for _, col := range c.CreateSchema.Schema {
-		exprs[i] = expression.WrapExpression(col.Default)
-		i++
+		exprs = append(exprs, expression.WrapExpression(col.Default))
+	}
+	for _, col := range c.CreateSchema.Schema {
+		if col.Generated != nil {
+			exprs = append(exprs, col.Generated)
+		}
 	}
 	for _, ch := range c.chDefs {
-		exprs[i] = ch.Expr
-		i++
+		exprs = append(exprs, ch.Expr)
+	}
+	for _, idxDef := range c.idxDefs {
+		exprs = append(exprs, functionalIndexExpressions(idxDef.Columns)...)
 	}
 	return exprs
 }
@@ -628,6 +698,14 @@ func (c *CreateTable) Temporary() TempTableOption {
 
 func (c CreateTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 	length := len(c.CreateSchema.Schema) + len(c.chDefs)
+	for _, col := range c.CreateSchema.Schema {
+		if col.Generated != nil {
+			length++
+		}
+	}
+	for _, idxDef := range c.idxDefs {
+		length += len(functionalIndexExpressions(idxDef.Columns))
+	}
 	if len(exprs) != length {
 		return nil, sql.ErrInvalidChildrenNumber.New(c, len(exprs), length)
 	}
@@ -645,12 +723,32 @@ func (c CreateTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error)
 			ns[i].Default = nil
 		}
 	}
+	for _, col := range ns {
+		if col.Generated != nil {
+			generated, ok := exprs[i].(*sql.ColumnDefaultValue)
+			if !ok {
+				return nil, fmt.Errorf("expected generated column expression, found %T", exprs[i])
+			}
+			col.Generated = generated
+			i++
+		}
+	}
 	nc.CreateSchema = sql.NewPrimaryKeySchema(ns, c.CreateSchema.PkOrdinals...)
 
-	ncd, err := c.chDefs.FromExpressions(exprs[i:])
+	ncd, err := c.chDefs.FromExpressions(exprs[i : i+len(c.chDefs)])
 	if err != nil {
 		return nil, err
 	}
+	i += len(c.chDefs)
+
+	nc.idxDefs = make([]*IndexDefinition, len(c.idxDefs))
+	for j, idxDef := range c.idxDefs {
+		nid := *idxDef
+		var used int
+		nid.Columns, used = withFunctionalIndexExpressions(idxDef.Columns, exprs[i:])
+		i += used
+		nc.idxDefs[j] = &nid
+	}
 
 	nc.chDefs = ncd
 	return &nc, nil
@@ -0,0 +1,104 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// DeclareCursor represents the DECLARE ... CURSOR statement.
+type DeclareCursor struct {
+	Name   string
+	Select sql.Node
+	pRef   *expression.ProcedureParamReference
+}
+
+var _ sql.Node = (*DeclareCursor)(nil)
+var _ sql.DebugStringer = (*DeclareCursor)(nil)
+var _ expression.ProcedureReferencable = (*DeclareCursor)(nil)
+
+// NewDeclareCursor returns a new *DeclareCursor node.
+func NewDeclareCursor(name string, selectStatement sql.Node) *DeclareCursor {
+	return &DeclareCursor{
+		Name:   strings.ToLower(name),
+		Select: selectStatement,
+	}
+}
+
+// Resolved implements the interface sql.Node.
+func (d *DeclareCursor) Resolved() bool {
+	return d.Select.Resolved()
+}
+
+// String implements the interface sql.Node.
+func (d *DeclareCursor) String() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("DECLARE %s CURSOR FOR", d.Name)
+	_ = p.WriteChildren(d.Select.String())
+	return p.String()
+}
+
+// DebugString implements the interface sql.DebugStringer.
+func (d *DeclareCursor) DebugString() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("DECLARE %s CURSOR FOR", d.Name)
+	_ = p.WriteChildren(sql.DebugString(d.Select))
+	return p.String()
+}
+
+// Schema implements the interface sql.Node.
+func (d *DeclareCursor) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the interface sql.Node.
+func (d *DeclareCursor) Children() []sql.Node {
+	return []sql.Node{d.Select}
+}
+
+// WithChildren implements the interface sql.Node.
+func (d *DeclareCursor) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 1)
+	}
+	nd := *d
+	nd.Select = children[0]
+	return &nd, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (d *DeclareCursor) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return d.Select.CheckPrivileges(ctx, opChecker)
+}
+
+// WithParamReference implements the interface expression.ProcedureReferencable.
+func (d *DeclareCursor) WithParamReference(pRef *expression.ProcedureParamReference) sql.Node {
+	nd := *d
+	nd.pRef = pRef
+	return &nd
+}
+
+// RowIter implements the interface sql.Node.
+func (d *DeclareCursor) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if d.pRef == nil {
+		return nil, fmt.Errorf("DECLARE CURSOR is only supported within stored procedures")
+	}
+	d.pRef.InitializeCursor(d.Name, d.Select)
+	return sql.RowsToRowIter(), nil
+}
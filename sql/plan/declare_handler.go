@@ -0,0 +1,226 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// DeclareHandlerAction represents the action that is taken once the handler's statement has been executed.
+type DeclareHandlerAction byte
+
+const (
+	// DeclareHandlerAction_Continue continues execution with the statement following the one that raised the condition.
+	DeclareHandlerAction_Continue DeclareHandlerAction = iota
+	// DeclareHandlerAction_Exit ends execution of the BEGIN/END block that declared the handler.
+	DeclareHandlerAction_Exit
+	// DeclareHandlerAction_Undo is not supported by MySQL, and exists only for parity with the parser.
+	DeclareHandlerAction_Undo
+)
+
+// DeclareHandlerConditionType represents the kind of condition that a handler responds to.
+type DeclareHandlerConditionType byte
+
+const (
+	// DeclareHandlerConditionType_MysqlErrorCode matches errors with the given MySQL error code.
+	DeclareHandlerConditionType_MysqlErrorCode DeclareHandlerConditionType = iota
+	// DeclareHandlerConditionType_SqlState matches errors with the given SQLSTATE value.
+	DeclareHandlerConditionType_SqlState
+	// DeclareHandlerConditionType_ConditionName matches a named condition, which is replaced by the analyzer with the
+	// condition's SQLSTATE value or MySQL error code.
+	DeclareHandlerConditionType_ConditionName
+	// DeclareHandlerConditionType_SqlWarning matches any SQLSTATE value that begins with '01'.
+	DeclareHandlerConditionType_SqlWarning
+	// DeclareHandlerConditionType_NotFound matches any SQLSTATE value that begins with '02'.
+	DeclareHandlerConditionType_NotFound
+	// DeclareHandlerConditionType_SqlException matches any SQLSTATE value that does not begin with '00', '01', or '02'.
+	DeclareHandlerConditionType_SqlException
+)
+
+// DeclareHandlerCondition is a single condition that a handler responds to.
+type DeclareHandlerCondition struct {
+	Type          DeclareHandlerConditionType
+	MysqlErrCode  int64
+	SqlStateValue string
+	ConditionName string
+}
+
+// DeclareHandler represents the DECLARE ... HANDLER statement.
+type DeclareHandler struct {
+	Action     DeclareHandlerAction
+	Conditions []DeclareHandlerCondition
+	Statement  sql.Node
+	pRef       *expression.ProcedureParamReference
+}
+
+var _ sql.Node = (*DeclareHandler)(nil)
+var _ sql.DebugStringer = (*DeclareHandler)(nil)
+var _ expression.ProcedureReferencable = (*DeclareHandler)(nil)
+var _ expression.ProcedureHandler = (*DeclareHandler)(nil)
+
+// NewDeclareHandler returns a new *DeclareHandler node.
+func NewDeclareHandler(action DeclareHandlerAction, conditions []DeclareHandlerCondition, statement sql.Node) (*DeclareHandler, error) {
+	if action == DeclareHandlerAction_Undo {
+		return nil, sql.ErrUnsupportedFeature.New("DECLARE UNDO HANDLER")
+	}
+	return &DeclareHandler{
+		Action:     action,
+		Conditions: conditions,
+		Statement:  statement,
+	}, nil
+}
+
+// Resolved implements the interface sql.Node.
+func (d *DeclareHandler) Resolved() bool {
+	return d.Statement.Resolved()
+}
+
+// String implements the interface sql.Node.
+func (d *DeclareHandler) String() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("DECLARE %s HANDLER FOR %s", d.Action, d.conditionsString())
+	_ = p.WriteChildren(d.Statement.String())
+	return p.String()
+}
+
+// DebugString implements the interface sql.DebugStringer.
+func (d *DeclareHandler) DebugString() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("DECLARE %s HANDLER FOR %s", d.Action, d.conditionsString())
+	_ = p.WriteChildren(sql.DebugString(d.Statement))
+	return p.String()
+}
+
+// conditionsString returns the conditions of this handler as a comma-separated string.
+func (d *DeclareHandler) conditionsString() string {
+	conditions := make([]string, len(d.Conditions))
+	for i, condition := range d.Conditions {
+		conditions[i] = condition.String()
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// Schema implements the interface sql.Node.
+func (d *DeclareHandler) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the interface sql.Node.
+func (d *DeclareHandler) Children() []sql.Node {
+	return []sql.Node{d.Statement}
+}
+
+// WithChildren implements the interface sql.Node.
+func (d *DeclareHandler) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 1)
+	}
+	nd := *d
+	nd.Statement = children[0]
+	return &nd, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (d *DeclareHandler) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return d.Statement.CheckPrivileges(ctx, opChecker)
+}
+
+// WithParamReference implements the interface expression.ProcedureReferencable.
+func (d *DeclareHandler) WithParamReference(pRef *expression.ProcedureParamReference) sql.Node {
+	nd := *d
+	nd.pRef = pRef
+	return &nd
+}
+
+// MatchesError implements the interface expression.ProcedureHandler.
+func (d *DeclareHandler) MatchesError(err error) bool {
+	sqlErr := sql.CastSQLError(err)
+	if sqlErr == nil {
+		return false
+	}
+	sqlState := sqlErr.SQLState()
+	for _, condition := range d.Conditions {
+		switch condition.Type {
+		case DeclareHandlerConditionType_MysqlErrorCode:
+			if int64(sqlErr.Number()) == condition.MysqlErrCode {
+				return true
+			}
+		case DeclareHandlerConditionType_SqlState:
+			if sqlState == condition.SqlStateValue {
+				return true
+			}
+		case DeclareHandlerConditionType_SqlWarning:
+			if strings.HasPrefix(sqlState, "01") {
+				return true
+			}
+		case DeclareHandlerConditionType_NotFound:
+			if strings.HasPrefix(sqlState, "02") {
+				return true
+			}
+		case DeclareHandlerConditionType_SqlException:
+			if !strings.HasPrefix(sqlState, "00") && !strings.HasPrefix(sqlState, "01") && !strings.HasPrefix(sqlState, "02") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RowIter implements the interface sql.Node.
+func (d *DeclareHandler) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if d.pRef == nil {
+		return nil, fmt.Errorf("DECLARE HANDLER is only supported within stored procedures")
+	}
+	d.pRef.InitializeHandler(d)
+	return sql.RowsToRowIter(), nil
+}
+
+// String returns the original SQL representation.
+func (a DeclareHandlerAction) String() string {
+	switch a {
+	case DeclareHandlerAction_Continue:
+		return "CONTINUE"
+	case DeclareHandlerAction_Exit:
+		return "EXIT"
+	case DeclareHandlerAction_Undo:
+		return "UNDO"
+	default:
+		panic(fmt.Errorf("invalid handler action value `%d`", byte(a)))
+	}
+}
+
+// String returns the original SQL representation.
+func (c DeclareHandlerCondition) String() string {
+	switch c.Type {
+	case DeclareHandlerConditionType_MysqlErrorCode:
+		return fmt.Sprintf("%d", c.MysqlErrCode)
+	case DeclareHandlerConditionType_SqlState:
+		return fmt.Sprintf("SQLSTATE '%s'", c.SqlStateValue)
+	case DeclareHandlerConditionType_ConditionName:
+		return c.ConditionName
+	case DeclareHandlerConditionType_SqlWarning:
+		return "SQLWARNING"
+	case DeclareHandlerConditionType_NotFound:
+		return "NOT FOUND"
+	case DeclareHandlerConditionType_SqlException:
+		return "SQLEXCEPTION"
+	default:
+		panic(fmt.Errorf("invalid handler condition type `%d`", byte(c.Type)))
+	}
+}
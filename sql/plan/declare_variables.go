@@ -0,0 +1,131 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// DeclareVariables represents the DECLARE statement for local variables.
+type DeclareVariables struct {
+	Names      []string
+	Type       sql.Type
+	DefaultVal sql.Expression
+	pRef       *expression.ProcedureParamReference
+}
+
+var _ sql.Node = (*DeclareVariables)(nil)
+var _ sql.Expressioner = (*DeclareVariables)(nil)
+var _ expression.ProcedureReferencable = (*DeclareVariables)(nil)
+
+// NewDeclareVariables returns a new *DeclareVariables node. The default value may be nil, in which case the variables
+// are initialized to NULL.
+func NewDeclareVariables(names []string, typ sql.Type, defaultVal sql.Expression) *DeclareVariables {
+	lowercasedNames := make([]string, len(names))
+	for i, name := range names {
+		lowercasedNames[i] = strings.ToLower(name)
+	}
+	return &DeclareVariables{
+		Names:      lowercasedNames,
+		Type:       typ,
+		DefaultVal: defaultVal,
+	}
+}
+
+// Resolved implements the interface sql.Node.
+func (d *DeclareVariables) Resolved() bool {
+	return d.DefaultVal == nil || d.DefaultVal.Resolved()
+}
+
+// String implements the interface sql.Node.
+func (d *DeclareVariables) String() string {
+	defaultStr := ""
+	if d.DefaultVal != nil {
+		defaultStr = fmt.Sprintf(" DEFAULT %s", d.DefaultVal.String())
+	}
+	return fmt.Sprintf("DECLARE %s %s%s", strings.Join(d.Names, ", "), d.Type.String(), defaultStr)
+}
+
+// Schema implements the interface sql.Node.
+func (d *DeclareVariables) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the interface sql.Node.
+func (d *DeclareVariables) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the interface sql.Node.
+func (d *DeclareVariables) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(d, children...)
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (d *DeclareVariables) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return true
+}
+
+// Expressions implements the interface sql.Expressioner.
+func (d *DeclareVariables) Expressions() []sql.Expression {
+	if d.DefaultVal == nil {
+		return nil
+	}
+	return []sql.Expression{d.DefaultVal}
+}
+
+// WithExpressions implements the interface sql.Expressioner.
+func (d *DeclareVariables) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(d.Expressions()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(exprs), len(d.Expressions()))
+	}
+	nd := *d
+	if len(exprs) == 1 {
+		nd.DefaultVal = exprs[0]
+	}
+	return &nd, nil
+}
+
+// WithParamReference implements the interface expression.ProcedureReferencable.
+func (d *DeclareVariables) WithParamReference(pRef *expression.ProcedureParamReference) sql.Node {
+	nd := *d
+	nd.pRef = pRef
+	return &nd
+}
+
+// RowIter implements the interface sql.Node.
+func (d *DeclareVariables) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if d.pRef == nil {
+		return nil, fmt.Errorf("DECLARE variables are only supported within stored procedures")
+	}
+	var defaultVal interface{}
+	if d.DefaultVal != nil {
+		var err error
+		defaultVal, err = d.DefaultVal.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range d.Names {
+		if err := d.pRef.InitializeVariable(name, d.Type, defaultVal); err != nil {
+			return nil, err
+		}
+	}
+	return sql.RowsToRowIter(), nil
+}
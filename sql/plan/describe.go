@@ -91,6 +91,8 @@ func (i *describeIter) Close(*sql.Context) error {
 type DescribeQuery struct {
 	child  sql.Node
 	Format string
+	// Analyze determines whether the query is executed, so that its plan may be annotated with runtime statistics.
+	Analyze bool
 }
 
 func (d *DescribeQuery) Resolved() bool {
@@ -120,7 +122,13 @@ var DescribeSchema = sql.Schema{
 
 // NewDescribeQuery creates a new DescribeQuery node.
 func NewDescribeQuery(format string, child sql.Node) *DescribeQuery {
-	return &DescribeQuery{child, format}
+	return &DescribeQuery{child: child, Format: format}
+}
+
+// NewExplainAnalyze creates a new DescribeQuery node that executes the query, annotating the description of each node
+// with its runtime statistics.
+func NewExplainAnalyze(format string, child sql.Node) *DescribeQuery {
+	return &DescribeQuery{child: child, Format: format, Analyze: true}
 }
 
 // Schema implements the Node interface.
@@ -130,12 +138,29 @@ func (d *DescribeQuery) Schema() sql.Schema {
 
 // RowIter implements the Node interface.
 func (d *DescribeQuery) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	child := d.child
+	if d.Analyze {
+		var err error
+		child, err = runExplainAnalyze(ctx, child, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if d.Format == "json" {
+		jsonString, err := describeJSON(ctx, child)
+		if err != nil {
+			return nil, err
+		}
+		return sql.RowsToRowIter(sql.NewRow(jsonString)), nil
+	}
+
 	var rows []sql.Row
 	var formatString string
 	if d.Format == "debug" {
-		formatString = sql.DebugString(d.child)
+		formatString = sql.DebugString(child)
 	} else {
-		formatString = d.child.String()
+		formatString = child.String()
 	}
 
 	for _, l := range strings.Split(formatString, "\n") {
@@ -148,7 +173,7 @@ func (d *DescribeQuery) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, err
 
 func (d *DescribeQuery) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("DescribeQuery(format=%s)", d.Format)
+	_ = pr.WriteNode("DescribeQuery(format=%s%s)", d.Format, d.analyzeString())
 	if d.Format == "debug" {
 		_ = pr.WriteChildren(sql.DebugString(d.child))
 	} else {
@@ -159,11 +184,19 @@ func (d *DescribeQuery) String() string {
 
 func (d *DescribeQuery) DebugString() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("DescribeQuery(format=%s)", d.Format)
+	_ = pr.WriteNode("DescribeQuery(format=%s%s)", d.Format, d.analyzeString())
 	_ = pr.WriteChildren(sql.DebugString(d.child))
 	return pr.String()
 }
 
+// analyzeString returns the portion of the node description that denotes EXPLAIN ANALYZE.
+func (d *DescribeQuery) analyzeString() string {
+	if d.Analyze {
+		return ", analyze"
+	}
+	return ""
+}
+
 // Query returns the query node being described
 func (d *DescribeQuery) Query() sql.Node {
 	return d.child
@@ -171,5 +204,7 @@ func (d *DescribeQuery) Query() sql.Node {
 
 // WithQuery returns a copy of this node with the query node given
 func (d *DescribeQuery) WithQuery(child sql.Node) sql.Node {
-	return NewDescribeQuery(d.Format, child)
+	nd := *d
+	nd.child = child
+	return &nd
 }
@@ -16,6 +16,7 @@ package plan
 
 import (
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -105,3 +106,97 @@ func TestDescribeQuery(t *testing.T) {
 
 	require.Equal(expected, rows)
 }
+
+func TestExplainAnalyze(t *testing.T) {
+	require := require.New(t)
+
+	table := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Source: "foo", Name: "a", Type: sql.Text},
+		{Source: "foo", Name: "b", Type: sql.Text},
+	}), nil)
+	ctx := sql.NewEmptyContext()
+	for _, row := range []sql.Row{{"foo", "1"}, {"bar", "2"}, {"foo", "3"}} {
+		require.NoError(table.Insert(ctx, row))
+	}
+
+	node := NewExplainAnalyze("tree", NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Text, "foo", "a", false),
+			expression.NewGetFieldWithTable(1, sql.Text, "foo", "b", false),
+		},
+		NewFilter(
+			expression.NewEquals(
+				expression.NewGetFieldWithTable(0, sql.Text, "foo", "a", false),
+				expression.NewLiteral("foo", sql.LongText),
+			),
+			NewResolvedTable(table, nil, nil),
+		),
+	))
+
+	iter, err := node.RowIter(ctx, nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(ctx, nil, iter)
+	require.NoError(err)
+
+	// The timings differ between runs, so we replace them with a constant
+	timeRegex := regexp.MustCompile(`time=[0-9.]+ms`)
+	for _, row := range rows {
+		row[0] = timeRegex.ReplaceAllString(row[0].(string), "time=0ms")
+	}
+
+	expected := []sql.Row{
+		{"Project"},
+		{" ├─ columns: [foo.a, foo.b] (actual rows=2 loops=1 time=0ms)"},
+		{" └─ Filter(foo.a = 'foo') (actual rows=2 loops=1 time=0ms)"},
+		{"     └─ Table(foo) (actual rows=3 loops=1 time=0ms)"},
+	}
+
+	require.Equal(expected, rows)
+}
+
+func TestDescribeQueryJSON(t *testing.T) {
+	require := require.New(t)
+
+	table := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Source: "foo", Name: "a", Type: sql.Text},
+		{Source: "foo", Name: "b", Type: sql.Text},
+	}), nil)
+	ctx := sql.NewEmptyContext()
+	for _, row := range []sql.Row{{"foo", "1"}, {"bar", "2"}, {"foo", "3"}} {
+		require.NoError(table.Insert(ctx, row))
+	}
+
+	node := NewDescribeQuery("json", NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Text, "foo", "a", false),
+		},
+		NewFilter(
+			expression.NewEquals(
+				expression.NewGetFieldWithTable(0, sql.Text, "foo", "a", false),
+				expression.NewLiteral("foo", sql.LongText),
+			),
+			NewResolvedTable(table, nil, nil),
+		),
+	))
+
+	iter, err := node.RowIter(ctx, nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(ctx, nil, iter)
+	require.NoError(err)
+	require.Len(rows, 1)
+
+	expected := `{
+  "query_block": {
+    "select_id": 1,
+    "table": {
+      "table_name": "foo",
+      "access_type": "ALL",
+      "rows_examined_per_scan": 3,
+      "attached_condition": "(foo.a = 'foo')"
+    }
+  }
+}`
+	require.Equal(expected, rows[0][0])
+}
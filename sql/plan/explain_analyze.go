@@ -0,0 +1,237 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+)
+
+// explainAnalyzeStats holds the runtime statistics for a single node in EXPLAIN ANALYZE.
+type explainAnalyzeStats struct {
+	mutex *sync.Mutex
+	rows  int64
+	loops int64
+	time  time.Duration
+}
+
+// addLoop records a call to RowIter, along with the time that it took.
+func (s *explainAnalyzeStats) addLoop(elapsed time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.loops++
+	s.time += elapsed
+}
+
+// addNext records a call to Next, along with the time that it took and whether it returned a row.
+func (s *explainAnalyzeStats) addNext(elapsed time.Duration, returnedRow bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if returnedRow {
+		s.rows++
+	}
+	s.time += elapsed
+}
+
+// String returns the statistics in the form that they're appended to the node's description.
+func (s *explainAnalyzeStats) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return fmt.Sprintf("(actual rows=%d loops=%d time=%.3fms)", s.rows, s.loops, float64(s.time)/float64(time.Millisecond))
+}
+
+// explainAnalyzeNode wraps a node for EXPLAIN ANALYZE, collecting statistics from each sql.RowIter that the node
+// returns. The node's description is annotated with the statistics.
+type explainAnalyzeNode struct {
+	sql.Node
+	stats *explainAnalyzeStats
+}
+
+var _ sql.Node = (*explainAnalyzeNode)(nil)
+var _ sql.DebugStringer = (*explainAnalyzeNode)(nil)
+
+// newExplainAnalyzeTree wraps every node in the tree, including the nodes of subqueries, with an explainAnalyzeNode.
+// Nodes that require their children to be of a particular type, such as HashLookup, keep their children as they are,
+// and the children of those are wrapped instead.
+func newExplainAnalyzeTree(node sql.Node) (sql.Node, error) {
+	node, _, err := transform.OneNodeExpressions(node, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+		subquery, ok := e.(*Subquery)
+		if !ok {
+			return e, transform.SameTree, nil
+		}
+		newQuery, err := newExplainAnalyzeTree(subquery.Query)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		return subquery.WithQuery(newQuery), transform.NewTree, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	node, err = wrapExplainAnalyzeChildren(node)
+	if err != nil {
+		return nil, err
+	}
+	return &explainAnalyzeNode{
+		Node:  node,
+		stats: &explainAnalyzeStats{mutex: &sync.Mutex{}},
+	}, nil
+}
+
+// wrapExplainAnalyzeChildren returns the given node with each of its children wrapped by newExplainAnalyzeTree. If the
+// node doesn't accept the wrapped children, then the children of its children are wrapped instead.
+func wrapExplainAnalyzeChildren(node sql.Node) (sql.Node, error) {
+	children := node.Children()
+	if len(children) == 0 {
+		return node, nil
+	}
+	wrapped := make([]sql.Node, len(children))
+	for i, child := range children {
+		var err error
+		wrapped[i], err = newExplainAnalyzeTree(child)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if newNode, err := node.WithChildren(wrapped...); err == nil {
+		return newNode, nil
+	}
+	for i := range wrapped {
+		wrapped[i] = wrapped[i].(*explainAnalyzeNode).Node
+	}
+	return node.WithChildren(wrapped...)
+}
+
+// String implements the interface sql.Node.
+func (n *explainAnalyzeNode) String() string {
+	children := n.Node.Children()
+	childDescriptions := make([]string, len(children))
+	for i, child := range children {
+		childDescriptions[i] = child.String()
+	}
+	return n.annotate(n.Node.String(), childDescriptions)
+}
+
+// DebugString implements the interface sql.DebugStringer.
+func (n *explainAnalyzeNode) DebugString() string {
+	children := n.Node.Children()
+	childDescriptions := make([]string, len(children))
+	for i, child := range children {
+		childDescriptions[i] = sql.DebugString(child)
+	}
+	return n.annotate(sql.DebugString(n.Node), childDescriptions)
+}
+
+// annotate returns the given description of the node with its statistics written after the node's own description,
+// which is the portion that precedes the given descriptions of its children. The descriptions of nodes that don't
+// write their children last are annotated at the end.
+func (n *explainAnalyzeNode) annotate(description string, childDescriptions []string) string {
+	own := description
+	if len(childDescriptions) > 0 {
+		pr := sql.NewTreePrinter()
+		_ = pr.WriteNode("")
+		_ = pr.WriteChildren(childDescriptions...)
+		childrenDescription := pr.String()[1:]
+		if strings.HasSuffix(description, childrenDescription) {
+			own = strings.TrimSuffix(description, childrenDescription)
+		} else {
+			childDescriptions = nil
+		}
+	}
+
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("%s %s", strings.TrimSuffix(own, "\n"), n.stats)
+	_ = pr.WriteChildren(childDescriptions...)
+	return pr.String()
+}
+
+// WithChildren implements the interface sql.Node.
+func (n *explainAnalyzeNode) WithChildren(children ...sql.Node) (sql.Node, error) {
+	newNode, err := n.Node.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return &explainAnalyzeNode{
+		Node:  newNode,
+		stats: n.stats,
+	}, nil
+}
+
+// RowIter implements the interface sql.Node.
+func (n *explainAnalyzeNode) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	start := time.Now()
+	iter, err := n.Node.RowIter(ctx, row)
+	n.stats.addLoop(time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	return &explainAnalyzeIter{
+		iter:  iter,
+		stats: n.stats,
+	}, nil
+}
+
+// explainAnalyzeIter is the sql.RowIter of an explainAnalyzeNode.
+type explainAnalyzeIter struct {
+	iter  sql.RowIter
+	stats *explainAnalyzeStats
+}
+
+var _ sql.RowIter = (*explainAnalyzeIter)(nil)
+
+// Next implements the interface sql.RowIter.
+func (i *explainAnalyzeIter) Next(ctx *sql.Context) (sql.Row, error) {
+	start := time.Now()
+	row, err := i.iter.Next(ctx)
+	i.stats.addNext(time.Since(start), err == nil)
+	return row, err
+}
+
+// Close implements the interface sql.RowIter.
+func (i *explainAnalyzeIter) Close(ctx *sql.Context) error {
+	return i.iter.Close(ctx)
+}
+
+// runExplainAnalyze executes the given node, returning the node with the collected statistics.
+func runExplainAnalyze(ctx *sql.Context, node sql.Node, row sql.Row) (sql.Node, error) {
+	analyzedNode, err := newExplainAnalyzeTree(node)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := analyzedNode.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, err = iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			_ = iter.Close(ctx)
+			return nil, err
+		}
+	}
+	if err = iter.Close(ctx); err != nil {
+		return nil, err
+	}
+	return analyzedNode, nil
+}
@@ -0,0 +1,581 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+)
+
+// The EXPLAIN FORMAT=JSON output follows the layout of MySQL's: each SELECT is a query block, whose tables are listed
+// in a nested loop when they are joined, wrapped in the sorting, grouping and duplicate removal operations applied to
+// them. Join algorithms that MySQL doesn't have are described through the access types of their tables.
+
+// explainJSONQueryBlock is a "query_block" object, which describes a single SELECT.
+type explainJSONQueryBlock struct {
+	SelectID                int                    `json:"select_id,omitempty"`
+	Message                 string                 `json:"message,omitempty"`
+	SelectListSubqueries    []*explainJSONSubquery `json:"select_list_subqueries,omitempty"`
+	HavingSubqueries        []*explainJSONSubquery `json:"having_subqueries,omitempty"`
+	OptimizedAwaySubqueries []*explainJSONSubquery `json:"optimized_away_subqueries,omitempty"`
+	explainJSONOperation
+}
+
+// explainJSONOperation holds the operation at the top of a query block, or of one of its sorting operations. Only one
+// of its fields is set.
+type explainJSONOperation struct {
+	OrderingOperation *explainJSONSorting     `json:"ordering_operation,omitempty"`
+	GroupingOperation *explainJSONSorting     `json:"grouping_operation,omitempty"`
+	DuplicatesRemoval *explainJSONSorting     `json:"duplicates_removal,omitempty"`
+	UnionResult       *explainJSONUnionResult `json:"union_result,omitempty"`
+	NestedLoop        []*explainJSONOperation `json:"nested_loop,omitempty"`
+	Table             *explainJSONTable       `json:"table,omitempty"`
+}
+
+// explainJSONSorting is an "ordering_operation", "grouping_operation" or "duplicates_removal" object.
+type explainJSONSorting struct {
+	UsingTemporaryTable bool   `json:"using_temporary_table,omitempty"`
+	UsingFilesort       bool   `json:"using_filesort"`
+	HavingCondition     string `json:"having_condition,omitempty"`
+	explainJSONOperation
+}
+
+// explainJSONUnionResult is a "union_result" object, which describes the query blocks of a UNION.
+type explainJSONUnionResult struct {
+	UsingTemporaryTable bool                   `json:"using_temporary_table"`
+	TableName           string                 `json:"table_name"`
+	AccessType          string                 `json:"access_type"`
+	QuerySpecifications []*explainJSONSubquery `json:"query_specifications"`
+}
+
+// explainJSONTable is a "table" object, which describes how a table is read.
+type explainJSONTable struct {
+	TableName                string                 `json:"table_name"`
+	AccessType               string                 `json:"access_type"`
+	Key                      string                 `json:"key,omitempty"`
+	UsedKeyParts             []string               `json:"used_key_parts,omitempty"`
+	Ref                      []string               `json:"ref,omitempty"`
+	Ranges                   []string               `json:"ranges,omitempty"`
+	RowsExaminedPerScan      *uint64                `json:"rows_examined_per_scan,omitempty"`
+	UsingJoinBuffer          string                 `json:"using_join_buffer,omitempty"`
+	UsedColumns              []string               `json:"used_columns,omitempty"`
+	PushedCondition          string                 `json:"pushed_condition,omitempty"`
+	AttachedCondition        string                 `json:"attached_condition,omitempty"`
+	AttachedSubqueries       []*explainJSONSubquery `json:"attached_subqueries,omitempty"`
+	MaterializedFromSubquery *explainJSONSubquery   `json:"materialized_from_subquery,omitempty"`
+}
+
+// explainJSONSubquery describes a query block nested in another one, such as a subquery expression, a derived table,
+// or a SELECT of a UNION.
+type explainJSONSubquery struct {
+	UsingTemporaryTable bool                   `json:"using_temporary_table,omitempty"`
+	Dependent           bool                   `json:"dependent"`
+	Cacheable           bool                   `json:"cacheable"`
+	QueryBlock          *explainJSONQueryBlock `json:"query_block"`
+}
+
+// Access types, named after their equivalents in MySQL.
+const (
+	explainAccessTypeFullScan  = "ALL"
+	explainAccessTypeIndexScan = "index"
+	explainAccessTypeRange     = "range"
+	explainAccessTypeRef       = "ref"
+	explainAccessTypeEqRef     = "eq_ref"
+	explainAccessTypeFulltext  = "fulltext"
+)
+
+// describeJSON returns the description of the query plan for EXPLAIN FORMAT=JSON.
+func describeJSON(ctx *sql.Context, node sql.Node) (string, error) {
+	b := &explainJSONBuilder{ctx: ctx}
+	block, err := b.queryBlock(node)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(map[string]*explainJSONQueryBlock{"query_block": block}); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// explainJSONBuilder builds the EXPLAIN FORMAT=JSON description of a plan, numbering its query blocks in the order
+// they're found.
+type explainJSONBuilder struct {
+	ctx          *sql.Context
+	lastSelectID int
+	// subqueryIDs are the select_ids of the query blocks of the subquery expressions described so far
+	subqueryIDs map[*Subquery]int
+}
+
+// queryBlock returns the query block of the given node, which is the root of a SELECT or of a UNION.
+func (b *explainJSONBuilder) queryBlock(node sql.Node) (*explainJSONQueryBlock, error) {
+	block := &explainJSONQueryBlock{}
+	// A UNION isn't a SELECT itself, so only the query blocks of its SELECTs are numbered
+	if _, ok := unwrapExplainJSONNode(node).(*Union); !ok {
+		b.lastSelectID++
+		block.SelectID = b.lastSelectID
+	}
+	op, err := b.operation(node, block)
+	if err != nil {
+		return nil, err
+	}
+	if op == nil {
+		block.Message = "No tables used"
+	} else {
+		block.explainJSONOperation = *op
+	}
+	return block, nil
+}
+
+// operation returns the operation that reads the given node, or nil if it doesn't read any table. Subqueries of
+// expressions that aren't attached to a table are added to the query block given.
+func (b *explainJSONBuilder) operation(node sql.Node, block *explainJSONQueryBlock) (*explainJSONOperation, error) {
+	switch n := node.(type) {
+	case *ResolvedTable:
+		if IsDualTable(n.Table) {
+			return nil, nil
+		}
+		if _, ok := n.Table.(sql.TableFunction); ok {
+			return &explainJSONOperation{Table: &explainJSONTable{
+				TableName:  n.Name(),
+				AccessType: explainAccessTypeFullScan,
+			}}, nil
+		}
+		table := &explainJSONTable{
+			TableName:  n.Name(),
+			AccessType: explainAccessTypeFullScan,
+		}
+		if err := b.addTableDetails(table, n.Table); err != nil {
+			return nil, err
+		}
+		return &explainJSONOperation{Table: table}, nil
+	case *IndexedTableAccess:
+		table, err := b.indexedTable(n)
+		if err != nil {
+			return nil, err
+		}
+		return &explainJSONOperation{Table: table}, nil
+	case *TableAlias:
+		op, err := b.operation(n.Child, block)
+		if err != nil {
+			return nil, err
+		}
+		if op != nil && op.Table != nil {
+			op.Table.TableName = n.Name()
+		}
+		return op, nil
+	case *SubqueryAlias:
+		subBlock, err := b.queryBlock(n.Child)
+		if err != nil {
+			return nil, err
+		}
+		return &explainJSONOperation{Table: &explainJSONTable{
+			TableName:  n.Name(),
+			AccessType: explainAccessTypeFullScan,
+			MaterializedFromSubquery: &explainJSONSubquery{
+				UsingTemporaryTable: true,
+				Dependent:           n.OuterScopeVisibility,
+				Cacheable:           !n.OuterScopeVisibility,
+				QueryBlock:          subBlock,
+			},
+		}}, nil
+	case *Union:
+		var specs []*explainJSONSubquery
+		for _, child := range unionSelects(n) {
+			subBlock, err := b.queryBlock(child)
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, &explainJSONSubquery{Cacheable: true, QueryBlock: subBlock})
+		}
+		var ids []string
+		for _, spec := range specs {
+			if spec.QueryBlock.SelectID != 0 {
+				ids = append(ids, fmt.Sprint(spec.QueryBlock.SelectID))
+			}
+		}
+		return &explainJSONOperation{UnionResult: &explainJSONUnionResult{
+			UsingTemporaryTable: n.Distinct,
+			TableName:           fmt.Sprintf("<union%s>", strings.Join(ids, ",")),
+			AccessType:          explainAccessTypeFullScan,
+			QuerySpecifications: specs,
+		}}, nil
+	case *JoinNode:
+		return b.join(n, block)
+	case *IndexedInSubqueryFilter:
+		op, err := b.operation(n.child, block)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.attachCondition(op, NewInSubquery(n.getField, n.subquery), block); err != nil {
+			return nil, err
+		}
+		// the table is looked up with the values returned by the subquery
+		if table := lastExplainJSONTable(op); table != nil && len(table.AttachedSubqueries) > 0 {
+			sq := table.AttachedSubqueries[len(table.AttachedSubqueries)-1]
+			table.Ref = []string{fmt.Sprintf("<subquery%d>", sq.QueryBlock.SelectID)}
+		}
+		return op, nil
+	case *Filter:
+		op, err := b.operation(n.Child, block)
+		if err != nil {
+			return nil, err
+		}
+		return op, b.attachCondition(op, n.Expression, block)
+	case *Project:
+		if err := b.addSubqueries(&block.SelectListSubqueries, n.Projections...); err != nil {
+			return nil, err
+		}
+		return b.operation(n.Child, block)
+	case *Sort:
+		return b.sorting(n.Child, block, func(op *explainJSONOperation, s *explainJSONSorting) {
+			s.UsingFilesort = true
+			op.OrderingOperation = s
+		})
+	case *TopN:
+		return b.sorting(n.Child, block, func(op *explainJSONOperation, s *explainJSONSorting) {
+			s.UsingFilesort = true
+			op.OrderingOperation = s
+		})
+	case *GroupBy:
+		if err := b.addSubqueries(&block.SelectListSubqueries, n.SelectedExprs...); err != nil {
+			return nil, err
+		}
+		return b.sorting(n.Child, block, func(op *explainJSONOperation, s *explainJSONSorting) {
+			s.UsingTemporaryTable = true
+			op.GroupingOperation = s
+		})
+	case *Having:
+		op, err := b.operation(n.Child, block)
+		if err != nil {
+			return nil, err
+		}
+		if op != nil && op.GroupingOperation != nil {
+			if err := b.addSubqueries(&block.HavingSubqueries, n.Cond); err != nil {
+				return nil, err
+			}
+			op.GroupingOperation.HavingCondition = b.conditionString(n.Cond)
+			return op, nil
+		}
+		return op, b.attachCondition(op, n.Cond, block)
+	case *Distinct, *OrderedDistinct:
+		return b.sorting(node.Children()[0], block, func(op *explainJSONOperation, s *explainJSONSorting) {
+			s.UsingTemporaryTable = true
+			op.DuplicatesRemoval = s
+		})
+	}
+
+	children := node.Children()
+	switch len(children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return b.operation(children[0], block)
+	default:
+		loop := &explainJSONOperation{}
+		for _, child := range children {
+			op, err := b.operation(child, block)
+			if err != nil {
+				return nil, err
+			}
+			loop.addToNestedLoop(op)
+		}
+		return loop, nil
+	}
+}
+
+// sorting returns a sorting operation over the given node, which is set by the function given.
+func (b *explainJSONBuilder) sorting(child sql.Node, block *explainJSONQueryBlock, set func(*explainJSONOperation, *explainJSONSorting)) (*explainJSONOperation, error) {
+	op, err := b.operation(child, block)
+	if err != nil {
+		return nil, err
+	}
+	s := &explainJSONSorting{}
+	if op != nil {
+		s.explainJSONOperation = *op
+	}
+	res := &explainJSONOperation{}
+	set(res, s)
+	return res, nil
+}
+
+// join returns the nested loop that reads the tables of the join given. The join condition is attached to the last
+// table of the join, and tables that are read into a hash table are marked as using a join buffer.
+func (b *explainJSONBuilder) join(n *JoinNode, block *explainJSONQueryBlock) (*explainJSONOperation, error) {
+	left, err := b.operation(n.left, block)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.operation(n.right, block)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := n.right.(*HashLookup); ok {
+		if table := firstExplainJSONTable(right); table != nil {
+			table.UsingJoinBuffer = "hash join"
+		}
+	}
+
+	loop := &explainJSONOperation{}
+	loop.addToNestedLoop(left)
+	loop.addToNestedLoop(right)
+	if n.Filter != nil && !n.Op.IsLookup() {
+		if err := b.attachCondition(loop, n.Filter, block); err != nil {
+			return nil, err
+		}
+	}
+	return loop, nil
+}
+
+// addToNestedLoop adds the tables read by the given operation to the nested loop of this operation.
+func (o *explainJSONOperation) addToNestedLoop(op *explainJSONOperation) {
+	switch {
+	case op == nil:
+	case op.NestedLoop != nil:
+		o.NestedLoop = append(o.NestedLoop, op.NestedLoop...)
+	default:
+		o.NestedLoop = append(o.NestedLoop, op)
+	}
+}
+
+// attachCondition attaches the condition given to the last table read by the operation, along with its subqueries. If
+// the operation doesn't read a table, the subqueries are added to the query block.
+func (b *explainJSONBuilder) attachCondition(op *explainJSONOperation, cond sql.Expression, block *explainJSONQueryBlock) error {
+	table := lastExplainJSONTable(op)
+	if table == nil {
+		return b.addSubqueries(&block.OptimizedAwaySubqueries, cond)
+	}
+	if err := b.addSubqueries(&table.AttachedSubqueries, cond); err != nil {
+		return err
+	}
+	if table.AttachedCondition == "" {
+		table.AttachedCondition = b.conditionString(cond)
+	} else {
+		table.AttachedCondition = fmt.Sprintf("(%s AND %s)", table.AttachedCondition, b.conditionString(cond))
+	}
+	return nil
+}
+
+// conditionString returns the description of the condition given, in which the subqueries already described are
+// referenced by the select_id of their query blocks, as MySQL does.
+func (b *explainJSONBuilder) conditionString(cond sql.Expression) string {
+	s := cond.String()
+	transform.InspectExpr(cond, func(e sql.Expression) bool {
+		if sq, ok := e.(*Subquery); ok {
+			if id, ok := b.subqueryIDs[sq]; ok {
+				s = strings.Replace(s, sq.String(), fmt.Sprintf("(select #%d)", id), 1)
+			}
+		}
+		return false
+	})
+	return s
+}
+
+// addSubqueries adds the query blocks of the subqueries in the given expressions to the list given.
+func (b *explainJSONBuilder) addSubqueries(subqueries *[]*explainJSONSubquery, exprs ...sql.Expression) error {
+	for _, expr := range exprs {
+		var err error
+		transform.InspectExpr(expr, func(e sql.Expression) bool {
+			sq, ok := e.(*Subquery)
+			if !ok {
+				return false
+			}
+			var block *explainJSONQueryBlock
+			block, err = b.queryBlock(sq.Query)
+			if err != nil {
+				return true
+			}
+			if b.subqueryIDs == nil {
+				b.subqueryIDs = make(map[*Subquery]int)
+			}
+			b.subqueryIDs[sq] = block.SelectID
+			*subqueries = append(*subqueries, &explainJSONSubquery{
+				Dependent:  !sq.canCacheResults,
+				Cacheable:  sq.canCacheResults,
+				QueryBlock: block,
+			})
+			return false
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexedTable returns the description of a table read through an index.
+func (b *explainJSONBuilder) indexedTable(n *IndexedTableAccess) (*explainJSONTable, error) {
+	idx := n.Index()
+	table := &explainJSONTable{
+		TableName: n.Name(),
+		Key:       idx.ID(),
+	}
+	for _, expr := range idx.Expressions() {
+		if i := strings.IndexByte(expr, '.'); i >= 0 && GetColumnFromIndexExpr(expr, n.ResolvedTable.Table) != nil {
+			expr = expr[i+1:]
+		}
+		table.UsedKeyParts = append(table.UsedKeyParts, strings.ToLower(expr))
+	}
+
+	switch {
+	case n.lookup.Fulltext != nil:
+		table.AccessType = explainAccessTypeFulltext
+	case n.lookup.Spatial != nil:
+		table.AccessType = explainAccessTypeRange
+		table.Ranges = []string{n.lookup.Spatial.String()}
+	case n.IsStatic():
+		table.AccessType = explainAccessTypeRange
+		if isFullExplainJSONRange(n.lookup.Ranges) {
+			table.AccessType = explainAccessTypeIndexScan
+		} else {
+			for _, rang := range n.lookup.Ranges {
+				table.Ranges = append(table.Ranges, rang.DebugString())
+			}
+		}
+	default:
+		table.AccessType = explainAccessTypeRef
+		if idx.IsUnique() && len(n.lb.keyExprs) == len(idx.Expressions()) {
+			table.AccessType = explainAccessTypeEqRef
+		}
+		table.UsedKeyParts = table.UsedKeyParts[:len(n.lb.keyExprs)]
+		for _, keyExpr := range n.lb.keyExprs {
+			table.Ref = append(table.Ref, keyExpr.String())
+		}
+	}
+
+	if err := b.addTableDetails(table, n.Table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// addTableDetails adds the estimated row count, pushed down filters, and projected columns of the table given.
+func (b *explainJSONBuilder) addTableDetails(table *explainJSONTable, t sql.Table) error {
+	rowCount, err := sql.EstimateRowCount(b.ctx, t)
+	if err != nil {
+		return err
+	}
+	table.RowsExaminedPerScan = &rowCount
+	if ft, ok := t.(sql.FilteredTable); ok {
+		var filters []string
+		for _, filter := range ft.Filters() {
+			filters = append(filters, filter.String())
+		}
+		table.PushedCondition = strings.Join(filters, " AND ")
+	}
+	if pt, ok := t.(sql.ProjectedTable); ok {
+		for _, column := range pt.Projections() {
+			table.UsedColumns = append(table.UsedColumns, strings.ToLower(column))
+		}
+	}
+	return nil
+}
+
+// firstExplainJSONTable returns the first table read by the operation given, if any.
+func firstExplainJSONTable(op *explainJSONOperation) *explainJSONTable {
+	tables := explainJSONTables(op)
+	if len(tables) == 0 {
+		return nil
+	}
+	return tables[0]
+}
+
+// lastExplainJSONTable returns the last table read by the operation given, if any.
+func lastExplainJSONTable(op *explainJSONOperation) *explainJSONTable {
+	tables := explainJSONTables(op)
+	if len(tables) == 0 {
+		return nil
+	}
+	return tables[len(tables)-1]
+}
+
+// explainJSONTables returns the tables read by the operation given, in order.
+func explainJSONTables(op *explainJSONOperation) []*explainJSONTable {
+	switch {
+	case op == nil:
+		return nil
+	case op.Table != nil:
+		return []*explainJSONTable{op.Table}
+	case op.NestedLoop != nil:
+		var tables []*explainJSONTable
+		for _, entry := range op.NestedLoop {
+			tables = append(tables, explainJSONTables(entry)...)
+		}
+		return tables
+	case op.OrderingOperation != nil:
+		return explainJSONTables(&op.OrderingOperation.explainJSONOperation)
+	case op.GroupingOperation != nil:
+		return explainJSONTables(&op.GroupingOperation.explainJSONOperation)
+	case op.DuplicatesRemoval != nil:
+		return explainJSONTables(&op.DuplicatesRemoval.explainJSONOperation)
+	default:
+		return nil
+	}
+}
+
+// unwrapExplainJSONNode returns the first descendant of the node given that has an operation of its own, skipping
+// the nodes that wrap the whole query, such as QueryProcess.
+func unwrapExplainJSONNode(node sql.Node) sql.Node {
+	for {
+		switch n := node.(type) {
+		case *QueryProcess:
+			node = n.Child()
+		case *TransactionCommittingNode:
+			node = n.Child()
+		case *Limit:
+			node = n.Child
+		case *Offset:
+			node = n.Child
+		default:
+			return node
+		}
+	}
+}
+
+// unionSelects returns the SELECTs of the union given, in order.
+func unionSelects(n *Union) []sql.Node {
+	var selects []sql.Node
+	for _, child := range []sql.Node{n.left, n.right} {
+		if u, ok := unwrapExplainJSONNode(child).(*Union); ok && u.Distinct == n.Distinct && u.Limit == nil && len(u.SortFields) == 0 {
+			selects = append(selects, unionSelects(u)...)
+		} else {
+			selects = append(selects, child)
+		}
+	}
+	return selects
+}
+
+// isFullExplainJSONRange returns whether the ranges given cover every value of the index, including NULL.
+func isFullExplainJSONRange(ranges sql.RangeCollection) bool {
+	if len(ranges) != 1 {
+		return false
+	}
+	for _, col := range ranges[0] {
+		if _, ok := col.LowerBound.(sql.BelowNull); !ok {
+			return false
+		}
+		if _, ok := col.UpperBound.(sql.AboveAll); !ok {
+			return false
+		}
+	}
+	return true
+}
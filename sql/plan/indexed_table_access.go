@@ -221,7 +221,11 @@ func (i *IndexedTableAccess) String() string {
 	pr.WriteNode("IndexedTableAccess(%s)", i.ResolvedTable.Name())
 	var children []string
 	children = append(children, fmt.Sprintf("index: %s", formatIndexDecoratorString(i.Index())))
-	if !i.lookup.IsEmpty() {
+	if i.lookup.Fulltext != nil {
+		children = append(children, fmt.Sprintf("search: %s", i.lookup.Fulltext))
+	} else if i.lookup.Spatial != nil {
+		children = append(children, fmt.Sprintf("box: %s", i.lookup.Spatial))
+	} else if !i.lookup.IsEmpty() {
 		children = append(children, fmt.Sprintf("filters: %s", i.lookup.Ranges.DebugString()))
 	}
 	if pt, ok := i.Table.(sql.ProjectedTable); ok {
@@ -259,7 +263,13 @@ func (i *IndexedTableAccess) DebugString() string {
 	pr.WriteNode("IndexedTableAccess(%s)", sql.DebugString(i.Table))
 	var children []string
 	children = append(children, fmt.Sprintf("index: %s", formatIndexDecoratorString(i.Index())))
-	if !i.lookup.IsEmpty() {
+	if i.lookup.Fulltext != nil {
+		children = append(children, fmt.Sprintf("search: %s", i.lookup.Fulltext))
+		children = append(children, fmt.Sprintf("lookup: STATIC LOOKUP(%s)", sql.DebugString(i.lookup)))
+	} else if i.lookup.Spatial != nil {
+		children = append(children, fmt.Sprintf("box: %s", i.lookup.Spatial))
+		children = append(children, fmt.Sprintf("lookup: STATIC LOOKUP(%s)", sql.DebugString(i.lookup)))
+	} else if !i.lookup.IsEmpty() {
 		children = append(children, fmt.Sprintf("filters: %s", i.lookup.Ranges.DebugString()))
 		children = append(children, fmt.Sprintf("lookup: STATIC LOOKUP(%s)", sql.DebugString(i.lookup)))
 	} else {
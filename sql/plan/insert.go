@@ -278,6 +278,11 @@ func newInsertIter(
 		}
 	}
 
+	err = validateGeneratedColumnUpdates(onDupUpdateExpr, dstSchema)
+	if err != nil {
+		return nil, err
+	}
+
 	rowIter, err := values.RowIter(ctx, row)
 	if err != nil {
 		return nil, err
@@ -341,6 +346,11 @@ func (i *insertIter) Next(ctx *sql.Context) (returnRow sql.Row, returnErr error)
 		row = row[len(row)-len(i.schema):]
 	}
 
+	err = updateGeneratedColumns(ctx, i.schema, row, i.ignore)
+	if err != nil {
+		return nil, i.ignoreOrClose(ctx, row, err)
+	}
+
 	err = i.validateNullability(ctx, i.schema, row)
 	if err != nil {
 		return nil, i.ignoreOrClose(ctx, row, err)
@@ -356,7 +366,7 @@ func (i *insertIter) Next(ctx *sql.Context) (returnRow sql.Row, returnErr error)
 		if row[idx] != nil {
 			converted, cErr := col.Type.Convert(row[idx]) // allows for better error handling
 			if cErr != nil {
-				if i.ignore {
+				if downgradeConversionError(ctx, i.ignore, cErr) {
 					row = convertDataAndWarn(ctx, i.schema, row, idx, cErr)
 					continue
 				} else {
@@ -425,23 +435,14 @@ func (i *insertIter) handleOnDuplicateKeyUpdate(ctx *sql.Context, row, rowToUpda
 		return nil, err
 	}
 
-	newRow := rowToUpdate
-	for _, updateExpr := range i.updateExprs {
-		val, err := updateExpr.Eval(i.ctx, newRow)
-		if err != nil {
-			if i.ignore {
-				idx, ok := getFieldIndexFromUpdateExpr(updateExpr)
-				if !ok {
-					return nil, err
-				}
-
-				val = convertDataAndWarn(ctx, i.schema, row, idx, err)
-			} else {
-				return nil, err
-			}
-		}
+	newRow, err := applyUpdateExpressionsWithIgnore(ctx, i.updateExprs, i.schema, rowToUpdate, i.ignore)
+	if err != nil {
+		return nil, err
+	}
 
-		newRow = val.(sql.Row)
+	err = updateGeneratedColumns(ctx, i.schema, newRow, i.ignore)
+	if err != nil {
+		return nil, i.ignoreOrClose(ctx, newRow, err)
 	}
 
 	// Should revaluate the check conditions.
@@ -558,29 +559,157 @@ func (i *insertIter) ignoreOrClose(ctx *sql.Context, row sql.Row, err error) err
 	return warnOnIgnorableError(ctx, row, err)
 }
 
-// convertDataAndWarn modifies a row with data conversion issues in INSERT/UPDATE IGNORE calls
+// nonStrictConversionErrors are the data conversion errors that are downgraded to warnings when strict mode is not
+// enabled, in which case the closest valid value is written instead.
+var nonStrictConversionErrors = []*errors.Kind{
+	sql.ErrLengthBeyondLimit,
+	sql.ErrOutOfRange,
+	sql.ErrInvalidValue,
+	sql.ErrConvertingToDecimal,
+	sql.ErrConvertToDecimalLimit,
+	sql.ErrConvertingToTime,
+	sql.ErrConvertingToTimeOutOfRange,
+	sql.ErrConvertingToTimeType,
+	sql.ErrConvertingToYear,
+	sql.ErrConvertingToEnum,
+	sql.ErrConvertingToSet,
+	sql.ErrInvalidSetValue,
+}
+
+// downgradeConversionError returns whether the data conversion error given should be replaced with a warning, which is
+// the case for any conversion error in INSERT/UPDATE IGNORE calls, and for invalid or out of range values when the
+// session's sql_mode is not strict.
+func downgradeConversionError(ctx *sql.Context, ignore bool, err error) bool {
+	if ignore {
+		return true
+	}
+	for _, kind := range nonStrictConversionErrors {
+		if kind.Is(err) {
+			return !sql.LoadSqlMode(ctx).Strict()
+		}
+	}
+	return false
+}
+
+// convertDataAndWarn modifies a row with data conversion issues in INSERT/UPDATE IGNORE calls, and in writes when strict
+// mode is not enabled.
 // Per MySQL docs "Rows set to values that would cause data conversion errors are set to the closest valid values instead"
 // cc. https://dev.mysql.com/doc/refman/8.0/en/sql-mode.html#sql-mode-strict
 func convertDataAndWarn(ctx *sql.Context, tableSchema sql.Schema, row sql.Row, columnIdx int, err error) sql.Row {
-	if sql.ErrLengthBeyondLimit.Is(err) {
-		maxLength := tableSchema[columnIdx].Type.(sql.StringType).MaxCharacterLength()
-		row[columnIdx] = row[columnIdx].(string)[:maxLength] // truncate string
-	} else {
-		row[columnIdx] = tableSchema[columnIdx].Type.Zero()
+	code := sql.CastSQLError(err).Num
+	colType := tableSchema[columnIdx].Type
+	switch {
+	case sql.ErrLengthBeyondLimit.Is(err):
+		row[columnIdx] = truncateString(colType.(sql.StringType), row[columnIdx])
+		code = 1265 // WARN_DATA_TRUNCATED. TODO: Needs to be added to vitess
+	case sql.ErrOutOfRange.Is(err), sql.ErrConvertToDecimalLimit.Is(err):
+		if clamped, ok := sql.ClampNumber(colType, row[columnIdx]); ok {
+			row[columnIdx] = clamped
+		} else {
+			row[columnIdx] = colType.Zero()
+		}
+	default:
+		row[columnIdx] = colType.Zero()
 	}
 
-	sqlerr := sql.CastSQLError(err)
-
 	// Add a warning instead
 	ctx.Session.Warn(&sql.Warning{
 		Level:   "Note",
-		Code:    sqlerr.Num,
+		Code:    code,
 		Message: err.Error(),
 	})
 
 	return row
 }
 
+// truncateString returns the value given cut to the maximum length of the string type given. The value is cut at a
+// character boundary, unless the type is binary, in which case the length is in bytes.
+func truncateString(typ sql.StringType, v interface{}) interface{} {
+	converted, err := sql.LongText.Convert(v)
+	if err != nil {
+		return typ.Zero()
+	}
+	str := converted.(string)
+
+	maxLength := int(typ.MaxCharacterLength())
+	if typ.Collation() == sql.Collation_binary {
+		if len(str) > maxLength {
+			str = str[:maxLength]
+		}
+	} else {
+		chars := 0
+		for i := range str {
+			if chars == maxLength {
+				str = str[:i]
+				break
+			}
+			chars++
+		}
+	}
+
+	truncated, err := typ.Convert(str)
+	if err != nil {
+		return typ.Zero()
+	}
+	return truncated
+}
+
+// updateGeneratedColumns sets the values of the generated columns in the row given, which must have the schema given.
+// The schema may contain the columns of several tables, in which case the expression of each generated column is
+// evaluated against the part of the row belonging to its own table. Columns are computed in schema order, since
+// generated columns may refer to the generated columns defined before them. Values that don't fit the type of their
+// column are handled like any other written value: they are an error, unless ignore is set or strict mode is disabled,
+// in which case the closest valid value is written and a warning is added.
+func updateGeneratedColumns(ctx *sql.Context, schema sql.Schema, row sql.Row, ignore bool) error {
+	tableStart := 0
+	for i, col := range schema {
+		if i > 0 && col.Source != schema[i-1].Source {
+			tableStart = i
+		}
+		if col.Generated == nil {
+			continue
+		}
+
+		val, err := col.Generated.Expression.Eval(ctx, row[tableStart:])
+		if err != nil {
+			return err
+		}
+		row[i] = val
+		if val == nil {
+			continue
+		}
+
+		converted, err := col.Type.Convert(val)
+		if err != nil {
+			if sql.ErrLengthBeyondLimit.Is(err) {
+				err = sql.ErrLengthBeyondLimit.New(val, col.Name)
+			}
+			if !downgradeConversionError(ctx, ignore, err) {
+				return err
+			}
+			convertDataAndWarn(ctx, schema, row, i, err)
+			continue
+		}
+		row[i] = converted
+	}
+	return nil
+}
+
+// validateGeneratedColumnUpdates returns an error if any of the update expressions given sets the value of a generated
+// column in the schema given.
+func validateGeneratedColumnUpdates(updateExprs []sql.Expression, schema sql.Schema) error {
+	for _, updateExpr := range updateExprs {
+		idx, ok := getFieldIndexFromUpdateExpr(updateExpr)
+		if !ok || idx >= len(schema) {
+			continue
+		}
+		if col := schema[idx]; col.Generated != nil {
+			return sql.ErrGeneratedColumnValue.New(col.Name, col.Source)
+		}
+	}
+	return nil
+}
+
 func warnOnIgnorableError(ctx *sql.Context, row sql.Row, err error) error {
 	// Check that this error is a part of the list of Ignorable Errors and create the relevant warning
 	for _, ie := range IgnorableErrors {
@@ -15,7 +15,11 @@
 package plan
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -24,10 +28,23 @@ import (
 )
 
 // Into is a node to wrap the top-level node in a query plan so that any result will set user-defined or others
-// variables given
+// variables given, or will be written to the file given by Outfile or Dumpfile.
 type Into struct {
 	UnaryNode
 	IntoVars []sql.Expression
+	// Outfile is the name of the file that rows are written to, formatted according to the FIELDS and LINES options.
+	Outfile string
+	// Dumpfile is the name of the file that a single row is written to, without any formatting.
+	Dumpfile string
+
+	// The FIELDS and LINES options of an outfile. The parser doesn't accept these options yet, so outfiles are always
+	// written with their defaults.
+	fieldsTerminatedBy         string
+	fieldsEnclosedBy           string
+	fieldsEnclosedByOptionally bool
+	fieldsEscapedBy            string
+	linesStartingBy            string
+	linesTerminatedBy          string
 }
 
 func NewInto(child sql.Node, variables []sql.Expression) *Into {
@@ -37,32 +54,73 @@ func NewInto(child sql.Node, variables []sql.Expression) *Into {
 	}
 }
 
+// NewIntoOutfile returns an Into node that writes the rows of its child to the file given, using the default FIELDS
+// and LINES options of SELECT ... INTO OUTFILE.
+func NewIntoOutfile(child sql.Node, outfile string) *Into {
+	return &Into{
+		UnaryNode:                  UnaryNode{child},
+		Outfile:                    outfile,
+		fieldsTerminatedBy:         defaultFieldsTerminatedByDelim,
+		fieldsEnclosedBy:           defaultFieldsEnclosedByDelim,
+		fieldsEnclosedByOptionally: defaultFieldsOptionallyDelim,
+		fieldsEscapedBy:            defaultFieldsEscapedByDelim,
+		linesStartingBy:            defaultLinesStartingByDelim,
+		linesTerminatedBy:          defaultLinesTerminatedByDelim,
+	}
+}
+
+// NewIntoDumpfile returns an Into node that writes the single row of its child to the file given.
+func NewIntoDumpfile(child sql.Node, dumpfile string) *Into {
+	return &Into{
+		UnaryNode: UnaryNode{child},
+		Dumpfile:  dumpfile,
+	}
+}
+
+// Schema implements the sql.Node interface.
+func (i *Into) Schema() sql.Schema {
+	if i.Outfile != "" || i.Dumpfile != "" {
+		return sql.OkResultSchema
+	}
+	return i.Child.Schema()
+}
+
 func (i *Into) String() string {
 	p := sql.NewTreePrinter()
-	var vars = make([]string, len(i.IntoVars))
-	for j, v := range i.IntoVars {
-		vars[j] = fmt.Sprintf(v.String())
-	}
-	_ = p.WriteNode("Into(%s)", strings.Join(vars, ", "))
+	_ = p.WriteNode("Into(%s)", i.target(func(e sql.Expression) string { return e.String() }))
 	_ = p.WriteChildren(i.Child.String())
 	return p.String()
 }
 
 func (i *Into) DebugString() string {
 	p := sql.NewTreePrinter()
+	_ = p.WriteNode("Into(%s)", i.target(func(e sql.Expression) string { return sql.DebugString(e) }))
+	_ = p.WriteChildren(sql.DebugString(i.Child))
+	return p.String()
+}
+
+func (i *Into) target(exprString func(sql.Expression) string) string {
+	switch {
+	case i.Outfile != "":
+		return fmt.Sprintf("OUTFILE '%s'", i.Outfile)
+	case i.Dumpfile != "":
+		return fmt.Sprintf("DUMPFILE '%s'", i.Dumpfile)
+	}
 	var vars = make([]string, len(i.IntoVars))
 	for j, v := range i.IntoVars {
-		vars[j] = sql.DebugString(v)
+		vars[j] = exprString(v)
 	}
-	_ = p.WriteNode("Into(%s)", strings.Join(vars, ", "))
-	_ = p.WriteChildren(sql.DebugString(i.Child))
-	return p.String()
+	return strings.Join(vars, ", ")
 }
 
 func (i *Into) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	span, ctx := ctx.Span("plan.Into")
 	defer span.End()
 
+	if i.Outfile != "" || i.Dumpfile != "" {
+		return i.writeFile(ctx, row)
+	}
+
 	rowIter, err := i.Child.RowIter(ctx, row)
 	if err != nil {
 		return nil, err
@@ -115,11 +173,18 @@ func (i *Into) WithChildren(children ...sql.Node) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
 	}
 
-	return NewInto(children[0], i.IntoVars), nil
+	ni := *i
+	ni.Child = children[0]
+	return &ni, nil
 }
 
 // CheckPrivileges implements the interface sql.Node.
 func (i *Into) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	if i.Outfile != "" || i.Dumpfile != "" {
+		if !opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation("", "", "", sql.PrivilegeType_File)) {
+			return false
+		}
+	}
 	return i.Child.CheckPrivileges(ctx, opChecker)
 }
 
@@ -129,10 +194,197 @@ func (i *Into) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(i, len(exprs), len(i.IntoVars))
 	}
 
-	return NewInto(i.Child, exprs), nil
+	ni := *i
+	ni.IntoVars = exprs
+	return &ni, nil
 }
 
 // Expressions implements the sql.Expressioner interface.
 func (i *Into) Expressions() []sql.Expression {
 	return i.IntoVars
 }
+
+// writeFile writes the rows of the child node to the Outfile or Dumpfile of this node, returning the number of rows
+// written as an OkResult.
+func (i *Into) writeFile(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	name := i.Outfile
+	if name == "" {
+		name = i.Dumpfile
+	}
+	path, err := intoFilePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rowIter, err := i.Child.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	defer rowIter.Close(ctx)
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, sql.ErrFileExists.New(name)
+		}
+		return nil, err
+	}
+
+	w := bufio.NewWriter(file)
+	var n uint64
+	if i.Outfile != "" {
+		n, err = i.writeOutfile(ctx, w, rowIter)
+	} else {
+		n, err = i.writeDumpfile(ctx, w, rowIter)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Don't leave a partially written file behind, since it would prevent the statement from being retried
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(sql.NewRow(sql.NewOkResult(int(n)))), nil
+}
+
+// intoFilePath returns the path of the file to write for the name given. As with LOAD DATA and LOAD_FILE, an empty
+// secure_file_priv allows files to be written anywhere. Otherwise, files can only be written inside of the
+// secure_file_priv directory, and relative names are relative to it.
+func intoFilePath(name string) (string, error) {
+	_, dir, ok := sql.SystemVariables.GetGlobal("secure_file_priv")
+	if !ok {
+		return "", fmt.Errorf("error: secure_file_priv variable was not found")
+	}
+	if dir == nil || dir.(string) == "" {
+		return name, nil
+	}
+
+	secureDir, err := filepath.Abs(dir.(string))
+	if err != nil {
+		return "", err
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(secureDir, path)
+	}
+	rel, err := filepath.Rel(secureDir, filepath.Clean(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", sql.ErrSecureFilePriv.New()
+	}
+	return path, nil
+}
+
+func (i *Into) writeOutfile(ctx *sql.Context, w *bufio.Writer, iter sql.RowIter) (uint64, error) {
+	sch := i.Child.Schema()
+	var n uint64
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+
+		if _, err = w.WriteString(i.linesStartingBy); err != nil {
+			return n, err
+		}
+		for j, v := range row {
+			if j > 0 {
+				if _, err = w.WriteString(i.fieldsTerminatedBy); err != nil {
+					return n, err
+				}
+			}
+			field, err := i.formatField(ctx, sch[j].Type, v)
+			if err != nil {
+				return n, err
+			}
+			if _, err = w.WriteString(field); err != nil {
+				return n, err
+			}
+		}
+		if _, err = w.WriteString(i.linesTerminatedBy); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// formatField returns the value given as it is written to an outfile, escaped and enclosed according to the FIELDS
+// options. NULL values are written as \N, or as NULL when there is no escape character.
+func (i *Into) formatField(ctx *sql.Context, typ sql.Type, v interface{}) (string, error) {
+	if v == nil {
+		if i.fieldsEscapedBy == "" {
+			return "NULL", nil
+		}
+		return i.fieldsEscapedBy + "N", nil
+	}
+
+	val, err := typ.SQL(ctx, nil, v)
+	if err != nil {
+		return "", err
+	}
+	field := val.ToString()
+
+	if i.fieldsEscapedBy != "" {
+		special := i.fieldsEscapedBy + i.fieldsEnclosedBy
+		if i.fieldsTerminatedBy != "" {
+			special += i.fieldsTerminatedBy[:1]
+		}
+		if i.linesTerminatedBy != "" {
+			special += i.linesTerminatedBy[:1]
+		}
+		var sb strings.Builder
+		for _, r := range field {
+			switch {
+			case r == 0:
+				sb.WriteString(i.fieldsEscapedBy)
+				sb.WriteByte('0')
+			case strings.ContainsRune(special, r):
+				sb.WriteString(i.fieldsEscapedBy)
+				sb.WriteRune(r)
+			default:
+				sb.WriteRune(r)
+			}
+		}
+		field = sb.String()
+	}
+
+	if i.fieldsEnclosedBy != "" && (!i.fieldsEnclosedByOptionally || sql.IsText(typ) || sql.IsEnum(typ) || sql.IsSet(typ)) {
+		field = i.fieldsEnclosedBy + field + i.fieldsEnclosedBy
+	}
+	return field, nil
+}
+
+func (i *Into) writeDumpfile(ctx *sql.Context, w *bufio.Writer, iter sql.RowIter) (uint64, error) {
+	sch := i.Child.Schema()
+	rows, err := sql.RowIterToRows(ctx, nil, iter)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) > 1 {
+		return 0, sql.ErrMoreThanOneRow.New()
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	for j, v := range rows[0] {
+		if v == nil {
+			continue
+		}
+		val, err := sch[j].Type.SQL(ctx, nil, v)
+		if err != nil {
+			return 0, err
+		}
+		if _, err = w.Write(val.Raw()); err != nil {
+			return 0, err
+		}
+	}
+	return 1, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestIntoOutfile(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	dir := t.TempDir()
+	setSecureFilePriv(t, dir)
+
+	table := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "foo"},
+		{Name: "b", Type: sql.LongText, Source: "foo", Nullable: true},
+	}), nil)
+	for _, row := range []sql.Row{
+		{int64(1), "plain"},
+		{int64(2), "tab\tand \"quote\""},
+		{int64(3), nil},
+	} {
+		require.NoError(t, table.Insert(ctx, row))
+	}
+
+	tests := []struct {
+		name     string
+		into     func(*Into)
+		expected string
+	}{
+		{
+			name:     "defaults",
+			into:     func(*Into) {},
+			expected: "1\tplain\n2\ttab\\\tand \"quote\"\n3\t\\N\n",
+		},
+		{
+			name: "csv",
+			into: func(i *Into) {
+				i.fieldsTerminatedBy = ","
+				i.fieldsEnclosedBy = `"`
+				i.fieldsEnclosedByOptionally = true
+				i.linesTerminatedBy = "\r\n"
+			},
+			expected: "1,\"plain\"\r\n2,\"tab\tand \\\"quote\\\"\"\r\n3,\\N\r\n",
+		},
+		{
+			name: "no escaping",
+			into: func(i *Into) {
+				i.fieldsTerminatedBy = "|"
+				i.fieldsEscapedBy = ""
+				i.linesStartingBy = "> "
+			},
+			expected: "> 1|plain\n> 2|tab\tand \"quote\"\n> 3|NULL\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			into := NewIntoOutfile(NewResolvedTable(table, nil, nil), tt.name+".txt")
+			tt.into(into)
+
+			rows := intoRows(t, ctx, into)
+			require.Equal(t, []sql.Row{{sql.NewOkResult(3)}}, rows)
+
+			contents, err := os.ReadFile(filepath.Join(dir, tt.name+".txt"))
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(contents))
+
+			_, err = into.RowIter(ctx, nil)
+			require.True(t, sql.ErrFileExists.Is(err))
+		})
+	}
+}
+
+func TestIntoDumpfile(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	dir := t.TempDir()
+	setSecureFilePriv(t, dir)
+
+	table := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.LongBlob, Source: "foo"},
+	}), nil)
+	require.NoError(table.Insert(ctx, sql.Row{[]byte("first\n\tline")}))
+
+	rows := intoRows(t, ctx, NewIntoDumpfile(NewResolvedTable(table, nil, nil), "dump"))
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, rows)
+	contents, err := os.ReadFile(filepath.Join(dir, "dump"))
+	require.NoError(err)
+	require.Equal("first\n\tline", string(contents))
+
+	require.NoError(table.Insert(ctx, sql.Row{[]byte("second")}))
+	_, err = NewIntoDumpfile(NewResolvedTable(table, nil, nil), "dump2").RowIter(ctx, nil)
+	require.True(sql.ErrMoreThanOneRow.Is(err))
+	_, err = os.Stat(filepath.Join(dir, "dump2"))
+	require.True(os.IsNotExist(err))
+}
+
+func TestIntoSecureFilePriv(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewTable("foo", sql.NewPrimaryKeySchema(sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "foo"},
+	}), nil)
+
+	// Files can be written anywhere without a secure_file_priv directory
+	setSecureFilePriv(t, "")
+	other := filepath.Join(t.TempDir(), "other.txt")
+	_, err := NewIntoOutfile(NewResolvedTable(table, nil, nil), other).RowIter(ctx, nil)
+	require.NoError(err)
+	_, err = os.Stat(other)
+	require.NoError(err)
+
+	setSecureFilePriv(t, t.TempDir())
+	for _, name := range []string{"../escape.txt", other} {
+		_, err := NewIntoOutfile(NewResolvedTable(table, nil, nil), name).RowIter(ctx, nil)
+		require.True(sql.ErrSecureFilePriv.Is(err), "%s: %v", name, err)
+	}
+}
+
+func setSecureFilePriv(t *testing.T, dir string) {
+	_, prev, _ := sql.SystemVariables.GetGlobal("secure_file_priv")
+	require.NoError(t, sql.SystemVariables.AssignValues(map[string]interface{}{"secure_file_priv": dir}))
+	t.Cleanup(func() {
+		require.NoError(t, sql.SystemVariables.AssignValues(map[string]interface{}{"secure_file_priv": prev}))
+	})
+}
+
+func intoRows(t *testing.T, ctx *sql.Context, into *Into) []sql.Row {
+	iter, err := into.RowIter(ctx, nil)
+	require.NoError(t, err)
+	rows, err := sql.RowIterToRows(ctx, nil, iter)
+	require.NoError(t, err)
+	return rows
+}
@@ -47,13 +47,16 @@ const (
 	JoinTypeLeftOuterLookup                 // LeftOuterLookupJoin
 	JoinTypeHash                            // HashJoin
 	JoinTypeLeftOuterHash                   // LeftOuterHashJoin
+	JoinTypeMerge                           // MergeJoin
+	JoinTypeLeftOuterMerge                  // LeftOuterMergeJoin
 	JoinTypeNatural                         // NaturalJoin
 )
 
 func (i JoinType) IsLeftOuter() bool {
 	return i == JoinTypeLeftOuter ||
 		i == JoinTypeLeftOuterLookup ||
-		i == JoinTypeLeftOuterHash
+		i == JoinTypeLeftOuterHash ||
+		i == JoinTypeLeftOuterMerge
 }
 
 func (i JoinType) IsRightOuter() bool {
@@ -68,7 +71,9 @@ func (i JoinType) IsPhysical() bool {
 	return i == JoinTypeLookup ||
 		i == JoinTypeLeftOuterLookup ||
 		i == JoinTypeHash ||
-		i == JoinTypeLeftOuterHash
+		i == JoinTypeLeftOuterHash ||
+		i == JoinTypeMerge ||
+		i == JoinTypeLeftOuterMerge
 }
 
 func (i JoinType) IsInner() bool {
@@ -100,6 +105,11 @@ func (i JoinType) IsLookup() bool {
 		i == JoinTypeLeftOuterLookup
 }
 
+func (i JoinType) IsMerge() bool {
+	return i == JoinTypeMerge ||
+		i == JoinTypeLeftOuterMerge
+}
+
 func (i JoinType) IsCross() bool {
 	return i == JoinTypeCross
 }
@@ -116,6 +126,9 @@ type JoinNode struct {
 	Op         JoinType
 	CommentStr string
 	ScopeLen   int
+	// UsingCols are the columns named by the USING clause of the join, if any. A join with a USING clause is a
+	// placeholder until the analyzer replaces the clause with the equivalent join condition.
+	UsingCols []string
 }
 
 func NewJoin(left, right sql.Node, op JoinType, cond sql.Expression) *JoinNode {
@@ -128,12 +141,17 @@ func NewJoin(left, right sql.Node, op JoinType, cond sql.Expression) *JoinNode {
 
 // Expressions implements sql.Expression
 func (j *JoinNode) Expressions() []sql.Expression {
-	if j.Op.IsDegenerate() {
+	if j.Op.IsDegenerate() || j.IsUsing() {
 		return nil
 	}
 	return []sql.Expression{j.Filter}
 }
 
+// IsUsing returns whether this join is a placeholder for a join with a USING clause.
+func (j *JoinNode) IsUsing() bool {
+	return len(j.UsingCols) > 0
+}
+
 func (j *JoinNode) JoinCond() sql.Expression {
 	return j.Filter
 }
@@ -146,7 +164,7 @@ func (j *JoinNode) Comment() string {
 // Resolved implements the Resolvable interface.
 func (j *JoinNode) Resolved() bool {
 	switch {
-	case j.Op.IsNatural():
+	case j.Op.IsNatural(), j.IsUsing():
 		return false
 	case j.Op.IsDegenerate():
 		return j.left.Resolved() && j.right.Resolved()
@@ -158,7 +176,7 @@ func (j *JoinNode) Resolved() bool {
 func (j *JoinNode) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 	ret := *j
 	switch {
-	case j.Op.IsDegenerate():
+	case j.Op.IsDegenerate(), j.IsUsing():
 		if len(exprs) != 0 {
 			return nil, sql.ErrInvalidChildrenNumber.New(j, len(exprs), 0)
 		}
@@ -220,6 +238,8 @@ func (j *JoinNode) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 		return newCrossJoinIter(ctx, j, row)
 	case j.Op.IsPlaceholder():
 		panic(fmt.Sprintf("%s is a placeholder, RowIter called", j.Op))
+	case j.Op.IsMerge():
+		return newMergeJoinIter(ctx, j, row)
 	default:
 		return newJoinIter(ctx, j, row)
 	}
@@ -253,6 +273,8 @@ func (j *JoinNode) String() string {
 	var filter string
 	if j.Filter != nil {
 		filter = j.Filter.String()
+	} else if j.IsUsing() {
+		filter = fmt.Sprintf(" USING (%s)", strings.Join(j.UsingCols, ", "))
 	}
 	pr.WriteNode("%s%s", j.Op, filter)
 	pr.WriteChildren(j.left.String(), j.right.String())
@@ -264,6 +286,8 @@ func (j *JoinNode) DebugString() string {
 	var filter string
 	if j.Filter != nil {
 		filter = sql.DebugString(j.Filter)
+	} else if j.IsUsing() {
+		filter = fmt.Sprintf(" USING (%s)", strings.Join(j.UsingCols, ", "))
 	}
 	_ = pr.WriteNode("%s%s, comment=%s", j.Op, filter, j.Comment())
 	_ = pr.WriteChildren(sql.DebugString(j.left), sql.DebugString(j.right))
@@ -286,6 +310,17 @@ func NewLeftOuterHashJoin(left, right sql.Node, cond sql.Expression) *JoinNode {
 	return NewJoin(left, right, JoinTypeLeftOuterHash, cond)
 }
 
+// NewMergeJoin returns a join that merges its children, which must both be sorted on the join keys. The first
+// conjunct of the join condition must be an equality between an expression of the left child and an expression of
+// the right child, in the order that the children are sorted on.
+func NewMergeJoin(left, right sql.Node, cond sql.Expression) *JoinNode {
+	return NewJoin(left, right, JoinTypeMerge, cond)
+}
+
+func NewLeftOuterMergeJoin(left, right sql.Node, cond sql.Expression) *JoinNode {
+	return NewJoin(left, right, JoinTypeLeftOuterMerge, cond)
+}
+
 func NewLeftOuterLookupJoin(left, right sql.Node, cond sql.Expression) *JoinNode {
 	return NewJoin(left, right, JoinTypeLeftOuterLookup, cond)
 }
@@ -310,6 +345,14 @@ func NewNaturalJoin(left, right sql.Node) *JoinNode {
 	return NewJoin(left, right, JoinTypeNatural, nil)
 }
 
+// NewUsingJoin returns a join of the given type on the columns named by a USING clause. It is a placeholder node,
+// which is transformed into a join with the equivalent condition during analysis.
+func NewUsingJoin(left, right sql.Node, op JoinType, usingCols []string) *JoinNode {
+	j := NewJoin(left, right, op, nil)
+	j.UsingCols = usingCols
+	return j
+}
+
 // An LookupJoin is a join that uses index lookups for the secondary table.
 func NewLookupJoin(left, right sql.Node, cond sql.Expression) *JoinNode {
 	return NewJoin(left, right, JoinTypeLookup, cond)
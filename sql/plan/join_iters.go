@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 func newJoinIter(ctx *sql.Context, j *JoinNode, row sql.Row) (sql.RowIter, error) {
@@ -530,8 +531,11 @@ func newCrossJoinIter(ctx *sql.Context, j *JoinNode, row sql.Row) (sql.RowIter,
 	}
 
 	return sql.NewSpanIter(span, &crossJoinIterator{
-		l:  li,
-		rp: j.right,
+		parentRow: row,
+		l:         li,
+		rp:        j.right,
+		rowSize:   len(row) + len(j.left.Schema()) + len(j.right.Schema()),
+		scopeLen:  j.ScopeLen,
 	}), nil
 }
 
@@ -540,11 +544,14 @@ type rowIterProvider interface {
 }
 
 type crossJoinIterator struct {
-	l  sql.RowIter
-	rp rowIterProvider
-	r  sql.RowIter
+	parentRow sql.Row
+	l         sql.RowIter
+	rp        rowIterProvider
+	r         sql.RowIter
 
-	leftRow sql.Row
+	leftRow  sql.Row
+	rowSize  int
+	scopeLen int
 
 	dispose sql.DisposeFunc
 }
@@ -564,7 +571,7 @@ func (i *crossJoinIterator) Next(ctx *sql.Context) (sql.Row, error) {
 				return nil, err
 			}
 
-			i.leftRow = r
+			i.leftRow = i.parentRow.Append(r)
 		}
 
 		if i.r == nil {
@@ -587,14 +594,20 @@ func (i *crossJoinIterator) Next(ctx *sql.Context) (sql.Row, error) {
 			return nil, err
 		}
 
-		var row sql.Row
-		row = append(row, i.leftRow...)
-		row = append(row, rightRow...)
+		row := make(sql.Row, i.rowSize)
+		copy(row, i.leftRow)
+		copy(row[len(i.leftRow):], rightRow)
 
-		return row, nil
+		return i.removeParentRow(row), nil
 	}
 }
 
+func (i *crossJoinIterator) removeParentRow(r sql.Row) sql.Row {
+	copy(r[i.scopeLen:], r[len(i.parentRow):])
+	r = r[:len(r)-len(i.parentRow)+i.scopeLen]
+	return r
+}
+
 func (i *crossJoinIterator) Close(ctx *sql.Context) (err error) {
 	if i.l != nil {
 		err = i.l.Close(ctx)
@@ -610,3 +623,235 @@ func (i *crossJoinIterator) Close(ctx *sql.Context) (err error) {
 
 	return err
 }
+
+func newMergeJoinIter(ctx *sql.Context, j *JoinNode, row sql.Row) (sql.RowIter, error) {
+	cmp := j.Filter
+	for {
+		and, ok := cmp.(*expression.And)
+		if !ok {
+			break
+		}
+		cmp = and.Left
+	}
+	eq, ok := cmp.(*expression.Equals)
+	if !ok {
+		return nil, fmt.Errorf("expected the first condition of a merge join to be an equality, found %s", cmp)
+	}
+
+	span, ctx := ctx.Span("plan.mergeJoinIter")
+
+	l, err := j.left.RowIter(ctx, row)
+	if err != nil {
+		span.End()
+		return nil, err
+	}
+	r, err := j.right.RowIter(ctx, row)
+	if err != nil {
+		span.End()
+		l.Close(ctx)
+		return nil, err
+	}
+	return sql.NewSpanIter(span, &mergeJoinIter{
+		parentRow: row,
+		left:      l,
+		right:     r,
+		leftKey:   eq.Left(),
+		rightKey:  eq.Right(),
+		cond:      j.Filter,
+		joinType:  j.Op,
+		leftLen:   len(row) + len(j.left.Schema()),
+		rowSize:   len(row) + len(j.left.Schema()) + len(j.right.Schema()),
+		scopeLen:  j.ScopeLen,
+	}), nil
+}
+
+// mergeJoinIter joins two children that are both sorted in ascending order on the join keys. Each row of the left
+// child is only compared to the run of right rows with the same key, which is read once and reused by every left
+// row with that key.
+type mergeJoinIter struct {
+	parentRow sql.Row
+	left      sql.RowIter
+	right     sql.RowIter
+	leftKey   sql.Expression
+	rightKey  sql.Expression
+	cond      sql.Expression
+	joinType  JoinType
+
+	leftRow    sql.Row
+	foundMatch bool
+	matches    []sql.Row
+	matchIdx   int
+
+	// group is the run of right rows with key groupKey
+	group    []sql.Row
+	groupKey interface{}
+	// peek is the first right row after the last group read, if any
+	peek      sql.Row
+	peekKey   interface{}
+	rightDone bool
+
+	leftLen  int
+	rowSize  int
+	scopeLen int
+}
+
+func (i *mergeJoinIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		if i.leftRow == nil {
+			r, err := i.left.Next(ctx)
+			if err != nil {
+				return nil, err
+			}
+			i.leftRow = i.parentRow.Append(r)
+			i.foundMatch = false
+			if err := i.loadMatches(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if i.matchIdx < len(i.matches) {
+			row := i.buildRow(i.leftRow, i.matches[i.matchIdx])
+			i.matchIdx++
+			matches, err := conditionIsTrue(ctx, row, i.cond)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+			i.foundMatch = true
+			return i.removeParentRow(row), nil
+		}
+
+		left := i.leftRow
+		i.leftRow = nil
+		if !i.foundMatch && i.joinType.IsLeftOuter() {
+			return i.removeParentRow(i.buildRow(left, nil)), nil
+		}
+	}
+}
+
+// loadMatches sets the right rows that may match the current left row, which are the rows with the same key.
+func (i *mergeJoinIter) loadMatches(ctx *sql.Context) error {
+	i.matches = nil
+	i.matchIdx = 0
+
+	key, err := i.leftKey.Eval(ctx, i.buildRow(i.leftRow, nil))
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return nil
+	}
+
+	typ := i.leftKey.Type()
+	if i.group != nil {
+		cmp, err := typ.Compare(i.groupKey, key)
+		if err != nil {
+			return err
+		}
+		if cmp == 0 {
+			i.matches = i.group
+			return nil
+		}
+	}
+
+	// The left keys are ascending, so the right rows before this key are never needed again
+	i.group = nil
+	for {
+		if err := i.loadPeek(ctx); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if i.peekKey == nil {
+			i.peek = nil
+			continue
+		}
+		cmp, err := typ.Compare(i.peekKey, key)
+		if err != nil {
+			return err
+		}
+		if cmp > 0 {
+			return nil
+		}
+		if cmp == 0 {
+			break
+		}
+		i.peek = nil
+	}
+
+	i.groupKey = key
+	for {
+		i.group = append(i.group, i.peek)
+		i.peek = nil
+		if err := i.loadPeek(ctx); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if i.peekKey == nil {
+			break
+		}
+		cmp, err := typ.Compare(i.peekKey, key)
+		if err != nil {
+			return err
+		}
+		if cmp != 0 {
+			break
+		}
+	}
+	i.matches = i.group
+	return nil
+}
+
+// loadPeek reads the next right row into peek, unless there's one already.
+func (i *mergeJoinIter) loadPeek(ctx *sql.Context) error {
+	if i.peek != nil {
+		return nil
+	}
+	if i.rightDone {
+		return io.EOF
+	}
+	r, err := i.right.Next(ctx)
+	if err == io.EOF {
+		i.rightDone = true
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	row := make(sql.Row, i.rowSize)
+	copy(row[i.leftLen:], r)
+	key, err := i.rightKey.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+	i.peek = r
+	i.peekKey = key
+	return nil
+}
+
+func (i *mergeJoinIter) removeParentRow(r sql.Row) sql.Row {
+	copy(r[i.scopeLen:], r[len(i.parentRow):])
+	r = r[:len(r)-len(i.parentRow)+i.scopeLen]
+	return r
+}
+
+// buildRow builds the result set row using the rows from the primary and secondary tables
+func (i *mergeJoinIter) buildRow(primary, secondary sql.Row) sql.Row {
+	row := make(sql.Row, i.rowSize)
+
+	copy(row, primary)
+	copy(row[len(primary):], secondary)
+
+	return row
+}
+
+func (i *mergeJoinIter) Close(ctx *sql.Context) error {
+	err := i.left.Close(ctx)
+	if rErr := i.right.Close(ctx); err == nil {
+		err = rErr
+	}
+	return err
+}
@@ -274,3 +274,57 @@ type mockReporter struct {
 
 func (m mockReporter) UsedMemory() uint64 { return m.val }
 func (m mockReporter) MaxMemory() uint64  { return m.max }
+
+func TestMergeJoin(t *testing.T) {
+	schema := func(name string) sql.PrimaryKeySchema {
+		return sql.NewPrimaryKeySchema(sql.Schema{
+			{Name: "i", Type: sql.Int64, Source: name, Nullable: true},
+			{Name: "s", Type: sql.Text, Source: name},
+		})
+	}
+	sorted := func(name string, rows ...sql.Row) sql.Node {
+		table := memory.NewTable(name, schema(name), nil)
+		ctx := sql.NewEmptyContext()
+		for _, row := range rows {
+			require.NoError(t, table.Insert(ctx, row))
+		}
+		return NewSort(
+			[]sql.SortField{{Column: expression.NewGetFieldWithTable(0, sql.Int64, name, "i", true), Order: sql.Ascending}},
+			NewResolvedTable(table, nil, nil),
+		)
+	}
+	left := sorted("l", sql.NewRow(int64(4), "e"), sql.NewRow(int64(2), "b"), sql.NewRow(nil, "d"), sql.NewRow(int64(1), "a"), sql.NewRow(int64(2), "c"))
+	right := sorted("r", sql.NewRow(int64(2), "x"), sql.NewRow(nil, "w"), sql.NewRow(int64(3), "z"), sql.NewRow(int64(4), "v"), sql.NewRow(int64(2), "y"))
+	cond := expression.NewEquals(
+		expression.NewGetFieldWithTable(0, sql.Int64, "l", "i", true),
+		expression.NewGetFieldWithTable(2, sql.Int64, "r", "i", true),
+	)
+
+	inner := []sql.Row{
+		{int64(2), "b", int64(2), "x"},
+		{int64(2), "b", int64(2), "y"},
+		{int64(2), "c", int64(2), "x"},
+		{int64(2), "c", int64(2), "y"},
+		{int64(4), "e", int64(4), "v"},
+	}
+
+	t.Run("inner", func(t *testing.T) {
+		require.ElementsMatch(t, inner, collectRows(t, NewMergeJoin(left, right, cond)))
+	})
+
+	t.Run("left outer", func(t *testing.T) {
+		expected := append([]sql.Row{
+			{nil, "d", nil, nil},
+			{int64(1), "a", nil, nil},
+		}, inner...)
+		require.ElementsMatch(t, expected, collectRows(t, NewLeftOuterMergeJoin(left, right, cond)))
+	})
+
+	t.Run("residual filter", func(t *testing.T) {
+		filtered := expression.NewAnd(cond, expression.NewNot(expression.NewEquals(
+			expression.NewGetFieldWithTable(1, sql.Text, "l", "s", false),
+			expression.NewLiteral("b", sql.LongText),
+		)))
+		require.ElementsMatch(t, inner[2:], collectRows(t, NewMergeJoin(left, right, filtered)))
+	})
+}
@@ -15,7 +15,6 @@
 package plan
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -164,10 +163,11 @@ func (t *JSONTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 		return &jsonTableRowIter{}, nil
 	}
 
-	var jsonData interface{}
-	if err := json.Unmarshal(strData.([]byte), &jsonData); err != nil {
+	doc, err := sql.JSON.Convert(strData)
+	if err != nil {
 		return nil, err
 	}
+	jsonData := doc.(sql.JSONDocument).Val
 
 	// Get data specified from initial path
 	var jsonPathData []interface{}
@@ -250,6 +250,14 @@ func (i *showCreateTablesIter) produceCreateTableStatement(ctx *sql.Context, tab
 	for i, col := range schema {
 		stmt := fmt.Sprintf("  %s %s", quoteIdentifier(col.Name), col.Type.String())
 
+		if col.Generated != nil {
+			storage := "STORED"
+			if col.Virtual {
+				storage = "VIRTUAL"
+			}
+			stmt = fmt.Sprintf("%s GENERATED ALWAYS AS %s %s", stmt, col.Generated.String(), storage)
+		}
+
 		if !col.Nullable {
 			stmt = fmt.Sprintf("%s NOT NULL", stmt)
 		}
@@ -309,12 +317,18 @@ func (i *showCreateTablesIter) produceCreateTableStatement(ctx *sql.Context, tab
 			col := GetColumnFromIndexExpr(expr, table)
 			if col != nil {
 				indexCols = append(indexCols, quoteIdentifier(col.Name))
+			} else {
+				indexCols = append(indexCols, fmt.Sprintf("(%s)", IndexExpressionString(expr, table)))
 			}
 		}
 
 		unique := ""
 		if index.IsUnique() {
 			unique = "UNIQUE "
+		} else if _, ok := index.(sql.FulltextIndex); ok {
+			unique = "FULLTEXT "
+		} else if _, ok := index.(sql.SpatialIndex); ok {
+			unique = "SPATIAL "
 		}
 
 		key := fmt.Sprintf("  %sKEY %s (%s)", unique, quoteIdentifier(index.ID()), strings.Join(indexCols, ","))
@@ -17,6 +17,8 @@ package plan
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 )
@@ -124,6 +126,9 @@ func (i *showIndexesIter) Next(ctx *sql.Context) (sql.Row, error) {
 		if col.Nullable {
 			nullable = "YES"
 		}
+	} else {
+		expression = IndexExpressionString(show.expression, tbl)
+		nullable = "YES"
 	}
 
 	visible := "YES"
@@ -169,6 +174,24 @@ func GetColumnFromIndexExpr(expr string, table sql.Table) *sql.Column {
 	return nil
 }
 
+// IndexExpressionString returns the expression string given, which comes from an index of the table given, as it's
+// shown to users: the columns of the table it refers to, in the form "table.column", are replaced with their quoted
+// names.
+func IndexExpressionString(expr string, table sql.Table) string {
+	cols := make(sql.Schema, len(table.Schema()))
+	copy(cols, table.Schema())
+	// longer names go first, so that no column is replaced by a column whose name is a prefix of its own
+	sort.SliceStable(cols, func(i, j int) bool {
+		return len(cols[i].Name) > len(cols[j].Name)
+	})
+
+	oldnew := make([]string, 0, 2*len(cols))
+	for _, col := range cols {
+		oldnew = append(oldnew, col.Source+"."+col.Name, quoteIdentifier(col.Name))
+	}
+	return strings.NewReplacer(oldnew...).Replace(expr)
+}
+
 func (i *showIndexesIter) Close(*sql.Context) error {
 	return nil
 }
@@ -253,6 +253,13 @@ func prependRowInPlan(row sql.Row) func(n sql.Node) (sql.Node, transform.TreeIde
 			newChildNode, _, err := transform.Node(n.Child, prependRowInPlan(row))
 			newSubqueryAlias.Child = newChildNode
 			return &newSubqueryAlias, transform.NewTree, err
+		case *explainAnalyzeNode:
+			// The wrapped node is checked directly, as its children have already been transformed
+			newNode, same, err := prependRowInPlan(row)(n.Node)
+			if err != nil || same {
+				return n, transform.SameTree, err
+			}
+			return &explainAnalyzeNode{Node: newNode, stats: n.stats}, transform.NewTree, nil
 		}
 
 		return n, transform.SameTree, nil
@@ -195,7 +195,8 @@ func (u *updateIter) Next(ctx *sql.Context) (sql.Row, error) {
 }
 
 // Applies the update expressions given to the row given, returning the new resultant row. In the case that ignore is
-// provided and there is a type conversion error, this function sets the value to the zero value as per the MySQL standard.
+// provided or strict mode is not enabled and there is a type conversion error, this function sets the value to the
+// closest valid value as per the MySQL standard.
 // TODO: a set of update expressions should probably be its own expression type with an Eval method that does this
 func applyUpdateExpressionsWithIgnore(ctx *sql.Context, updateExprs []sql.Expression, tableSchema sql.Schema, row sql.Row, ignore bool) (sql.Row, error) {
 	var ok bool
@@ -204,7 +205,7 @@ func applyUpdateExpressionsWithIgnore(ctx *sql.Context, updateExprs []sql.Expres
 		val, err := updateExpr.Eval(ctx, prev)
 		if err != nil {
 			wtce, ok2 := err.(sql.WrappedTypeConversionError)
-			if !ok2 || !ignore {
+			if !ok2 || !downgradeConversionError(ctx, ignore, wtce.Err) {
 				return nil, err
 			}
 
@@ -119,6 +119,11 @@ func (u *updateSourceIter) Next(ctx *sql.Context) (sql.Row, error) {
 		newRow = newRow[len(newRow)-expectedSchemaLen:]
 	}
 
+	err = updateGeneratedColumns(ctx, u.tableSchema, newRow, u.ignore)
+	if err != nil {
+		return nil, err
+	}
+
 	return oldRow.Append(newRow), nil
 }
 
@@ -128,7 +133,7 @@ func (u *updateSourceIter) Close(ctx *sql.Context) error {
 
 func (u *UpdateSource) getChildSchema() (sql.Schema, error) {
 	if nodeHasJoin(u.Child) {
-		return u.Child.Schema(), nil
+		return joinSchemaWithGeneratedColumns(u.Child.Schema(), u.Child), nil
 	}
 
 	table, err := GetUpdatable(u.Child)
@@ -139,6 +144,37 @@ func (u *UpdateSource) getChildSchema() (sql.Schema, error) {
 	return table.Schema(), nil
 }
 
+// joinSchemaWithGeneratedColumns returns the schema given, which is the schema of a join, with the generated column
+// expressions of the tables in the join. The projection of a join doesn't carry them over from the tables.
+func joinSchemaWithGeneratedColumns(schema sql.Schema, node sql.Node) sql.Schema {
+	tableSchemas := make(map[string]sql.Schema)
+	transform.Inspect(node, func(node sql.Node) bool {
+		switch node := node.(type) {
+		case *TableAlias, *ResolvedTable, *IndexedTableAccess:
+			tableSchemas[strings.ToLower(node.(sql.Nameable).Name())] = node.Schema()
+			return false
+		default:
+			return true
+		}
+	})
+
+	newSchema := make(sql.Schema, len(schema))
+	for i, col := range schema {
+		newSchema[i] = col
+		tableSchema, ok := tableSchemas[strings.ToLower(col.Source)]
+		if !ok {
+			continue
+		}
+		if idx := tableSchema.IndexOfColName(col.Name); idx != -1 && tableSchema[idx].Generated != nil {
+			nc := *col
+			nc.Generated = tableSchema[idx].Generated
+			nc.Virtual = tableSchema[idx].Virtual
+			newSchema[i] = &nc
+		}
+	}
+	return newSchema
+}
+
 func nodeHasJoin(node sql.Node) bool {
 	hasJoinNode := false
 	transform.Inspect(node, func(node sql.Node) bool {
@@ -155,12 +191,17 @@ func nodeHasJoin(node sql.Node) bool {
 }
 
 func (u *UpdateSource) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	rowIter, err := u.Child.RowIter(ctx, row)
+	schema, err := u.getChildSchema()
 	if err != nil {
 		return nil, err
 	}
 
-	schema, err := u.getChildSchema()
+	err = validateGeneratedColumnUpdates(u.UpdateExprs, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rowIter, err := u.Child.RowIter(ctx, row)
 	if err != nil {
 		return nil, err
 	}
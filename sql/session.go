@@ -643,6 +643,11 @@ type Context struct {
 	queryTime   time.Time
 	tracer      trace.Tracer
 	rootSpan    trace.Span
+	// isSelect and executionTimeHint are recorded by the parser for the statement most recently parsed with this
+	// context, see SetMaxExecutionTimeHint.
+	isSelect             bool
+	executionTimeHint    int64
+	hasExecutionTimeHint bool
 }
 
 // ContextOption is a function to configure the context.
@@ -772,6 +777,20 @@ func (c Context) WithQuery(q string) *Context {
 	return &c
 }
 
+// SetMaxExecutionTimeHint records whether the statement being run is a SELECT statement, which are the only statements
+// whose execution time is limited, along with the timeout in milliseconds given by its MAX_EXECUTION_TIME optimizer
+// hint. The last argument is false if the statement gave no such hint.
+func (c *Context) SetMaxExecutionTimeHint(isSelect bool, timeout int64, ok bool) {
+	c.isSelect = isSelect
+	c.executionTimeHint = timeout
+	c.hasExecutionTimeHint = ok
+}
+
+// MaxExecutionTimeHint returns what was recorded by SetMaxExecutionTimeHint for the statement being run.
+func (c *Context) MaxExecutionTimeHint() (isSelect bool, timeout int64, ok bool) {
+	return c.isSelect, c.executionTimeHint, c.hasExecutionTimeHint
+}
+
 // QueryTime returns the time.Time when the context associated with this query was created
 func (c *Context) QueryTime() time.Time {
 	return c.queryTime
@@ -0,0 +1,111 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"math"
+)
+
+// SpatialBox is an axis-aligned rectangle in the coordinates of a spatial reference system, such as the minimum
+// bounding rectangle of a geometry. Degenerate boxes, whose sides may have zero length, bound points and straight
+// lines.
+type SpatialBox struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// BoundingBox returns the minimum bounding rectangle of the geometry, or false if the geometry has no points.
+func BoundingBox(g GeometryValue) (SpatialBox, bool) {
+	box := SpatialBox{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	var addPoints func(g GeometryValue)
+	addPoints = func(g GeometryValue) {
+		switch g := g.(type) {
+		case Point:
+			box = box.Extend(SpatialBox{MinX: g.X, MinY: g.Y, MaxX: g.X, MaxY: g.Y})
+		case LineString:
+			for _, p := range g.Points {
+				addPoints(p)
+			}
+		case Polygon:
+			for _, l := range g.Lines {
+				addPoints(l)
+			}
+		case MultiPoint:
+			for _, p := range g.Points {
+				addPoints(p)
+			}
+		case MultiLineString:
+			for _, l := range g.Lines {
+				addPoints(l)
+			}
+		case MultiPolygon:
+			for _, p := range g.Polygons {
+				addPoints(p)
+			}
+		case GeomColl:
+			for _, c := range g.Geoms {
+				addPoints(c)
+			}
+		}
+	}
+	addPoints(g)
+	return box, box.MinX <= box.MaxX
+}
+
+// Extend returns the smallest box that holds both this box and the other.
+func (b SpatialBox) Extend(o SpatialBox) SpatialBox {
+	return SpatialBox{
+		MinX: math.Min(b.MinX, o.MinX),
+		MinY: math.Min(b.MinY, o.MinY),
+		MaxX: math.Max(b.MaxX, o.MaxX),
+		MaxY: math.Max(b.MaxY, o.MaxY),
+	}
+}
+
+// Intersects returns whether the two boxes share any point, including points on their sides.
+func (b SpatialBox) Intersects(o SpatialBox) bool {
+	return b.MinX <= o.MaxX && o.MinX <= b.MaxX && b.MinY <= o.MaxY && o.MinY <= b.MaxY
+}
+
+// Contains returns whether every point of the other box lies within this box.
+func (b SpatialBox) Contains(o SpatialBox) bool {
+	return b.MinX <= o.MinX && o.MaxX <= b.MaxX && b.MinY <= o.MinY && o.MaxY <= b.MaxY
+}
+
+// Area returns the area of the box.
+func (b SpatialBox) Area() float64 {
+	return (b.MaxX - b.MinX) * (b.MaxY - b.MinY)
+}
+
+// Margin returns half of the perimeter of the box.
+func (b SpatialBox) Margin() float64 {
+	return (b.MaxX - b.MinX) + (b.MaxY - b.MinY)
+}
+
+func (b SpatialBox) String() string {
+	return fmt.Sprintf("MBR(%v %v,%v %v)", b.MinX, b.MinY, b.MaxX, b.MaxY)
+}
+
+// SpatialIndex is an extension of |Index| for SPATIAL indexes, which index the minimum bounding rectangles of a
+// single geometry column. A SPATIAL index can't be used for range lookups. Instead, it's given IndexLookups with a
+// Spatial box, for which it must return every row whose geometry has a bounding rectangle that intersects the box.
+// Other rows may be returned as well, so the condition that produced the lookup must still be evaluated.
+type SpatialIndex interface {
+	Index
+	// SRID returns the SRID of the indexed column, and whether the column is restricted to that SRID. The geometries
+	// of a column without a fixed SRID can't be compared, so lookups are only made on indexes of columns that have
+	// one.
+	SRID() (uint32, bool)
+}
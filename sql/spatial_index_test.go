@@ -0,0 +1,62 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		g        GeometryValue
+		expected SpatialBox
+		ok       bool
+	}{
+		{"point", Point{X: 1, Y: 2}, SpatialBox{MinX: 1, MinY: 2, MaxX: 1, MaxY: 2}, true},
+		{"linestring", LineString{Points: []Point{{X: 3, Y: -1}, {X: 0, Y: 2}}}, SpatialBox{MinX: 0, MinY: -1, MaxX: 3, MaxY: 2}, true},
+		{"polygon", Polygon{Lines: []LineString{{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 5, Y: 4}, {X: 0, Y: 0}}}}}, SpatialBox{MinX: 0, MinY: 0, MaxX: 5, MaxY: 4}, true},
+		{"geometry collection", GeomColl{Geoms: []GeometryValue{Point{X: -2, Y: 1}, MultiPoint{Points: []Point{{X: 6, Y: 7}}}}}, SpatialBox{MinX: -2, MinY: 1, MaxX: 6, MaxY: 7}, true},
+		{"empty geometry collection", GeomColl{}, SpatialBox{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box, ok := BoundingBox(tt.g)
+			require.Equal(t, tt.ok, ok)
+			if ok {
+				require.Equal(t, tt.expected, box)
+			}
+		})
+	}
+}
+
+func TestSpatialBox(t *testing.T) {
+	require := require.New(t)
+	a := SpatialBox{MinX: 0, MinY: 0, MaxX: 2, MaxY: 2}
+	b := SpatialBox{MinX: 2, MinY: 1, MaxX: 3, MaxY: 4}
+	c := SpatialBox{MinX: 5, MinY: 5, MaxX: 6, MaxY: 6}
+
+	require.True(a.Intersects(b))
+	require.False(a.Intersects(c))
+	require.False(a.Contains(b))
+	require.True(a.Extend(b).Contains(b))
+	require.Equal(SpatialBox{MinX: 0, MinY: 0, MaxX: 3, MaxY: 4}, a.Extend(b))
+	require.Equal(4.0, a.Area())
+	require.Equal(4.0, a.Margin())
+	require.Equal("MBR(0 0,3 4)", a.Extend(b).String())
+}
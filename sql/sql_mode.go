@@ -0,0 +1,98 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	SqlModeStrictTransTables  = "STRICT_TRANS_TABLES"
+	SqlModeStrictAllTables    = "STRICT_ALL_TABLES"
+	SqlModeTraditional        = "TRADITIONAL"
+	SqlModeOnlyFullGroupBy    = "ONLY_FULL_GROUP_BY"
+	SqlModeNoBackslashEscapes = "NO_BACKSLASH_ESCAPES"
+)
+
+// SqlMode is the set of modes enabled by the sql_mode system variable.
+type SqlMode struct {
+	modes      map[string]struct{}
+	modeString string
+}
+
+// LoadSqlMode returns the SqlMode of the session of the context given.
+func LoadSqlMode(ctx *Context) *SqlMode {
+	val, err := ctx.GetSessionVariable(ctx, "sql_mode")
+	if err != nil || val == nil {
+		return NewSqlModeFromString("")
+	}
+	switch val := val.(type) {
+	case string:
+		return NewSqlModeFromString(val)
+	case uint64:
+		// Values that have been set are stored as the bit field of the set type
+		sysVar, _, ok := SystemVariables.GetGlobal("sql_mode")
+		if setType, isSet := sysVar.Type.(SetType); ok && isSet {
+			if s, err := setType.BitsToString(val); err == nil {
+				return NewSqlModeFromString(s)
+			}
+		}
+	}
+	return NewSqlModeFromString(fmt.Sprint(val))
+}
+
+// NewSqlModeFromString returns the SqlMode for the comma separated list of modes given.
+func NewSqlModeFromString(modeString string) *SqlMode {
+	modes := make(map[string]struct{})
+	for _, mode := range strings.Split(modeString, ",") {
+		mode = strings.ToUpper(strings.TrimSpace(mode))
+		if mode != "" {
+			modes[mode] = struct{}{}
+		}
+	}
+	return &SqlMode{
+		modes:      modes,
+		modeString: strings.ToUpper(modeString),
+	}
+}
+
+// ModeEnabled returns whether the mode given is enabled. Modes that are enabled by a combination mode, such as
+// TRADITIONAL, are reported as enabled as well.
+func (s *SqlMode) ModeEnabled(mode string) bool {
+	mode = strings.ToUpper(mode)
+	if _, ok := s.modes[mode]; ok {
+		return true
+	}
+	if _, ok := s.modes[SqlModeTraditional]; ok {
+		switch mode {
+		case SqlModeStrictTransTables, SqlModeStrictAllTables, "NO_ZERO_IN_DATE", "NO_ZERO_DATE",
+			"ERROR_FOR_DIVISION_BY_ZERO", "NO_ENGINE_SUBSTITUTION":
+			return true
+		}
+	}
+	return false
+}
+
+// Strict returns whether either of the strict modes is enabled, in which case invalid or out of range values are
+// rejected by writes rather than adjusted with a warning.
+func (s *SqlMode) Strict() bool {
+	return s.ModeEnabled(SqlModeStrictTransTables) || s.ModeEnabled(SqlModeStrictAllTables)
+}
+
+// String returns the sql_mode value this SqlMode was created from.
+func (s *SqlMode) String() string {
+	return s.modeString
+}
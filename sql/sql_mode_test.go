@@ -0,0 +1,59 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlMode(t *testing.T) {
+	tests := []struct {
+		mode   string
+		strict bool
+	}{
+		{"", false},
+		{"STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", true},
+		{"strict_all_tables", true},
+		{"TRADITIONAL", true},
+		{"NO_ENGINE_SUBSTITUTION", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			assert.Equal(t, tt.strict, NewSqlModeFromString(tt.mode).Strict())
+		})
+	}
+
+	mode := NewSqlModeFromString("ANSI_QUOTES, traditional")
+	assert.True(t, mode.ModeEnabled("ansi_quotes"))
+	assert.True(t, mode.ModeEnabled("ERROR_FOR_DIVISION_BY_ZERO"))
+	assert.False(t, mode.ModeEnabled("PIPES_AS_CONCAT"))
+}
+
+func TestLoadSqlMode(t *testing.T) {
+	ctx := NewEmptyContext()
+	assert.True(t, LoadSqlMode(ctx).Strict())
+
+	sysVar, _, _ := SystemVariables.GetGlobal("sql_mode")
+	bits, err := sysVar.Type.Convert("ANSI_QUOTES,STRICT_ALL_TABLES")
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.SetSessionVariable(ctx, "sql_mode", bits))
+	assert.True(t, LoadSqlMode(ctx).ModeEnabled("ANSI_QUOTES"))
+	assert.True(t, LoadSqlMode(ctx).Strict())
+
+	assert.NoError(t, ctx.SetSessionVariable(ctx, "sql_mode", ""))
+	assert.False(t, LoadSqlMode(ctx).Strict())
+}
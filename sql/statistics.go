@@ -182,3 +182,23 @@ type StatisticsTable interface {
 	// Statistics returns the statistics for this table
 	Statistics(ctx *Context) (TableStatistics, error)
 }
+
+// defaultRowCountEstimate is the number of rows that is estimated for tables that do not provide statistics.
+const defaultRowCountEstimate = 1000
+
+// EstimateRowCount returns the estimated number of rows in the table, which is based on the table's statistics when
+// they are available.
+func EstimateRowCount(ctx *Context, t Table) (uint64, error) {
+	if w, ok := t.(TableWrapper); ok {
+		t = w.Underlying()
+	}
+	tab, ok := t.(StatisticsTable)
+	if !ok {
+		return defaultRowCountEstimate, nil
+	}
+	stats, err := tab.Statistics(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return stats.RowCount(), nil
+}
@@ -14,10 +14,6 @@
 
 package sql
 
-import (
-	"encoding/json"
-)
-
 func MustConvert(val interface{}, err error) interface{} {
 	if err != nil {
 		panic(err)
@@ -26,8 +22,8 @@ func MustConvert(val interface{}, err error) interface{} {
 }
 
 func MustJSON(s string) JSONDocument {
-	var doc interface{}
-	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+	doc, err := unmarshalJSON([]byte(s))
+	if err != nil {
 		panic(err)
 	}
 	return JSONDocument{Val: doc}